@@ -194,6 +194,40 @@ func GetAccessTokenBySHA(ctx context.Context, token string) (*AccessToken, error
 	return nil, ErrAccessTokenNotExist{token}
 }
 
+// FindAccessTokensBySHA returns the access tokens among the candidates, keyed by candidate. The candidates
+// are looked up by the last eight characters of the tokens in batches, instead of one query per candidate.
+func FindAccessTokensBySHA(ctx context.Context, candidates []string) (map[string]*AccessToken, error) {
+	byLastEight := make(map[string][]string, len(candidates))
+	for _, candidate := range candidates {
+		if len(candidate) == 40 {
+			lastEight := candidate[len(candidate)-8:]
+			byLastEight[lastEight] = append(byLastEight[lastEight], candidate)
+		}
+	}
+	lastEights := make([]string, 0, len(byLastEight))
+	for lastEight := range byLastEight {
+		lastEights = append(lastEights, lastEight)
+	}
+
+	found := make(map[string]*AccessToken)
+	for len(lastEights) > 0 {
+		limit := min(len(lastEights), db.DefaultMaxInSize)
+		var tokens []*AccessToken
+		if err := db.GetEngine(ctx).In("token_last_eight", lastEights[:limit]).Find(&tokens); err != nil {
+			return nil, err
+		}
+		for _, t := range tokens {
+			for _, candidate := range byLastEight[t.TokenLastEight] {
+				if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(HashToken(candidate, t.TokenSalt))) == 1 {
+					found[candidate] = t
+				}
+			}
+		}
+		lastEights = lastEights[limit:]
+	}
+	return found, nil
+}
+
 // AccessTokenByNameExists checks if a token name has been used already by a user.
 func AccessTokenByNameExists(ctx context.Context, token *AccessToken) (bool, error) {
 	return db.GetEngine(ctx).Table("access_token").Where("name = ?", token.Name).And("uid = ?", token.UID).Exist()
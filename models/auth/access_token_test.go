@@ -84,6 +84,23 @@ func TestGetAccessTokenBySHA(t *testing.T) {
 	assert.True(t, auth_model.IsErrAccessTokenEmpty(err))
 }
 
+func TestFindAccessTokensBySHA(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	tokens, err := auth_model.FindAccessTokensBySHA(db.DefaultContext, []string{
+		"d2c6c1ba3890b309189a8e618c72a162e4efbf36",
+		"0123456789abcdef0123456789abcdef01234567",
+		"0123456789abcdef0123456789abcdefe4efbf36",
+		"notahash",
+	})
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "Token A", tokens["d2c6c1ba3890b309189a8e618c72a162e4efbf36"].Name)
+
+	tokens, err = auth_model.FindAccessTokensBySHA(db.DefaultContext, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
 func TestListAccessTokens(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	tokens, err := db.Find[auth_model.AccessToken](db.DefaultContext, auth_model.ListAccessTokensOptions{UserID: 1})
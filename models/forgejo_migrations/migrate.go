@@ -98,6 +98,34 @@ var migrations = []*Migration{
 	NewMigration("Add public key information to `FederatedUser` and `FederationHost`", AddPublicKeyInformationForFederation),
 	// v29 -> v30
 	NewMigration("Migrate `User.NormalizedFederatedURI` column to extract port & schema into FederatedHost", MigrateNormalizedFederatedURI),
+	// v30 -> v31
+	NewMigration("Add `push_policy` table", AddPushPolicyTable),
+	// v31 -> v32
+	NewMigration("Add `secret_scan_alert` and `secret_scan_pattern` tables", AddSecretScanTables),
+	// v32 -> v33
+	NewMigration("Add `repo_dependency` table", AddRepoDependencyTable),
+	// v33 -> v34
+	NewMigration("Add custom issue fields", AddIssueCustomFieldTables),
+	// v34 -> v35
+	NewMigration("Add saved issue searches", AddSavedSearchTables),
+	// v35 -> v36
+	NewMigration("Add issue redirects for transferred issues", AddIssueRedirectTable),
+	// v36 -> v37
+	NewMigration("Add issue form values", AddIssueFormValuesTable),
+	// v37 -> v38
+	NewMigration("Add sub-issues", AddSubIssueTable),
+	// v38 -> v39
+	NewMigration("Add project automation rules", AddProjectAutomationTable),
+	// v39 -> v40
+	NewMigration("Add incoming email addresses and external reporters", AddIncomingEmailTables),
+	// v40 -> v41
+	NewMigration("Add viewed blobs to review states", AddViewedBlobsColumnToReviewStateTable),
+	// v41 -> v42
+	NewMigration("Add review rules to protected branches", AddReviewRulesColumnToProtectedBranchTable),
+	// v42 -> v43
+	NewMigration("Add review assignment to teams", AddReviewAssignmentToTeamTable),
+	// v43 -> v44
+	NewMigration("Add checksums to release assets", AddChecksumsToReleaseAssets),
 }
 
 // GetCurrentDBVersion returns the current Forgejo database version.
@@ -0,0 +1,29 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPushPolicyTable(x *xorm.Engine) error {
+	type PushPolicy struct {
+		ID                    int64              `xorm:"pk autoincr"`
+		OwnerID               int64              `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+		RepoID                int64              `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+		CommitMessagePattern  string             `xorm:"TEXT"`
+		AuthorEmailDomains    string             `xorm:"TEXT"`
+		MaxFileSize           int64              `xorm:"NOT NULL DEFAULT 0"`
+		ForbiddenFilePatterns string             `xorm:"TEXT"`
+		ForbiddenExtensions   string             `xorm:"TEXT"`
+		DetectSecrets         bool               `xorm:"NOT NULL DEFAULT false"`
+		RequireSignOff        bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix           timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix           timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PushPolicy))
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSecretScanTables(x *xorm.Engine) error {
+	type SecretScanAlert struct {
+		ID           int64  `xorm:"pk autoincr"`
+		RepoID       int64  `xorm:"INDEX UNIQUE(repo_secret) NOT NULL"`
+		SecretHash   string `xorm:"UNIQUE(repo_secret) VARCHAR(64) NOT NULL"`
+		RuleID       string `xorm:"NOT NULL"`
+		Description  string
+		Redacted     string
+		Path         string `xorm:"TEXT"`
+		Line         int
+		RefName      string `xorm:"TEXT"`
+		CommitID     string `xorm:"VARCHAR(64)"`
+		PusherID     int64
+		IsRevoked    bool               `xorm:"NOT NULL DEFAULT false"`
+		IsResolved   bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	type SecretScanPattern struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name        string             `xorm:"NOT NULL"`
+		Pattern     string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	return x.Sync(new(SecretScanAlert), new(SecretScanPattern))
+}
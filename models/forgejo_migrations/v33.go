@@ -0,0 +1,26 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRepoDependencyTable(x *xorm.Engine) error {
+	type RepoDependency struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"INDEX NOT NULL"`
+		CommitID    string `xorm:"VARCHAR(64)"`
+		Manifest    string `xorm:"TEXT"`
+		Ecosystem   string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		Name        string `xorm:"NOT NULL"`
+		Version     string
+		IsDev       bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	return x.Sync(new(RepoDependency))
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueCustomFieldTables(x *xorm.Engine) error {
+	type IssueCustomField struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name        string             `xorm:"NOT NULL"`
+		Description string             `xorm:"TEXT"`
+		Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+		Options     string             `xorm:"TEXT"`
+		Sort        int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+
+	type IssueCustomFieldValue struct {
+		ID      int64  `xorm:"pk autoincr"`
+		IssueID int64  `xorm:"INDEX NOT NULL"`
+		FieldID int64  `xorm:"INDEX NOT NULL"`
+		Value   string `xorm:"VARCHAR(255) NOT NULL"`
+	}
+
+	return x.Sync(new(IssueCustomField), new(IssueCustomFieldValue))
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSavedSearchTables(x *xorm.Engine) error {
+	type SavedSearch struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"INDEX NOT NULL"`
+		OrgID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		TeamID      int64              `xorm:"NOT NULL DEFAULT 0"`
+		IsShared    bool               `xorm:"NOT NULL DEFAULT false"`
+		IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+		Name        string             `xorm:"NOT NULL"`
+		Query       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+
+	type SavedSearchSubscription struct {
+		ID              int64              `xorm:"pk autoincr"`
+		SavedSearchID   int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID          int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Mode            int                `xorm:"NOT NULL DEFAULT 0"`
+		LastCheckedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(SavedSearch), new(SavedSearchSubscription))
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddIssueRedirectTable(x *xorm.Engine) error {
+	type IssueRedirect struct {
+		ID        int64 `xorm:"pk autoincr"`
+		OldRepoID int64 `xorm:"UNIQUE(s) NOT NULL"`
+		OldIndex  int64 `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID   int64 `xorm:"INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(IssueRedirect))
+}
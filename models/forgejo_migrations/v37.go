@@ -0,0 +1,29 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueFormValuesTable(x *xorm.Engine) error {
+	type IssueFormFieldValue struct {
+		ID     string   `json:"id"`
+		Type   string   `json:"type"`
+		Label  string   `json:"label"`
+		Values []string `json:"values"`
+	}
+
+	type IssueFormValues struct {
+		ID          int64                  `xorm:"pk autoincr"`
+		IssueID     int64                  `xorm:"UNIQUE NOT NULL"`
+		Template    string                 `xorm:"TEXT"`
+		Fields      []*IssueFormFieldValue `xorm:"JSON LONGTEXT"`
+		CreatedUnix timeutil.TimeStamp     `xorm:"created"`
+	}
+
+	return x.Sync(new(IssueFormValues))
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type projectAutomation struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	Event     string `xorm:"VARCHAR(32) NOT NULL"`
+	LabelID   int64  `xorm:"NOT NULL DEFAULT 0"`
+	ColumnID  int64  `xorm:"INDEX NOT NULL"`
+	CreatorID int64  `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (projectAutomation) TableName() string {
+	return "project_automation"
+}
+
+func AddProjectAutomationTable(x *xorm.Engine) error {
+	return x.Sync(new(projectAutomation))
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type repoIncomingEmail struct {
+	ID                int64  `xorm:"pk autoincr"`
+	RepoID            int64  `xorm:"UNIQUE NOT NULL"`
+	Token             string `xorm:"VARCHAR(32) UNIQUE NOT NULL"`
+	EnableServiceDesk bool   `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (repoIncomingEmail) TableName() string {
+	return "repo_incoming_email"
+}
+
+type issueExternalReporter struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Email       string             `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL DEFAULT ''"`
+	Token       string             `xorm:"VARCHAR(32) UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func (issueExternalReporter) TableName() string {
+	return "issue_external_reporter"
+}
+
+func AddIncomingEmailTables(x *xorm.Engine) error {
+	return x.Sync(new(repoIncomingEmail), new(issueExternalReporter))
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddViewedBlobsColumnToReviewStateTable(x *xorm.Engine) error {
+	type ReviewState struct {
+		ID          int64             `xorm:"pk autoincr"`
+		ViewedBlobs map[string]string `xorm:"LONGTEXT JSON"`
+	}
+	return x.Sync(new(ReviewState))
+}
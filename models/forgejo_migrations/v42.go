@@ -0,0 +1,19 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddReviewRulesColumnToProtectedBranchTable(x *xorm.Engine) error {
+	type ReviewRule struct {
+		FilePatterns      string  `json:"file_patterns"`
+		RequiredApprovals int64   `json:"required_approvals"`
+		TeamIDs           []int64 `json:"team_ids"`
+	}
+	type ProtectedBranch struct {
+		ID          int64         `xorm:"pk autoincr"`
+		ReviewRules []*ReviewRule `xorm:"JSON TEXT"`
+	}
+	return x.Sync(new(ProtectedBranch))
+}
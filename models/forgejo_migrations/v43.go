@@ -0,0 +1,24 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddReviewAssignmentToTeamTable(x *xorm.Engine) error {
+	type Team struct {
+		ID                    int64 `xorm:"pk autoincr"`
+		ReviewAssignmentMode  int   `xorm:"NOT NULL DEFAULT 0"`
+		ReviewAssignmentCount int   `xorm:"NOT NULL DEFAULT 1"`
+		LastReviewAssigneeID  int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+	if err := x.Sync(new(Team)); err != nil {
+		return err
+	}
+
+	type TeamUser struct {
+		ID                  int64 `xorm:"pk autoincr"`
+		IsReviewUnavailable bool  `xorm:"NOT NULL DEFAULT false"`
+	}
+	return x.Sync(new(TeamUser))
+}
@@ -0,0 +1,23 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import "xorm.io/xorm"
+
+func AddChecksumsToReleaseAssets(x *xorm.Engine) error {
+	type Attachment struct {
+		ID     int64  `xorm:"pk autoincr"`
+		SHA256 string `xorm:"VARCHAR(64)"`
+	}
+	if err := x.Sync(new(Attachment)); err != nil {
+		return err
+	}
+
+	type Release struct {
+		ID                 int64  `xorm:"pk autoincr"`
+		SignedChecksums    string `xorm:"TEXT"`
+		ChecksumsSignature string `xorm:"TEXT"`
+	}
+	return x.Sync(new(Release))
+}
@@ -38,27 +38,28 @@ type ProtectedBranch struct {
 	isPlainName                   bool                   `xorm:"-"`
 	CanPush                       bool                   `xorm:"NOT NULL DEFAULT false"`
 	EnableWhitelist               bool
-	WhitelistUserIDs              []int64  `xorm:"JSON TEXT"`
-	WhitelistTeamIDs              []int64  `xorm:"JSON TEXT"`
-	EnableMergeWhitelist          bool     `xorm:"NOT NULL DEFAULT false"`
-	WhitelistDeployKeys           bool     `xorm:"NOT NULL DEFAULT false"`
-	MergeWhitelistUserIDs         []int64  `xorm:"JSON TEXT"`
-	MergeWhitelistTeamIDs         []int64  `xorm:"JSON TEXT"`
-	EnableStatusCheck             bool     `xorm:"NOT NULL DEFAULT false"`
-	StatusCheckContexts           []string `xorm:"JSON TEXT"`
-	EnableApprovalsWhitelist      bool     `xorm:"NOT NULL DEFAULT false"`
-	ApprovalsWhitelistUserIDs     []int64  `xorm:"JSON TEXT"`
-	ApprovalsWhitelistTeamIDs     []int64  `xorm:"JSON TEXT"`
-	RequiredApprovals             int64    `xorm:"NOT NULL DEFAULT 0"`
-	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
-	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
-	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
-	IgnoreStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
-	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
-	ProtectedFilePatterns         string   `xorm:"TEXT"`
-	UnprotectedFilePatterns       string   `xorm:"TEXT"`
-	ApplyToAdmins                 bool     `xorm:"NOT NULL DEFAULT false"`
+	WhitelistUserIDs              []int64       `xorm:"JSON TEXT"`
+	WhitelistTeamIDs              []int64       `xorm:"JSON TEXT"`
+	EnableMergeWhitelist          bool          `xorm:"NOT NULL DEFAULT false"`
+	WhitelistDeployKeys           bool          `xorm:"NOT NULL DEFAULT false"`
+	MergeWhitelistUserIDs         []int64       `xorm:"JSON TEXT"`
+	MergeWhitelistTeamIDs         []int64       `xorm:"JSON TEXT"`
+	EnableStatusCheck             bool          `xorm:"NOT NULL DEFAULT false"`
+	StatusCheckContexts           []string      `xorm:"JSON TEXT"`
+	EnableApprovalsWhitelist      bool          `xorm:"NOT NULL DEFAULT false"`
+	ApprovalsWhitelistUserIDs     []int64       `xorm:"JSON TEXT"`
+	ApprovalsWhitelistTeamIDs     []int64       `xorm:"JSON TEXT"`
+	RequiredApprovals             int64         `xorm:"NOT NULL DEFAULT 0"`
+	BlockOnRejectedReviews        bool          `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOfficialReviewRequests bool          `xorm:"NOT NULL DEFAULT false"`
+	BlockOnOutdatedBranch         bool          `xorm:"NOT NULL DEFAULT false"`
+	DismissStaleApprovals         bool          `xorm:"NOT NULL DEFAULT false"`
+	IgnoreStaleApprovals          bool          `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits          bool          `xorm:"NOT NULL DEFAULT false"`
+	ProtectedFilePatterns         string        `xorm:"TEXT"`
+	UnprotectedFilePatterns       string        `xorm:"TEXT"`
+	ApplyToAdmins                 bool          `xorm:"NOT NULL DEFAULT false"`
+	ReviewRules                   []*ReviewRule `xorm:"JSON TEXT"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -226,6 +227,71 @@ func getFilePatterns(filePatterns string) []glob.Glob {
 	return extarr
 }
 
+// ReviewRule requires approvals for the changes of a pull request to the files matching its patterns,
+// in addition to the required approvals of the protected branch
+type ReviewRule struct {
+	// FilePatterns is a semicolon separated list of glob patterns, like the protected file patterns
+	FilePatterns      string `json:"file_patterns"`
+	RequiredApprovals int64  `json:"required_approvals"`
+	// TeamIDs are the teams whose members can approve the changes. If empty, anyone with write access can.
+	TeamIDs []int64 `json:"team_ids"`
+}
+
+// GetFilePatterns parses the file patterns of the rule and returns a glob.Glob slice
+func (rule *ReviewRule) GetFilePatterns() []glob.Glob {
+	return getFilePatterns(rule.FilePatterns)
+}
+
+// CheckFilePatterns returns an error if one of the file patterns of the rule is not a valid glob pattern
+func (rule *ReviewRule) CheckFilePatterns() error {
+	for _, expr := range strings.Split(strings.ToLower(rule.FilePatterns), ";") {
+		if _, err := glob.Compile(strings.TrimSpace(expr), '.', '/'); err != nil {
+			return fmt.Errorf("invalid file pattern %q: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+// ErrReviewRuleTeamNoAccess represents an error that a team of a review rule has no access to the repository
+type ErrReviewRuleTeamNoAccess struct {
+	FilePatterns string
+	TeamID       int64
+}
+
+// IsErrReviewRuleTeamNoAccess checks if an error is an ErrReviewRuleTeamNoAccess.
+func IsErrReviewRuleTeamNoAccess(err error) bool {
+	_, ok := err.(ErrReviewRuleTeamNoAccess)
+	return ok
+}
+
+func (err ErrReviewRuleTeamNoAccess) Error() string {
+	return fmt.Sprintf("team of review rule has no access to the repository [file_patterns: %s, team_id: %d]", err.FilePatterns, err.TeamID)
+}
+
+func (err ErrReviewRuleTeamNoAccess) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// MatchFiles returns the files which are matched by the patterns of the rule
+func (rule *ReviewRule) MatchFiles(files []string) []string {
+	patterns := rule.GetFilePatterns()
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var matched []string
+	for _, file := range files {
+		lpath := strings.ToLower(strings.TrimSpace(file))
+		for _, pat := range patterns {
+			if pat.Match(lpath) {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
+
 // MergeBlockedByProtectedFiles returns true if merge is blocked by protected files change
 func (protectBranch *ProtectedBranch) MergeBlockedByProtectedFiles(changedProtectedFiles []string) bool {
 	glob := protectBranch.GetProtectedFilePatterns()
@@ -366,6 +432,20 @@ func UpdateProtectBranch(ctx context.Context, repo *repo_model.Repository, prote
 	}
 	protectBranch.ApprovalsWhitelistTeamIDs = whitelist
 
+	// unlike the whitelists, the teams of a review rule are not dropped silently: a rule without
+	// teams could be approved by anyone with write access
+	for _, rule := range protectBranch.ReviewRules {
+		whitelist, err = updateTeamWhitelist(ctx, repo, nil, rule.TeamIDs)
+		if err != nil {
+			return err
+		}
+		for _, teamID := range rule.TeamIDs {
+			if !slices.Contains(whitelist, teamID) {
+				return ErrReviewRuleTeamNoAccess{FilePatterns: rule.FilePatterns, TeamID: teamID}
+			}
+		}
+	}
+
 	// Make sure protectBranch.ID is not 0 for whitelists
 	if protectBranch.ID == 0 {
 		if _, err = db.GetEngine(ctx).Insert(protectBranch); err != nil {
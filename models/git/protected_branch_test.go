@@ -6,7 +6,12 @@ package git
 import (
 	"testing"
 
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBranchRuleMatch(t *testing.T) {
@@ -67,3 +72,46 @@ func TestBranchRuleMatch(t *testing.T) {
 		assert.Equal(t, kase.ExpectedMatch, pb.Match(kase.BranchName), "%s - %s", kase.BranchName, kase.Rule)
 	}
 }
+
+func TestReviewRuleMatchFiles(t *testing.T) {
+	files := []string{"deploy/prod/values.yaml", "docs/index.md", "Deploy/README.md", "main.go"}
+
+	rule := &ReviewRule{FilePatterns: "deploy/**", RequiredApprovals: 2}
+	assert.Equal(t, []string{"deploy/prod/values.yaml", "Deploy/README.md"}, rule.MatchFiles(files))
+
+	rule = &ReviewRule{FilePatterns: "docs/**; *.go", RequiredApprovals: 1}
+	assert.Equal(t, []string{"docs/index.md", "main.go"}, rule.MatchFiles(files))
+
+	rule = &ReviewRule{FilePatterns: "vendor/**", RequiredApprovals: 1}
+	assert.Empty(t, rule.MatchFiles(files))
+
+	rule = &ReviewRule{RequiredApprovals: 1}
+	assert.Empty(t, rule.MatchFiles(files))
+}
+
+func TestReviewRuleCheckFilePatterns(t *testing.T) {
+	require.NoError(t, (&ReviewRule{FilePatterns: "deploy/**; *.go"}).CheckFilePatterns())
+	require.Error(t, (&ReviewRule{FilePatterns: "docs/**; deploy/[prod"}).CheckFilePatterns())
+}
+
+func TestUpdateProtectBranchReviewRuleTeams(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// teams 1 and 2 of org 3 have access to repo 3, team 7 has not
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	pb := &ProtectedBranch{
+		RepoID:      repo.ID,
+		RuleName:    "master",
+		ReviewRules: []*ReviewRule{{FilePatterns: "deploy/**", RequiredApprovals: 1, TeamIDs: []int64{2, 7}}},
+	}
+	err := UpdateProtectBranch(db.DefaultContext, repo, pb, WhitelistOptions{})
+	require.Error(t, err)
+	assert.True(t, IsErrReviewRuleTeamNoAccess(err))
+	assert.Equal(t, ErrReviewRuleTeamNoAccess{FilePatterns: "deploy/**", TeamID: 7}, err)
+	unittest.AssertNotExistsBean(t, &ProtectedBranch{RepoID: repo.ID, RuleName: "master"})
+
+	pb.ReviewRules[0].TeamIDs = []int64{2}
+	require.NoError(t, UpdateProtectBranch(db.DefaultContext, repo, pb, WhitelistOptions{}))
+	pb = unittest.AssertExistsAndLoadBean(t, &ProtectedBranch{RepoID: repo.ID, RuleName: "master"})
+	assert.Equal(t, []int64{2}, pb.ReviewRules[0].TeamIDs)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/gobwas/glob"
+)
+
+// PushPolicy holds the declarative rules pushes to a repository are checked
+// against. A policy belongs either to a repository (RepoID is set) or to an
+// owner (only OwnerID is set); the owner's policy applies to all of its
+// repositories, in addition to their own policies.
+type PushPolicy struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+	RepoID  int64 `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+
+	// CommitMessagePattern is a regular expression every commit message must match.
+	CommitMessagePattern string `xorm:"TEXT"`
+	// AuthorEmailDomains is a semicolon separated list of allowed author email domains.
+	AuthorEmailDomains string `xorm:"TEXT"`
+	// MaxFileSize is the maximum size of a pushed file in bytes, 0 means unlimited.
+	MaxFileSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ForbiddenFilePatterns is a semicolon separated list of glob patterns of paths that must not be pushed.
+	ForbiddenFilePatterns string `xorm:"TEXT"`
+	// ForbiddenExtensions is a semicolon separated list of file extensions that must not be pushed.
+	ForbiddenExtensions string `xorm:"TEXT"`
+	DetectSecrets       bool   `xorm:"NOT NULL DEFAULT false"`
+	RequireSignOff      bool   `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	commitMessageRegexp *regexp.Regexp `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(PushPolicy))
+}
+
+// IsEmpty returns true if the policy does not contain any rule
+func (p *PushPolicy) IsEmpty() bool {
+	return p.CommitMessagePattern == "" && p.AuthorEmailDomains == "" && p.MaxFileSize <= 0 &&
+		p.ForbiddenFilePatterns == "" && p.ForbiddenExtensions == "" && !p.DetectSecrets && !p.RequireSignOff
+}
+
+// IsInherited returns true if the policy belongs to the owner of a repository rather than to the repository itself
+func (p *PushPolicy) IsInherited() bool {
+	return p.RepoID == 0
+}
+
+// GetCommitMessageRegexp compiles the commit message pattern, it returns nil if there is none
+func (p *PushPolicy) GetCommitMessageRegexp() (*regexp.Regexp, error) {
+	if p.CommitMessagePattern == "" || p.commitMessageRegexp != nil {
+		return p.commitMessageRegexp, nil
+	}
+	re, err := regexp.Compile(p.CommitMessagePattern)
+	if err != nil {
+		return nil, err
+	}
+	p.commitMessageRegexp = re
+	return re, nil
+}
+
+// GetAuthorEmailDomains returns the lower-cased allowed author email domains
+func (p *PushPolicy) GetAuthorEmailDomains() []string {
+	return splitPolicyList(p.AuthorEmailDomains, "@")
+}
+
+// GetForbiddenFilePatterns parses the forbidden file patterns and returns a glob.Glob slice
+func (p *PushPolicy) GetForbiddenFilePatterns() []glob.Glob {
+	return getFilePatterns(p.ForbiddenFilePatterns)
+}
+
+// GetForbiddenExtensions returns the lower-cased forbidden file extensions, each starting with a dot
+func (p *PushPolicy) GetForbiddenExtensions() []string {
+	exts := splitPolicyList(p.ForbiddenExtensions, ".")
+	for i := range exts {
+		exts[i] = "." + exts[i]
+	}
+	return exts
+}
+
+func splitPolicyList(list, trimPrefix string) []string {
+	var items []string
+	for _, item := range strings.Split(strings.ToLower(list), ";") {
+		item = strings.TrimPrefix(strings.TrimSpace(item), trimPrefix)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetPushPolicy returns the policy set on the given owner or repository, or nil if there is none
+func GetPushPolicy(ctx context.Context, ownerID, repoID int64) (*PushPolicy, error) {
+	p := new(PushPolicy)
+	has, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=?", ownerID, repoID).Get(p)
+	if err != nil || !has {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetEffectivePushPolicies returns the non-empty policies that apply to pushes to a
+// repository: the policy of its owner followed by its own policy. A push has to comply
+// with all of them, so the policy of a repository cannot loosen the one of its owner.
+func GetEffectivePushPolicies(ctx context.Context, ownerID, repoID int64) ([]*PushPolicy, error) {
+	var policies []*PushPolicy
+	for _, ids := range [][2]int64{{ownerID, 0}, {0, repoID}} {
+		p, err := GetPushPolicy(ctx, ids[0], ids[1])
+		if err != nil {
+			return nil, err
+		}
+		if p != nil && !p.IsEmpty() {
+			policies = append(policies, p)
+		}
+	}
+	return policies, nil
+}
+
+// SetPushPolicy creates or updates the policy of the owner or repository the policy belongs to
+func SetPushPolicy(ctx context.Context, p *PushPolicy) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetPushPolicy(ctx, p.OwnerID, p.RepoID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			_, err = db.GetEngine(ctx).Insert(p)
+			return err
+		}
+		p.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(p.ID).AllCols().Update(p)
+		return err
+	})
+}
+
+// DeletePushPolicy deletes the policy of an owner or repository
+func DeletePushPolicy(ctx context.Context, ownerID, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=?", ownerID, repoID).Delete(&PushPolicy{})
+	return err
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushPolicy(t *testing.T) {
+	p := &git_model.PushPolicy{}
+	assert.True(t, p.IsEmpty())
+
+	p = &git_model.PushPolicy{
+		AuthorEmailDomains:  " @Example.com; example.org;",
+		ForbiddenExtensions: "exe; .JAR",
+	}
+	assert.False(t, p.IsEmpty())
+	assert.Equal(t, []string{"example.com", "example.org"}, p.GetAuthorEmailDomains())
+	assert.Equal(t, []string{".exe", ".jar"}, p.GetForbiddenExtensions())
+}
+
+func TestGetEffectivePushPolicies(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo 3 belongs to org 3
+	policies, err := git_model.GetEffectivePushPolicies(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	require.NoError(t, git_model.SetPushPolicy(db.DefaultContext, &git_model.PushPolicy{OwnerID: 3, RequireSignOff: true}))
+	policies, err = git_model.GetEffectivePushPolicies(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.True(t, policies[0].IsInherited())
+	assert.True(t, policies[0].RequireSignOff)
+
+	// the policy of the repository applies in addition to the one of the organization
+	require.NoError(t, git_model.SetPushPolicy(db.DefaultContext, &git_model.PushPolicy{RepoID: 3, DetectSecrets: true}))
+	require.NoError(t, git_model.SetPushPolicy(db.DefaultContext, &git_model.PushPolicy{RepoID: 3, MaxFileSize: 1024}))
+	policies, err = git_model.GetEffectivePushPolicies(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.True(t, policies[0].IsInherited())
+	assert.True(t, policies[0].RequireSignOff)
+	assert.False(t, policies[1].IsInherited())
+	assert.False(t, policies[1].RequireSignOff)
+	assert.False(t, policies[1].DetectSecrets)
+	assert.EqualValues(t, 1024, policies[1].MaxFileSize)
+	unittest.AssertCount(t, &git_model.PushPolicy{RepoID: 3}, 1)
+
+	// an empty policy is ignored
+	require.NoError(t, git_model.SetPushPolicy(db.DefaultContext, &git_model.PushPolicy{OwnerID: 3}))
+	policies, err = git_model.GetEffectivePushPolicies(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.False(t, policies[0].IsInherited())
+
+	require.NoError(t, git_model.DeletePushPolicy(db.DefaultContext, 0, 3))
+	policies, err = git_model.GetEffectivePushPolicies(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
@@ -116,6 +116,13 @@ const (
 	CommentTypeUnpin // 37 unpin Issue
 
 	CommentTypeAggregator // 38 Aggregator of comments
+
+	CommentTypeIssueTransfer // 39 Issue transferred from another repository
+
+	CommentTypeAddSubIssue       // 40 Sub-issue added
+	CommentTypeRemoveSubIssue    // 41 Sub-issue removed
+	CommentTypeAddParentIssue    // 42 Added as a sub-issue of a parent
+	CommentTypeRemoveParentIssue // 43 Removed from the sub-issues of a parent
 )
 
 var commentStrings = []string{
@@ -158,6 +165,11 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"action_aggregator",
+	"issue_transfer",
+	"add_sub_issue",
+	"remove_sub_issue",
+	"add_parent_issue",
+	"remove_parent_issue",
 }
 
 func (t CommentType) String() string {
@@ -197,6 +209,15 @@ func (t CommentType) HasMailReplySupport() bool {
 	return false
 }
 
+// IsSubIssue returns true for the comment types recording the change of a parent-child relation
+func (t CommentType) IsSubIssue() bool {
+	switch t {
+	case CommentTypeAddSubIssue, CommentTypeRemoveSubIssue, CommentTypeAddParentIssue, CommentTypeRemoveParentIssue:
+		return true
+	}
+	return false
+}
+
 func (t CommentType) CountedAsConversation() bool {
 	for _, ct := range ConversationCountedCommentType() {
 		if t == ct {
@@ -850,6 +871,7 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 		RefIsPull:        opts.RefIsPull,
 		IsForcePush:      opts.IsForcePush,
 		Invalidated:      opts.Invalidated,
+		OriginalAuthor:   opts.OriginalAuthor,
 	}
 	if opts.Issue.NoAutoTime {
 		// Preload the comment with the Issue containing the forced update
@@ -1038,6 +1060,8 @@ type CreateCommentOptions struct {
 	RefIsPull        bool
 	IsForcePush      bool
 	Invalidated      bool
+	// OriginalAuthor is the name of the author without account the comment is posted on behalf of
+	OriginalAuthor string
 }
 
 // GetCommentByID returns the comment by given ID.
@@ -0,0 +1,89 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"regexp"
+	"strings"
+)
+
+// suggestionBlockRegex matches the ```suggestion blocks of a comment, the content of a block replaces the commented line
+var suggestionBlockRegex = regexp.MustCompile("(?ms)^```suggestion[ \\t]*\\r?\\n(.*?)^```[ \\t]*\\r?$")
+
+// ParseCodeSuggestions returns the contents of the suggestion blocks of a comment, without their final line break.
+// An empty suggestion removes the commented line.
+func ParseCodeSuggestions(content string) []string {
+	matches := suggestionBlockRegex.FindAllStringSubmatch(content, -1)
+	suggestions := make([]string, 0, len(matches))
+	for _, match := range matches {
+		suggestion := strings.TrimSuffix(match[1], "\n")
+		suggestions = append(suggestions, strings.TrimSuffix(suggestion, "\r"))
+	}
+	return suggestions
+}
+
+// CodeSuggestion returns the replacement of the commented line proposed by a code comment,
+// false if it isn't a comment on a line of the head of the pull request or doesn't have exactly one suggestion
+func (c *Comment) CodeSuggestion() (string, bool) {
+	if c.Type != CommentTypeCode || c.Line <= 0 {
+		return "", false
+	}
+	suggestions := ParseCodeSuggestions(c.Content)
+	if len(suggestions) != 1 {
+		return "", false
+	}
+	return suggestions[0], true
+}
+
+// HasCodeSuggestion returns true if the code comment proposes a replacement of the commented line
+func (c *Comment) HasCodeSuggestion() bool {
+	_, ok := c.CodeSuggestion()
+	return ok
+}
+
+// CommentedLine returns the content of the commented line, which ends the patch of a code comment,
+// false if it is unknown
+func (c *Comment) CommentedLine() (string, bool) {
+	lines := strings.Split(strings.TrimRight(c.Patch, "\n"), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSuffix(lines[i], "\r")
+		if line == "" || line[0] == '\\' {
+			// "\ No newline at end of file"
+			continue
+		}
+		if line[0] == '@' || (line[0] == '-' && c.Line > 0) || (line[0] == '+' && c.Line < 0) {
+			return "", false
+		}
+		return line[1:], true
+	}
+	return "", false
+}
+
+// RenderableContent returns the content of a code comment with its suggestion blocks turned into diffs
+// of the commented line, so that they are rendered as such
+func (c *Comment) RenderableContent() string {
+	if c.Type != CommentTypeCode || c.Line <= 0 {
+		return c.Content
+	}
+	line, ok := c.CommentedLine()
+	if !ok {
+		return c.Content
+	}
+	return suggestionBlockRegex.ReplaceAllStringFunc(c.Content, func(block string) string {
+		suggestion := ParseCodeSuggestions(block)[0]
+		var diff strings.Builder
+		diff.WriteString("```diff\n-")
+		diff.WriteString(line)
+		diff.WriteString("\n")
+		if suggestion != "" {
+			for _, l := range strings.Split(suggestion, "\n") {
+				diff.WriteString("+")
+				diff.WriteString(strings.TrimSuffix(l, "\r"))
+				diff.WriteString("\n")
+			}
+		}
+		diff.WriteString("```")
+		return diff.String()
+	})
+}
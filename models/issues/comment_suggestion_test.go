@@ -0,0 +1,67 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "forgejo.org/models/issues"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeSuggestions(t *testing.T) {
+	assert.Empty(t, issues_model.ParseCodeSuggestions("no suggestion\n```go\nfoo()\n```"))
+	assert.Equal(t, []string{"foo()\nbar()"}, issues_model.ParseCodeSuggestions("Better:\n```suggestion\nfoo()\nbar()\n```\n"))
+	assert.Equal(t, []string{""}, issues_model.ParseCodeSuggestions("```suggestion\r\n```"))
+	assert.Equal(t, []string{"a", "b"}, issues_model.ParseCodeSuggestions("```suggestion\na\n```\nor\n```suggestion\nb\n```"))
+	// not at the start of a line
+	assert.Empty(t, issues_model.ParseCodeSuggestions("see ```suggestion\na\n```"))
+}
+
+func TestCommentCodeSuggestion(t *testing.T) {
+	patch := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1,2 +1,2 @@\n # repo\n-old line\n+new line\n"
+	comment := &issues_model.Comment{
+		Type:    issues_model.CommentTypeCode,
+		Line:    2,
+		Patch:   patch,
+		Content: "Typo:\n```suggestion\nnew line!\n```",
+	}
+
+	suggestion, ok := comment.CodeSuggestion()
+	assert.True(t, ok)
+	assert.Equal(t, "new line!", suggestion)
+	assert.True(t, comment.HasCodeSuggestion())
+
+	line, ok := comment.CommentedLine()
+	assert.True(t, ok)
+	assert.Equal(t, "new line", line)
+
+	assert.Equal(t, "Typo:\n```diff\n-new line\n+new line!\n```", comment.RenderableContent())
+
+	t.Run("Removed line", func(t *testing.T) {
+		comment := &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: -2, Patch: patch, Content: comment.Content}
+		assert.False(t, comment.HasCodeSuggestion())
+		assert.Equal(t, comment.Content, comment.RenderableContent())
+	})
+
+	t.Run("Several suggestions", func(t *testing.T) {
+		comment := &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: 2, Patch: patch, Content: "```suggestion\na\n```\n```suggestion\nb\n```"}
+		assert.False(t, comment.HasCodeSuggestion())
+	})
+
+	t.Run("Not a code comment", func(t *testing.T) {
+		comment := &issues_model.Comment{Type: issues_model.CommentTypeComment, Content: comment.Content}
+		assert.False(t, comment.HasCodeSuggestion())
+		assert.Equal(t, comment.Content, comment.RenderableContent())
+	})
+
+	t.Run("Line removal", func(t *testing.T) {
+		comment := &issues_model.Comment{Type: issues_model.CommentTypeCode, Line: 2, Patch: patch + "\\ No newline at end of file\n", Content: "```suggestion\n```"}
+		suggestion, ok := comment.CodeSuggestion()
+		assert.True(t, ok)
+		assert.Empty(t, suggestion)
+		assert.Equal(t, "```diff\n-new line\n```", comment.RenderableContent())
+	})
+}
@@ -0,0 +1,439 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// IssueCustomFieldType is the type of the values of a custom issue field
+type IssueCustomFieldType string
+
+const (
+	IssueCustomFieldTypeText        IssueCustomFieldType = "text"
+	IssueCustomFieldTypeNumber      IssueCustomFieldType = "number"
+	IssueCustomFieldTypeDate        IssueCustomFieldType = "date"
+	IssueCustomFieldTypeSelect      IssueCustomFieldType = "select"
+	IssueCustomFieldTypeMultiSelect IssueCustomFieldType = "multiselect"
+	IssueCustomFieldTypeUser        IssueCustomFieldType = "user"
+)
+
+// IssueCustomFieldTypes lists all the types of custom issue fields
+var IssueCustomFieldTypes = []IssueCustomFieldType{
+	IssueCustomFieldTypeText,
+	IssueCustomFieldTypeNumber,
+	IssueCustomFieldTypeDate,
+	IssueCustomFieldTypeSelect,
+	IssueCustomFieldTypeMultiSelect,
+	IssueCustomFieldTypeUser,
+}
+
+// IsValid returns true if the type is known
+func (t IssueCustomFieldType) IsValid() bool {
+	return slices.Contains(IssueCustomFieldTypes, t)
+}
+
+// HasOptions returns true if the values must be chosen from the options of the field
+func (t IssueCustomFieldType) HasOptions() bool {
+	return t == IssueCustomFieldTypeSelect || t == IssueCustomFieldTypeMultiSelect
+}
+
+// IssueCustomFieldMaxValueLength is the maximum length of a single value
+const IssueCustomFieldMaxValueLength = 255
+
+// ErrIssueCustomFieldNotExist represents a "IssueCustomFieldNotExist" kind of error.
+type ErrIssueCustomFieldNotExist struct {
+	ID   int64
+	Name string
+}
+
+// IsErrIssueCustomFieldNotExist checks if an error is a ErrIssueCustomFieldNotExist.
+func IsErrIssueCustomFieldNotExist(err error) bool {
+	_, ok := err.(ErrIssueCustomFieldNotExist)
+	return ok
+}
+
+func (err ErrIssueCustomFieldNotExist) Error() string {
+	return fmt.Sprintf("custom issue field does not exist [id: %d, name: %s]", err.ID, err.Name)
+}
+
+func (err ErrIssueCustomFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrInvalidIssueCustomFieldValue represents a value which is not valid for the type or options of a field
+type ErrInvalidIssueCustomFieldValue struct {
+	Field string
+	Value string
+}
+
+// IsErrInvalidIssueCustomFieldValue checks if an error is a ErrInvalidIssueCustomFieldValue.
+func IsErrInvalidIssueCustomFieldValue(err error) bool {
+	_, ok := err.(ErrInvalidIssueCustomFieldValue)
+	return ok
+}
+
+func (err ErrInvalidIssueCustomFieldValue) Error() string {
+	return fmt.Sprintf("invalid value for custom issue field %q: %q", err.Field, err.Value)
+}
+
+func (err ErrInvalidIssueCustomFieldValue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// IssueCustomField is a field defined by the administrators of a repository or organization
+// which can be set on the issues of the repository, or of all the repositories of the organization
+type IssueCustomField struct {
+	ID          int64                `xorm:"pk autoincr"`
+	OwnerID     int64                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string               `xorm:"NOT NULL"`
+	Description string               `xorm:"TEXT"`
+	Type        IssueCustomFieldType `xorm:"VARCHAR(20) NOT NULL"`
+	Options     string               `xorm:"TEXT"` // the choices of select fields, one per line
+	Sort        int64                `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp   `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp   `xorm:"INDEX updated"`
+}
+
+// IssueCustomFieldValue is a value of a custom field set on an issue,
+// fields which accept multiple values have one record per value
+type IssueCustomFieldValue struct {
+	ID      int64  `xorm:"pk autoincr"`
+	IssueID int64  `xorm:"INDEX NOT NULL"`
+	FieldID int64  `xorm:"INDEX NOT NULL"`
+	Value   string `xorm:"VARCHAR(255) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueCustomField))
+	db.RegisterModel(new(IssueCustomFieldValue))
+}
+
+// BelongsToOrg returns true if the field is defined by an organization
+func (f *IssueCustomField) BelongsToOrg() bool {
+	return f.OwnerID > 0
+}
+
+// GetOptions returns the choices of a select field
+func (f *IssueCustomField) GetOptions() []string {
+	var options []string
+	for _, option := range strings.Split(f.Options, "\n") {
+		if option = strings.TrimSpace(option); option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// NormalizeValues validates the values given for the field and returns them in their canonical form.
+// Empty values are dropped, so an empty result clears the field.
+// Values of user fields must be user IDs.
+func (f *IssueCustomField) NormalizeValues(values []string) ([]string, error) {
+	result := make([]string, 0, len(values))
+	seen := make(container.Set[string], len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		normalized, err := f.normalizeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen.Add(normalized) {
+			result = append(result, normalized)
+		}
+	}
+	if len(result) > 1 && f.Type != IssueCustomFieldTypeMultiSelect {
+		return nil, ErrInvalidIssueCustomFieldValue{Field: f.Name, Value: strings.Join(result, ", ")}
+	}
+	return result, nil
+}
+
+func (f *IssueCustomField) normalizeValue(value string) (string, error) {
+	invalid := ErrInvalidIssueCustomFieldValue{Field: f.Name, Value: value}
+	switch f.Type {
+	case IssueCustomFieldTypeText:
+		if len(value) > IssueCustomFieldMaxValueLength {
+			return "", invalid
+		}
+		return value, nil
+	case IssueCustomFieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", invalid
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case IssueCustomFieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return "", invalid
+		}
+		return value, nil
+	case IssueCustomFieldTypeSelect, IssueCustomFieldTypeMultiSelect:
+		for _, option := range f.GetOptions() {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		return "", invalid
+	case IssueCustomFieldTypeUser:
+		if id, err := strconv.ParseInt(value, 10, 64); err != nil || id <= 0 {
+			return "", invalid
+		}
+		return value, nil
+	}
+	return "", invalid
+}
+
+// NewIssueCustomField creates a custom issue field
+func NewIssueCustomField(ctx context.Context, f *IssueCustomField) error {
+	return db.Insert(ctx, f)
+}
+
+// UpdateIssueCustomField updates the definition of a custom issue field
+func UpdateIssueCustomField(ctx context.Context, f *IssueCustomField) error {
+	_, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "description", "options", "sort").Update(f)
+	return err
+}
+
+// DeleteIssueCustomField deletes a custom issue field and its values, and returns the IDs of the issues
+// which had a value for the field
+func DeleteIssueCustomField(ctx context.Context, f *IssueCustomField) ([]int64, error) {
+	var issueIDs []int64
+	return issueIDs, db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.GetEngine(ctx).Table("issue_custom_field_value").Where("field_id = ?", f.ID).
+			Distinct("issue_id").Find(&issueIDs); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", f.ID).Delete(&IssueCustomFieldValue{}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[IssueCustomField](ctx, f.ID)
+		return err
+	})
+}
+
+// GetIssueCustomFieldByID returns the custom issue field of a repository or organization
+func GetIssueCustomFieldByID(ctx context.Context, ownerID, repoID, id int64) (*IssueCustomField, error) {
+	f := &IssueCustomField{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ? AND repo_id = ?", id, ownerID, repoID).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueCustomFieldNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// FindIssueCustomFieldsOptions represents the options to find custom issue fields.
+// If both OwnerID and RepoID are set, the fields of the organization and of the repository are returned.
+type FindIssueCustomFieldsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+}
+
+func (opts FindIssueCustomFieldsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.Or(builder.Eq{"owner_id": opts.OwnerID, "repo_id": 0})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.Or(builder.Eq{"repo_id": opts.RepoID})
+	}
+	return cond
+}
+
+func (opts FindIssueCustomFieldsOptions) ToOrders() string {
+	return "owner_id DESC, sort ASC, id ASC"
+}
+
+// GetIssueCustomFieldsForRepo returns the fields which can be set on the issues of a repository,
+// the fields of the organization come first
+func GetIssueCustomFieldsForRepo(ctx context.Context, ownerID, repoID int64) ([]*IssueCustomField, error) {
+	return db.Find[IssueCustomField](ctx, FindIssueCustomFieldsOptions{
+		ListOptions: db.ListOptionsAll,
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+	})
+}
+
+// GetIssueCustomFieldValues returns the values set on an issue, grouped by field ID
+func GetIssueCustomFieldValues(ctx context.Context, issueID int64) (map[int64][]string, error) {
+	values, err := GetIssueCustomFieldValuesByIssueIDs(ctx, []int64{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return values[issueID], nil
+}
+
+// GetIssueCustomFieldValuesByIssueIDs returns the values set on the issues, grouped by issue ID and field ID
+func GetIssueCustomFieldValuesByIssueIDs(ctx context.Context, issueIDs []int64) (map[int64]map[int64][]string, error) {
+	result := make(map[int64]map[int64][]string, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	var values []*IssueCustomFieldValue
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Asc("id").Find(&values); err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if result[v.IssueID] == nil {
+			result[v.IssueID] = make(map[int64][]string)
+		}
+		result[v.IssueID][v.FieldID] = append(result[v.IssueID][v.FieldID], v.Value)
+	}
+	return result, nil
+}
+
+// SetIssueCustomFieldValues replaces the values of a field on an issue, the values must be normalized
+func SetIssueCustomFieldValues(ctx context.Context, issueID int64, field *IssueCustomField, values []string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("issue_id = ? AND field_id = ?", issueID, field.ID).Delete(&IssueCustomFieldValue{}); err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		records := make([]*IssueCustomFieldValue, 0, len(values))
+		for _, value := range values {
+			records = append(records, &IssueCustomFieldValue{IssueID: issueID, FieldID: field.ID, Value: value})
+		}
+		_, err := db.GetEngine(ctx).Insert(&records)
+		return err
+	})
+}
+
+// IssueCustomFieldEntry is a field which can be set on an issue, along with the values of the issue
+type IssueCustomFieldEntry struct {
+	Field  *IssueCustomField
+	Values []string
+	Users  []*user_model.User // the users of a user field
+}
+
+// DisplayValues returns the values, with the names of the users for user fields
+func (e *IssueCustomFieldEntry) DisplayValues() []string {
+	if e.Field.Type != IssueCustomFieldTypeUser {
+		return e.Values
+	}
+	names := make([]string, 0, len(e.Users))
+	for _, u := range e.Users {
+		names = append(names, u.Name)
+	}
+	return names
+}
+
+// GetIssueCustomFieldEntries returns all the fields which can be set on the issue, along with their values
+func GetIssueCustomFieldEntries(ctx context.Context, issue *Issue) ([]*IssueCustomFieldEntry, error) {
+	entries, err := GetIssueCustomFieldEntriesByIssues(ctx, IssueList{issue})
+	if err != nil {
+		return nil, err
+	}
+	return entries[issue.ID], nil
+}
+
+// GetIssueCustomFieldEntriesByIssues returns the fields which can be set on the issues along with their values, grouped by issue ID
+func GetIssueCustomFieldEntriesByIssues(ctx context.Context, issues IssueList) (map[int64][]*IssueCustomFieldEntry, error) {
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	values, err := GetIssueCustomFieldValuesByIssueIDs(ctx, issues.getIssueIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	fieldsByRepo := make(map[int64][]*IssueCustomField)
+	userIDs := make(container.Set[int64])
+	result := make(map[int64][]*IssueCustomFieldEntry, len(issues))
+	for _, issue := range issues {
+		fields, ok := fieldsByRepo[issue.RepoID]
+		if !ok {
+			fields, err = GetIssueCustomFieldsForRepo(ctx, issue.Repo.OwnerID, issue.RepoID)
+			if err != nil {
+				return nil, err
+			}
+			fieldsByRepo[issue.RepoID] = fields
+		}
+
+		entries := make([]*IssueCustomFieldEntry, 0, len(fields))
+		for _, field := range fields {
+			entry := &IssueCustomFieldEntry{Field: field, Values: values[issue.ID][field.ID]}
+			if field.Type == IssueCustomFieldTypeUser {
+				for _, value := range entry.Values {
+					id, _ := strconv.ParseInt(value, 10, 64)
+					userIDs.Add(id)
+				}
+			}
+			entries = append(entries, entry)
+		}
+		result[issue.ID] = entries
+	}
+
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+	users, err := user_model.GetUsersByIDs(ctx, userIDs.Values())
+	if err != nil {
+		return nil, err
+	}
+	usersByID := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+	for _, entries := range result {
+		for _, entry := range entries {
+			if entry.Field.Type != IssueCustomFieldTypeUser {
+				continue
+			}
+			for _, value := range entry.Values {
+				id, _ := strconv.ParseInt(value, 10, 64)
+				if u, ok := usersByID[id]; ok {
+					entry.Users = append(entry.Users, u)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// DeleteOwnerIssueCustomFieldValuesOfRepo removes the values of the fields of an organization
+// from the issues of a repository, which is needed when the repository leaves the organization
+func DeleteOwnerIssueCustomFieldValuesOfRepo(ctx context.Context, ownerID, repoID int64) error {
+	_, err := db.GetEngine(ctx).
+		In("field_id", builder.Select("id").From("issue_custom_field").Where(builder.Eq{"owner_id": ownerID, "repo_id": 0})).
+		In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID})).
+		Delete(&IssueCustomFieldValue{})
+	return err
+}
+
+// IssueCustomFieldFilter matches the issues which have a value of a custom field
+type IssueCustomFieldFilter struct {
+	FieldID int64
+	Value   string
+}
+
+// applyCustomFieldsCondition only keeps the issues which match all the filters, text values are compared case-insensitively
+func applyCustomFieldsCondition(sess *xorm.Session, opts *IssuesOptions) {
+	for _, filter := range opts.CustomFields {
+		sess.In("issue.id", builder.Select("issue_id").From("issue_custom_field_value").Where(
+			builder.Eq{"field_id": filter.FieldID}.And(builder.Expr("LOWER(value) = ?", strings.ToLower(filter.Value))),
+		))
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCustomFieldNormalizeValues(t *testing.T) {
+	test := func(field *issues_model.IssueCustomField, values, expected []string) {
+		t.Helper()
+		normalized, err := field.NormalizeValues(values)
+		require.NoError(t, err)
+		assert.Equal(t, expected, normalized)
+	}
+	testInvalid := func(field *issues_model.IssueCustomField, values []string) {
+		t.Helper()
+		_, err := field.NormalizeValues(values)
+		assert.True(t, issues_model.IsErrInvalidIssueCustomFieldValue(err), "%v", values)
+	}
+
+	text := &issues_model.IssueCustomField{Name: "customer", Type: issues_model.IssueCustomFieldTypeText}
+	test(text, []string{" ACME "}, []string{"ACME"})
+	test(text, []string{"", " "}, []string{})
+	testInvalid(text, []string{"ACME", "Initech"})
+
+	number := &issues_model.IssueCustomField{Name: "estimate", Type: issues_model.IssueCustomFieldTypeNumber}
+	test(number, []string{"1.50"}, []string{"1.5"})
+	testInvalid(number, []string{"one"})
+
+	date := &issues_model.IssueCustomField{Name: "release", Type: issues_model.IssueCustomFieldTypeDate}
+	test(date, []string{"2025-02-28"}, []string{"2025-02-28"})
+	testInvalid(date, []string{"2025-02-30"})
+
+	sel := &issues_model.IssueCustomField{Name: "severity", Type: issues_model.IssueCustomFieldTypeSelect, Options: "Low\nHigh\n"}
+	test(sel, []string{"high"}, []string{"High"})
+	testInvalid(sel, []string{"Critical"})
+	testInvalid(sel, []string{"Low", "High"})
+
+	multi := &issues_model.IssueCustomField{Name: "component", Type: issues_model.IssueCustomFieldTypeMultiSelect, Options: "api\nweb"}
+	test(multi, []string{"web", "API", "Web"}, []string{"web", "api"})
+
+	user := &issues_model.IssueCustomField{Name: "customer contact", Type: issues_model.IssueCustomFieldTypeUser}
+	test(user, []string{"2"}, []string{"2"})
+	testInvalid(user, []string{"user2"})
+}
+
+func TestIssueCustomFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	orgField := &issues_model.IssueCustomField{OwnerID: 3, Name: "severity", Type: issues_model.IssueCustomFieldTypeSelect, Options: "low\nhigh"}
+	require.NoError(t, issues_model.NewIssueCustomField(db.DefaultContext, orgField))
+	repoField := &issues_model.IssueCustomField{RepoID: 3, Name: "contact", Type: issues_model.IssueCustomFieldTypeUser}
+	require.NoError(t, issues_model.NewIssueCustomField(db.DefaultContext, repoField))
+	otherField := &issues_model.IssueCustomField{RepoID: 1, Name: "estimate", Type: issues_model.IssueCustomFieldTypeNumber}
+	require.NoError(t, issues_model.NewIssueCustomField(db.DefaultContext, otherField))
+
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(db.DefaultContext, 3, 3)
+	require.NoError(t, err)
+	if assert.Len(t, fields, 2) {
+		assert.Equal(t, orgField.ID, fields[0].ID)
+		assert.Equal(t, repoField.ID, fields[1].ID)
+	}
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, issue.ID, orgField, []string{"high"}))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, issue.ID, repoField, []string{"2"}))
+
+	entries, err := issues_model.GetIssueCustomFieldEntries(db.DefaultContext, issue)
+	require.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, []string{"high"}, entries[0].DisplayValues())
+		assert.Equal(t, []string{"user2"}, entries[1].DisplayValues())
+	}
+
+	issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+		RepoIDs:      []int64{3},
+		CustomFields: []issues_model.IssueCustomFieldFilter{{FieldID: orgField.ID, Value: "HIGH"}},
+	})
+	require.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, issue.ID, issues[0].ID)
+	}
+
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, issue.ID, orgField, nil))
+	values, err := issues_model.GetIssueCustomFieldValues(db.DefaultContext, issue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]string{repoField.ID: {"2"}}, values)
+
+	issueIDs, err := issues_model.DeleteIssueCustomField(db.DefaultContext, repoField)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{issue.ID}, issueIDs)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{FieldID: repoField.ID})
+}
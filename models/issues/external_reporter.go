@@ -0,0 +1,68 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"encoding/hex"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ExternalReporter is the sender without account of the email an issue was opened from, through the service desk
+// of a repository. They get the comments on the issue by email and can reply to them.
+type ExternalReporter struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Email       string             `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL DEFAULT ''"`
+	Token       string             `xorm:"VARCHAR(32) UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName return the real table name
+func (ExternalReporter) TableName() string {
+	return "issue_external_reporter"
+}
+
+func init() {
+	db.RegisterModel(new(ExternalReporter))
+}
+
+// DisplayName returns the name of the reporter, their email if they gave none
+func (r *ExternalReporter) DisplayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Email
+}
+
+// CreateExternalReporter stores the external reporter of an issue, with a new token for their replies
+func CreateExternalReporter(ctx context.Context, reporter *ExternalReporter) error {
+	// lower case only, some mail servers don't preserve the case of addresses
+	reporter.Token = hex.EncodeToString(util.CryptoRandomBytes(16))
+	return db.Insert(ctx, reporter)
+}
+
+// GetExternalReporterByIssueID returns the external reporter of an issue, nil if it has none
+func GetExternalReporterByIssueID(ctx context.Context, issueID int64) (*ExternalReporter, error) {
+	reporter := &ExternalReporter{}
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(reporter)
+	if err != nil || !has {
+		return nil, err
+	}
+	return reporter, nil
+}
+
+// GetExternalReporterByToken returns the external reporter of the token, nil if there is none
+func GetExternalReporterByToken(ctx context.Context, token string) (*ExternalReporter, error) {
+	reporter := &ExternalReporter{}
+	has, err := db.GetEngine(ctx).Where("token = ?", token).Get(reporter)
+	if err != nil || !has {
+		return nil, err
+	}
+	return reporter, nil
+}
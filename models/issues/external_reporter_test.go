@@ -0,0 +1,40 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalReporter(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	reporter, err := issues_model.GetExternalReporterByIssueID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Nil(t, reporter)
+
+	reporter = &issues_model.ExternalReporter{IssueID: 1, Email: "reporter@example.com"}
+	require.NoError(t, issues_model.CreateExternalReporter(db.DefaultContext, reporter))
+	assert.Len(t, reporter.Token, 32)
+	assert.Equal(t, "reporter@example.com", reporter.DisplayName())
+
+	byIssue, err := issues_model.GetExternalReporterByIssueID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Equal(t, reporter.ID, byIssue.ID)
+
+	byToken, err := issues_model.GetExternalReporterByToken(db.DefaultContext, reporter.Token)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, byToken.IssueID)
+
+	byToken, err = issues_model.GetExternalReporterByToken(db.DefaultContext, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, byToken)
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+)
+
+// IssueFormValues are the values submitted through the form template an issue or pull request was created from.
+// They are kept alongside the content rendered from them, so that they can be read without parsing it.
+type IssueFormValues struct {
+	ID          int64                      `xorm:"pk autoincr"`
+	IssueID     int64                      `xorm:"UNIQUE NOT NULL"`
+	Template    string                     `xorm:"TEXT"`
+	Fields      []*api.IssueFormFieldValue `xorm:"JSON LONGTEXT"`
+	CreatedUnix timeutil.TimeStamp         `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueFormValues))
+}
+
+// CreateIssueFormValues stores the form values of an issue
+func CreateIssueFormValues(ctx context.Context, values *IssueFormValues) error {
+	return db.Insert(ctx, values)
+}
+
+// GetIssueFormValues returns the form values of an issue, or nil if it wasn't created from a form template
+func GetIssueFormValues(ctx context.Context, issueID int64) (*IssueFormValues, error) {
+	values := &IssueFormValues{}
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(values)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return values, nil
+}
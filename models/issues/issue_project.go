@@ -169,6 +169,48 @@ func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_mo
 	})
 }
 
+// MoveIssueToProjectColumn moves an issue to the end of another column of the project it belongs to,
+// and records the move in its timeline
+func MoveIssueToProjectColumn(ctx context.Context, issue *Issue, doer *user_model.User, column *project_model.Column) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var ip project_model.ProjectIssue
+		has, err := db.GetEngine(ctx).Where("issue_id=? AND project_id=?", issue.ID, column.ProjectID).Get(&ip)
+		if err != nil {
+			return err
+		} else if !has {
+			return util.NewInvalidArgumentErrorf("issue %d is not in project %d", issue.ID, column.ProjectID)
+		}
+		if ip.ProjectColumnID == column.ID {
+			return nil
+		}
+
+		var oldTitle string
+		if oldColumn, err := project_model.GetColumn(ctx, ip.ProjectColumnID); err == nil {
+			oldTitle = oldColumn.Title
+		} else if !project_model.IsErrProjectColumnNotExist(err) {
+			return err
+		}
+
+		if err := project_model.MoveIssueToColumnEnd(ctx, column, issue.ID); err != nil {
+			return err
+		}
+
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		_, err = CreateComment(ctx, &CreateCommentOptions{
+			Type:      CommentTypeProjectColumn,
+			Doer:      doer,
+			Repo:      issue.Repo,
+			Issue:     issue,
+			ProjectID: column.ProjectID,
+			OldTitle:  oldTitle,
+			NewTitle:  column.Title,
+		})
+		return err
+	})
+}
+
 // NumIssuesInProjects returns the amount of issues assigned to one of the project
 // in the list which the doer can access.
 func NumIssuesInProjects(ctx context.Context, pl []*project_model.Project, doer *user_model.User, org *org_model.Organization, isClosed optional.Option[bool]) (map[int64]int, error) {
@@ -49,6 +49,8 @@ type IssuesOptions struct { //nolint
 	// prioritize issues from this repo
 	PriorityRepoID int64
 	IsArchived     optional.Option[bool]
+	// only keep issues which have all these custom field values
+	CustomFields []IssueCustomFieldFilter
 
 	// If combined with AllPublic, then private as well as public issues
 	// that matches the criteria will be returned, if AllPublic is false
@@ -272,6 +274,8 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyLabelsCondition(sess, opts)
 
+	applyCustomFieldsCondition(sess, opts)
+
 	if opts.User != nil {
 		cond := issuePullAccessibleRepoCond("issue.repo_id", opts.User.ID, opts.Org, opts.Team, opts.IsPull.Value())
 		// If AllPublic was set, then also consider all issues in public
@@ -0,0 +1,303 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"forgejo.org/models/db"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// IssueRedirect records the former repository and index of an issue transferred to another repository,
+// so that the links and references to its former location keep leading to it
+type IssueRedirect struct {
+	ID        int64 `xorm:"pk autoincr"`
+	OldRepoID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	OldIndex  int64 `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64 `xorm:"INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueRedirect))
+}
+
+// ErrIssueRedirectNotExist represents a "IssueRedirectNotExist" kind of error.
+type ErrIssueRedirectNotExist struct {
+	RepoID int64
+	Index  int64
+}
+
+// IsErrIssueRedirectNotExist checks if an error is a ErrIssueRedirectNotExist.
+func IsErrIssueRedirectNotExist(err error) bool {
+	_, ok := err.(ErrIssueRedirectNotExist)
+	return ok
+}
+
+func (err ErrIssueRedirectNotExist) Error() string {
+	return fmt.Sprintf("issue redirect does not exist [repo_id: %d, index: %d]", err.RepoID, err.Index)
+}
+
+func (err ErrIssueRedirectNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// LookupIssueRedirect returns the ID of the issue which was transferred away from the given repository and index
+func LookupIssueRedirect(ctx context.Context, repoID, index int64) (int64, error) {
+	redirect := &IssueRedirect{}
+	has, err := db.GetEngine(ctx).Where("old_repo_id = ? AND old_index = ?", repoID, index).Get(redirect)
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, ErrIssueRedirectNotExist{RepoID: repoID, Index: index}
+	}
+	return redirect.IssueID, nil
+}
+
+// GetTransferredIssueByIndex returns the issue which was transferred away from the given repository and index
+func GetTransferredIssueByIndex(ctx context.Context, repoID, index int64) (*Issue, error) {
+	issueID, err := LookupIssueRedirect(ctx, repoID, index)
+	if err != nil {
+		return nil, err
+	}
+	return GetIssueByID(ctx, issueID)
+}
+
+// TransferredFromLink returns the link to the former location of the issue of an issue transfer comment,
+// which redirects to the issue
+func (c *Comment) TransferredFromLink() string {
+	repoFullName, index, ok := strings.Cut(c.OldRef, "#")
+	if !ok {
+		return ""
+	}
+	return setting.AppSubURL + "/" + util.PathEscapeSegments(repoFullName) + "/issues/" + index
+}
+
+// TransferIssue moves an issue to another repository, where it gets a new index. The comments, attachments,
+// reactions, tracked times and subscriptions stay with the issue. Its labels, milestone and custom field values
+// are replaced by the ones of the same name in the new repository, the assignees who can't be assigned there
+// are removed, and a redirect is recorded for its former location.
+func TransferIssue(ctx context.Context, issue *Issue, doer *user_model.User, newRepo *repo_model.Repository) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		oldRepo := issue.Repo
+		oldIndex := issue.Index
+		oldMilestoneID := issue.MilestoneID
+		oldLabels := issue.Labels
+
+		newLabels, err := mapLabelsToRepo(ctx, oldLabels, newRepo)
+		if err != nil {
+			return err
+		}
+		newMilestoneID, err := mapMilestoneToRepo(ctx, oldRepo.ID, oldMilestoneID, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if err := issue.Unpin(ctx, doer); err != nil {
+			return err
+		}
+		issue.PinOrder = 0
+
+		newIndex, err := db.GetNextResourceIndex(ctx, "issue_index", newRepo.ID)
+		if err != nil {
+			return err
+		}
+		issue.RepoID = newRepo.ID
+		issue.Repo = newRepo
+		issue.Index = newIndex
+		issue.MilestoneID = newMilestoneID
+		issue.Milestone = nil
+		issue.Ref = ""
+		if err := UpdateIssueCols(ctx, issue, "repo_id", "index", "milestone_id", "ref"); err != nil {
+			return err
+		}
+
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Delete(&IssueLabel{}); err != nil {
+			return err
+		}
+		for _, label := range newLabels {
+			if err := db.Insert(ctx, &IssueLabel{IssueID: issue.ID, LabelID: label.ID}); err != nil {
+				return err
+			}
+		}
+		for _, label := range append(oldLabels, newLabels...) {
+			if err := updateLabelCols(ctx, label, "num_issues", "num_closed_issue"); err != nil {
+				return err
+			}
+		}
+		if err := issue.ReloadLabels(ctx); err != nil {
+			return err
+		}
+
+		for _, milestoneID := range []int64{oldMilestoneID, newMilestoneID} {
+			if milestoneID > 0 {
+				if err := UpdateMilestoneCounters(ctx, milestoneID); err != nil {
+					return err
+				}
+			}
+		}
+		for _, repoID := range []int64{oldRepo.ID, newRepo.ID} {
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, false); err != nil {
+				return err
+			}
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, true); err != nil {
+				return err
+			}
+		}
+
+		// the rows denormalizing the repository of the issue
+		for _, table := range []string{"attachment", "notification"} {
+			if _, err := db.GetEngine(ctx).Table(table).Where("issue_id = ?", issue.ID).
+				Update(map[string]any{"repo_id": newRepo.ID}); err != nil {
+				return err
+			}
+		}
+		// the references made by the issue to other issues
+		if _, err := db.GetEngine(ctx).Table("comment").Where("ref_repo_id = ? AND ref_issue_id = ?", oldRepo.ID, issue.ID).
+			Update(map[string]any{"ref_repo_id": newRepo.ID}); err != nil {
+			return err
+		}
+
+		if err := issue.LoadAssignees(ctx); err != nil {
+			return err
+		}
+		for _, assignee := range issue.Assignees {
+			if canBeAssigned, err := access_model.CanBeAssigned(ctx, assignee, newRepo, false); err != nil {
+				return err
+			} else if !canBeAssigned {
+				if _, err := db.DeleteByBean(ctx, &IssueAssignees{IssueID: issue.ID, AssigneeID: assignee.ID}); err != nil {
+					return err
+				}
+			}
+		}
+		issue.isAssigneeLoaded = false
+
+		issue.Project = nil
+		if err := issue.LoadProject(ctx); err != nil {
+			return err
+		}
+		if issue.Project != nil && !issue.Project.CanBeAccessedByOwnerRepo(newRepo.OwnerID, newRepo) {
+			if _, err := db.DeleteByBean(ctx, &project_model.ProjectIssue{IssueID: issue.ID}); err != nil {
+				return err
+			}
+			issue.Project = nil
+		}
+
+		if err := transferIssueCustomFieldValues(ctx, issue.ID, newRepo); err != nil {
+			return err
+		}
+
+		if err := db.Insert(ctx, &IssueRedirect{OldRepoID: oldRepo.ID, OldIndex: oldIndex, IssueID: issue.ID}); err != nil {
+			return err
+		}
+
+		_, err = CreateComment(ctx, &CreateCommentOptions{
+			Type:   CommentTypeIssueTransfer,
+			Doer:   doer,
+			Repo:   newRepo,
+			Issue:  issue,
+			OldRef: fmt.Sprintf("%s#%d", oldRepo.FullName(), oldIndex),
+		})
+		return err
+	})
+}
+
+// mapLabelsToRepo returns the labels of the repository, or of its organization, having the names of the given labels
+func mapLabelsToRepo(ctx context.Context, labels []*Label, repo *repo_model.Repository) ([]*Label, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	mapped := make([]*Label, 0, len(labels))
+	for _, label := range labels {
+		if label.BelongsToOrg() && label.OrgID == repo.OwnerID {
+			mapped = append(mapped, label)
+			continue
+		}
+		newLabel, err := GetLabelInRepoByName(ctx, repo.ID, label.Name)
+		if IsErrRepoLabelNotExist(err) && repo.Owner.IsOrganization() {
+			newLabel, err = GetLabelInOrgByName(ctx, repo.OwnerID, label.Name)
+		}
+		if err != nil {
+			if IsErrRepoLabelNotExist(err) || IsErrOrgLabelNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		mapped = append(mapped, newLabel)
+	}
+	return mapped, nil
+}
+
+// mapMilestoneToRepo returns the ID of the milestone of the repository having the name of the given milestone, or 0
+func mapMilestoneToRepo(ctx context.Context, oldRepoID, milestoneID int64, repo *repo_model.Repository) (int64, error) {
+	if milestoneID == 0 {
+		return 0, nil
+	}
+	milestone, err := GetMilestoneByRepoID(ctx, oldRepoID, milestoneID)
+	if err != nil {
+		if IsErrMilestoneNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	newMilestone, err := GetMilestoneByRepoIDANDName(ctx, repo.ID, milestone.Name)
+	if err != nil {
+		if IsErrMilestoneNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return newMilestone.ID, nil
+}
+
+// transferIssueCustomFieldValues moves the values of an issue to the fields of the same name and type
+// which can be set in the repository, the values which don't fit these fields are dropped
+func transferIssueCustomFieldValues(ctx context.Context, issueID int64, repo *repo_model.Repository) error {
+	values, err := GetIssueCustomFieldValues(ctx, issueID)
+	if err != nil || len(values) == 0 {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Delete(&IssueCustomFieldValue{}); err != nil {
+		return err
+	}
+
+	oldFields := make([]*IssueCustomField, 0, len(values))
+	if err := db.GetEngine(ctx).In("id", util.KeysOfMap(values)).Find(&oldFields); err != nil {
+		return err
+	}
+	newFields, err := GetIssueCustomFieldsForRepo(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		return err
+	}
+	for _, oldField := range oldFields {
+		for _, newField := range newFields {
+			if newField.Type != oldField.Type || !strings.EqualFold(newField.Name, oldField.Name) {
+				continue
+			}
+			normalized, err := newField.NormalizeValues(values[oldField.ID])
+			if err != nil {
+				break
+			}
+			if err := SetIssueCustomFieldValues(ctx, issueID, newField, normalized); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	newRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	label := &issues_model.Label{RepoID: newRepo.ID, Name: "label1", Color: "#abcdef"}
+	require.NoError(t, issues_model.NewLabel(db.DefaultContext, label))
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, RepoID: 1, Index: 1})
+	require.NoError(t, issues_model.TransferIssue(db.DefaultContext, issue, doer, newRepo))
+
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	assert.EqualValues(t, newRepo.ID, issue.RepoID)
+	assert.EqualValues(t, 3, issue.Index)
+
+	// the label is replaced by the one of the same name in the new repository
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: label.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 1})
+	assert.EqualValues(t, 1, unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: label.ID}).NumIssues)
+
+	// the former location leads to the issue
+	_, err := issues_model.GetIssueByIndex(db.DefaultContext, 1, 1)
+	require.True(t, issues_model.IsErrIssueNotExist(err))
+	transferred, err := issues_model.GetTransferredIssueByIndex(db.DefaultContext, 1, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, transferred.ID)
+	_, err = issues_model.GetTransferredIssueByIndex(db.DefaultContext, 1, 2)
+	require.True(t, issues_model.IsErrIssueRedirectNotExist(err))
+
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeIssueTransfer})
+	assert.Equal(t, "user2/repo1#1", comment.OldRef)
+	assert.Equal(t, "/user2/repo1/issues/1", comment.TransferredFromLink())
+}
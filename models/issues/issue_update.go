@@ -709,6 +709,12 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		// Sub-issues may be in other repositories, in both directions
+		_, err = sess.Where(builder.In("issue_id", issueIDs).Or(builder.In("parent_id", issueIDs))).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
@@ -734,6 +740,26 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueCustomFieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueRedirect{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueFormValues{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&ExternalReporter{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&project_model.ProjectIssue{})
 		if err != nil {
 			return nil, err
@@ -765,6 +791,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 		}
 	}
 
+	// Redirects of the issues transferred away from this repository
+	if _, err = sess.Where("old_repo_id = ?", repoID).Delete(&IssueRedirect{}); err != nil {
+		return nil, err
+	}
+
 	return attachmentPaths, err
 }
 
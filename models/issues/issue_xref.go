@@ -5,6 +5,7 @@ package issues
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"forgejo.org/models/db"
@@ -13,6 +14,7 @@ import (
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/references"
+	"forgejo.org/modules/util"
 )
 
 type crossReference struct {
@@ -202,7 +204,14 @@ func (issue *Issue) verifyReferencedIssue(stdCtx context.Context, ctx *crossRefe
 	e := db.GetEngine(stdCtx)
 
 	if has, _ := e.Get(refIssue); !has {
-		return nil, references.XRefActionNone, nil
+		// the issue may have been transferred to another repository
+		var err error
+		if refIssue, err = GetTransferredIssueByIndex(stdCtx, repo.ID, ref.Index); err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, references.XRefActionNone, nil
+			}
+			return nil, references.XRefActionNone, err
+		}
 	}
 	if err := refIssue.LoadRepo(stdCtx); err != nil {
 		return nil, references.XRefActionNone, err
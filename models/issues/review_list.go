@@ -198,3 +198,39 @@ func GetReviewsByIssueID(ctx context.Context, issueID int64) (ReviewList, error)
 
 	return reviews, nil
 }
+
+// CountPendingReviewRequests returns the number of open pull requests whose review is requested from each of
+// the given users and which they have not reviewed yet
+func CountPendingReviewRequests(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	type reviewerCount struct {
+		ReviewerID int64
+		Count      int64
+	}
+	reviewerCounts := make([]*reviewerCount, 0, len(userIDs))
+	if err := db.GetEngine(ctx).Table("review").
+		Select("review.reviewer_id, count(*) AS count").
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		Where(builder.In("review.id", builder.
+			Select("max(id)").
+			From("review").
+			Where(builder.In("reviewer_id", userIDs).
+				And(builder.Eq{"reviewer_team_id": 0, "original_author_id": 0}).
+				And(builder.In("type", ReviewTypeApprove, ReviewTypeReject, ReviewTypeRequest))).
+			GroupBy("issue_id, reviewer_id"))).
+		And("review.type = ?", ReviewTypeRequest).
+		And("issue.is_closed = ?", false).
+		GroupBy("review.reviewer_id").
+		Find(&reviewerCounts); err != nil {
+		return nil, err
+	}
+
+	for _, reviewerCount := range reviewerCounts {
+		counts[reviewerCount.ReviewerID] = reviewerCount.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SavedSearchSubscriptionMode is the kind of changes a subscriber of a saved search is notified about
+type SavedSearchSubscriptionMode int
+
+const (
+	// SavedSearchSubscriptionNone means the user is not subscribed
+	SavedSearchSubscriptionNone SavedSearchSubscriptionMode = iota
+	// SavedSearchSubscriptionNew notifies about new issues matching the search
+	SavedSearchSubscriptionNew
+	// SavedSearchSubscriptionUpdated notifies about new and updated issues matching the search
+	SavedSearchSubscriptionUpdated
+)
+
+// IsValid returns true if the mode is known
+func (m SavedSearchSubscriptionMode) IsValid() bool {
+	return m >= SavedSearchSubscriptionNone && m <= SavedSearchSubscriptionUpdated
+}
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SavedSearch is a filter of the issue or pull request overview, saved by a user.
+// Searches scoped to an organization can be shared with its members.
+type SavedSearch struct {
+	ID       int64  `xorm:"pk autoincr"`
+	UserID   int64  `xorm:"INDEX NOT NULL"`
+	OrgID    int64  `xorm:"INDEX NOT NULL DEFAULT 0"` // organization the search is scoped to
+	TeamID   int64  `xorm:"NOT NULL DEFAULT 0"`       // team of the organization the search is scoped to
+	IsShared bool   `xorm:"NOT NULL DEFAULT false"`   // visible to all the members of the organization
+	IsPull   bool   `xorm:"NOT NULL DEFAULT false"`
+	Name     string `xorm:"NOT NULL"`
+	Query    string `xorm:"TEXT"` // the query string of the overview page
+
+	Org  *organization.Organization `xorm:"-"`
+	Team *organization.Team         `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// SavedSearchSubscription is the subscription of a user to a saved search
+type SavedSearchSubscription struct {
+	ID              int64                       `xorm:"pk autoincr"`
+	SavedSearchID   int64                       `xorm:"UNIQUE(s) NOT NULL"`
+	UserID          int64                       `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Mode            SavedSearchSubscriptionMode `xorm:"NOT NULL DEFAULT 0"`
+	LastCheckedUnix timeutil.TimeStamp          `xorm:"NOT NULL DEFAULT 0"` // issues updated before have been notified
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+	db.RegisterModel(new(SavedSearchSubscription))
+}
+
+// LoadAttributes loads the organization and team the search is scoped to
+func (s *SavedSearch) LoadAttributes(ctx context.Context) (err error) {
+	if s.OrgID > 0 && s.Org == nil {
+		if s.Org, err = organization.GetOrgByID(ctx, s.OrgID); err != nil {
+			return err
+		}
+	}
+	if s.TeamID > 0 && s.Team == nil {
+		if s.Team, err = organization.GetTeamByID(ctx, s.TeamID); err != nil {
+			if !organization.IsErrTeamNotExist(err) {
+				return err
+			}
+			// the team has been deleted, fall back to the whole organization
+			s.TeamID = 0
+		}
+	}
+	return nil
+}
+
+// Link returns the link to the overview page showing the results of the search, attributes must be loaded
+func (s *SavedSearch) Link() string {
+	link := setting.AppSubURL
+	if s.Org != nil {
+		link += "/org/" + url.PathEscape(s.Org.Name)
+	}
+	if s.IsPull {
+		link += "/pulls"
+	} else {
+		link += "/issues"
+	}
+	if s.Team != nil {
+		link += "/" + url.PathEscape(s.Team.Name)
+	}
+	if s.Query != "" {
+		link += "?" + s.Query
+	}
+	return link
+}
+
+// IsVisibleTo returns true if the user created the search, or if it is shared with an organization the user is a member of
+func (s *SavedSearch) IsVisibleTo(ctx context.Context, userID int64) (bool, error) {
+	if s.UserID == userID {
+		return true, nil
+	}
+	if !s.IsShared || s.OrgID == 0 {
+		return false, nil
+	}
+	return organization.IsOrganizationMember(ctx, s.OrgID, userID)
+}
+
+// CreateSavedSearch saves a search
+func CreateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return db.Insert(ctx, s)
+}
+
+// GetSavedSearchByID returns the saved search with the given ID
+func GetSavedSearchByID(ctx context.Context, id int64) (*SavedSearch, error) {
+	s, exist, err := db.GetByID[SavedSearch](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, ErrSavedSearchNotExist{ID: id}
+	}
+	return s, nil
+}
+
+// FindSavedSearchesOptions represents the options to find saved searches
+type FindSavedSearchesOptions struct {
+	db.ListOptions
+	VisibleToUserID int64 // searches created by the user, or shared with an organization the user is a member of
+	IsPull          optional.Option[bool]
+}
+
+func (opts FindSavedSearchesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.VisibleToUserID > 0 {
+		cond = cond.And(builder.Or(
+			builder.Eq{"user_id": opts.VisibleToUserID},
+			builder.Eq{"is_shared": true}.And(builder.In("org_id",
+				builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": opts.VisibleToUserID}),
+			)),
+		))
+	}
+	if opts.IsPull.Has() {
+		cond = cond.And(builder.Eq{"is_pull": opts.IsPull.Value()})
+	}
+	return cond
+}
+
+func (opts FindSavedSearchesOptions) ToOrders() string {
+	return "name ASC, id ASC"
+}
+
+// SavedSearchList is a list of saved searches
+type SavedSearchList []*SavedSearch
+
+// LoadAttributes loads the organizations and teams the searches are scoped to
+func (l SavedSearchList) LoadAttributes(ctx context.Context) error {
+	for _, s := range l {
+		if err := s.LoadAttributes(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteSavedSearch deletes a saved search and its subscriptions
+func DeleteSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return deleteSavedSearches(ctx, builder.Eq{"id": s.ID})
+}
+
+// DeleteSavedSearchesOfUser deletes the searches saved by a user and the subscriptions of the user
+func DeleteSavedSearchesOfUser(ctx context.Context, userID int64) error {
+	if _, err := db.GetEngine(ctx).Where("user_id = ?", userID).Delete(&SavedSearchSubscription{}); err != nil {
+		return err
+	}
+	return deleteSavedSearches(ctx, builder.Eq{"user_id": userID})
+}
+
+// DeleteSavedSearchesOfOrg deletes the searches scoped to an organization
+func DeleteSavedSearchesOfOrg(ctx context.Context, orgID int64) error {
+	return deleteSavedSearches(ctx, builder.Eq{"org_id": orgID})
+}
+
+func deleteSavedSearches(ctx context.Context, cond builder.Cond) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.In("saved_search_id",
+			builder.Select("id").From("saved_search").Where(cond),
+		)).Delete(&SavedSearchSubscription{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(cond).Delete(&SavedSearch{})
+		return err
+	})
+}
+
+// GetSavedSearchSubscriptions returns the subscriptions of a user to saved searches, by search ID
+func GetSavedSearchSubscriptions(ctx context.Context, userID int64) (map[int64]*SavedSearchSubscription, error) {
+	subs := make([]*SavedSearchSubscription, 0, 10)
+	if err := db.GetEngine(ctx).Where("user_id = ?", userID).Find(&subs); err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*SavedSearchSubscription, len(subs))
+	for _, sub := range subs {
+		result[sub.SavedSearchID] = sub
+	}
+	return result, nil
+}
+
+// SetSavedSearchSubscription subscribes a user to a saved search, or unsubscribes with SavedSearchSubscriptionNone.
+// Only the issues updated after the subscription are notified.
+func SetSavedSearchSubscription(ctx context.Context, searchID, userID int64, mode SavedSearchSubscriptionMode) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		sub := &SavedSearchSubscription{}
+		has, err := db.GetEngine(ctx).Where("saved_search_id = ? AND user_id = ?", searchID, userID).Get(sub)
+		if err != nil {
+			return err
+		}
+		switch {
+		case mode == SavedSearchSubscriptionNone:
+			if has {
+				_, err = db.DeleteByID[SavedSearchSubscription](ctx, sub.ID)
+			}
+		case has:
+			sub.Mode = mode
+			_, err = db.GetEngine(ctx).ID(sub.ID).Cols("mode").Update(sub)
+		default:
+			err = db.Insert(ctx, &SavedSearchSubscription{
+				SavedSearchID:   searchID,
+				UserID:          userID,
+				Mode:            mode,
+				LastCheckedUnix: timeutil.TimeStampNow(),
+			})
+		}
+		return err
+	})
+}
+
+// UpdateSavedSearchSubscriptionChecked records that the issues updated before the given time have been notified
+func UpdateSavedSearchSubscriptionChecked(ctx context.Context, sub *SavedSearchSubscription, checked timeutil.TimeStamp) error {
+	sub.LastCheckedUnix = checked
+	_, err := db.GetEngine(ctx).ID(sub.ID).Cols("last_checked_unix").Update(sub)
+	return err
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	personal := &issues_model.SavedSearch{UserID: 2, Name: "mine", Query: "type=assigned"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, personal))
+	shared := &issues_model.SavedSearch{UserID: 2, OrgID: 3, IsShared: true, IsPull: true, Name: "security", Query: "labels=1"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, shared))
+
+	require.NoError(t, shared.LoadAttributes(db.DefaultContext))
+	assert.Equal(t, "/org/org3/pulls?labels=1", shared.Link())
+
+	// user 4 is a member of org 3, user 5 is not
+	for userID, expected := range map[int64][]int64{2: {personal.ID, shared.ID}, 4: {shared.ID}, 5: {}} {
+		searches, err := db.Find[issues_model.SavedSearch](db.DefaultContext, issues_model.FindSavedSearchesOptions{VisibleToUserID: userID})
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(searches))
+		for _, s := range searches {
+			ids = append(ids, s.ID)
+			visible, err := s.IsVisibleTo(db.DefaultContext, userID)
+			require.NoError(t, err)
+			assert.True(t, visible)
+		}
+		assert.ElementsMatch(t, expected, ids, "user %d", userID)
+	}
+	visible, err := personal.IsVisibleTo(db.DefaultContext, 4)
+	require.NoError(t, err)
+	assert.False(t, visible)
+
+	searches, err := db.Find[issues_model.SavedSearch](db.DefaultContext, issues_model.FindSavedSearchesOptions{VisibleToUserID: 2, IsPull: optional.Some(false)})
+	require.NoError(t, err)
+	if assert.Len(t, searches, 1) {
+		assert.Equal(t, personal.ID, searches[0].ID)
+	}
+
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, shared.ID, 4, issues_model.SavedSearchSubscriptionNew))
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, shared.ID, 4, issues_model.SavedSearchSubscriptionUpdated))
+	subs, err := issues_model.GetSavedSearchSubscriptions(db.DefaultContext, 4)
+	require.NoError(t, err)
+	if assert.Contains(t, subs, shared.ID) {
+		assert.Equal(t, issues_model.SavedSearchSubscriptionUpdated, subs[shared.ID].Mode)
+		assert.NotZero(t, subs[shared.ID].LastCheckedUnix)
+	}
+
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, personal.ID, 2, issues_model.SavedSearchSubscriptionNew))
+	require.NoError(t, issues_model.SetSavedSearchSubscription(db.DefaultContext, personal.ID, 2, issues_model.SavedSearchSubscriptionNone))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{SavedSearchID: personal.ID})
+
+	require.NoError(t, issues_model.DeleteSavedSearchesOfOrg(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: shared.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.SavedSearchSubscription{SavedSearchID: shared.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearch{ID: personal.ID})
+}
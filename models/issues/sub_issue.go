@@ -0,0 +1,279 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSubIssueHasParent represents an error where the issue to add as a sub-issue already has a parent.
+type ErrSubIssueHasParent struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue already has a parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueHasParent) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrSubIssueNotExist represents an error where an issue is not a sub-issue of the given parent.
+type ErrSubIssueNotExist struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("issue is not a sub-issue of the parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrCircularSubIssue represents an error where adding a sub-issue would make an issue its own ancestor.
+type ErrCircularSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("circular sub-issues (an issue would be its own ancestor) [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSubIssueOtherOwner represents an error where the sub-issue belongs to a repository of another owner than the parent.
+type ErrSubIssueOtherOwner struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueOtherOwner checks if an error is a ErrSubIssueOtherOwner.
+func IsErrSubIssueOtherOwner(err error) bool {
+	_, ok := err.(ErrSubIssueOtherOwner)
+	return ok
+}
+
+func (err ErrSubIssueOtherOwner) Error() string {
+	return fmt.Sprintf("sub-issue and parent belong to repositories of different owners [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueOtherOwner) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue represents the parent-child relation of two issues. An issue has at most one parent,
+// which belongs to a repository of the same owner.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	UserID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// SubIssueProgress counts the sub-issues of an issue and how many of them are closed
+type SubIssueProgress struct {
+	Total  int64
+	Closed int64
+}
+
+// Percent returns the percentage of closed sub-issues
+func (p *SubIssueProgress) Percent() int {
+	if p == nil || p.Total == 0 {
+		return 0
+	}
+	return int(p.Closed * 100 / p.Total)
+}
+
+// AddSubIssue makes an issue a sub-issue of the parent
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if parent.ID == issue.ID {
+			return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+		}
+		if err := parent.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if parent.Repo.OwnerID != issue.Repo.OwnerID {
+			return ErrSubIssueOtherOwner{IssueID: issue.ID, ParentID: parent.ID}
+		}
+
+		existing := &SubIssue{}
+		if has, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Get(existing); err != nil {
+			return err
+		} else if has {
+			return ErrSubIssueHasParent{IssueID: issue.ID, ParentID: existing.ParentID}
+		}
+
+		// The issue must not be an ancestor of its new parent
+		ancestorID := parent.ID
+		for {
+			ancestor := &SubIssue{}
+			has, err := db.GetEngine(ctx).Where("issue_id = ?", ancestorID).Get(ancestor)
+			if err != nil {
+				return err
+			} else if !has {
+				break
+			}
+			if ancestor.ParentID == issue.ID {
+				return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+			}
+			ancestorID = ancestor.ParentID
+		}
+
+		if err := db.Insert(ctx, &SubIssue{ParentID: parent.ID, IssueID: issue.ID, UserID: doer.ID}); err != nil {
+			return err
+		}
+		return createSubIssueComments(ctx, doer, parent, issue, true)
+	})
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the parent
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, issue.ID).Delete(&SubIssue{})
+		if err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrSubIssueNotExist{IssueID: issue.ID, ParentID: parent.ID}
+		}
+		if err := parent.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		return createSubIssueComments(ctx, doer, parent, issue, false)
+	})
+}
+
+// createSubIssueComments makes two comments, one in the parent and one in the sub-issue
+func createSubIssueComments(ctx context.Context, doer *user_model.User, parent, issue *Issue, add bool) error {
+	parentType, issueType := CommentTypeAddSubIssue, CommentTypeAddParentIssue
+	if !add {
+		parentType, issueType = CommentTypeRemoveSubIssue, CommentTypeRemoveParentIssue
+	}
+	if _, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             parentType,
+		Doer:             doer,
+		Repo:             parent.Repo,
+		Issue:            parent,
+		DependentIssueID: issue.ID,
+	}); err != nil {
+		return err
+	}
+	_, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             issueType,
+		Doer:             doer,
+		Repo:             issue.Repo,
+		Issue:            issue,
+		DependentIssueID: parent.ID,
+	})
+	return err
+}
+
+// GetSubIssues returns the sub-issues of an issue in the order they were added
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", parentID).
+		Asc("sub_issue.id").
+		Find(&issues)
+}
+
+// GetParentIssue returns the parent of an issue, or nil if it has none
+func GetParentIssue(ctx context.Context, issueID int64) (*Issue, error) {
+	subIssue := &SubIssue{}
+	if has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(subIssue); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return GetIssueByID(ctx, subIssue.ParentID)
+}
+
+// GetSubIssueProgressByIssues returns the progress of the sub-issues of the given issues which have any, keyed by issue ID
+func GetSubIssueProgressByIssues(ctx context.Context, issues IssueList) (map[int64]*SubIssueProgress, error) {
+	ret := make(map[int64]*SubIssueProgress)
+	if len(issues) == 0 {
+		return ret, nil
+	}
+
+	type progressRow struct {
+		ParentID int64
+		IsClosed bool
+		Count    int64
+	}
+	var rows []progressRow
+	if err := db.GetEngine(ctx).Table("sub_issue").
+		Join("INNER", "issue", "issue.id = sub_issue.issue_id").
+		Where(builder.In("sub_issue.parent_id", issues.getIssueIDs())).
+		Select("sub_issue.parent_id, issue.is_closed, COUNT(*) AS count").
+		GroupBy("sub_issue.parent_id, issue.is_closed").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		progress, ok := ret[row.ParentID]
+		if !ok {
+			progress = &SubIssueProgress{}
+			ret[row.ParentID] = progress
+		}
+		progress.Total += row.Count
+		if row.IsClosed {
+			progress.Closed += row.Count
+		}
+	}
+	return ret, nil
+}
+
+// GetSubIssueProgress returns the progress of the sub-issues of an issue, or nil if it has none
+func GetSubIssueProgress(ctx context.Context, issue *Issue) (*SubIssueProgress, error) {
+	progress, err := GetSubIssueProgressByIssues(ctx, IssueList{issue})
+	if err != nil {
+		return nil, err
+	}
+	return progress[issue.ID], nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	closed := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	// in another repository of the same owner
+	open := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+	// in a repository of another owner
+	other := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, parent, closed))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, parent, open))
+
+	err := issues_model.AddSubIssue(db.DefaultContext, doer, parent, other)
+	assert.True(t, issues_model.IsErrSubIssueOtherOwner(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, open, closed)
+	assert.True(t, issues_model.IsErrSubIssueHasParent(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, parent, parent)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	err = issues_model.AddSubIssue(db.DefaultContext, doer, open, parent)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+
+	subIssues, err := issues_model.GetSubIssues(db.DefaultContext, parent.ID)
+	require.NoError(t, err)
+	if assert.Len(t, subIssues, 2) {
+		assert.EqualValues(t, closed.ID, subIssues[0].ID)
+		assert.EqualValues(t, open.ID, subIssues[1].ID)
+	}
+
+	parentOf, err := issues_model.GetParentIssue(db.DefaultContext, open.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, parent.ID, parentOf.ID)
+
+	progress, err := issues_model.GetSubIssueProgress(db.DefaultContext, parent)
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.SubIssueProgress{Total: 2, Closed: 1}, progress)
+	assert.Equal(t, 50, progress.Percent())
+
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: parent.ID, DependentIssueID: open.ID, Type: issues_model.CommentTypeAddSubIssue})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: open.ID, DependentIssueID: parent.ID, Type: issues_model.CommentTypeAddParentIssue})
+
+	require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, doer, parent, open))
+	err = issues_model.RemoveSubIssue(db.DefaultContext, doer, parent, open)
+	assert.True(t, issues_model.IsErrSubIssueNotExist(err))
+
+	parentOf, err = issues_model.GetParentIssue(db.DefaultContext, open.ID)
+	require.NoError(t, err)
+	assert.Nil(t, parentOf)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: parent.ID, DependentIssueID: open.ID, Type: issues_model.CommentTypeRemoveSubIssue})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: open.ID, DependentIssueID: parent.ID, Type: issues_model.CommentTypeRemoveParentIssue})
+
+	progress, err = issues_model.GetSubIssueProgress(db.DefaultContext, parent)
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.SubIssueProgress{Total: 1, Closed: 1}, progress)
+}
@@ -36,6 +36,40 @@ func init() {
 	db.RegisterModel(new(TrackedTime))
 }
 
+// ErrTrackedTimeLocked represents a "TrackedTimeLocked" kind of error:
+// tracked times older than setting.Service.TimetrackingLockPeriod can't be changed.
+type ErrTrackedTimeLocked struct {
+	ID int64
+}
+
+// IsErrTrackedTimeLocked checks if an error is a ErrTrackedTimeLocked
+func IsErrTrackedTimeLocked(err error) bool {
+	_, ok := err.(ErrTrackedTimeLocked)
+	return ok
+}
+
+func (err ErrTrackedTimeLocked) Error() string {
+	return fmt.Sprintf("tracked time is locked [id: %d]", err.ID)
+}
+
+func (err ErrTrackedTimeLocked) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
+// TrackedTimesLockedBefore returns the unix time before which tracked times are locked, 0 if they are never locked
+func TrackedTimesLockedBefore() int64 {
+	if setting.Service.TimetrackingLockPeriod <= 0 {
+		return 0
+	}
+	return time.Now().Add(-setting.Service.TimetrackingLockPeriod).Unix()
+}
+
+// IsLocked returns true if the tracked time can no longer be changed
+func (t *TrackedTime) IsLocked() bool {
+	lockedBefore := TrackedTimesLockedBefore()
+	return lockedBefore > 0 && t.CreatedUnix < lockedBefore
+}
+
 // TrackedTimeList is a List of TrackedTime's
 type TrackedTimeList []*TrackedTime
 
@@ -168,6 +202,10 @@ func GetTrackedSeconds(ctx context.Context, opts FindTrackedTimesOptions) (track
 
 // AddTime will add the given time (in seconds) to the issue
 func AddTime(ctx context.Context, user *user_model.User, issue *Issue, amount int64, created time.Time) (*TrackedTime, error) {
+	if lockedBefore := TrackedTimesLockedBefore(); lockedBefore > 0 && !created.IsZero() && created.Unix() < lockedBefore {
+		return nil, ErrTrackedTimeLocked{}
+	}
+
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return nil, err
@@ -239,7 +277,7 @@ func TotalTimesForEachUser(ctx context.Context, options *FindTrackedTimesOptions
 	return totalTimes, nil
 }
 
-// DeleteIssueUserTimes deletes times for issue
+// DeleteIssueUserTimes deletes times for issue, except the locked ones
 func DeleteIssueUserTimes(ctx context.Context, issue *Issue, user *user_model.User) error {
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
@@ -248,8 +286,9 @@ func DeleteIssueUserTimes(ctx context.Context, issue *Issue, user *user_model.Us
 	defer committer.Close()
 
 	opts := FindTrackedTimesOptions{
-		IssueID: issue.ID,
-		UserID:  user.ID,
+		IssueID:          issue.ID,
+		UserID:           user.ID,
+		CreatedAfterUnix: TrackedTimesLockedBefore(),
 	}
 
 	removedTime, err := deleteTimes(ctx, opts)
@@ -257,6 +296,14 @@ func DeleteIssueUserTimes(ctx context.Context, issue *Issue, user *user_model.Us
 		return err
 	}
 	if removedTime == 0 {
+		if opts.CreatedAfterUnix > 0 {
+			opts.CreatedAfterUnix = 0
+			if locked, err := CountTrackedTimes(ctx, &opts); err != nil {
+				return err
+			} else if locked > 0 {
+				return ErrTrackedTimeLocked{}
+			}
+		}
 		return db.ErrNotExist{Resource: "tracked_time"}
 	}
 
@@ -286,6 +333,10 @@ func DeleteTime(ctx context.Context, t *TrackedTime) error {
 	}
 	defer committer.Close()
 
+	if t.IsLocked() {
+		return ErrTrackedTimeLocked{ID: t.ID}
+	}
+
 	if err := t.LoadAttributes(ctx); err != nil {
 		return err
 	}
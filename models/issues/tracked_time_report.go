@@ -0,0 +1,298 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"sort"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+
+	"xorm.io/builder"
+)
+
+// TimeReportGroupBy is the attribute the tracked times of a report are summed up by
+type TimeReportGroupBy string
+
+const (
+	TimeReportGroupByRepo      TimeReportGroupBy = "repo"
+	TimeReportGroupByMilestone TimeReportGroupBy = "milestone"
+	TimeReportGroupByLabel     TimeReportGroupBy = "label"
+	TimeReportGroupByUser      TimeReportGroupBy = "user"
+)
+
+// TimeReportGroupBys are all the ways to sum up the tracked times of a report
+var TimeReportGroupBys = []TimeReportGroupBy{
+	TimeReportGroupByRepo,
+	TimeReportGroupByMilestone,
+	TimeReportGroupByLabel,
+	TimeReportGroupByUser,
+}
+
+// IsValid checks if the grouping is known
+func (g TimeReportGroupBy) IsValid() bool {
+	for _, groupBy := range TimeReportGroupBys {
+		if g == groupBy {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeReportOptions selects the tracked times of a report. All the times of the issues of RepoIDs are selected,
+// but only the times of DoerID for the issues of OwnTimesRepoIDs. If an ID is 0 it will be ignored.
+type TimeReportOptions struct {
+	RepoIDs         []int64
+	OwnTimesRepoIDs []int64
+	DoerID          int64
+	MilestoneID     int64
+	LabelID         int64
+	UserID          int64
+	Since           int64
+	Before          int64
+	GroupBy         TimeReportGroupBy
+}
+
+func (opts *TimeReportOptions) toConds() builder.Cond {
+	repoCond := builder.NewCond()
+	if len(opts.RepoIDs) > 0 {
+		repoCond = repoCond.Or(builder.In("issue.repo_id", opts.RepoIDs))
+	}
+	if len(opts.OwnTimesRepoIDs) > 0 && opts.DoerID > 0 {
+		repoCond = repoCond.Or(builder.In("issue.repo_id", opts.OwnTimesRepoIDs).And(builder.Eq{"tracked_time.user_id": opts.DoerID}))
+	}
+	if !repoCond.IsValid() {
+		// no repository to report on
+		return builder.Expr("1 = 0")
+	}
+
+	cond := builder.NewCond().And(builder.Eq{"tracked_time.deleted": false}, repoCond)
+	if opts.MilestoneID != 0 {
+		cond = cond.And(builder.Eq{"issue.milestone_id": opts.MilestoneID})
+	}
+	if opts.LabelID != 0 {
+		cond = cond.And(builder.In("issue.id", builder.Select("issue_id").From("issue_label").Where(builder.Eq{"label_id": opts.LabelID})))
+	}
+	if opts.UserID != 0 {
+		cond = cond.And(builder.Eq{"tracked_time.user_id": opts.UserID})
+	}
+	if opts.Since != 0 {
+		cond = cond.And(builder.Gte{"tracked_time.created_unix": opts.Since})
+	}
+	if opts.Before != 0 {
+		cond = cond.And(builder.Lt{"tracked_time.created_unix": opts.Before})
+	}
+	return cond
+}
+
+// TimeReportEntry is the time tracked for a repository, milestone, label or user. The entry of ID 0 sums up
+// the times of the issues without milestone or label.
+type TimeReportEntry struct {
+	ID        int64
+	Time      int64
+	Repo      *repo_model.Repository
+	Milestone *Milestone
+	Label     *Label
+	User      *user_model.User
+}
+
+// Name returns the full name of the repository, or the name of the milestone, label or user of the entry,
+// an empty string if it has none
+func (entry *TimeReportEntry) Name() string {
+	switch {
+	case entry.Repo != nil:
+		return entry.Repo.FullName()
+	case entry.Milestone != nil:
+		return entry.Milestone.Name
+	case entry.Label != nil:
+		return entry.Label.Name
+	case entry.User != nil:
+		return entry.User.Name
+	}
+	return ""
+}
+
+// TimeReport sums up tracked times
+type TimeReport struct {
+	GroupBy TimeReportGroupBy
+	// Total is the sum of all the times, the times of an issue with several labels are counted once
+	Total   int64
+	Entries []*TimeReportEntry
+}
+
+// GetTimeReport sums up the tracked times selected by the options, grouped by repository, milestone, label or user.
+// The entries are sorted by decreasing time.
+func GetTimeReport(ctx context.Context, opts *TimeReportOptions) (*TimeReport, error) {
+	if !opts.GroupBy.IsValid() {
+		opts.GroupBy = TimeReportGroupByRepo
+	}
+	report := &TimeReport{GroupBy: opts.GroupBy}
+
+	total, err := db.GetEngine(ctx).Table("tracked_time").
+		Join("INNER", "issue", "issue.id = tracked_time.issue_id").
+		Where(opts.toConds()).
+		SumInt(&TrackedTime{}, "tracked_time.time")
+	if err != nil {
+		return nil, err
+	}
+	report.Total = total
+
+	sess := db.GetEngine(ctx).Table("tracked_time").
+		Join("INNER", "issue", "issue.id = tracked_time.issue_id")
+	var groupCol string
+	switch opts.GroupBy {
+	case TimeReportGroupByRepo:
+		groupCol = "issue.repo_id"
+	case TimeReportGroupByMilestone:
+		groupCol = "issue.milestone_id"
+	case TimeReportGroupByUser:
+		groupCol = "tracked_time.user_id"
+	case TimeReportGroupByLabel:
+		groupCol = "issue_label.label_id"
+		sess = sess.Join("LEFT", "issue_label", "issue_label.issue_id = issue.id")
+	}
+
+	type timeByGroup struct {
+		GroupID int64
+		Time    int64
+	}
+	var rows []timeByGroup
+	if err := sess.Where(opts.toConds()).
+		Select("COALESCE(" + groupCol + ", 0) AS group_id, SUM(tracked_time.time) AS time").
+		GroupBy(groupCol).
+		Find(&rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		report.Entries = append(report.Entries, &TimeReportEntry{ID: row.GroupID, Time: row.Time})
+		if row.GroupID > 0 {
+			ids = append(ids, row.GroupID)
+		}
+	}
+	if err := report.loadAttributes(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		if report.Entries[i].Time != report.Entries[j].Time {
+			return report.Entries[i].Time > report.Entries[j].Time
+		}
+		return report.Entries[i].ID < report.Entries[j].ID
+	})
+	return report, nil
+}
+
+func (report *TimeReport) loadAttributes(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	switch report.GroupBy {
+	case TimeReportGroupByRepo:
+		repos, err := repo_model.GetRepositoriesMapByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, entry := range report.Entries {
+			entry.Repo = repos[entry.ID]
+		}
+	case TimeReportGroupByMilestone:
+		milestones := make(map[int64]*Milestone, len(ids))
+		if err := db.GetEngine(ctx).In("id", ids).Find(&milestones); err != nil {
+			return err
+		}
+		for _, entry := range report.Entries {
+			entry.Milestone = milestones[entry.ID]
+		}
+	case TimeReportGroupByLabel:
+		labels, err := GetLabelsByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		labelsMap := make(map[int64]*Label, len(labels))
+		for _, label := range labels {
+			labelsMap[label.ID] = label
+		}
+		for _, entry := range report.Entries {
+			entry.Label = labelsMap[entry.ID]
+		}
+	case TimeReportGroupByUser:
+		users, err := user_model.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		usersMap := make(map[int64]*user_model.User, len(users))
+		for _, user := range users {
+			usersMap[user.ID] = user
+		}
+		for _, entry := range report.Entries {
+			if entry.User = usersMap[entry.ID]; entry.User == nil {
+				entry.User = user_model.NewGhostUser()
+			}
+		}
+	}
+	return nil
+}
+
+// GetTimeReportItems returns the tracked times selected by the options, oldest first,
+// with their issues, the repositories and milestones of the issues, and their users
+func GetTimeReportItems(ctx context.Context, opts *TimeReportOptions) (TrackedTimeList, error) {
+	var times TrackedTimeList
+	if err := db.GetEngine(ctx).Table("tracked_time").
+		Join("INNER", "issue", "issue.id = tracked_time.issue_id").
+		Where(opts.toConds()).
+		Asc("tracked_time.created_unix", "tracked_time.id").
+		Select("tracked_time.*").
+		Find(&times); err != nil {
+		return nil, err
+	}
+	return times, times.loadReportAttributes(ctx)
+}
+
+func (tl TrackedTimeList) loadReportAttributes(ctx context.Context) error {
+	if len(tl) == 0 {
+		return nil
+	}
+	issueIDs := make([]int64, 0, len(tl))
+	userIDs := make([]int64, 0, len(tl))
+	for _, t := range tl {
+		issueIDs = append(issueIDs, t.IssueID)
+		userIDs = append(userIDs, t.UserID)
+	}
+
+	issues, err := GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		return err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return err
+	}
+	if err := issues.LoadMilestones(ctx); err != nil {
+		return err
+	}
+	issuesMap := make(map[int64]*Issue, len(issues))
+	for _, issue := range issues {
+		issuesMap[issue.ID] = issue
+	}
+
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	usersMap := make(map[int64]*user_model.User, len(users))
+	for _, user := range users {
+		usersMap[user.ID] = user
+	}
+
+	for _, t := range tl {
+		t.Issue = issuesMap[t.IssueID]
+		if t.User = usersMap[t.UserID]; t.User == nil {
+			t.User = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
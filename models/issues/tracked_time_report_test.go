@@ -0,0 +1,80 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTimeReport(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	test := func(opts *issues_model.TimeReportOptions, total int64, expected map[int64]int64) {
+		t.Helper()
+		report, err := issues_model.GetTimeReport(db.DefaultContext, opts)
+		require.NoError(t, err)
+		assert.Equal(t, total, report.Total)
+		entries := make(map[int64]int64, len(report.Entries))
+		for _, entry := range report.Entries {
+			entries[entry.ID] = entry.Time
+		}
+		assert.Equal(t, expected, entries)
+	}
+
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1, 2}, GroupBy: issues_model.TimeReportGroupByRepo},
+		4158, map[int64]int64{1: 4083, 2: 75})
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, GroupBy: issues_model.TimeReportGroupByUser},
+		4083, map[int64]int64{1: 420, 2: 3663})
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, GroupBy: issues_model.TimeReportGroupByMilestone},
+		4083, map[int64]int64{0: 401, 1: 3682})
+	// the times of an issue with several labels are counted for each of them, but once in the total
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, GroupBy: issues_model.TimeReportGroupByLabel},
+		4083, map[int64]int64{1: 4082, 2: 1, 4: 3682})
+
+	// filters
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, LabelID: 2, GroupBy: issues_model.TimeReportGroupByUser},
+		1, map[int64]int64{2: 1})
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, MilestoneID: 1, UserID: 1, GroupBy: issues_model.TimeReportGroupByUser},
+		20, map[int64]int64{1: 20})
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{1}, Since: 946684802, Before: 946684805, GroupBy: issues_model.TimeReportGroupByUser},
+		2, map[int64]int64{2: 2})
+
+	// only the own times of the doer
+	test(&issues_model.TimeReportOptions{RepoIDs: []int64{2}, OwnTimesRepoIDs: []int64{1}, DoerID: 1, GroupBy: issues_model.TimeReportGroupByRepo},
+		495, map[int64]int64{1: 420, 2: 75})
+	test(&issues_model.TimeReportOptions{GroupBy: issues_model.TimeReportGroupByRepo},
+		0, map[int64]int64{})
+
+	report, err := issues_model.GetTimeReport(db.DefaultContext, &issues_model.TimeReportOptions{RepoIDs: []int64{1}, GroupBy: issues_model.TimeReportGroupByUser})
+	require.NoError(t, err)
+	if assert.Len(t, report.Entries, 2) {
+		assert.Equal(t, "user2", report.Entries[0].Name())
+		assert.Equal(t, "user1", report.Entries[1].Name())
+	}
+}
+
+func TestGetTimeReportItems(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	times, err := issues_model.GetTimeReportItems(db.DefaultContext, &issues_model.TimeReportOptions{RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	if assert.Len(t, times, 5) {
+		ids := make([]int64, 0, len(times))
+		for _, tt := range times {
+			ids = append(ids, tt.ID)
+			assert.NotNil(t, tt.Issue)
+			assert.NotNil(t, tt.Issue.Repo)
+			assert.NotNil(t, tt.User)
+		}
+		assert.Equal(t, []int64{1, 2, 3, 5, 6}, ids)
+		assert.Equal(t, "milestone1", times[1].Issue.Milestone.Name)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,3 +135,31 @@ func TestGetIssueTotalTrackedTime(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, 3682, ttt)
 }
+
+func TestTrackedTimeLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Service.TimetrackingLockPeriod, 30*24*time.Hour)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+
+	// the times of the fixtures are from 2000
+	tt := unittest.AssertExistsAndLoadBean(t, &issues_model.TrackedTime{ID: 2})
+	assert.True(t, tt.IsLocked())
+	err := issues_model.DeleteTime(db.DefaultContext, tt)
+	assert.True(t, issues_model.IsErrTrackedTimeLocked(err))
+
+	err = issues_model.DeleteIssueUserTimes(db.DefaultContext, issue2, user2)
+	assert.True(t, issues_model.IsErrTrackedTimeLocked(err))
+
+	_, err = issues_model.AddTime(db.DefaultContext, user2, issue2, 60, time.Now().AddDate(0, -2, 0))
+	assert.True(t, issues_model.IsErrTrackedTimeLocked(err))
+
+	// recent times can still be changed
+	added, err := issues_model.AddTime(db.DefaultContext, user2, issue2, 60, time.Now())
+	require.NoError(t, err)
+	assert.False(t, added.IsLocked())
+	require.NoError(t, issues_model.DeleteIssueUserTimes(db.DefaultContext, issue2, user2))
+	unittest.AssertExistsIf(t, true, &issues_model.TrackedTime{ID: added.ID, Deleted: true})
+	unittest.AssertExistsIf(t, true, &issues_model.TrackedTime{ID: 2, Deleted: false})
+}
@@ -86,6 +86,12 @@ type Team struct {
 	Units                   []*TeamUnit `xorm:"-"`
 	IncludesAllRepositories bool        `xorm:"NOT NULL DEFAULT false"`
 	CanCreateOrgRepo        bool        `xorm:"NOT NULL DEFAULT false"`
+	// ReviewAssignmentMode is how reviewers are picked among the members when the team is requested to review
+	ReviewAssignmentMode ReviewAssignmentMode `xorm:"NOT NULL DEFAULT 0"`
+	// ReviewAssignmentCount is the number of members picked by the review assignment
+	ReviewAssignmentCount int `xorm:"NOT NULL DEFAULT 1"`
+	// LastReviewAssigneeID is the member picked last by the review assignment, the round-robin continues after them
+	LastReviewAssigneeID int64 `xorm:"NOT NULL DEFAULT 0"`
 }
 
 func init() {
@@ -0,0 +1,87 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+)
+
+// ReviewAssignmentMode is how reviewers are picked among the members of a team when the team is requested
+// to review a pull request
+type ReviewAssignmentMode int
+
+const (
+	// ReviewAssignmentNone picks nobody, all the members of the team are notified
+	ReviewAssignmentNone ReviewAssignmentMode = iota
+	// ReviewAssignmentRoundRobin picks the members in turn
+	ReviewAssignmentRoundRobin
+	// ReviewAssignmentLoadBalance picks the members with the fewest pending review requests
+	ReviewAssignmentLoadBalance
+)
+
+var reviewAssignmentModeNames = map[ReviewAssignmentMode]string{
+	ReviewAssignmentNone:        "none",
+	ReviewAssignmentRoundRobin:  "round-robin",
+	ReviewAssignmentLoadBalance: "load-balance",
+}
+
+// String returns the name of the review assignment mode
+func (mode ReviewAssignmentMode) String() string {
+	return reviewAssignmentModeNames[mode]
+}
+
+// ParseReviewAssignmentMode returns the review assignment mode of a name, none if the name is empty,
+// and false if the name is unknown
+func ParseReviewAssignmentMode(name string) (ReviewAssignmentMode, bool) {
+	if name == "" {
+		return ReviewAssignmentNone, true
+	}
+	for mode, modeName := range reviewAssignmentModeNames {
+		if modeName == name {
+			return mode, true
+		}
+	}
+	return ReviewAssignmentNone, false
+}
+
+// MaxReviewAssignmentCount is the maximum number of members picked by the review assignment of a team
+const MaxReviewAssignmentCount = 10
+
+// HasReviewAssignment returns true if reviewers are picked among the members when the team is requested to review
+func (t *Team) HasReviewAssignment() bool {
+	return t.ReviewAssignmentMode != ReviewAssignmentNone
+}
+
+// LockTeamLastReviewAssignee returns the member picked last by the review assignment of a team, and locks the
+// team until the end of the transaction so that the next members are picked and recorded in turn. It must be
+// called in a transaction.
+func LockTeamLastReviewAssignee(ctx context.Context, teamID int64) (int64, error) {
+	// updating the row takes the lock of the team in every supported database
+	if _, err := db.GetEngine(ctx).Exec("UPDATE `team` SET last_review_assignee_id = last_review_assignee_id WHERE id = ?", teamID); err != nil {
+		return 0, err
+	}
+	var lastReviewAssigneeID int64
+	if _, err := db.GetEngine(ctx).Table("team").Where("id = ?", teamID).Cols("last_review_assignee_id").Get(&lastReviewAssigneeID); err != nil {
+		return 0, err
+	}
+	return lastReviewAssigneeID, nil
+}
+
+// UpdateTeamLastReviewAssignee records the member picked last by the review assignment of a team
+func UpdateTeamLastReviewAssignee(ctx context.Context, teamID, userID int64) error {
+	_, err := db.GetEngine(ctx).ID(teamID).Cols("last_review_assignee_id").Update(&Team{LastReviewAssigneeID: userID})
+	return err
+}
+
+// SetTeamMemberReviewAvailability sets whether a member of a team can be picked by the review assignment of the team
+func SetTeamMemberReviewAvailability(ctx context.Context, teamID, userID int64, available bool) error {
+	_, err := db.GetEngine(ctx).
+		Where("team_id=?", teamID).
+		And("uid=?", userID).
+		Cols("is_review_unavailable").
+		Update(&TeamUser{IsReviewUnavailable: !available})
+	return err
+}
@@ -18,6 +18,8 @@ type TeamUser struct {
 	OrgID  int64 `xorm:"INDEX"`
 	TeamID int64 `xorm:"UNIQUE(s)"`
 	UID    int64 `xorm:"UNIQUE(s)"`
+	// IsReviewUnavailable is set when the member does not want to be picked by the review assignment of the team
+	IsReviewUnavailable bool `xorm:"NOT NULL DEFAULT false"`
 }
 
 // IsTeamMember returns true if given user is a member of team.
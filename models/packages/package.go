@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/util"
 
 	"xorm.io/builder"
@@ -304,6 +305,33 @@ func GetPackagesByType(ctx context.Context, ownerID int64, packageType Type) ([]
 		Find(&ps)
 }
 
+// GetPackagesByNames gets the packages of a specific type with one of the names, regardless of their owner.
+// The packages with the same name are sorted by ID.
+func GetPackagesByNames(ctx context.Context, packageType Type, names []string) ([]*Package, error) {
+	lowerNames := make(container.Set[string], len(names))
+	for _, name := range names {
+		lowerNames.Add(strings.ToLower(name))
+	}
+	left := lowerNames.Values()
+
+	ps := make([]*Package, 0, 10)
+	for len(left) > 0 {
+		limit := min(len(left), db.DefaultMaxInSize)
+		if err := db.GetEngine(ctx).
+			Where(builder.Eq{
+				"package.type":        packageType,
+				"package.is_internal": false,
+			}).
+			And(builder.In("package.lower_name", left[:limit])).
+			Asc("package.id").
+			Find(&ps); err != nil {
+			return nil, err
+		}
+		left = left[limit:]
+	}
+	return ps, nil
+}
+
 // FindUnreferencedPackages gets all packages without associated versions
 func FindUnreferencedPackages(ctx context.Context) ([]int64, error) {
 	var pIDs []int64
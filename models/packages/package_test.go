@@ -5,6 +5,9 @@
 package packages_test
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"forgejo.org/models/db"
@@ -13,6 +16,7 @@ import (
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -309,3 +313,62 @@ func TestHasCountPackages(t *testing.T) {
 	require.EqualValues(t, 1, count)
 	require.NoError(t, err)
 }
+
+func TestGetPackagesAndVersionsByNames(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	insertPackage := func(t *testing.T, ownerID int64, name string, versions ...string) *packages_model.Package {
+		t.Helper()
+		p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+			OwnerID:   ownerID,
+			Name:      name,
+			LowerName: strings.ToLower(name),
+			Type:      packages_model.TypeNpm,
+		})
+		require.NoError(t, err)
+		for _, version := range versions {
+			_, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{
+				PackageID:    p.ID,
+				Version:      version,
+				LowerVersion: strings.ToLower(version),
+			})
+			require.NoError(t, err)
+		}
+		return p
+	}
+	first := insertPackage(t, 2, "first", "1.0.0", "1.1.0-RC1")
+	other := insertPackage(t, 3, "first", "1.0.0")
+	last := insertPackage(t, 2, "Last", "2.0.0")
+
+	// more names than fit in a single IN list
+	names := []string{"FIRST"}
+	for i := range 60 {
+		names = append(names, fmt.Sprintf("missing-%d", i))
+	}
+	names = append(names, "last", "first")
+
+	ps, err := packages_model.GetPackagesByNames(db.DefaultContext, packages_model.TypeNpm, names)
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(ps))
+	for _, p := range ps {
+		ids = append(ids, p.ID)
+	}
+	assert.ElementsMatch(t, []int64{first.ID, other.ID, last.ID}, ids)
+	assert.Less(t, slices.Index(ids, first.ID), slices.Index(ids, other.ID))
+
+	ps, err = packages_model.GetPackagesByNames(db.DefaultContext, packages_model.TypeCargo, names)
+	require.NoError(t, err)
+	assert.Empty(t, ps)
+
+	pvs, err := packages_model.GetVersionsByPackageIDsAndVersions(db.DefaultContext, []int64{first.ID, last.ID}, []string{"1.0.0", "1.1.0-rc1", "2.0.0", "3.0.0"})
+	require.NoError(t, err)
+	versions := make([]string, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, fmt.Sprintf("%d@%s", pv.PackageID, pv.LowerVersion))
+	}
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf("%d@1.0.0", first.ID),
+		fmt.Sprintf("%d@1.1.0-rc1", first.ID),
+		fmt.Sprintf("%d@2.0.0", last.ID),
+	}, versions)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
@@ -89,6 +90,34 @@ func GetVersionByNameAndVersion(ctx context.Context, ownerID int64, packageType
 	return getVersionByNameAndVersion(ctx, ownerID, packageType, name, version, false)
 }
 
+// GetVersionsByPackageIDsAndVersions gets the versions of the packages with one of the version numbers
+func GetVersionsByPackageIDsAndVersions(ctx context.Context, packageIDs []int64, versions []string) ([]*PackageVersion, error) {
+	lowerVersions := make(container.Set[string], len(versions))
+	for _, version := range versions {
+		lowerVersions.Add(strings.ToLower(version))
+	}
+	versionsLeft := lowerVersions.Values()
+
+	pvs := make([]*PackageVersion, 0, 10)
+	for len(versionsLeft) > 0 {
+		versionsLimit := min(len(versionsLeft), db.DefaultMaxInSize)
+		idsLeft := packageIDs
+		for len(idsLeft) > 0 {
+			idsLimit := min(len(idsLeft), db.DefaultMaxInSize)
+			if err := db.GetEngine(ctx).
+				Where(builder.Eq{"package_version.is_internal": false}).
+				And(builder.In("package_version.package_id", idsLeft[:idsLimit])).
+				And(builder.In("package_version.lower_version", versionsLeft[:versionsLimit])).
+				Find(&pvs); err != nil {
+				return nil, err
+			}
+			idsLeft = idsLeft[idsLimit:]
+		}
+		versionsLeft = versionsLeft[versionsLimit:]
+	}
+	return pvs, nil
+}
+
 // GetInternalVersionByNameAndVersion gets a version by name and version number
 func GetInternalVersionByNameAndVersion(ctx context.Context, ownerID int64, packageType Type, name, version string) (*PackageVersion, error) {
 	return getVersionByNameAndVersion(ctx, ownerID, packageType, name, version, true)
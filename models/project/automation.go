@@ -0,0 +1,190 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationEvent is the event on an issue or pull request which triggers an automation rule
+type AutomationEvent string
+
+const (
+	// AutomationEventItemOpened adds a new issue or pull request to the project, or moves it if it already is on it
+	AutomationEventItemOpened AutomationEvent = "item_opened"
+	// AutomationEventItemLabeled adds an issue or pull request to the project when the label is added to it,
+	// or moves it if it already is on it
+	AutomationEventItemLabeled AutomationEvent = "item_labeled"
+	// AutomationEventItemClosed moves an issue or pull request of the project when it is closed
+	AutomationEventItemClosed AutomationEvent = "item_closed"
+	// AutomationEventItemReopened moves an issue or pull request of the project when it is reopened
+	AutomationEventItemReopened AutomationEvent = "item_reopened"
+	// AutomationEventPullRequestOpened moves the issues of the project which a new pull request closes
+	AutomationEventPullRequestOpened AutomationEvent = "pull_request_opened"
+)
+
+// AutomationEvents are all the events an automation rule can be triggered by
+var AutomationEvents = []AutomationEvent{
+	AutomationEventItemOpened,
+	AutomationEventItemLabeled,
+	AutomationEventItemClosed,
+	AutomationEventItemReopened,
+	AutomationEventPullRequestOpened,
+}
+
+// IsValid checks if the event is known
+func (e AutomationEvent) IsValid() bool {
+	for _, event := range AutomationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAddItems returns true for the events which add items to the project which are not on it yet
+func (e AutomationEvent) CanAddItems() bool {
+	return e == AutomationEventItemOpened || e == AutomationEventItemLabeled
+}
+
+// SupportsLabel returns true for the events which can be restricted to items with a label
+func (e AutomationEvent) SupportsLabel() bool {
+	return e == AutomationEventItemOpened || e == AutomationEventItemLabeled
+}
+
+// ErrAutomationNotExist represents a "AutomationNotExist" kind of error.
+type ErrAutomationNotExist struct {
+	ID        int64
+	ProjectID int64
+}
+
+// IsErrAutomationNotExist checks if an error is a ErrAutomationNotExist
+func IsErrAutomationNotExist(err error) bool {
+	_, ok := err.(ErrAutomationNotExist)
+	return ok
+}
+
+func (err ErrAutomationNotExist) Error() string {
+	return fmt.Sprintf("project automation does not exist [id: %d, project id: %d]", err.ID, err.ProjectID)
+}
+
+func (err ErrAutomationNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Automation is a rule moving the issues and pull requests of a project to a column when an event happens on them
+type Automation struct {
+	ID        int64           `xorm:"pk autoincr"`
+	ProjectID int64           `xorm:"INDEX NOT NULL"`
+	Event     AutomationEvent `xorm:"VARCHAR(32) NOT NULL"`
+	// LabelID restricts the rule to items with that label, it is required for AutomationEventItemLabeled
+	LabelID   int64 `xorm:"NOT NULL DEFAULT 0"`
+	ColumnID  int64 `xorm:"INDEX NOT NULL"`
+	CreatorID int64 `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (Automation) TableName() string {
+	return "project_automation"
+}
+
+func init() {
+	db.RegisterModel(new(Automation))
+}
+
+// NewAutomation adds an automation rule to a project, its column must belong to the project
+func NewAutomation(ctx context.Context, automation *Automation) error {
+	if !automation.Event.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown event %q", automation.Event)
+	}
+	if automation.Event == AutomationEventItemLabeled && automation.LabelID == 0 {
+		return util.NewInvalidArgumentErrorf("a label is required for the event %q", automation.Event)
+	}
+	if !automation.Event.SupportsLabel() {
+		automation.LabelID = 0
+	}
+
+	column, err := GetColumn(ctx, automation.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.ProjectID != automation.ProjectID {
+		return ErrProjectColumnNotExist{ColumnID: automation.ColumnID}
+	}
+
+	return db.Insert(ctx, automation)
+}
+
+// GetAutomationsByProjectID returns the automation rules of a project
+func GetAutomationsByProjectID(ctx context.Context, projectID int64) ([]*Automation, error) {
+	automations := make([]*Automation, 0, 5)
+	return automations, db.GetEngine(ctx).Where("project_id = ?", projectID).Asc("id").Find(&automations)
+}
+
+// GetAutomationByID returns an automation rule of a project
+func GetAutomationByID(ctx context.Context, projectID, id int64) (*Automation, error) {
+	automation := &Automation{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", id, projectID).Get(automation)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAutomationNotExist{ID: id, ProjectID: projectID}
+	}
+	return automation, nil
+}
+
+// DeleteAutomationByID deletes an automation rule of a project
+func DeleteAutomationByID(ctx context.Context, projectID, id int64) error {
+	affected, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", id, projectID).Delete(&Automation{})
+	if err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrAutomationNotExist{ID: id, ProjectID: projectID}
+	}
+	return nil
+}
+
+// FindAutomationsOptions selects the automation rules triggered by an event, either of a project
+// or of the projects of a repository and of its owner
+type FindAutomationsOptions struct {
+	Event     AutomationEvent
+	ProjectID int64
+	RepoID    int64
+	OwnerID   int64
+}
+
+// FindAutomations returns the automation rules of open projects triggered by an event, in the order they were created
+func FindAutomations(ctx context.Context, opts FindAutomationsOptions) ([]*Automation, error) {
+	cond := builder.NewCond()
+	if opts.ProjectID > 0 {
+		cond = cond.And(builder.Eq{"project.id": opts.ProjectID})
+	} else {
+		cond = cond.And(builder.Eq{"project.repo_id": opts.RepoID}.
+			Or(builder.Eq{"project.owner_id": opts.OwnerID, "project.repo_id": 0}))
+	}
+
+	automations := make([]*Automation, 0, 5)
+	return automations, db.GetEngine(ctx).
+		Join("INNER", "project", "project.id = project_automation.project_id").
+		Where(cond).
+		And("project_automation.event = ?", opts.Event).
+		And("project.is_closed = ?", false).
+		Asc("project_automation.id").
+		Find(&automations)
+}
+
+func deleteAutomationsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&Automation{})
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	err := NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: "unknown", ColumnID: 3})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventItemLabeled, ColumnID: 3})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	// column of another project
+	err = NewAutomation(db.DefaultContext, &Automation{ProjectID: 1, Event: AutomationEventItemClosed, ColumnID: 4})
+	assert.True(t, IsErrProjectColumnNotExist(err))
+
+	closed := &Automation{ProjectID: 1, Event: AutomationEventItemClosed, LabelID: 1, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, closed))
+	// the label is ignored for events which do not support it
+	assert.EqualValues(t, 0, closed.LabelID)
+	ownerClosed := &Automation{ProjectID: 4, Event: AutomationEventItemClosed, ColumnID: 4, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, ownerClosed))
+	opened := &Automation{ProjectID: 1, Event: AutomationEventItemOpened, ColumnID: 2, CreatorID: 2}
+	require.NoError(t, NewAutomation(db.DefaultContext, opened))
+
+	automations, err := FindAutomations(db.DefaultContext, FindAutomationsOptions{Event: AutomationEventItemClosed, RepoID: 1, OwnerID: 2})
+	require.NoError(t, err)
+	if assert.Len(t, automations, 2) {
+		assert.EqualValues(t, closed.ID, automations[0].ID)
+		assert.EqualValues(t, ownerClosed.ID, automations[1].ID)
+	}
+	automations, err = FindAutomations(db.DefaultContext, FindAutomationsOptions{Event: AutomationEventItemClosed, ProjectID: 4})
+	require.NoError(t, err)
+	if assert.Len(t, automations, 1) {
+		assert.EqualValues(t, ownerClosed.ID, automations[0].ID)
+	}
+
+	require.NoError(t, DeleteAutomationByID(db.DefaultContext, 1, closed.ID))
+	_, err = GetAutomationByID(db.DefaultContext, 1, closed.ID)
+	assert.True(t, IsErrAutomationNotExist(err))
+	// the rule belongs to another project
+	err = DeleteAutomationByID(db.DefaultContext, 1, ownerClosed.ID)
+	assert.True(t, IsErrAutomationNotExist(err))
+
+	// deleting a column deletes its rules
+	require.NoError(t, DeleteColumnByID(db.DefaultContext, 2))
+	_, err = GetAutomationByID(db.DefaultContext, 1, opened.ID)
+	assert.True(t, IsErrAutomationNotExist(err))
+}
@@ -190,7 +190,8 @@ func deleteColumnByID(ctx context.Context, columnID int64) error {
 	if _, err := db.GetEngine(ctx).ID(column.ID).NoAutoCondition().Delete(column); err != nil {
 		return err
 	}
-	return nil
+	_, err = db.GetEngine(ctx).Where("column_id = ?", column.ID).Delete(&Automation{})
+	return err
 }
 
 func deleteColumnByProjectID(ctx context.Context, projectID int64) error {
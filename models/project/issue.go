@@ -86,6 +86,24 @@ func MoveIssuesOnProjectColumn(ctx context.Context, column *Column, sortedIssueI
 	})
 }
 
+// MoveIssueToColumnEnd moves an issue already added to the project of the column to the end of the column
+func MoveIssueToColumnEnd(ctx context.Context, column *Column, issueID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		res := struct {
+			MaxSorting int64
+			IssueCount int64
+		}{}
+		if _, err := db.GetEngine(ctx).Select("max(sorting) as max_sorting, count(*) as issue_count").Table("project_issue").
+			Where("project_id=?", column.ProjectID).
+			And("project_board_id=?", column.ID).
+			Get(&res); err != nil {
+			return err
+		}
+		sorting := util.Iif(res.IssueCount > 0, res.MaxSorting+1, 0)
+		return MoveIssuesOnProjectColumn(ctx, column, map[int64]int64{sorting: issueID})
+	})
+}
+
 func (c *Column) moveIssuesToAnotherColumn(ctx context.Context, newColumn *Column) error {
 	if c.ProjectID != newColumn.ProjectID {
 		return fmt.Errorf("columns have to be in the same project")
@@ -41,6 +41,7 @@ type ReviewState struct {
 	PullID       int64                  `xorm:"NOT NULL INDEX UNIQUE(pull_commit_user) DEFAULT 0"` // Which PR was the review on?
 	CommitSHA    string                 `xorm:"NOT NULL VARCHAR(64) UNIQUE(pull_commit_user)"`     // Which commit was the head commit for the review?
 	UpdatedFiles map[string]ViewedState `xorm:"NOT NULL LONGTEXT JSON"`                            // Stores for each of the changed files of a PR whether they have been viewed, changed since last viewed, or not viewed
+	ViewedBlobs  map[string]string      `xorm:"LONGTEXT JSON"`                                     // Stores for each of the viewed files the ID of the blob which was viewed
 	UpdatedUnix  timeutil.TimeStamp     `xorm:"updated"`                                           // Is an accurate indicator of the order of commits as we do not expect it to be possible to make reviews on previous commits
 }
 
@@ -57,9 +58,28 @@ func GetReviewState(ctx context.Context, userID, pullID int64, commitSHA string)
 	return review, has, err
 }
 
+// GetViewedState returns the viewed state of the file at path. blobID is the ID of the blob of the current content
+// of the file, and changedSinceReview tells whether the file changed since the commit of the review.
+// A file whose viewed content is known stays viewed as long as its content is the same, even if the commits
+// changed in the meantime, e.g. by a rebase.
+func (review *ReviewState) GetViewedState(path, blobID string, changedSinceReview bool) ViewedState {
+	if viewedBlobID, ok := review.ViewedBlobs[path]; ok && blobID != "" {
+		if viewedBlobID == blobID {
+			return Viewed
+		}
+		return HasChanged
+	}
+
+	if changedSinceReview {
+		return HasChanged
+	}
+	return review.UpdatedFiles[path]
+}
+
 // UpdateReviewState updates the given review inside the database, regardless of whether it existed before or not
-// The given map of files with their viewed state will be merged with the previous review, if present
-func UpdateReviewState(ctx context.Context, userID, pullID int64, commitSHA string, updatedFiles map[string]ViewedState) error {
+// The given map of files with their viewed state will be merged with the previous review, if present.
+// viewedBlobs stores the IDs of the blobs of the files which are marked as viewed, if known.
+func UpdateReviewState(ctx context.Context, userID, pullID int64, commitSHA string, updatedFiles map[string]ViewedState, viewedBlobs map[string]string) error {
 	log.Trace("Updating review for user %d, repo %d, commit %s with the updated files %v.", userID, pullID, commitSHA, updatedFiles)
 
 	review, exists, err := GetReviewState(ctx, userID, pullID, commitSHA)
@@ -75,9 +95,11 @@ func UpdateReviewState(ctx context.Context, userID, pullID int64, commitSHA stri
 		// Overwrite the viewed files of the previous review if present
 	} else if previousReview != nil {
 		review.UpdatedFiles = mergeFiles(previousReview.UpdatedFiles, updatedFiles)
+		review.ViewedBlobs = previousReview.ViewedBlobs
 	} else {
 		review.UpdatedFiles = updatedFiles
 	}
+	review.ViewedBlobs = mergeViewedBlobs(review.ViewedBlobs, updatedFiles, viewedBlobs)
 
 	// Insert or Update review
 	engine := db.GetEngine(ctx)
@@ -87,10 +109,27 @@ func UpdateReviewState(ctx context.Context, userID, pullID int64, commitSHA stri
 		return err
 	}
 	log.Trace("Updating already existing review with ID %d (user %d, repo %d, commit %s) with the updated files %v.", review.ID, userID, pullID, commitSHA, review.UpdatedFiles)
-	_, err = engine.ID(review.ID).Update(&ReviewState{UpdatedFiles: review.UpdatedFiles})
+	_, err = engine.ID(review.ID).Cols("updated_files", "viewed_blobs").Update(&ReviewState{UpdatedFiles: review.UpdatedFiles, ViewedBlobs: review.ViewedBlobs})
 	return err
 }
 
+// mergeViewedBlobs updates the viewed blobs of the files with the given viewed states: the blobs of the
+// files which are not viewed anymore are forgotten, and the blobs of the newly viewed files are recorded
+func mergeViewedBlobs(oldBlobs map[string]string, updatedFiles map[string]ViewedState, newBlobs map[string]string) map[string]string {
+	blobs := make(map[string]string, len(oldBlobs)+len(newBlobs))
+	for file, blobID := range oldBlobs {
+		blobs[file] = blobID
+	}
+	for file, state := range updatedFiles {
+		if blobID, ok := newBlobs[file]; ok && state == Viewed {
+			blobs[file] = blobID
+		} else {
+			delete(blobs, file)
+		}
+	}
+	return blobs
+}
+
 // mergeFiles merges the given maps of files with their viewing state into one map.
 // Values from oldFiles will be overridden with values from newFiles
 func mergeFiles(oldFiles, newFiles map[string]ViewedState) map[string]ViewedState {
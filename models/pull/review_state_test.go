@@ -0,0 +1,36 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewStateGetViewedState(t *testing.T) {
+	review := &ReviewState{
+		UpdatedFiles: map[string]ViewedState{"a.go": Viewed, "b.go": Viewed, "c.go": HasChanged, "d.go": Viewed},
+		ViewedBlobs:  map[string]string{"a.go": "1111", "b.go": "2222"},
+	}
+
+	// the viewed content is compared, whatever the commits
+	assert.Equal(t, Viewed, review.GetViewedState("a.go", "1111", true))
+	assert.Equal(t, HasChanged, review.GetViewedState("b.go", "3333", false))
+
+	// without a known content, the changes since the review are used
+	assert.Equal(t, HasChanged, review.GetViewedState("c.go", "", false))
+	assert.Equal(t, Viewed, review.GetViewedState("d.go", "4444", false))
+	assert.Equal(t, HasChanged, review.GetViewedState("d.go", "4444", true))
+	assert.Equal(t, Unviewed, review.GetViewedState("e.go", "5555", false))
+}
+
+func TestMergeViewedBlobs(t *testing.T) {
+	blobs := mergeViewedBlobs(
+		map[string]string{"a.go": "1111", "b.go": "2222"},
+		map[string]ViewedState{"a.go": Unviewed, "c.go": Viewed, "d.go": Viewed},
+		map[string]string{"c.go": "3333"},
+	)
+	assert.Equal(t, map[string]string{"b.go": "2222", "c.go": "3333"}, blobs)
+}
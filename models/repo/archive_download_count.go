@@ -64,6 +64,10 @@ func GetArchiveDownloadCount(ctx context.Context, repoID, releaseID int64) (*api
 			tagCounter.Zip = singleCount.Count
 		case git.TARGZ:
 			tagCounter.TarGz = singleCount.Count
+		case git.TARZST:
+			tagCounter.TarZst = singleCount.Count
+		case git.TARXZ:
+			tagCounter.TarXz = singleCount.Count
 		}
 	}
 
@@ -0,0 +1,86 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/dependency"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// RepoDependency is a dependency declared in a manifest or lock file on the default branch of a repository
+type RepoDependency struct { //revive:disable-line:exported
+	ID          int64  `xorm:"pk autoincr"`
+	RepoID      int64  `xorm:"INDEX NOT NULL"`
+	CommitID    string `xorm:"VARCHAR(64)"`
+	Manifest    string `xorm:"TEXT"`
+	Ecosystem   string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	Name        string `xorm:"NOT NULL"`
+	Version     string
+	IsDev       bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoDependency))
+}
+
+// ToDependency converts the record to a parsed dependency
+func (d *RepoDependency) ToDependency() *dependency.Dependency {
+	return &dependency.Dependency{
+		Ecosystem: dependency.Ecosystem(d.Ecosystem),
+		Name:      d.Name,
+		Version:   d.Version,
+		IsDev:     d.IsDev,
+	}
+}
+
+// UpdateRepoDependencies replaces the dependencies of a repository with those found at the commit
+func UpdateRepoDependencies(ctx context.Context, repoID int64, commitID string, deps []*RepoDependency) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&RepoDependency{}); err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			dep.RepoID = repoID
+			dep.CommitID = commitID
+		}
+		// Insert in batches to stay below the limit of parameters per statement
+		for len(deps) > 0 {
+			batch := deps[:min(len(deps), 100)]
+			if _, err := db.GetEngine(ctx).Insert(&batch); err != nil {
+				return err
+			}
+			deps = deps[len(batch):]
+		}
+		return nil
+	})
+}
+
+// FindRepoDependenciesOptions represents the options to find the dependencies of a repository
+type FindRepoDependenciesOptions struct {
+	db.ListOptions
+	RepoID    int64
+	Ecosystem string
+	Keyword   string
+}
+
+func (opts FindRepoDependenciesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.Ecosystem != "" {
+		cond = cond.And(builder.Eq{"ecosystem": opts.Ecosystem})
+	}
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"name", opts.Keyword})
+	}
+	return cond
+}
+
+func (opts FindRepoDependenciesOptions) ToOrders() string {
+	return "ecosystem, name, version, id"
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRepoDependencies(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, repo_model.UpdateRepoDependencies(db.DefaultContext, 1, "commit1", []*repo_model.RepoDependency{
+		{Manifest: "go.mod", Ecosystem: "golang", Name: "github.com/stretchr/testify", Version: "v1.10.0"},
+		{Manifest: "web/package-lock.json", Ecosystem: "npm", Name: "left-pad", Version: "1.3.0", IsDev: true},
+	}))
+	require.NoError(t, repo_model.UpdateRepoDependencies(db.DefaultContext, 2, "commit2", []*repo_model.RepoDependency{
+		{Manifest: "Cargo.lock", Ecosystem: "cargo", Name: "serde", Version: "1.0.200"},
+	}))
+
+	deps, err := db.Find[repo_model.RepoDependency](db.DefaultContext, repo_model.FindRepoDependenciesOptions{RepoID: 1})
+	require.NoError(t, err)
+	require.Len(t, deps, 2)
+	assert.Equal(t, "github.com/stretchr/testify", deps[0].Name)
+	assert.Equal(t, "commit1", deps[0].CommitID)
+
+	deps, err = db.Find[repo_model.RepoDependency](db.DefaultContext, repo_model.FindRepoDependenciesOptions{RepoID: 1, Ecosystem: "npm", Keyword: "pad"})
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.True(t, deps[0].IsDev)
+	assert.Equal(t, "pkg:npm/left-pad@1.3.0", deps[0].ToDependency().PURL())
+
+	// Updating replaces all dependencies of the repository only
+	require.NoError(t, repo_model.UpdateRepoDependencies(db.DefaultContext, 1, "commit3", nil))
+	unittest.AssertNotExistsBean(t, &repo_model.RepoDependency{RepoID: 1})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.RepoDependency{RepoID: 2, Name: "serde"})
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/hex"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// IncomingEmail is the address of a repository which opens a new issue for each email it receives
+type IncomingEmail struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"UNIQUE NOT NULL"`
+	Token  string `xorm:"VARCHAR(32) UNIQUE NOT NULL"`
+	// EnableServiceDesk accepts emails from senders without account, they get the comments on their issues by email
+	EnableServiceDesk bool `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (IncomingEmail) TableName() string {
+	return "repo_incoming_email"
+}
+
+func init() {
+	db.RegisterModel(new(IncomingEmail))
+}
+
+// GenerateIncomingEmailToken returns a random token for an incoming email address
+func GenerateIncomingEmailToken() string {
+	// lower case only, some mail servers don't preserve the case of addresses
+	return hex.EncodeToString(util.CryptoRandomBytes(16))
+}
+
+// GetIncomingEmail returns the incoming email address of a repository, nil if it has none
+func GetIncomingEmail(ctx context.Context, repoID int64) (*IncomingEmail, error) {
+	incoming := &IncomingEmail{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(incoming)
+	if err != nil || !has {
+		return nil, err
+	}
+	return incoming, nil
+}
+
+// GetIncomingEmailByToken returns the incoming email address of the token, nil if there is none
+func GetIncomingEmailByToken(ctx context.Context, token string) (*IncomingEmail, error) {
+	incoming := &IncomingEmail{}
+	has, err := db.GetEngine(ctx).Where("token = ?", token).Get(incoming)
+	if err != nil || !has {
+		return nil, err
+	}
+	return incoming, nil
+}
+
+// SetIncomingEmail enables the incoming email address of a repository, or changes its service desk mode
+func SetIncomingEmail(ctx context.Context, repoID int64, enableServiceDesk bool) (*IncomingEmail, error) {
+	incoming, err := GetIncomingEmail(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if incoming == nil {
+		incoming = &IncomingEmail{
+			RepoID:            repoID,
+			Token:             GenerateIncomingEmailToken(),
+			EnableServiceDesk: enableServiceDesk,
+		}
+		return incoming, db.Insert(ctx, incoming)
+	}
+	incoming.EnableServiceDesk = enableServiceDesk
+	_, err = db.GetEngine(ctx).ID(incoming.ID).Cols("enable_service_desk").Update(incoming)
+	return incoming, err
+}
+
+// ResetIncomingEmailToken changes the incoming email address of a repository, the former one stops working
+func ResetIncomingEmailToken(ctx context.Context, incoming *IncomingEmail) error {
+	incoming.Token = GenerateIncomingEmailToken()
+	_, err := db.GetEngine(ctx).ID(incoming.ID).Cols("token").Update(incoming)
+	return err
+}
+
+// DeleteIncomingEmail disables the incoming email address of a repository
+func DeleteIncomingEmail(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&IncomingEmail{})
+	return err
+}
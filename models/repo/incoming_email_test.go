@@ -0,0 +1,51 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomingEmail(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	incoming, err := repo_model.GetIncomingEmail(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Nil(t, incoming)
+
+	incoming, err = repo_model.SetIncomingEmail(db.DefaultContext, 1, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, incoming.RepoID)
+	assert.Len(t, incoming.Token, 32)
+	assert.False(t, incoming.EnableServiceDesk)
+	token := incoming.Token
+
+	incoming, err = repo_model.SetIncomingEmail(db.DefaultContext, 1, true)
+	require.NoError(t, err)
+	assert.True(t, incoming.EnableServiceDesk)
+	assert.Equal(t, token, incoming.Token)
+
+	byToken, err := repo_model.GetIncomingEmailByToken(db.DefaultContext, token)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, byToken.RepoID)
+	assert.True(t, byToken.EnableServiceDesk)
+
+	require.NoError(t, repo_model.ResetIncomingEmailToken(db.DefaultContext, incoming))
+	assert.NotEqual(t, token, incoming.Token)
+	byToken, err = repo_model.GetIncomingEmailByToken(db.DefaultContext, token)
+	require.NoError(t, err)
+	assert.Nil(t, byToken)
+
+	require.NoError(t, repo_model.DeleteIncomingEmail(db.DefaultContext, 1))
+	incoming, err = repo_model.GetIncomingEmail(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Nil(t, incoming)
+}
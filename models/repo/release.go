@@ -89,6 +89,9 @@ type Release struct {
 	Attachments          []*Attachment                    `xorm:"-"`
 	CreatedUnix          timeutil.TimeStamp               `xorm:"INDEX"`
 	ArchiveDownloadCount *structs.TagArchiveDownloadCount `xorm:"-"`
+	// SignedChecksums is the checksums manifest of the assets signed last, ChecksumsSignature is its detached signature
+	SignedChecksums    string `xorm:"TEXT"`
+	ChecksumsSignature string `xorm:"TEXT"`
 }
 
 func init() {
@@ -103,7 +106,7 @@ func (r *Release) LoadAttributes(ctx context.Context) error {
 	}
 
 	if r.Publisher == nil {
-		r.Publisher, err = user_model.GetUserByID(ctx, r.PublisherID)
+		r.Publisher, err = user_model.GetPossibleUserByID(ctx, r.PublisherID)
 		if err != nil {
 			if user_model.IsErrUserNotExist(err) {
 				r.Publisher = user_model.NewGhostUser()
@@ -184,6 +187,32 @@ func (r *Release) APIUploadURL() string {
 	return r.APIURL() + "/assets"
 }
 
+// ReleaseChecksumsFileName is the name of the generated file listing the checksums of the assets of a release
+const ReleaseChecksumsFileName = "SHA256SUMS"
+
+// ChecksumsURL the url of the generated checksums file of a release. release must have attributes loaded
+func (r *Release) ChecksumsURL() string {
+	return r.Repo.HTMLURL() + "/releases/download/" + util.PathEscapeSegments(r.TagName) + "/" + ReleaseChecksumsFileName
+}
+
+// ReleaseChecksumsSignatureFileName returns the name of the file of a detached signature of the checksums
+// of a release: SHA256SUMS.sig for an SSH signature and SHA256SUMS.asc for an OpenPGP signature
+func ReleaseChecksumsSignatureFileName(signature string) string {
+	if signature == "" {
+		return ""
+	}
+	if strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----") {
+		return ReleaseChecksumsFileName + ".sig"
+	}
+	return ReleaseChecksumsFileName + ".asc"
+}
+
+// ChecksumsSignatureFileName returns the name of the file of the detached signature of the checksums of
+// a release, or an empty string if they are not signed
+func (r *Release) ChecksumsSignatureFileName() string {
+	return ReleaseChecksumsSignatureFileName(r.ChecksumsSignature)
+}
+
 // Link the relative url for a release on the web UI. release must have attributes loaded
 func (r *Release) Link() string {
 	return r.Repo.Link() + "/releases/tag/" + util.PathEscapeSegments(r.TagName)
@@ -219,6 +248,12 @@ func UpdateRelease(ctx context.Context, rel *Release) error {
 	return err
 }
 
+// UpdateReleaseChecksumsSignature updates the signed checksums manifest of a release and its signature
+func UpdateReleaseChecksumsSignature(ctx context.Context, rel *Release) error {
+	_, err := db.GetEngine(ctx).ID(rel.ID).Cols("signed_checksums", "checksums_signature").Update(rel)
+	return err
+}
+
 // AddReleaseAttachments adds a release attachments
 func AddReleaseAttachments(ctx context.Context, releaseID int64, attachmentUUIDs []string) (err error) {
 	// Check attachments
@@ -30,7 +30,7 @@ func (r ReleaseList) LoadAttributes(ctx context.Context) error {
 
 		publisher, ok := userCache[release.PublisherID]
 		if !ok {
-			publisher, err = user_model.GetUserByID(ctx, release.PublisherID)
+			publisher, err = user_model.GetPossibleUserByID(ctx, release.PublisherID)
 			if err != nil {
 				if !user_model.IsErrUserNotExist(err) {
 					return err
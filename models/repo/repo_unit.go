@@ -161,6 +161,9 @@ type PullRequestsConfig struct {
 	DefaultMergeStyle             MergeStyle
 	DefaultUpdateStyle            UpdateStyle
 	DefaultAllowMaintainerEdit    bool
+	BlockMergeOutOfStackOrder     bool
+	// MergeMessageTemplates are the templates of the default merge messages, by merge style
+	MergeMessageTemplates map[MergeStyle]string
 }
 
 // FromDB fills up a PullRequestsConfig from serialized format.
@@ -198,6 +201,12 @@ func (cfg *PullRequestsConfig) GetDefaultMergeStyle() MergeStyle {
 	return MergeStyleMerge
 }
 
+// GetMergeMessageTemplate returns the template of the default merge message of a merge style,
+// empty if the repository does not have one
+func (cfg *PullRequestsConfig) GetMergeMessageTemplate(mergeStyle MergeStyle) string {
+	return cfg.MergeMessageTemplates[mergeStyle]
+}
+
 // IsUpdateStyleAllowed returns if update style is allowed
 func (cfg *PullRequestsConfig) IsUpdateStyleAllowed(updateStyle UpdateStyle) bool {
 	return updateStyle == UpdateStyleMerge ||
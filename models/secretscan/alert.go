@@ -0,0 +1,118 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SecretScanAlert is raised when a secret is found in content pushed to a repository.
+// There is only one alert per secret and repository, no matter how often it is pushed.
+type SecretScanAlert struct {
+	ID          int64  `xorm:"pk autoincr"`
+	RepoID      int64  `xorm:"INDEX UNIQUE(repo_secret) NOT NULL"`
+	SecretHash  string `xorm:"UNIQUE(repo_secret) VARCHAR(64) NOT NULL"` // sha256 of the secret
+	RuleID      string `xorm:"NOT NULL"`
+	Description string
+	Redacted    string
+	Path        string `xorm:"TEXT"`
+	Line        int
+	RefName     string `xorm:"TEXT"`
+	CommitID    string `xorm:"VARCHAR(64)"`
+	PusherID    int64
+	// IsRevoked is set if the secret was an access token of this instance and has been deleted
+	IsRevoked    bool               `xorm:"NOT NULL DEFAULT false"`
+	IsResolved   bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SecretScanAlert))
+}
+
+// ErrSecretScanAlertNotExist represents a "secret scan alert not exist" error.
+type ErrSecretScanAlertNotExist struct {
+	ID int64
+}
+
+func (err ErrSecretScanAlertNotExist) Error() string {
+	return fmt.Sprintf("secret scan alert does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSecretScanAlertNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// HashSecret returns the hash alerts are identified by
+func HashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// InsertAlertIfNotExist inserts the alert unless the secret has already been reported for
+// the repository. It returns true if the alert was inserted.
+func InsertAlertIfNotExist(ctx context.Context, alert *SecretScanAlert) (bool, error) {
+	inserted := false
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Exist(&SecretScanAlert{RepoID: alert.RepoID, SecretHash: alert.SecretHash})
+		if err != nil || has {
+			return err
+		}
+		inserted = true
+		return db.Insert(ctx, alert)
+	})
+	return inserted, err
+}
+
+// GetAlertByID returns the alert of a repository
+func GetAlertByID(ctx context.Context, repoID, id int64) (*SecretScanAlert, error) {
+	alert := new(SecretScanAlert)
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(alert)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSecretScanAlertNotExist{ID: id}
+	}
+	return alert, nil
+}
+
+// ResolveAlert marks an alert as resolved by the doer
+func ResolveAlert(ctx context.Context, alert *SecretScanAlert, doerID int64) error {
+	alert.IsResolved = true
+	alert.ResolverID = doerID
+	alert.ResolvedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(alert.ID).Cols("is_resolved", "resolver_id", "resolved_unix").Update(alert)
+	return err
+}
+
+// FindAlertsOptions represents the options to find the alerts of a repository
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID     int64
+	IsResolved optional.Option[bool]
+}
+
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.IsResolved.Has() {
+		cond = cond.And(builder.Eq{"is_resolved": opts.IsResolved.Value()})
+	}
+	return cond
+}
+
+func (opts FindAlertsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/secretscan"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// SecretScanPattern is a custom pattern secrets are detected by, in addition to the
+// built-in rules. Patterns with RepoID 0 are set by site administrators and apply to
+// all repositories.
+type SecretScanPattern struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Pattern     string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SecretScanPattern))
+}
+
+// ToRule compiles the pattern into a scanning rule
+func (p *SecretScanPattern) ToRule() (*secretscan.Rule, error) {
+	return secretscan.NewRule(fmt.Sprintf("custom-%d", p.ID), p.Name, p.Pattern)
+}
+
+// FindPatternsOptions represents the options to find custom patterns
+type FindPatternsOptions struct {
+	db.ListOptions
+	RepoID int64
+	// IncludeGlobal also returns the patterns applying to all repositories
+	IncludeGlobal bool
+}
+
+func (opts FindPatternsOptions) ToConds() builder.Cond {
+	if opts.IncludeGlobal {
+		return builder.In("repo_id", 0, opts.RepoID)
+	}
+	return builder.Eq{"repo_id": opts.RepoID}
+}
+
+// DeletePattern deletes a custom pattern of a repository, or a global one if repoID is 0
+func DeletePattern(ctx context.Context, repoID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Delete(&SecretScanPattern{})
+	return err
+}
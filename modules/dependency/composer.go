@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"forgejo.org/modules/json"
+)
+
+type composerLockPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type composerLock struct {
+	Packages    []*composerLockPackage `json:"packages"`
+	PackagesDev []*composerLockPackage `json:"packages-dev"`
+}
+
+// parseComposerLock returns the packages of a composer.lock file
+func parseComposerLock(content []byte) ([]*Dependency, error) {
+	var lock composerLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(lock.Packages)+len(lock.PackagesDev))
+	add := func(packages []*composerLockPackage, isDev bool) {
+		for _, pkg := range packages {
+			if pkg.Name == "" {
+				continue
+			}
+			deps = append(deps, &Dependency{
+				Ecosystem: EcosystemComposer,
+				Name:      pkg.Name,
+				Version:   pkg.Version,
+				IsDev:     isDev,
+			})
+		}
+	}
+	add(lock.Packages, false)
+	add(lock.PackagesDev, true)
+	return deps, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package dependency extracts the dependencies of a project from its manifest and lock files.
+package dependency
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Ecosystem is the package ecosystem a dependency belongs to, named like the package URL types
+type Ecosystem string
+
+// List of supported ecosystems
+const (
+	EcosystemGo       Ecosystem = "golang"
+	EcosystemNpm      Ecosystem = "npm"
+	EcosystemCargo    Ecosystem = "cargo"
+	EcosystemPyPI     Ecosystem = "pypi"
+	EcosystemMaven    Ecosystem = "maven"
+	EcosystemComposer Ecosystem = "composer"
+)
+
+// ErrUnsupportedManifest is returned for files which are not a supported manifest
+var ErrUnsupportedManifest = errors.New("unsupported manifest")
+
+// Dependency is a package a project depends on
+type Dependency struct {
+	Ecosystem Ecosystem
+	// Name is the name of the package in its ecosystem, Maven packages are named "groupId:artifactId"
+	Name string
+	// Version is empty if the manifest does not pin the dependency to a version
+	Version string
+	// IsDev is set for dependencies only used for development or tests
+	IsDev bool
+}
+
+// PURL returns the package URL of the dependency, see https://github.com/package-url/purl-spec
+func (d *Dependency) PURL() string {
+	var namespace, name string
+	switch d.Ecosystem {
+	case EcosystemGo, EcosystemComposer:
+		if i := strings.LastIndexByte(d.Name, '/'); i >= 0 {
+			namespace, name = d.Name[:i], d.Name[i+1:]
+		} else {
+			name = d.Name
+		}
+	case EcosystemNpm:
+		if strings.HasPrefix(d.Name, "@") {
+			namespace, name, _ = strings.Cut(d.Name, "/")
+		} else {
+			name = d.Name
+		}
+	case EcosystemMaven:
+		namespace, name, _ = strings.Cut(d.Name, ":")
+	case EcosystemPyPI:
+		name = NormalizePyPIName(d.Name)
+	default:
+		name = d.Name
+	}
+
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(string(d.Ecosystem))
+	sb.WriteByte('/')
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			sb.WriteString(escapePURLSegment(segment))
+			sb.WriteByte('/')
+		}
+	}
+	sb.WriteString(escapePURLSegment(name))
+	if d.Version != "" {
+		sb.WriteByte('@')
+		sb.WriteString(escapePURLSegment(d.Version))
+	}
+	return sb.String()
+}
+
+// escapePURLSegment escapes a segment of a package URL, "@" separates the version and needs to be escaped as well
+func escapePURLSegment(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+}
+
+// NormalizePyPIName normalizes a Python package name as described in PEP 503
+func NormalizePyPIName(name string) string {
+	return strings.ToLower(pypiNameSeparators.ReplaceAllString(name, "-"))
+}
+
+type parser func(content []byte) ([]*Dependency, error)
+
+var parsers = map[string]parser{
+	"go.mod":            parseGoMod,
+	"package-lock.json": parseNpmLock,
+	"Cargo.lock":        parseCargoLock,
+	"poetry.lock":       parsePoetryLock,
+	"pom.xml":           parseMavenPom,
+	"composer.lock":     parseComposerLock,
+}
+
+func getParser(filePath string) parser {
+	name := path.Base(filePath)
+	if p, ok := parsers[name]; ok {
+		return p
+	}
+	if strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt") {
+		return parseRequirements
+	}
+	return nil
+}
+
+// IsManifest returns true if the file at the path is a supported manifest or lock file
+func IsManifest(filePath string) bool {
+	return getParser(filePath) != nil
+}
+
+// Parse extracts the dependencies from the content of a manifest or lock file
+func Parse(filePath string, content []byte) ([]*Dependency, error) {
+	p := getParser(filePath)
+	if p == nil {
+		return nil, ErrUnsupportedManifest
+	}
+	return p(content)
+}
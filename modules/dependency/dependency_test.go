@@ -0,0 +1,216 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSorted(t *testing.T, filePath, content string) []*Dependency {
+	deps, err := Parse(filePath, []byte(content))
+	require.NoError(t, err)
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Name != deps[j].Name {
+			return deps[i].Name < deps[j].Name
+		}
+		return deps[i].Version < deps[j].Version
+	})
+	return deps
+}
+
+func TestIsManifest(t *testing.T) {
+	for _, p := range []string{"go.mod", "web/package-lock.json", "Cargo.lock", "requirements.txt", "requirements-dev.txt", "poetry.lock", "a/b/pom.xml", "composer.lock"} {
+		assert.True(t, IsManifest(p), p)
+	}
+	for _, p := range []string{"go.sum", "package.json", "Cargo.toml", "requirements.in", "pyproject.toml", "composer.json"} {
+		assert.False(t, IsManifest(p), p)
+	}
+
+	_, err := Parse("README.md", nil)
+	require.ErrorIs(t, err, ErrUnsupportedManifest)
+}
+
+func TestParseGoMod(t *testing.T) {
+	deps := parseSorted(t, "go.mod", `module example.com/app
+
+go 1.24
+
+require (
+	example.com/local v0.0.0
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.25.0 // indirect
+)
+
+replace example.com/local => ../local
+
+replace golang.org/x/text => github.com/fork/text v0.26.0
+`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemGo, Name: "github.com/fork/text", Version: "v0.26.0"},
+		{Ecosystem: EcosystemGo, Name: "github.com/stretchr/testify", Version: "v1.10.0"},
+	}, deps)
+}
+
+func TestParseNpmLock(t *testing.T) {
+	t.Run("Version3", func(t *testing.T) {
+		deps := parseSorted(t, "package-lock.json", `{
+  "name": "app",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/@scope/pkg": {"version": "2.0.0"},
+    "node_modules/left-pad": {"version": "1.3.0", "dev": true},
+    "node_modules/@scope/pkg/node_modules/left-pad": {"version": "1.1.0"},
+    "node_modules/workspace": {"resolved": "packages/workspace", "link": true}
+  }
+}`)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemNpm, Name: "@scope/pkg", Version: "2.0.0"},
+			{Ecosystem: EcosystemNpm, Name: "left-pad", Version: "1.1.0"},
+			{Ecosystem: EcosystemNpm, Name: "left-pad", Version: "1.3.0", IsDev: true},
+		}, deps)
+	})
+
+	t.Run("Version1", func(t *testing.T) {
+		deps := parseSorted(t, "package-lock.json", `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0", "dev": true}}}
+  }
+}`)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemNpm, Name: "a", Version: "1.0.0"},
+			{Ecosystem: EcosystemNpm, Name: "b", Version: "2.0.0", IsDev: true},
+		}, deps)
+	})
+}
+
+func TestParseCargoLock(t *testing.T) {
+	deps := parseSorted(t, "Cargo.lock", `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.200"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "ddc6f9cc94d67c0e21aaf7eda3a010fd3af78ebf6e096aa6e2e13c79749cce4f"
+`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemCargo, Name: "serde", Version: "1.0.200"},
+	}, deps)
+}
+
+func TestParsePoetryLock(t *testing.T) {
+	deps := parseSorted(t, "poetry.lock", `[[package]]
+name = "pytest"
+version = "8.2.0"
+description = "pytest: simple powerful testing with Python"
+category = "dev"
+optional = false
+
+[package.dependencies]
+pluggy = ">=1.5,<2.0"
+
+[[package]]
+name = "requests"
+version = "2.32.3"
+
+[metadata]
+lock-version = "2.0"
+`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemPyPI, Name: "pytest", Version: "8.2.0", IsDev: true},
+		{Ecosystem: EcosystemPyPI, Name: "requests", Version: "2.32.3"},
+	}, deps)
+}
+
+func TestParseRequirements(t *testing.T) {
+	deps := parseSorted(t, "requirements.txt", `# comment
+-r base.txt
+--index-url https://example.com/simple
+Django==5.0.6  # web framework
+requests[security] >= 2.0
+numpy==1.26.4 ; python_version >= "3.9"
+pkg @ https://example.com/pkg.tar.gz
+flask==3.*
+`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemPyPI, Name: "Django", Version: "5.0.6"},
+		{Ecosystem: EcosystemPyPI, Name: "flask"},
+		{Ecosystem: EcosystemPyPI, Name: "numpy", Version: "1.26.4"},
+		{Ecosystem: EcosystemPyPI, Name: "requests"},
+	}, deps)
+}
+
+func TestParseMavenPom(t *testing.T) {
+	deps := parseSorted(t, "pom.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <groupId>org.example</groupId>
+  <artifactId>app</artifactId>
+  <version>1.2.0</version>
+  <properties>
+    <junit.version>5.10.2</junit.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+      <version>${junit.version}</version>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>lib</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>managed</artifactId>
+      <version>${unknown.version}</version>
+    </dependency>
+  </dependencies>
+</project>`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemMaven, Name: "org.example:lib", Version: "1.2.0"},
+		{Ecosystem: EcosystemMaven, Name: "org.example:managed"},
+		{Ecosystem: EcosystemMaven, Name: "org.junit.jupiter:junit-jupiter", Version: "5.10.2", IsDev: true},
+	}, deps)
+}
+
+func TestParseComposerLock(t *testing.T) {
+	deps := parseSorted(t, "composer.lock", `{
+  "packages": [{"name": "monolog/monolog", "version": "3.6.0"}],
+  "packages-dev": [{"name": "phpunit/phpunit", "version": "11.1.3"}]
+}`)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: EcosystemComposer, Name: "monolog/monolog", Version: "3.6.0"},
+		{Ecosystem: EcosystemComposer, Name: "phpunit/phpunit", Version: "11.1.3", IsDev: true},
+	}, deps)
+}
+
+func TestPURL(t *testing.T) {
+	cases := map[string]*Dependency{
+		"pkg:golang/github.com/stretchr/testify@v1.10.0":   {Ecosystem: EcosystemGo, Name: "github.com/stretchr/testify", Version: "v1.10.0"},
+		"pkg:npm/%40scope/pkg@2.0.0":                       {Ecosystem: EcosystemNpm, Name: "@scope/pkg", Version: "2.0.0"},
+		"pkg:npm/left-pad":                                 {Ecosystem: EcosystemNpm, Name: "left-pad"},
+		"pkg:cargo/serde@1.0.200":                          {Ecosystem: EcosystemCargo, Name: "serde", Version: "1.0.200"},
+		"pkg:pypi/zope-interface@6.4":                      {Ecosystem: EcosystemPyPI, Name: "Zope.Interface", Version: "6.4"},
+		"pkg:maven/org.junit.jupiter/junit-jupiter@5.10.2": {Ecosystem: EcosystemMaven, Name: "org.junit.jupiter:junit-jupiter", Version: "5.10.2"},
+		"pkg:composer/monolog/monolog@3.6.0":               {Ecosystem: EcosystemComposer, Name: "monolog/monolog", Version: "3.6.0"},
+	}
+	for expected, dep := range cases {
+		assert.Equal(t, expected, dep.PURL())
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"golang.org/x/mod/modfile"
+)
+
+// parseGoMod returns the modules required by a go.mod file, with replacements applied
+func parseGoMod(content []byte) ([]*Dependency, error) {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := make(map[string]*modfile.Replace, len(f.Replace))
+	for _, r := range f.Replace {
+		replaced[r.Old.Path] = r
+	}
+
+	deps := make([]*Dependency, 0, len(f.Require))
+	for _, req := range f.Require {
+		name, version := req.Mod.Path, req.Mod.Version
+		if r, ok := replaced[name]; ok && (r.Old.Version == "" || r.Old.Version == version) {
+			if r.New.Version == "" {
+				// Replaced by a local directory
+				continue
+			}
+			name, version = r.New.Path, r.New.Version
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemGo,
+			Name:      name,
+			Version:   version,
+		})
+	}
+	return deps, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// tomlPackage holds the keys of a [[package]] table in Cargo.lock and poetry.lock files
+type tomlPackage map[string]string
+
+// parseTOMLPackages reads the string values of the [[package]] tables of a TOML lock file.
+// Lock files are generated, so this does not need to handle all of TOML.
+func parseTOMLPackages(content []byte) ([]tomlPackage, error) {
+	var packages []tomlPackage
+	var current tomlPackage
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if line == "[[package]]" {
+				current = tomlPackage{}
+				packages = append(packages, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if !strings.HasPrefix(value, `"`) {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			current[strings.TrimSpace(key)] = unquoted
+		}
+	}
+	return packages, scanner.Err()
+}
+
+// parseCargoLock returns the crates of a Cargo.lock file. Crates without a source are
+// part of the workspace and skipped.
+func parseCargoLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseTOMLPackages(content)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(packages))
+	for _, pkg := range packages {
+		if pkg["name"] == "" || pkg["source"] == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemCargo,
+			Name:      pkg["name"],
+			Version:   pkg["version"],
+		})
+	}
+	return deps, nil
+}
+
+// parsePoetryLock returns the packages of a poetry.lock file
+func parsePoetryLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseTOMLPackages(content)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(packages))
+	for _, pkg := range packages {
+		if pkg["name"] == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemPyPI,
+			Name:      pkg["name"],
+			Version:   pkg["version"],
+			// Only lock files written by Poetry before 1.5 have categories
+			IsDev: pkg["category"] == "dev",
+		})
+	}
+	return deps, nil
+}
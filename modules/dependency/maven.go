@@ -0,0 +1,78 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+
+	"golang.org/x/net/html/charset"
+)
+
+type mavenPom struct {
+	XMLName    xml.Name `xml:"project"`
+	Version    string   `xml:"version"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Parent struct {
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Dependencies []struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+	} `xml:"dependencies>dependency"`
+}
+
+var mavenPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parseMavenPom returns the dependencies declared in a pom.xml file. Versions referring
+// to properties of the same file are resolved, other unresolvable versions are omitted.
+func parseMavenPom(content []byte) ([]*Dependency, error) {
+	var pom mavenPom
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	dec.CharsetReader = charset.NewReaderLabel
+	if err := dec.Decode(&pom); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{
+		"project.version":        pom.Version,
+		"project.parent.version": pom.Parent.Version,
+	}
+	for _, entry := range pom.Properties.Entries {
+		properties[entry.XMLName.Local] = entry.Value
+	}
+
+	deps := make([]*Dependency, 0, len(pom.Dependencies))
+	for _, d := range pom.Dependencies {
+		if d.GroupID == "" || d.ArtifactID == "" {
+			continue
+		}
+		resolved := true
+		version := mavenPropertyPattern.ReplaceAllStringFunc(d.Version, func(s string) string {
+			value, ok := properties[s[2:len(s)-1]]
+			if !ok || value == "" {
+				resolved = false
+			}
+			return value
+		})
+		if !resolved {
+			version = ""
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemMaven,
+			Name:      d.GroupID + ":" + d.ArtifactID,
+			Version:   version,
+			IsDev:     d.Scope == "test",
+		})
+	}
+	return deps, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"strings"
+
+	"forgejo.org/modules/json"
+)
+
+type npmLockPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dev     bool   `json:"dev"`
+	Link    bool   `json:"link"`
+}
+
+type npmLockDependency struct {
+	Version      string                        `json:"version"`
+	Dev          bool                          `json:"dev"`
+	Dependencies map[string]*npmLockDependency `json:"dependencies"`
+}
+
+type npmLock struct {
+	// Packages is used by lockfile version 2 and later
+	Packages map[string]*npmLockPackage `json:"packages"`
+	// Dependencies is used by lockfile version 1
+	Dependencies map[string]*npmLockDependency `json:"dependencies"`
+}
+
+// parseNpmLock returns the packages installed by a package-lock.json file
+func parseNpmLock(content []byte) ([]*Dependency, error) {
+	var lock npmLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	if lock.Packages != nil {
+		for location, pkg := range lock.Packages {
+			// The root package has an empty location and workspace packages are links
+			if location == "" || pkg.Link {
+				continue
+			}
+			idx := strings.LastIndex(location, "node_modules/")
+			if idx < 0 {
+				continue
+			}
+			name := pkg.Name
+			if name == "" {
+				name = location[idx+len("node_modules/"):]
+			}
+			deps = append(deps, &Dependency{
+				Ecosystem: EcosystemNpm,
+				Name:      name,
+				Version:   pkg.Version,
+				IsDev:     pkg.Dev,
+			})
+		}
+		return deps, nil
+	}
+
+	var walk func(map[string]*npmLockDependency)
+	walk = func(m map[string]*npmLockDependency) {
+		for name, dep := range m {
+			deps = append(deps, &Dependency{
+				Ecosystem: EcosystemNpm,
+				Name:      name,
+				Version:   dep.Version,
+				IsDev:     dep.Dev,
+			})
+			walk(dep.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return deps, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+var (
+	pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+	// requirementPattern matches "name[extras] <specifiers>", see PEP 508
+	requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+)
+
+// parseRequirements returns the packages of a pip requirements file
+func parseRequirements(content []byte) ([]*Dependency, error) {
+	var deps []*Dependency
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// Skip comments, options like "-r other.txt" and direct references
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") || strings.Contains(line, " @ ") {
+			continue
+		}
+		// Drop environment markers
+		line, _, _ = strings.Cut(line, ";")
+
+		m := requirementPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		dep := &Dependency{
+			Ecosystem: EcosystemPyPI,
+			Name:      m[1],
+		}
+		if version, ok := strings.CutPrefix(strings.TrimSpace(m[2]), "=="); ok && !strings.ContainsAny(version, ",*") {
+			dep.Version = strings.TrimSpace(version)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, scanner.Err()
+}
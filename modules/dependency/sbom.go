@@ -0,0 +1,222 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SBOMFormat is the format of a software bill of materials
+type SBOMFormat string
+
+// List of supported SBOM formats
+const (
+	SBOMFormatSPDX      SBOMFormat = "spdx"
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+// SBOMSubject describes the project a software bill of materials is created for
+type SBOMSubject struct {
+	Name string
+	// Version is the commit the dependencies were read from
+	Version string
+	// Link is the web link to the project
+	Link string
+	// ToolName and ToolVersion describe the tool creating the document
+	ToolName    string
+	ToolVersion string
+	Created     time.Time
+}
+
+// uniqueDependencies removes dependencies with the same package URL, as every package
+// may only be listed once in a document
+func uniqueDependencies(deps []*Dependency) []*Dependency {
+	seen := make(map[string]bool, len(deps))
+	unique := make([]*Dependency, 0, len(deps))
+	for _, dep := range deps {
+		purl := dep.PURL()
+		if seen[purl] {
+			continue
+		}
+		seen[purl] = true
+		unique = append(unique, dep)
+	}
+	return unique
+}
+
+// SPDXDocument is an SPDX 2.3 document, see https://spdx.github.io/spdx-spec/v2.3/
+type SPDXDocument struct {
+	SPDXVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SPDXID            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo    `json:"creationInfo"`
+	Packages          []*SPDXPackage      `json:"packages"`
+	Relationships     []*SPDXRelationship `json:"relationships"`
+}
+
+// SPDXCreationInfo describes the creation of an SPDX document
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// SPDXPackage is a package of an SPDX document
+type SPDXPackage struct {
+	Name             string             `json:"name"`
+	SPDXID           string             `json:"SPDXID"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	FilesAnalyzed    bool               `json:"filesAnalyzed"`
+	ExternalRefs     []*SPDXExternalRef `json:"externalRefs,omitempty"`
+}
+
+// SPDXExternalRef is a reference of a package to an external source of information
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// SPDXRelationship is a relationship between the elements of an SPDX document
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// NewSPDXDocument creates an SPDX document listing the dependencies of the subject
+func NewSPDXDocument(subject *SBOMSubject, deps []*Dependency) *SPDXDocument {
+	const rootID = "SPDXRef-Project"
+
+	deps = uniqueDependencies(deps)
+	doc := &SPDXDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              subject.Name,
+		DocumentNamespace: fmt.Sprintf("%s/sbom/spdx/%s-%s", subject.Link, subject.Version, uuid.NewString()),
+		CreationInfo: SPDXCreationInfo{
+			Created:  subject.Created.UTC().Format(time.RFC3339),
+			Creators: []string{fmt.Sprintf("Tool: %s-%s", subject.ToolName, subject.ToolVersion)},
+		},
+		Packages: make([]*SPDXPackage, 0, len(deps)+1),
+		Relationships: []*SPDXRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: rootID},
+		},
+	}
+
+	doc.Packages = append(doc.Packages, &SPDXPackage{
+		Name:             subject.Name,
+		SPDXID:           rootID,
+		VersionInfo:      subject.Version,
+		DownloadLocation: subject.Link,
+	})
+	for i, dep := range deps {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		doc.Packages = append(doc.Packages, &SPDXPackage{
+			Name:             dep.Name,
+			SPDXID:           id,
+			VersionInfo:      dep.Version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []*SPDXExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: dep.PURL()},
+			},
+		})
+		if dep.IsDev {
+			doc.Relationships = append(doc.Relationships, &SPDXRelationship{SPDXElementID: id, RelationshipType: "DEV_DEPENDENCY_OF", RelatedSPDXElement: rootID})
+		} else {
+			doc.Relationships = append(doc.Relationships, &SPDXRelationship{SPDXElementID: rootID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: id})
+		}
+	}
+	return doc
+}
+
+// CycloneDXDocument is a CycloneDX 1.5 document, see https://cyclonedx.org/docs/1.5/json/
+type CycloneDXDocument struct {
+	BOMFormat    string                 `json:"bomFormat"`
+	SpecVersion  string                 `json:"specVersion"`
+	SerialNumber string                 `json:"serialNumber"`
+	Version      int                    `json:"version"`
+	Metadata     CycloneDXMetadata      `json:"metadata"`
+	Components   []*CycloneDXComponent  `json:"components"`
+	Dependencies []*CycloneDXDependency `json:"dependencies"`
+}
+
+// CycloneDXMetadata describes a CycloneDX document
+type CycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp"`
+	Tools     CycloneDXTools      `json:"tools"`
+	Component *CycloneDXComponent `json:"component"`
+}
+
+// CycloneDXTools lists the tools which created a CycloneDX document
+type CycloneDXTools struct {
+	Components []*CycloneDXComponent `json:"components"`
+}
+
+// CycloneDXComponent is a component of a CycloneDX document
+type CycloneDXComponent struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+}
+
+// CycloneDXDependency lists the components a component depends on
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// NewCycloneDXDocument creates a CycloneDX document listing the dependencies of the subject
+func NewCycloneDXDocument(subject *SBOMSubject, deps []*Dependency) *CycloneDXDocument {
+	deps = uniqueDependencies(deps)
+	root := &CycloneDXComponent{
+		Type:    "application",
+		BOMRef:  subject.Link,
+		Name:    subject.Name,
+		Version: subject.Version,
+	}
+	doc := &CycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: CycloneDXMetadata{
+			Timestamp: subject.Created.UTC().Format(time.RFC3339),
+			Tools: CycloneDXTools{
+				Components: []*CycloneDXComponent{{Type: "application", Name: subject.ToolName, Version: subject.ToolVersion}},
+			},
+			Component: root,
+		},
+		Components: make([]*CycloneDXComponent, 0, len(deps)),
+	}
+
+	dependsOn := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		purl := dep.PURL()
+		scope := "required"
+		if dep.IsDev {
+			scope = "optional"
+		}
+		doc.Components = append(doc.Components, &CycloneDXComponent{
+			Type:    "library",
+			BOMRef:  purl,
+			Name:    dep.Name,
+			Version: dep.Version,
+			PURL:    purl,
+			Scope:   scope,
+		})
+		dependsOn = append(dependsOn, purl)
+	}
+	doc.Dependencies = []*CycloneDXDependency{{Ref: root.BOMRef, DependsOn: dependsOn}}
+	return doc
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSubject = &SBOMSubject{
+		Name:        "user2/repo1",
+		Version:     "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		Link:        "https://example.com/user2/repo1",
+		ToolName:    "Forgejo",
+		ToolVersion: "11.0.0",
+		Created:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	testDependencies = []*Dependency{
+		{Ecosystem: EcosystemCargo, Name: "serde", Version: "1.0.200"},
+		{Ecosystem: EcosystemCargo, Name: "serde", Version: "1.0.200"},
+		{Ecosystem: EcosystemNpm, Name: "left-pad", Version: "1.3.0", IsDev: true},
+	}
+)
+
+func TestNewSPDXDocument(t *testing.T) {
+	doc := NewSPDXDocument(testSubject, testDependencies)
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "2025-01-02T03:04:05Z", doc.CreationInfo.Created)
+	assert.Equal(t, []string{"Tool: Forgejo-11.0.0"}, doc.CreationInfo.Creators)
+	assert.Contains(t, doc.DocumentNamespace, "https://example.com/user2/repo1/sbom/spdx/65f1bf27bc3bf70f64657658635e66094edbcb4d-")
+
+	// The project and the unique dependencies
+	require.Len(t, doc.Packages, 3)
+	assert.Equal(t, "SPDXRef-Project", doc.Packages[0].SPDXID)
+	assert.Equal(t, "pkg:cargo/serde@1.0.200", doc.Packages[1].ExternalRefs[0].ReferenceLocator)
+
+	assert.Equal(t, []*SPDXRelationship{
+		{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Project"},
+		{SPDXElementID: "SPDXRef-Project", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-1"},
+		{SPDXElementID: "SPDXRef-Package-2", RelationshipType: "DEV_DEPENDENCY_OF", RelatedSPDXElement: "SPDXRef-Project"},
+	}, doc.Relationships)
+}
+
+func TestNewCycloneDXDocument(t *testing.T) {
+	doc := NewCycloneDXDocument(testSubject, testDependencies)
+
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "2025-01-02T03:04:05Z", doc.Metadata.Timestamp)
+	assert.Equal(t, "user2/repo1", doc.Metadata.Component.Name)
+
+	assert.Equal(t, []*CycloneDXComponent{
+		{Type: "library", BOMRef: "pkg:cargo/serde@1.0.200", Name: "serde", Version: "1.0.200", PURL: "pkg:cargo/serde@1.0.200", Scope: "required"},
+		{Type: "library", BOMRef: "pkg:npm/left-pad@1.3.0", Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0", Scope: "optional"},
+	}, doc.Components)
+	assert.Equal(t, []*CycloneDXDependency{
+		{Ref: "https://example.com/user2/repo1", DependsOn: []string{"pkg:cargo/serde@1.0.200", "pkg:npm/left-pad@1.3.0"}},
+	}, doc.Dependencies)
+}
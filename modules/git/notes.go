@@ -10,11 +10,44 @@ import (
 	"strings"
 
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
 )
 
-// NotesRef is the git ref where Gitea will look for git-notes data.
-// The value ("refs/notes/commits") is the default ref used by git-notes.
-const NotesRef = "refs/notes/commits"
+const (
+	// NotesRef is the git ref where Gitea will look for git-notes data.
+	// The value ("refs/notes/commits") is the default ref used by git-notes.
+	NotesRef = "refs/notes/commits"
+	// NotesRefPrefix is the prefix of all refs holding git-notes data.
+	NotesRefPrefix = "refs/notes/"
+)
+
+// NotesRefName returns the full name of a notes ref, which may be given with
+// or without the "refs/notes/" prefix. An empty name means the default NotesRef.
+func NotesRefName(name string) (string, error) {
+	if name == "" {
+		return NotesRef, nil
+	}
+	if !strings.HasPrefix(name, NotesRefPrefix) {
+		name = NotesRefPrefix + name
+	}
+	if name == NotesRefPrefix || !IsValidRefPattern(name) {
+		return "", util.NewInvalidArgumentErrorf("invalid notes ref: %s", name)
+	}
+	return name, nil
+}
+
+// GetNotesRefs returns the full names of all notes refs of the repository.
+func GetNotesRefs(repo *Repository) ([]string, error) {
+	refs, err := repo.GetRefsFiltered(NotesRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names, nil
+}
 
 // Note stores information about a note created using git-notes.
 type Note struct {
@@ -23,43 +56,32 @@ type Note struct {
 }
 
 // GetNote retrieves the git-notes data for a given commit.
-// FIXME: Add LastCommitCache support
 func GetNote(ctx context.Context, repo *Repository, commitID string, note *Note) error {
+	return GetNoteInRef(ctx, repo, NotesRef, commitID, note)
+}
+
+// GetNoteInRef retrieves the git-notes data for a given commit from the given notes ref.
+// FIXME: Add LastCommitCache support
+func GetNoteInRef(ctx context.Context, repo *Repository, notesRef, commitID string, note *Note) error {
 	log.Trace("Searching for git note corresponding to the commit %q in the repository %q", commitID, repo.Path)
-	notes, err := repo.GetCommit(NotesRef)
+	notes, err := repo.GetCommit(notesRef)
 	if err != nil {
 		if IsErrNotExist(err) {
 			return err
 		}
-		log.Error("Unable to get commit from ref %q. Error: %v", NotesRef, err)
+		log.Error("Unable to get commit from ref %q. Error: %v", notesRef, err)
 		return err
 	}
 
-	path := ""
-
 	tree := &notes.Tree
 	log.Trace("Found tree with ID %q while searching for git note corresponding to the commit %q", tree.ID, commitID)
 
-	var entry *TreeEntry
-	originalCommitID := commitID
-	for len(commitID) > 2 {
-		entry, err = tree.GetTreeEntryByPath(commitID)
-		if err == nil {
-			path += commitID
-			break
-		}
-		if IsErrNotExist(err) {
-			tree, err = tree.SubTree(commitID[0:2])
-			path += commitID[0:2] + "/"
-			commitID = commitID[2:]
-		}
-		if err != nil {
-			// Err may have been updated by the SubTree we need to recheck if it's again an ErrNotExist
-			if !IsErrNotExist(err) {
-				log.Error("Unable to find git note corresponding to the commit %q. Error: %v", originalCommitID, err)
-			}
-			return err
+	entry, path, err := getNoteEntry(tree, commitID)
+	if err != nil {
+		if !IsErrNotExist(err) {
+			log.Error("Unable to find git note corresponding to the commit %q. Error: %v", commitID, err)
 		}
+		return err
 	}
 
 	blob := entry.Blob()
@@ -99,7 +121,68 @@ func GetNote(ctx context.Context, repo *Repository, commitID string, note *Note)
 	return nil
 }
 
+// getNoteEntry returns the entry of the notes tree holding the note of a commit, and its path, the notes
+// tree being possibly fanned out in subtrees named after the first characters of the commit IDs
+func getNoteEntry(tree *Tree, commitID string) (*TreeEntry, string, error) {
+	path := ""
+	for name := commitID; len(name) > 2; {
+		entry, err := tree.GetTreeEntryByPath(name)
+		if err == nil {
+			return entry, path + name, nil
+		}
+		if IsErrNotExist(err) {
+			tree, err = tree.SubTree(name[0:2])
+			path += name[0:2] + "/"
+			name = name[2:]
+		}
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return nil, "", ErrNotExist{ID: commitID}
+}
+
+// GetNoteMessages returns the git-notes messages stored in the given notes ref
+// for those of the given commits that have one, keyed by commit ID. Only the
+// notes of the given commits are looked up in the notes tree.
+func GetNoteMessages(ctx context.Context, repo *Repository, notesRef string, commitIDs []string) (map[string][]byte, error) {
+	messages := make(map[string][]byte)
+	if len(commitIDs) == 0 {
+		return messages, nil
+	}
+
+	notes, err := repo.GetCommit(notesRef)
+	if err != nil {
+		if IsErrNotExist(err) {
+			return messages, nil
+		}
+		return nil, err
+	}
+
+	for _, commitID := range commitIDs {
+		entry, _, err := getNoteEntry(&notes.Tree, commitID)
+		if err != nil {
+			if IsErrNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		message, err := entry.Blob().GetBlobContent(entry.Blob().Size())
+		if err != nil {
+			return nil, err
+		}
+		messages[commitID] = []byte(message)
+	}
+	return messages, nil
+}
+
+// SetNote adds or replaces the git-notes data for a given commit.
 func SetNote(ctx context.Context, repo *Repository, commitID, notes, doerName, doerEmail string) error {
+	return SetNoteInRef(ctx, repo, NotesRef, commitID, notes, doerName, doerEmail)
+}
+
+// SetNoteInRef adds or replaces the git-notes data for a given commit in the given notes ref.
+func SetNoteInRef(ctx context.Context, repo *Repository, notesRef, commitID, notes, doerName, doerEmail string) error {
 	_, err := repo.GetCommit(commitID)
 	if err != nil {
 		return err
@@ -112,7 +195,7 @@ func SetNote(ctx context.Context, repo *Repository, commitID, notes, doerName, d
 		"GIT_COMMITTER_EMAIL="+doerEmail,
 	)
 
-	cmd := NewCommand(ctx, "notes", "add", "-f", "-m")
+	cmd := NewCommand(ctx, "notes").AddOptionFormat("--ref=%s", notesRef).AddArguments("add", "-f", "-m")
 	cmd.AddDynamicArguments(notes, commitID)
 
 	_, stderr, err := cmd.RunStdString(&RunOpts{Dir: repo.Path, Env: env})
@@ -124,12 +207,21 @@ func SetNote(ctx context.Context, repo *Repository, commitID, notes, doerName, d
 	return nil
 }
 
+// RemoveNote removes the git-notes data for a given commit.
 func RemoveNote(ctx context.Context, repo *Repository, commitID string) error {
-	cmd := NewCommand(ctx, "notes", "remove")
+	return RemoveNoteFromRef(ctx, repo, NotesRef, commitID)
+}
+
+// RemoveNoteFromRef removes the git-notes data for a given commit from the given notes ref.
+func RemoveNoteFromRef(ctx context.Context, repo *Repository, notesRef, commitID string) error {
+	cmd := NewCommand(ctx, "notes").AddOptionFormat("--ref=%s", notesRef).AddArguments("remove")
 	cmd.AddDynamicArguments(commitID)
 
 	_, stderr, err := cmd.RunStdString(&RunOpts{Dir: repo.Path})
 	if err != nil {
+		if strings.Contains(stderr, "has no note") {
+			return ErrNotExist{ID: commitID, RelPath: notesRef}
+		}
 		log.Error("Error while running git notes remove: %s", stderr)
 		return err
 	}
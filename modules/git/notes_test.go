@@ -100,3 +100,71 @@ func TestRemoveNote(t *testing.T) {
 	require.Error(t, err)
 	assert.IsType(t, git.ErrNotExist{}, err)
 }
+
+func TestNotesRefName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"":                   git.NotesRef,
+		"commits":            git.NotesRef,
+		"refs/notes/commits": git.NotesRef,
+		"review":             "refs/notes/review",
+		"ci/results":         "refs/notes/ci/results",
+	} {
+		refName, err := git.NotesRefName(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, refName)
+	}
+
+	for _, name := range []string{"refs/notes/", "a..b", "bad name"} {
+		_, err := git.NotesRefName(name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestGetNoteMessages(t *testing.T) {
+	repo, err := openRepositoryWithDefaultContext(filepath.Join(testReposDir, "repo3_notes"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	messages, err := git.GetNoteMessages(t.Context(), repo, git.NotesRef, []string{
+		"3e668dbfac39cbc80a9ff9c61eb565d944453ba4",
+		"ba0a96fa63532d6c5087ecef070b0250ed72fa47",
+		"0000000000000000000000000000000000000000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"3e668dbfac39cbc80a9ff9c61eb565d944453ba4": []byte("Note 2"),
+		"ba0a96fa63532d6c5087ecef070b0250ed72fa47": []byte("Note 1"),
+	}, messages)
+
+	messages, err = git.GetNoteMessages(t.Context(), repo, "refs/notes/missing", []string{"3e668dbfac39cbc80a9ff9c61eb565d944453ba4"})
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestNotesInCustomRef(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, unittest.CopyDir(filepath.Join(testReposDir, "repo1_bare"), filepath.Join(tempDir, "repo1")))
+
+	repo, err := openRepositoryWithDefaultContext(filepath.Join(tempDir, "repo1"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	const commitID = "95bb4d39648ee7e325106df01a621c530863a653"
+	require.NoError(t, git.SetNoteInRef(t.Context(), repo, "refs/notes/review", commitID, "Reviewed", "Test", "test@test.com"))
+
+	refs, err := git.GetNotesRefs(repo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{git.NotesRef, "refs/notes/review"}, refs)
+
+	note := git.Note{}
+	require.NoError(t, git.GetNoteInRef(t.Context(), repo, "refs/notes/review", commitID, &note))
+	assert.Equal(t, []byte("Reviewed\n"), note.Message)
+
+	// The default notes ref is left untouched.
+	require.NoError(t, git.GetNote(t.Context(), repo, commitID, &note))
+	assert.Equal(t, []byte("Note contents\n"), note.Message)
+
+	require.NoError(t, git.RemoveNoteFromRef(t.Context(), repo, "refs/notes/review", commitID))
+	err = git.RemoveNoteFromRef(t.Context(), repo, "refs/notes/review", commitID)
+	assert.True(t, git.IsErrNotExist(err))
+}
@@ -104,6 +104,10 @@ func (ref RefName) IsFor() bool {
 	return strings.HasPrefix(string(ref), ForPrefix)
 }
 
+func (ref RefName) IsNotes() bool {
+	return strings.HasPrefix(string(ref), NotesRefPrefix)
+}
+
 func (ref RefName) nameWithoutPrefix(prefix string) string {
 	if strings.HasPrefix(string(ref), prefix) {
 		return strings.TrimPrefix(string(ref), prefix)
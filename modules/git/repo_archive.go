@@ -5,12 +5,20 @@
 package git
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"forgejo.org/modules/zstd"
+
+	"github.com/ulikunitz/xz"
 )
 
 // ArchiveType archive types
@@ -23,6 +31,10 @@ const (
 	TARGZ
 	// BUNDLE bundle archive type
 	BUNDLE
+	// TARZST tar zst archive type
+	TARZST
+	// TARXZ tar xz archive type
+	TARXZ
 )
 
 // String converts an ArchiveType to string
@@ -34,6 +46,10 @@ func (a ArchiveType) String() string {
 		return "tar.gz"
 	case BUNDLE:
 		return "bundle"
+	case TARZST:
+		return "tar.zst"
+	case TARXZ:
+		return "tar.xz"
 	}
 	return "unknown"
 }
@@ -46,21 +62,65 @@ func ToArchiveType(s string) ArchiveType {
 		return TARGZ
 	case "bundle":
 		return BUNDLE
+	case "tar.zst":
+		return TARZST
+	case "tar.xz":
+		return TARXZ
 	}
 	return 0
 }
 
+// ArchiveEntryFilter may replace the content of a regular file while an archive
+// is generated. It returns the content to write and its size; returning r and
+// hdr.Size keeps the file unchanged. If the returned reader is an io.Closer it
+// is closed once the content has been written.
+type ArchiveEntryFilter func(hdr *tar.Header, r io.Reader) (io.Reader, int64, error)
+
 // CreateArchive create archive content to the target path
 func (repo *Repository) CreateArchive(ctx context.Context, format ArchiveType, target io.Writer, usePrefix bool, commitID string) error {
-	if format.String() == "unknown" {
+	return repo.CreateFilteredArchive(ctx, format, target, usePrefix, commitID, nil)
+}
+
+// CreateFilteredArchive creates archive content like CreateArchive, passing
+// every regular file through filter if it is not nil.
+//
+// The output only depends on the commit: entries carry the commit time as their
+// modification time and compression is single-threaded, so generating the same
+// archive twice yields identical bytes.
+func (repo *Repository) CreateFilteredArchive(ctx context.Context, format ArchiveType, target io.Writer, usePrefix bool, commitID string, filter ArchiveEntryFilter) error {
+	switch format {
+	case ZIP, TARGZ:
+		if filter == nil {
+			// git produces reproducible zip and tar.gz archives on its own.
+			return repo.runArchive(ctx, format.String(), target, usePrefix, commitID)
+		}
+	case TARZST, TARXZ:
+	default:
 		return fmt.Errorf("unknown format: %v", format)
 	}
 
+	rd, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := repo.runArchive(ctx, "tar", w, usePrefix, commitID)
+		_ = w.CloseWithError(err)
+		done <- err
+	}()
+
+	err := rewriteTarArchive(rd, target, format, filter)
+	_ = rd.CloseWithError(err)
+	if archiveErr := <-done; archiveErr != nil && err == nil {
+		err = archiveErr
+	}
+	return err
+}
+
+func (repo *Repository) runArchive(ctx context.Context, format string, target io.Writer, usePrefix bool, commitID string) error {
 	cmd := NewCommand(ctx, "archive")
 	if usePrefix {
 		cmd.AddOptionFormat("--prefix=%s", filepath.Base(strings.TrimSuffix(repo.Path, ".git"))+"/")
 	}
-	cmd.AddOptionFormat("--format=%s", format.String())
+	cmd.AddOptionFormat("--format=%s", format)
 	cmd.AddDynamicArguments(commitID)
 
 	// Avoid LFS hooks getting installed because of /etc/gitconfig, which can break pull requests.
@@ -78,3 +138,135 @@ func (repo *Repository) CreateArchive(ctx context.Context, format ArchiveType, t
 	}
 	return nil
 }
+
+// archiveWriter receives the entries of a tar stream produced by git archive.
+type archiveWriter interface {
+	WriteEntry(hdr *tar.Header, content io.Reader) error
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	tw         *tar.Writer
+	compressor io.WriteCloser
+}
+
+func newTarArchiveWriter(target io.Writer, format ArchiveType) (*tarArchiveWriter, error) {
+	var compressor io.WriteCloser
+	var err error
+	switch format {
+	case TARGZ:
+		// The zero gzip header has neither a name nor a modification time.
+		compressor, err = gzip.NewWriterLevel(target, gzip.DefaultCompression)
+	case TARZST:
+		compressor, err = zstd.NewWriter(target, zstd.WithEncoderConcurrency(1))
+	case TARXZ:
+		compressor, err = xz.NewWriter(target)
+	default:
+		err = fmt.Errorf("unknown format: %v", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tarArchiveWriter{tw: tar.NewWriter(compressor), compressor: compressor}, nil
+}
+
+func (w *tarArchiveWriter) WriteEntry(hdr *tar.Header, content io.Reader) error {
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if content == nil {
+		return nil
+	}
+	_, err := io.Copy(w.tw, content)
+	return err
+}
+
+func (w *tarArchiveWriter) Close() error {
+	return errors.Join(w.tw.Close(), w.compressor.Close())
+}
+
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (w *zipArchiveWriter) WriteEntry(hdr *tar.Header, content io.Reader) error {
+	if hdr.Typeflag == tar.TypeXGlobalHeader {
+		// git stores the commit ID in the zip comment, just like in the tar global header.
+		return w.zw.SetComment(hdr.PAXRecords["comment"])
+	}
+
+	fh := &zip.FileHeader{
+		Name:     hdr.Name,
+		Method:   zip.Deflate,
+		Modified: hdr.ModTime,
+	}
+	fh.SetMode(hdr.FileInfo().Mode())
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		fh.Method = zip.Store
+		if !strings.HasSuffix(fh.Name, "/") {
+			fh.Name += "/"
+		}
+	case tar.TypeSymlink:
+		fh.Method = zip.Store
+		content = strings.NewReader(hdr.Linkname)
+	}
+
+	fw, err := w.zw.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return nil
+	}
+	_, err = io.Copy(fw, content)
+	return err
+}
+
+func (w *zipArchiveWriter) Close() error {
+	return w.zw.Close()
+}
+
+// rewriteTarArchive reads the uncompressed tar stream of git archive from rd
+// and writes it to target in the requested format, applying filter to all
+// regular files.
+func rewriteTarArchive(rd io.Reader, target io.Writer, format ArchiveType, filter ArchiveEntryFilter) (err error) {
+	var aw archiveWriter
+	if format == ZIP {
+		aw = &zipArchiveWriter{zw: zip.NewWriter(target)}
+	} else if aw, err = newTarArchiveWriter(target, format); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(rd)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Join(err, aw.Close())
+		}
+
+		var content io.Reader
+		if hdr.Typeflag == tar.TypeReg {
+			content = tr
+			if filter != nil {
+				filtered, size, err := filter(hdr, tr)
+				if err != nil {
+					return errors.Join(err, aw.Close())
+				}
+				hdr.Size = size
+				content = filtered
+			}
+		}
+
+		err = aw.WriteEntry(hdr, content)
+		if closer, ok := content.(io.Closer); ok {
+			err = errors.Join(err, closer.Close())
+		}
+		if err != nil {
+			return errors.Join(err, aw.Close())
+		}
+	}
+	return aw.Close()
+}
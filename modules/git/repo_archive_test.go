@@ -0,0 +1,114 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/zstd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+func TestArchiveType(t *testing.T) {
+	for _, tp := range []ArchiveType{ZIP, TARGZ, BUNDLE, TARZST, TARXZ} {
+		assert.Equal(t, tp, ToArchiveType(tp.String()))
+	}
+	assert.EqualValues(t, 0, ToArchiveType("tar.bz2"))
+}
+
+func TestRepository_CreateArchive(t *testing.T) {
+	repo, err := openRepositoryWithDefaultContext(filepath.Join(testReposDir, "repo1_bare"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	const commitID = "ce064814f4a0d337b333e646ece456cd39fab612"
+
+	create := func(t *testing.T, tp ArchiveType, filter ArchiveEntryFilter) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		require.NoError(t, repo.CreateFilteredArchive(t.Context(), tp, &buf, true, commitID, filter))
+		return buf.Bytes()
+	}
+
+	readTar := func(t *testing.T, r io.Reader) map[string]string {
+		t.Helper()
+		files := map[string]string{}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			// Entries use the commit time: Sun Nov 13 16:40:14 2022 +0100
+			assert.EqualValues(t, 1668354014, hdr.ModTime.Unix())
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(content)
+		}
+		return files
+	}
+
+	t.Run("tar.zst", func(t *testing.T) {
+		data := create(t, TARZST, nil)
+		assert.Equal(t, data, create(t, TARZST, nil), "archive must be reproducible")
+
+		zr, err := zstd.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		defer zr.Close()
+		assert.Contains(t, readTar(t, zr), "repo1_bare/file1.txt")
+	})
+
+	t.Run("tar.xz", func(t *testing.T) {
+		data := create(t, TARXZ, nil)
+		assert.Equal(t, data, create(t, TARXZ, nil), "archive must be reproducible")
+
+		xr, err := xz.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Contains(t, readTar(t, xr), "repo1_bare/file1.txt")
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		replace := func(hdr *tar.Header, r io.Reader) (io.Reader, int64, error) {
+			if !strings.HasSuffix(hdr.Name, "file1.txt") {
+				return r, hdr.Size, nil
+			}
+			return strings.NewReader("replaced"), int64(len("replaced")), nil
+		}
+
+		data := create(t, TARZST, replace)
+		zr, err := zstd.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		defer zr.Close()
+		assert.Equal(t, "replaced", readTar(t, zr)["repo1_bare/file1.txt"])
+
+		data = create(t, ZIP, replace)
+		assert.Equal(t, data, create(t, ZIP, replace), "archive must be reproducible")
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, commitID, zipReader.Comment)
+		f, err := zipReader.Open("repo1_bare/file1.txt")
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "replaced", string(content))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		require.Error(t, repo.CreateArchive(t.Context(), BUNDLE, io.Discard, false, commitID))
+	})
+}
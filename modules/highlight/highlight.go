@@ -69,6 +69,14 @@ func Code(fileName, language, code string) (output template.HTML, lexerName stri
 		return template.HTML(template.HTMLEscapeString(code)), ""
 	}
 
+	lexer := GetLexer(fileName, language)
+	return CodeFromLexer(lexer, code), formatLexerName(lexer.Config().Name)
+}
+
+// GetLexer returns the lexer for a file, matched from its language if known, otherwise from its name
+func GetLexer(fileName, language string) chroma.Lexer {
+	NewContext()
+
 	var lexer chroma.Lexer
 
 	if len(language) > 0 {
@@ -102,8 +110,7 @@ func Code(fileName, language, code string) (output template.HTML, lexerName stri
 		}
 		cache.Add(fileName, lexer)
 	}
-
-	return CodeFromLexer(lexer, code), formatLexerName(lexer.Config().Name)
+	return lexer
 }
 
 // CodeFromLexer returns a HTML version of code string with chroma syntax highlighting classes
@@ -78,6 +78,10 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 		User:               nil,
 	}
 
+	for _, filter := range options.CustomFields {
+		opts.CustomFields = append(opts.CustomFields, issue_model.IssueCustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
+
 	if len(options.MilestoneIDs) == 1 && options.MilestoneIDs[0] == 0 {
 		opts.MilestoneIDs = []int64{db.NoConditionID}
 	} else {
@@ -32,6 +32,10 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		// It's not a TO DO, it's just unnecessary.
 	}
 
+	for _, filter := range opts.CustomFields {
+		searchOpt.CustomFields = append(searchOpt.CustomFields, CustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
+
 	if len(opts.MilestoneIDs) == 1 && opts.MilestoneIDs[0] == db.NoConditionID {
 		searchOpt.MilestoneIDs = []int64{0}
 	} else {
@@ -269,6 +269,9 @@ func IsAvailable(ctx context.Context) bool {
 // SearchOptions indicates the options for searching issues
 type SearchOptions = internal.SearchOptions
 
+// CustomFieldFilter matches the issues which have a value of a custom field
+type CustomFieldFilter = internal.CustomFieldFilter
+
 const (
 	SortByScore        = internal.SortByScore
 	SortByCreatedDesc  = internal.SortByCreatedDesc
@@ -0,0 +1,36 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// CustomFieldFilter matches the issues which have a value of a custom field
+type CustomFieldFilter struct {
+	FieldID int64
+	Value   string
+}
+
+// customFieldTokenMask keeps the tokens exactly representable as float64,
+// which is how bleve stores numbers and how JSON numbers are commonly decoded
+const customFieldTokenMask = 1<<53 - 1
+
+// CustomFieldToken returns the number stored in the index for a custom field value.
+// Using numbers rather than strings lets all indexers filter on the values as they do on IDs,
+// the values are compared case-insensitively.
+func CustomFieldToken(fieldID int64, value string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.FormatInt(fieldID, 10)))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(strings.ToLower(value)))
+	return int64(h.Sum64() & customFieldTokenMask)
+}
+
+// Token returns the token of the value the filter matches
+func (f CustomFieldFilter) Token() int64 {
+	return CustomFieldToken(f.FieldID, f.Value)
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldToken(t *testing.T) {
+	token := CustomFieldToken(1, "Critical")
+	assert.Equal(t, token, CustomFieldToken(1, "critical"))
+	assert.Equal(t, token, CustomFieldFilter{FieldID: 1, Value: "CRITICAL"}.Token())
+	assert.NotEqual(t, token, CustomFieldToken(2, "critical"))
+	assert.NotEqual(t, CustomFieldToken(1, "1"), CustomFieldToken(11, ""))
+	assert.Positive(t, token)
+	assert.LessOrEqual(t, token, int64(1<<53-1))
+}
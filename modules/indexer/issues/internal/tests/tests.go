@@ -530,6 +530,30 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "CustomFields",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CustomFields: []internal.CustomFieldFilter{
+				{FieldID: 1, Value: "SEV1"},
+				{FieldID: 2, Value: "backend"},
+			},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			severity := internal.CustomFieldToken(1, "sev1")
+			component := internal.CustomFieldToken(2, "backend")
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, data[v.ID].CustomFieldValues, severity)
+				assert.Contains(t, data[v.ID].CustomFieldValues, component)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return slices.Contains(v.CustomFieldValues, severity) && slices.Contains(v.CustomFieldValues, component)
+			}), result.Total)
+		},
+	},
 	{
 		Name: "updated",
 		SearchOptions: &internal.SearchOptions{
@@ -739,6 +763,10 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 			for i := range subscriberIDs {
 				subscriberIDs[i] = int64(i) + 1 // SubscriberID should not be 0
 			}
+			customFieldValues := []int64{internal.CustomFieldToken(1, fmt.Sprintf("sev%d", id%3))}
+			if id%4 == 0 {
+				customFieldValues = append(customFieldValues, internal.CustomFieldToken(2, "backend"))
+			}
 
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
@@ -760,6 +788,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				CustomFieldValues:  customFieldValues,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
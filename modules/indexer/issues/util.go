@@ -87,6 +87,17 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		return nil, false, err
 	}
 
+	customFieldValues, err := issue_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	customFieldTokens := make([]int64, 0, len(customFieldValues))
+	for fieldID, values := range customFieldValues {
+		for _, value := range values {
+			customFieldTokens = append(customFieldTokens, internal.CustomFieldToken(fieldID, value))
+		}
+	}
+
 	var projectID int64
 	if issue.Project != nil {
 		projectID = issue.Project.ID
@@ -112,6 +123,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		CustomFieldValues:  customFieldTokens,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
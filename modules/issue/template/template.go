@@ -81,6 +81,9 @@ func validateYaml(template *api.IssueTemplate) error {
 			if err := validateStringItem(position, field.Validations, false, "regex"); err != nil {
 				return err
 			}
+			if err := validateRegex(position, field.Validations); err != nil {
+				return err
+			}
 		case api.IssueFormFieldTypeDropdown:
 			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
 				return err
@@ -108,6 +111,13 @@ func validateYaml(template *api.IssueTemplate) error {
 			return position.Errorf("unknown type")
 		}
 
+		if field.Type != api.IssueFormFieldTypeMarkdown {
+			// The name of a custom issue field which is set from the value of this field
+			if err := validateStringItem(position, field.Attributes, false, "custom_field"); err != nil {
+				return err
+			}
+		}
+
 		if err := validateRequired(field, idx); err != nil {
 			return err
 		}
@@ -242,6 +252,24 @@ func validateStringItem(position errorPosition, m map[string]any, required bool,
 	return nil
 }
 
+// validateRegex checks that the regex validation can be compiled, as the values are also checked on the server
+func validateRegex(position errorPosition, validations map[string]any) error {
+	pattern, _ := validations["regex"].(string)
+	if pattern == "" {
+		return nil
+	}
+	if _, err := compileFieldRegex(pattern); err != nil {
+		return position.Errorf("'regex' is not a valid regular expression: %v", err)
+	}
+	return nil
+}
+
+// compileFieldRegex compiles the regex validation of a field, which is matched against the whole value
+// like the browser does
+func compileFieldRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 func validateBoolItem(position errorPosition, m map[string]any, names ...string) error {
 	for _, name := range names {
 		v, ok := m[name]
@@ -309,6 +337,39 @@ func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 	return builder.String()
 }
 
+// CustomFieldValues returns the values submitted for the fields which set a custom issue field,
+// keyed by the name of the custom field. Dropdowns and checkboxes give the labels of the selected options.
+func CustomFieldValues(template *api.IssueTemplate, values url.Values) map[string][]string {
+	ret := make(map[string][]string)
+	for _, field := range template.Fields {
+		name, _ := field.Attributes["custom_field"].(string)
+		if name == "" || field.ID == "" {
+			continue
+		}
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		switch f.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			if value := f.Value(); value != "" {
+				ret[name] = append(ret[name], value)
+			}
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			for _, option := range f.Options() {
+				if option.IsChecked() {
+					ret[name] = append(ret[name], option.Label())
+				}
+			}
+		}
+		if _, ok := ret[name]; !ok {
+			// Keep the field, so that an empty submission still clears a default value
+			ret[name] = nil
+		}
+	}
+	return ret
+}
+
 type valuedField struct {
 	*api.IssueFormField
 	url.Values
@@ -52,6 +52,20 @@ body:
 `,
 			wantErr: "body[0](markdown): 'value' is required",
 		},
+		{
+			name: "custom_field invalid value",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "input"
+    id: "severity"
+    attributes:
+      label: "Severity"
+      custom_field: 1
+`,
+			wantErr: "body[0](input): 'custom_field' should be a string",
+		},
 		{
 			name: "markdown invalid value",
 			content: `
@@ -188,6 +202,21 @@ body:
 `,
 			wantErr: "body[0](input): 'regex' should be a string",
 		},
+		{
+			name: "input regex not supported by Go",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "input"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      regex: "(?=.*[0-9])[a-z0-9]+"
+`,
+			wantErr: "body[0](input): 'regex' is not a valid regular expression: error parsing regexp: invalid or unsupported Perl syntax: `(?=`",
+		},
 		{
 			name: "dropdown invalid description",
 			content: `
@@ -908,6 +937,63 @@ Option 1 of dropdown, Option 2 of dropdown
 	}
 }
 
+func TestCustomFieldValues(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Name
+title: Title
+about: About
+body:
+  - type: input
+    id: customer
+    attributes:
+      label: Customer
+      custom_field: Customer
+  - type: dropdown
+    id: components
+    attributes:
+      label: Components
+      multiple: true
+      custom_field: Component
+      options:
+        - API
+        - Web
+        - CLI
+  - type: checkboxes
+    id: platforms
+    attributes:
+      label: Platforms
+      custom_field: Platform
+      options:
+        - label: Linux
+        - label: Windows
+  - type: textarea
+    id: estimate
+    attributes:
+      label: Estimate
+      custom_field: Estimate
+  - type: textarea
+    id: details
+    attributes:
+      label: Details
+`))
+	require.NoError(t, err)
+
+	values := CustomFieldValues(template, url.Values{
+		"form-field-customer":     {" ACME "},
+		"form-field-components":   {"0,2"},
+		"form-field-platforms-1":  {"on"},
+		"form-field-estimate":     {""},
+		"form-field-details":      {"Some details"},
+		"form-field-non-existent": {"value"},
+	})
+	assert.Equal(t, map[string][]string{
+		"Customer":  {"ACME"},
+		"Component": {"API", "CLI"},
+		"Platform":  {"Windows"},
+		"Estimate":  nil,
+	}, values)
+}
+
 func Test_minQuotes(t *testing.T) {
 	type args struct {
 		value string
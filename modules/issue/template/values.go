@@ -0,0 +1,209 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+)
+
+// FieldValueErrorReason tells why a value submitted for a form field is rejected
+type FieldValueErrorReason string
+
+const (
+	FieldValueRequired        FieldValueErrorReason = "required"
+	FieldValueNotNumber       FieldValueErrorReason = "not_number"
+	FieldValuePatternMismatch FieldValueErrorReason = "pattern_mismatch"
+	FieldValueUnknownOption   FieldValueErrorReason = "unknown_option"
+	FieldValueTooManyOptions  FieldValueErrorReason = "too_many_options"
+	FieldValueUnknownField    FieldValueErrorReason = "unknown_field"
+)
+
+// ErrInvalidFieldValue represents an invalid value submitted for a field of a form template
+type ErrInvalidFieldValue struct {
+	ID     string
+	Label  string
+	Reason FieldValueErrorReason
+}
+
+// IsErrInvalidFieldValue checks if an error is a ErrInvalidFieldValue.
+func IsErrInvalidFieldValue(err error) bool {
+	_, ok := err.(ErrInvalidFieldValue)
+	return ok
+}
+
+func (err ErrInvalidFieldValue) Error() string {
+	return fmt.Sprintf("invalid value for the form field %q: %s", err.ID, err.Reason)
+}
+
+func (err ErrInvalidFieldValue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ValidateValues checks the values submitted for the fields of a form template against their validations:
+// required fields and checkboxes, numbers, regular expressions and the options of the dropdowns.
+func ValidateValues(template *api.IssueTemplate, values url.Values) error {
+	if template.Type() != api.IssueTemplateTypeYaml {
+		return nil
+	}
+
+	for _, field := range template.Fields {
+		if field.Type == api.IssueFormFieldTypeMarkdown || !field.VisibleOnForm() {
+			continue
+		}
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		invalid := func(reason FieldValueErrorReason) error {
+			return ErrInvalidFieldValue{ID: f.ID, Label: f.Label(), Reason: reason}
+		}
+		required, _ := f.Validations["required"].(bool)
+
+		switch f.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			value := f.Value()
+			if value == "" {
+				if required {
+					return invalid(FieldValueRequired)
+				}
+				continue
+			}
+			if f.Type != api.IssueFormFieldTypeInput {
+				continue
+			}
+			if isNumber, _ := f.Validations["is_number"].(bool); isNumber {
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return invalid(FieldValueNotNumber)
+				}
+			}
+			if pattern, _ := f.Validations["regex"].(string); pattern != "" {
+				re, err := compileFieldRegex(pattern)
+				if err != nil {
+					return fmt.Errorf("invalid regex of the form field %q: %w", f.ID, err)
+				}
+				if !re.MatchString(value) {
+					return invalid(FieldValuePatternMismatch)
+				}
+			}
+		case api.IssueFormFieldTypeDropdown:
+			selected := 0
+			for _, v := range strings.Split(values.Get("form-field-"+f.ID), ",") {
+				if v == "" {
+					continue
+				}
+				idx, err := strconv.Atoi(v)
+				if err != nil || idx < 0 || idx >= len(f.Options()) {
+					return invalid(FieldValueUnknownOption)
+				}
+				selected++
+			}
+			if selected == 0 && required {
+				return invalid(FieldValueRequired)
+			}
+			if multiple, _ := f.Attributes["multiple"].(bool); selected > 1 && !multiple {
+				return invalid(FieldValueTooManyOptions)
+			}
+		case api.IssueFormFieldTypeCheckboxes:
+			for _, option := range f.Options() {
+				opt, _ := option.data.(map[string]any)
+				if required, _ := opt["required"].(bool); required && !option.IsChecked() {
+					return invalid(FieldValueRequired)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FieldValues returns the values submitted for the fields of a form template: the text of the inputs and
+// textareas, and the labels of the selected options of the dropdowns and checkboxes.
+func FieldValues(template *api.IssueTemplate, values url.Values) []*api.IssueFormFieldValue {
+	ret := make([]*api.IssueFormFieldValue, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		if field.Type == api.IssueFormFieldTypeMarkdown || field.ID == "" {
+			continue
+		}
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		fieldValue := &api.IssueFormFieldValue{
+			ID:     f.ID,
+			Type:   f.Type,
+			Label:  f.Label(),
+			Values: []string{},
+		}
+		switch f.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			if value := f.Value(); value != "" {
+				fieldValue.Values = append(fieldValue.Values, value)
+			}
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			for _, option := range f.Options() {
+				if option.IsChecked() {
+					fieldValue.Values = append(fieldValue.Values, option.Label())
+				}
+			}
+		}
+		ret = append(ret, fieldValue)
+	}
+	return ret
+}
+
+// ValuesFromMap converts the values of the fields of a form template keyed by field ID, as given through the API,
+// to the values the form would submit. The options of dropdowns and checkboxes are given by their labels.
+func ValuesFromMap(template *api.IssueTemplate, fields map[string][]string) (url.Values, error) {
+	values := url.Values{}
+	for id, submitted := range fields {
+		var field *api.IssueFormField
+		for _, f := range template.Fields {
+			if f.ID == id && f.Type != api.IssueFormFieldTypeMarkdown {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil, ErrInvalidFieldValue{ID: id, Reason: FieldValueUnknownField}
+		}
+		f := &valuedField{IssueFormField: field}
+
+		switch f.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			if len(submitted) > 1 {
+				return nil, ErrInvalidFieldValue{ID: id, Label: f.Label(), Reason: FieldValueTooManyOptions}
+			}
+			values.Set("form-field-"+id, strings.Join(submitted, ""))
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			options := f.Options()
+			selected := make([]string, 0, len(submitted))
+			for _, label := range submitted {
+				idx := -1
+				for _, option := range options {
+					if option.Label() == label {
+						idx = option.index
+						break
+					}
+				}
+				if idx < 0 {
+					return nil, ErrInvalidFieldValue{ID: id, Label: f.Label(), Reason: FieldValueUnknownOption}
+				}
+				if f.Type == api.IssueFormFieldTypeCheckboxes {
+					values.Set(fmt.Sprintf("form-field-%s-%d", id, idx), "on")
+				} else {
+					selected = append(selected, strconv.Itoa(idx))
+				}
+			}
+			if f.Type == api.IssueFormFieldTypeDropdown {
+				values.Set("form-field-"+id, strings.Join(selected, ","))
+			}
+		}
+	}
+	return values, nil
+}
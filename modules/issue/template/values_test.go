@@ -0,0 +1,120 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"testing"
+
+	api "forgejo.org/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valuesTestTemplate = `
+name: Name
+title: Title
+about: About
+body:
+  - type: markdown
+    attributes:
+      value: Please fill in the form
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: input
+    id: count
+    attributes:
+      label: Count
+    validations:
+      is_number: true
+  - type: dropdown
+    id: component
+    attributes:
+      label: Component
+      options:
+        - API
+        - Web
+    validations:
+      required: true
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I agree
+          required: true
+        - label: Subscribe
+`
+
+func TestValidateValues(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+
+	valid := url.Values{
+		"form-field-version":   {"1.2"},
+		"form-field-count":     {"3"},
+		"form-field-component": {"1"},
+		"form-field-terms-0":   {"on"},
+	}
+	require.NoError(t, ValidateValues(template, valid))
+
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		id     string
+		reason FieldValueErrorReason
+	}{
+		{"required input", "form-field-version", " ", "version", FieldValueRequired},
+		{"pattern", "form-field-version", "1.2.3", "version", FieldValuePatternMismatch},
+		{"number", "form-field-count", "three", "count", FieldValueNotNumber},
+		{"required dropdown", "form-field-component", "", "component", FieldValueRequired},
+		{"unknown option", "form-field-component", "2", "component", FieldValueUnknownOption},
+		{"single option", "form-field-component", "0,1", "component", FieldValueTooManyOptions},
+		{"required checkbox", "form-field-terms-0", "", "terms", FieldValueRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := url.Values{}
+			for k, v := range valid {
+				values[k] = v
+			}
+			values.Set(tt.key, tt.value)
+			var invalid ErrInvalidFieldValue
+			require.ErrorAs(t, ValidateValues(template, values), &invalid)
+			assert.Equal(t, tt.id, invalid.ID)
+			assert.Equal(t, tt.reason, invalid.Reason)
+		})
+	}
+}
+
+func TestFieldValuesFromMap(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+
+	values, err := ValuesFromMap(template, map[string][]string{
+		"version":   {"1.2"},
+		"component": {"Web"},
+		"terms":     {"I agree", "Subscribe"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ValidateValues(template, values))
+	assert.Equal(t, []*api.IssueFormFieldValue{
+		{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Values: []string{"1.2"}},
+		{ID: "count", Type: api.IssueFormFieldTypeInput, Label: "Count", Values: []string{}},
+		{ID: "component", Type: api.IssueFormFieldTypeDropdown, Label: "Component", Values: []string{"Web"}},
+		{ID: "terms", Type: api.IssueFormFieldTypeCheckboxes, Label: "Terms", Values: []string{"I agree", "Subscribe"}},
+	}, FieldValues(template, values))
+
+	_, err = ValuesFromMap(template, map[string][]string{"component": {"CLI"}})
+	assert.Equal(t, ErrInvalidFieldValue{ID: "component", Label: "Component", Reason: FieldValueUnknownOption}, err)
+	_, err = ValuesFromMap(template, map[string][]string{"non-existent": {"value"}})
+	assert.Equal(t, ErrInvalidFieldValue{ID: "non-existent", Reason: FieldValueUnknownField}, err)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package secretscan finds credentials in file contents.
+package secretscan
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Rule describes one kind of secret
+type Rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+// Finding is a match of a rule
+type Finding struct {
+	RuleID      string
+	Description string
+	// Line is the 1-based line number of the match
+	Line int
+	// Secret is the matched text
+	Secret string
+}
+
+// Redacted returns the secret with all but its first four characters masked
+func (f *Finding) Redacted() string {
+	if len(f.Secret) <= 4 {
+		return "****"
+	}
+	return f.Secret[:4] + "****"
+}
+
+var builtinRules = []*Rule{
+	{ID: "aws-access-key-id", Description: "AWS access key ID", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{ID: "github-token", Description: "GitHub token", Pattern: regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,255}\b`)},
+	{ID: "github-fine-grained-token", Description: "GitHub fine-grained personal access token", Pattern: regexp.MustCompile(`\bgithub_pat_[0-9A-Za-z_]{82}\b`)},
+	{ID: "gitlab-token", Description: "GitLab personal access token", Pattern: regexp.MustCompile(`\bglpat-[0-9A-Za-z_-]{20}\b`)},
+	{ID: "slack-token", Description: "Slack token", Pattern: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}\b`)},
+	{ID: "stripe-secret-key", Description: "Stripe secret key", Pattern: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+	{ID: "google-api-key", Description: "Google API key", Pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{ID: "private-key", Description: "Private key", Pattern: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`)},
+}
+
+// AccessTokenRule matches candidates for access tokens of this instance. They
+// have no distinctive format, so its matches must be confirmed before they are
+// reported, which is why it is not part of BuiltinRules.
+var AccessTokenRule = &Rule{ID: "forgejo-access-token", Description: "Forgejo access token", Pattern: regexp.MustCompile(`\b[0-9a-f]{40}\b`)}
+
+// BuiltinRules returns the rules for well-known token formats
+func BuiltinRules() []*Rule {
+	return builtinRules
+}
+
+// NewRule compiles a custom rule
+func NewRule(id, description, pattern string) (*Rule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Rule{ID: id, Description: description, Pattern: re}, nil
+}
+
+// Scan returns the findings of all rules in content, in the order they appear
+func Scan(rules []*Rule, content []byte) []Finding {
+	var findings []Finding
+	line := 1
+	for len(content) > 0 {
+		var current []byte
+		if i := bytes.IndexByte(content, '\n'); i >= 0 {
+			current, content = content[:i], content[i+1:]
+		} else {
+			current, content = content, nil
+		}
+		for _, rule := range rules {
+			for _, match := range rule.Pattern.FindAll(current, -1) {
+				findings = append(findings, Finding{
+					RuleID:      rule.ID,
+					Description: rule.Description,
+					Line:        line,
+					Secret:      string(match),
+				})
+			}
+		}
+		line++
+	}
+	return findings
+}
@@ -118,6 +118,7 @@ var (
 			CRUDActions       []string `ini:"CRUD_ACTIONS"`
 			Merges            []string
 			Wiki              []string
+			Releases          []string
 			DefaultTrustModel string
 		} `ini:"repository.signing"`
 
@@ -271,6 +272,7 @@ var (
 			CRUDActions       []string `ini:"CRUD_ACTIONS"`
 			Merges            []string
 			Wiki              []string
+			Releases          []string
 			DefaultTrustModel string
 		}{
 			SigningKey:        "default",
@@ -281,6 +283,7 @@ var (
 			CRUDActions:       []string{"pubkey", "twofa", "parentsigned"},
 			Merges:            []string{"pubkey", "twofa", "basesigned", "commitssigned"},
 			Wiki:              []string{"never"},
+			Releases:          []string{"never"},
 			DefaultTrustModel: "collaborator",
 		},
 
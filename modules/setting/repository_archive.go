@@ -7,6 +7,8 @@ import "fmt"
 
 var RepoArchive = struct {
 	Storage *Storage
+	// IncludeLFS replaces LFS pointer files with their content in generated archives
+	IncludeLFS bool `ini:"INCLUDE_LFS"`
 }{}
 
 func loadRepoArchiveFrom(rootCfg ConfigProvider) (err error) {
@@ -110,3 +110,23 @@ MINIO_SECRET_ACCESS_KEY = correct_key
 	assert.EqualValues(t, "minio", storage.Type)
 	assert.Equal(t, "gitea", storage.MinioConfig.Bucket)
 }
+
+func TestRepoArchiveIncludeLFS(t *testing.T) {
+	defer func() { RepoArchive.IncludeLFS = false }()
+
+	cfg, err := NewConfigProviderFromData(`
+[repo-archive]
+INCLUDE_LFS = true
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadRepoArchiveFrom(cfg))
+	assert.True(t, RepoArchive.IncludeLFS)
+
+	cfg, err = NewConfigProviderFromData(`
+[repo-archive]
+INCLUDE_LFS = false
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadRepoArchiveFrom(cfg))
+	assert.False(t, RepoArchive.IncludeLFS)
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"forgejo.org/modules/log"
+)
+
+// SecretScanning settings
+var SecretScanning = struct {
+	Enabled bool
+	// MaxFileSize is the largest file that is scanned, in bytes
+	MaxFileSize int64
+	// RevokeAccessTokens deletes access tokens of this instance found in pushed content
+	RevokeAccessTokens bool
+}{
+	Enabled:            true,
+	MaxFileSize:        1024 * 1024,
+	RevokeAccessTokens: true,
+}
+
+func loadSecretScanningFrom(rootCfg ConfigProvider) {
+	if err := rootCfg.Section("secret_scanning").MapTo(&SecretScanning); err != nil {
+		log.Fatal("Failed to map SecretScanning settings: %v", err)
+	}
+}
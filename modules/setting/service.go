@@ -76,6 +76,7 @@ var Service = struct {
 	AllowDotsInUsernames                    bool
 	EnableTimetracking                      bool
 	DefaultEnableTimetracking               bool
+	TimetrackingLockPeriod                  time.Duration
 	DefaultEnableDependencies               bool
 	AllowCrossRepositoryDependencies        bool
 	DefaultAllowOnlyContributorsToTrackTime bool
@@ -238,6 +239,7 @@ func loadServiceFrom(rootCfg ConfigProvider) {
 	Service.EnableTimetracking = sec.Key("ENABLE_TIMETRACKING").MustBool(true)
 	if Service.EnableTimetracking {
 		Service.DefaultEnableTimetracking = sec.Key("DEFAULT_ENABLE_TIMETRACKING").MustBool(true)
+		Service.TimetrackingLockPeriod = sec.Key("TIMETRACKING_LOCK_PERIOD").MustDuration(0)
 	}
 	Service.DefaultEnableDependencies = sec.Key("DEFAULT_ENABLE_DEPENDENCIES").MustBool(true)
 	Service.AllowCrossRepositoryDependencies = sec.Key("ALLOW_CROSS_REPOSITORY_DEPENDENCIES").MustBool(true)
@@ -149,6 +149,7 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadI18nFrom(cfg)
 	loadGitFrom(cfg)
 	loadMirrorFrom(cfg)
+	loadSecretScanningFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadQuotaFrom(cfg)
 	loadOtherFrom(cfg)
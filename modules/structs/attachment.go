@@ -20,6 +20,8 @@ type Attachment struct {
 	DownloadURL string    `json:"browser_download_url"`
 	// enum: ["attachment", "external"]
 	Type string `json:"type"`
+	// SHA-256 checksum of the attachment, empty for external attachments
+	SHA256 string `json:"sha256"`
 }
 
 // EditAttachmentOptions options for editing attachments
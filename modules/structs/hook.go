@@ -343,9 +343,16 @@ type ChangesFromPayload struct {
 
 // ChangesPayload represents the payload information of issue change
 type ChangesPayload struct {
-	Title *ChangesFromPayload `json:"title,omitempty"`
-	Body  *ChangesFromPayload `json:"body,omitempty"`
-	Ref   *ChangesFromPayload `json:"ref,omitempty"`
+	Title       *ChangesFromPayload        `json:"title,omitempty"`
+	Body        *ChangesFromPayload        `json:"body,omitempty"`
+	Ref         *ChangesFromPayload        `json:"ref,omitempty"`
+	CustomField *ChangesCustomFieldPayload `json:"custom_field,omitempty"`
+}
+
+// ChangesCustomFieldPayload represents the previous values of a changed custom field
+type ChangesCustomFieldPayload struct {
+	Name string   `json:"name"`
+	From []string `json:"from"`
 }
 
 // __________      .__  .__    __________                                     __
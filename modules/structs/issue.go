@@ -80,6 +80,8 @@ type Issue struct {
 	Repo        *RepositoryMeta  `json:"repository"`
 
 	PinOrder int `json:"pin_order"`
+
+	CustomFields []*IssueCustomFieldValue `json:"custom_fields"`
 }
 
 // CreateIssueOption options to create one issue
@@ -98,6 +100,13 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// values of custom issue fields, keyed by the name of the field. Users are given by their username.
+	CustomFields map[string][]string `json:"custom_fields"`
+	// file name of a form template of the default branch, the body is then rendered from form_values
+	Template string `json:"template"`
+	// values of the fields of the form template, keyed by the id of the field. Dropdowns and checkboxes are given
+	// the labels of their options to select.
+	FormValues map[string][]string `json:"form_values"`
 }
 
 // EditIssueOption options for editing an issue
@@ -124,6 +133,14 @@ type EditDeadlineOption struct {
 	Deadline *time.Time `json:"due_date"`
 }
 
+// TransferIssueOption options for transferring an issue to another repository
+// swagger:model
+type TransferIssueOption struct {
+	// Repository to transfer the issue to, as owner/name
+	// required: true
+	Repository string `json:"repository" binding:"Required"`
+}
+
 // IssueDeadline represents an issue deadline
 // swagger:model
 type IssueDeadline struct {
@@ -167,6 +184,23 @@ func (iff IssueFormField) VisibleInContent() bool {
 	return slices.Contains(iff.Visible, IssueFormFieldVisibleContent)
 }
 
+// IssueFormFieldValue represents the value submitted for a field of an issue form
+type IssueFormFieldValue struct {
+	ID    string             `json:"id"`
+	Type  IssueFormFieldType `json:"type"`
+	Label string             `json:"label"`
+	// the text of an input or a textarea, or the labels of the selected options of a dropdown or checkboxes
+	Values []string `json:"values"`
+}
+
+// IssueFormValues represents the values submitted through the form template an issue or pull request was created from
+// swagger:model
+type IssueFormValues struct {
+	// file name of the form template
+	Template string                 `json:"template"`
+	Fields   []*IssueFormFieldValue `json:"fields"`
+}
+
 // IssueFormFieldVisible defines issue form field visible
 // swagger:model
 type IssueFormFieldVisible string
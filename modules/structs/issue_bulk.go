@@ -0,0 +1,65 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// BulkIssueOperation a change applied to every selected issue or pull request
+type BulkIssueOperation struct {
+	// required: true
+	// enum: add_labels,remove_labels,close,reopen,set_milestone,add_assignees,remove_assignees,move_project_column,transfer
+	Type string `json:"type" binding:"Required"`
+	// Labels to add or remove, a list of label IDs or a list of label names
+	Labels []any `json:"labels"`
+	// Milestone ID to set, 0 removes the milestone
+	Milestone int64 `json:"milestone"`
+	// Assignees to add or remove, by username
+	Assignees []string `json:"assignees"`
+	// ProjectColumn ID of the project column to move the issues to
+	ProjectColumn int64 `json:"project_column"`
+	// Repository to transfer the issues to, as owner/name, a transfer must be the last operation
+	Repository string `json:"repository"`
+}
+
+// BulkIssueQuery selects the issues and pull requests of a bulk edit like the issue list of the repository
+type BulkIssueQuery struct {
+	// enum: open,closed,all
+	State string `json:"state"`
+	// enum: issues,pulls
+	Type string `json:"type"`
+	// Label names, issues having any of them are selected
+	Labels []string `json:"labels"`
+	// Milestone names or IDs, issues having any of them are selected
+	Milestones []string `json:"milestones"`
+	Keyword    string   `json:"q"`
+}
+
+// BulkEditIssuesOption options for editing several issues and pull requests at once
+type BulkEditIssuesOption struct {
+	// Indexes of the issues and pull requests to edit, mutually exclusive with query
+	Indexes []int64 `json:"indexes"`
+	// Query selecting the issues and pull requests to edit, mutually exclusive with indexes
+	Query *BulkIssueQuery `json:"query"`
+	// Operations applied in order to each issue
+	// required: true
+	Operations []*BulkIssueOperation `json:"operations" binding:"Required"`
+	// Atomic applies all the changes or none of them, otherwise each issue is changed independently
+	Atomic bool `json:"atomic"`
+}
+
+// BulkIssueResult the outcome of a bulk edit for one issue or pull request
+type BulkIssueResult struct {
+	Index int64 `json:"index"`
+	// enum: changed,unchanged,failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// TransferredTo the new location of a transferred issue, as owner/name#index
+	TransferredTo string `json:"transferred_to,omitempty"`
+}
+
+// BulkEditIssuesResult the outcome of a bulk edit
+// swagger:model
+type BulkEditIssuesResult struct {
+	Results []*BulkIssueResult `json:"results"`
+	Changed int                `json:"changed"`
+	Failed  int                `json:"failed"`
+}
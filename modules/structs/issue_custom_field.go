@@ -0,0 +1,32 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// IssueCustomField represents a custom field which can be set on issues
+type IssueCustomField struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// enum: ["text", "number", "date", "select", "multiselect", "user"]
+	Type string `json:"type"`
+	// the choices of select and multiselect fields
+	Options []string `json:"options"`
+	// whether the field is defined by the organization owning the repository
+	IsOrgField bool `json:"is_org_field"`
+}
+
+// IssueCustomFieldValue represents the values of a custom field set on an issue
+type IssueCustomFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	// the values of the field, users are given by their username
+	Values []string `json:"values"`
+}
+
+// SetIssueCustomFieldOption options to set the values of a custom field on an issue
+type SetIssueCustomFieldOption struct {
+	// the new values, an empty list clears the field. Users are given by their username.
+	Values []string `json:"values"`
+}
@@ -35,3 +35,36 @@ type TrackedTime struct {
 
 // TrackedTimeList represents a list of tracked times
 type TrackedTimeList []*TrackedTime
+
+// TimeReportEntry is the time tracked for a repository, milestone, label or user
+type TimeReportEntry struct {
+	// ID of the repository, milestone, label or user, 0 for the issues without milestone or label
+	ID int64 `json:"id"`
+	// Name is the full name of the repository, or the name of the milestone, label or user
+	Name string `json:"name"`
+	// Time in seconds
+	Time int64 `json:"time"`
+}
+
+// TimeReport sums up tracked times
+type TimeReport struct {
+	// enum: repo,milestone,label,user
+	GroupBy string `json:"group_by"`
+	// Total time in seconds, the times of an issue with several labels are counted once
+	Total   int64              `json:"total"`
+	Entries []*TimeReportEntry `json:"entries"`
+}
+
+// TimeReportItem is a tracked time of an exported time report
+type TimeReportItem struct {
+	ID int64 `json:"id"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+	// Time in seconds
+	Time       int64  `json:"time"`
+	UserName   string `json:"user_name"`
+	Repository string `json:"repository"`
+	IssueIndex int64  `json:"issue_index"`
+	IssueTitle string `json:"issue_title"`
+	Milestone  string `json:"milestone"`
+}
@@ -19,6 +19,11 @@ type Team struct {
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"}
 	UnitsMap         map[string]string `json:"units_map"`
 	CanCreateOrgRepo bool              `json:"can_create_org_repo"`
+	// how reviewers are picked among the members when the team is requested to review a pull request
+	// enum: ["none", "round-robin", "load-balance"]
+	ReviewAssignmentMode string `json:"review_assignment_mode"`
+	// the number of members picked when the team is requested to review a pull request
+	ReviewAssignmentCount int `json:"review_assignment_count"`
 }
 
 // CreateTeamOption options for creating a team
@@ -35,6 +40,11 @@ type CreateTeamOption struct {
 	// example: {"repo.actions","repo.packages","repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"}
 	UnitsMap         map[string]string `json:"units_map"`
 	CanCreateOrgRepo bool              `json:"can_create_org_repo"`
+	// how reviewers are picked among the members when the team is requested to review a pull request
+	// enum: ["none", "round-robin", "load-balance"]
+	ReviewAssignmentMode string `json:"review_assignment_mode" binding:"In(,none,round-robin,load-balance)"`
+	// the number of members picked when the team is requested to review a pull request, 1 by default
+	ReviewAssignmentCount int `json:"review_assignment_count" binding:"Range(0,10)"`
 }
 
 // EditTeamOption options for editing a team
@@ -51,4 +61,9 @@ type EditTeamOption struct {
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"}
 	UnitsMap         map[string]string `json:"units_map"`
 	CanCreateOrgRepo *bool             `json:"can_create_org_repo"`
+	// how reviewers are picked among the members when the team is requested to review a pull request
+	// enum: ["none", "round-robin", "load-balance"]
+	ReviewAssignmentMode *string `json:"review_assignment_mode" binding:"In(,none,round-robin,load-balance)"`
+	// the number of members picked when the team is requested to review a pull request
+	ReviewAssignmentCount *int `json:"review_assignment_count"`
 }
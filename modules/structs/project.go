@@ -0,0 +1,35 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// ProjectAutomation represents a rule moving the issues and pull requests of a project to a column
+// when an event happens on them
+// swagger:model
+type ProjectAutomation struct {
+	ID int64 `json:"id"`
+	// enum: item_opened,item_labeled,item_closed,item_reopened,pull_request_opened
+	Event string `json:"event"`
+	// the rule only applies to issues and pull requests with this label, 0 for any
+	LabelID     int64  `json:"label_id"`
+	ColumnID    int64  `json:"column_id"`
+	ColumnTitle string `json:"column_title"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateProjectAutomationOption options for adding an automation rule to a project
+// swagger:model
+type CreateProjectAutomationOption struct {
+	// required: true
+	// enum: item_opened,item_labeled,item_closed,item_reopened,pull_request_opened
+	Event string `json:"event" binding:"Required"`
+	// the rule only applies to issues and pull requests with this label, required for item_labeled
+	LabelID int64 `json:"label_id"`
+	// required: true
+	ColumnID int64 `json:"column_id" binding:"Required"`
+}
@@ -70,6 +70,28 @@ type PRBranchInfo struct {
 	Repository *Repository `json:"repo"`
 }
 
+// PullRequestMergeability represents whether a pull request can be merged regarding the protection of its base branch
+type PullRequestMergeability struct {
+	// whether the pull request can be merged without conflicts
+	Mergeable bool `json:"mergeable"`
+	// whether the protection of the base branch allows to merge the pull request
+	Allowed bool `json:"allowed"`
+	// why the protection of the base branch does not allow to merge the pull request
+	Reason      string                         `json:"reason,omitempty"`
+	ReviewRules []*PullRequestReviewRuleStatus `json:"review_rules"`
+}
+
+// PullRequestReviewRuleStatus represents the state of a review rule of the protection of the base branch,
+// which matches files changed by a pull request
+type PullRequestReviewRuleStatus struct {
+	FilePatterns      string   `json:"file_patterns"`
+	RequiredApprovals int64    `json:"required_approvals"`
+	GrantedApprovals  int64    `json:"granted_approvals"`
+	Teams             []string `json:"teams"`
+	MatchedFiles      []string `json:"matched_files"`
+	Satisfied         bool     `json:"satisfied"`
+}
+
 // ListPullRequestsOptions options for listing pull requests
 type ListPullRequestsOptions struct {
 	Page  int    `json:"page"`
@@ -88,6 +110,11 @@ type CreatePullRequestOption struct {
 	Labels    []int64  `json:"labels"`
 	// swagger:strfmt date-time
 	Deadline *time.Time `json:"due_date"`
+	// file name of a form template of the default branch, the body is then rendered from form_values
+	Template string `json:"template"`
+	// values of the fields of the form template, keyed by the id of the field. Dropdowns and checkboxes are given
+	// the labels of their options to select.
+	FormValues map[string][]string `json:"form_values"`
 }
 
 // EditPullRequestOption options when modify pull request
@@ -103,6 +103,39 @@ type DismissPullReviewOptions struct {
 	Priors  bool   `json:"priors"`
 }
 
+// ApplySuggestionsOptions are options to apply the suggestions of code comments of a pull request
+type ApplySuggestionsOptions struct {
+	// IDs of the code comments whose suggestions are applied in a single commit
+	// required: true
+	CommentIDs []int64 `json:"comment_ids" binding:"Required"`
+	// message of the commit, a default one is used if empty
+	Message string `json:"message"`
+}
+
+// PullReviewViewedFile is the viewed state of a changed file of a pull request
+type PullReviewViewedFile struct {
+	Filename string `json:"filename"`
+	// "viewed", "unviewed", or "has-changed" if the file changed since it was viewed
+	State string `json:"state"`
+}
+
+// PullReviewViewedFiles is the progress of the review of a pull request by the authenticated user
+type PullReviewViewedFiles struct {
+	HeadCommitSHA string                  `json:"head_commit_sha"`
+	ViewedFiles   int                     `json:"viewed_files"`
+	TotalFiles    int                     `json:"total_files"`
+	Files         []*PullReviewViewedFile `json:"files"`
+}
+
+// UpdatePullReviewViewedFilesOptions are options to mark changed files of a pull request as viewed
+type UpdatePullReviewViewedFilesOptions struct {
+	// files to mark as viewed (true) or not viewed (false)
+	// required: true
+	Files map[string]bool `json:"files" binding:"Required"`
+	// head commit at which the files were viewed, the current head commit if empty
+	HeadCommitSHA string `json:"head_commit_sha"`
+}
+
 // PullReviewRequestOptions are options to add or remove pull review requests
 type PullReviewRequestOptions struct {
 	Reviewers     []string `json:"reviewers"`
@@ -29,6 +29,19 @@ type Release struct {
 	Publisher            *User                    `json:"author"`
 	Attachments          []*Attachment            `json:"assets"`
 	ArchiveDownloadCount *TagArchiveDownloadCount `json:"archive_download_count"`
+	// URL of the generated file listing the SHA-256 checksums of the assets
+	ChecksumsURL string `json:"checksums_url"`
+}
+
+// ReleaseChecksums represents the checksums of the assets of a release
+type ReleaseChecksums struct {
+	// SHA-256 checksums of the assets, in the format of sha256sum
+	Checksums string `json:"checksums"`
+	// armored detached signature of the checksums, empty if they are not signed
+	Signature string `json:"signature"`
+	// format of the signature, empty if the checksums are not signed
+	// enum: ["", "openpgp", "ssh"]
+	SignatureFormat string `json:"signature_format"`
 }
 
 // CreateReleaseOption options when creating a release
@@ -106,12 +106,15 @@ type Repository struct {
 	DefaultMergeStyle             string           `json:"default_merge_style"`
 	DefaultAllowMaintainerEdit    bool             `json:"default_allow_maintainer_edit"`
 	DefaultUpdateStyle            string           `json:"default_update_style"`
+	BlockMergeOutOfStackOrder     bool             `json:"block_merge_out_of_stack_order"`
 	AvatarURL                     string           `json:"avatar_url"`
 	Internal                      bool             `json:"internal"`
 	MirrorInterval                string           `json:"mirror_interval"`
 	// ObjectFormatName of the underlying git repository
 	// enum: ["sha1", "sha256"]
 	ObjectFormatName string `json:"object_format_name"`
+	// the templates of the default merge messages, by merge style
+	MergeMessageTemplates map[string]string `json:"merge_message_templates,omitempty"`
 	// swagger:strfmt date-time
 	MirrorUpdated time.Time     `json:"mirror_updated,omitempty"`
 	RepoTransfer  *RepoTransfer `json:"repo_transfer"`
@@ -230,6 +233,11 @@ type EditRepoOption struct {
 	DefaultUpdateStyle *string `json:"default_update_style,omitempty" binding:"In(merge,rebase)"`
 	// set to `true` to allow edits from maintainers by default
 	DefaultAllowMaintainerEdit *bool `json:"default_allow_maintainer_edit,omitempty"`
+	// set to `true` to block merging a pull request which is stacked on top of another open pull request
+	BlockMergeOutOfStackOrder *bool `json:"block_merge_out_of_stack_order,omitempty"`
+	// set the templates of the default merge messages, by merge style: "merge", "rebase", "rebase-merge" or "squash".
+	// An empty template removes the template of the merge style.
+	MergeMessageTemplates map[string]string `json:"merge_message_templates,omitempty"`
 	// set to `true` to archive this repository.
 	Archived *bool `json:"archived,omitempty"`
 	// set to a string like `8h30m0s` to set the mirror interval time
@@ -23,90 +23,102 @@ type Branch struct {
 // BranchProtection represents a branch protection for a repository
 type BranchProtection struct {
 	// Deprecated: true
-	BranchName                    string   `json:"branch_name"`
-	RuleName                      string   `json:"rule_name"`
-	EnablePush                    bool     `json:"enable_push"`
-	EnablePushWhitelist           bool     `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       bool     `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             bool     `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             int64    `json:"required_approvals"`
-	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 bool     `json:"apply_to_admins"`
+	BranchName                    string                        `json:"branch_name"`
+	RuleName                      string                        `json:"rule_name"`
+	EnablePush                    bool                          `json:"enable_push"`
+	EnablePushWhitelist           bool                          `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string                      `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string                      `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       bool                          `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool                          `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string                      `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string                      `json:"merge_whitelist_teams"`
+	EnableStatusCheck             bool                          `json:"enable_status_check"`
+	StatusCheckContexts           []string                      `json:"status_check_contexts"`
+	RequiredApprovals             int64                         `json:"required_approvals"`
+	EnableApprovalsWhitelist      bool                          `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string                      `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string                      `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        bool                          `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool                          `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool                          `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool                          `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool                          `json:"ignore_stale_approvals"`
+	RequireSignedCommits          bool                          `json:"require_signed_commits"`
+	ProtectedFilePatterns         string                        `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       string                        `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 bool                          `json:"apply_to_admins"`
+	ReviewRules                   []*BranchProtectionReviewRule `json:"review_rules"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
 	Updated time.Time `json:"updated_at"`
 }
 
+// BranchProtectionReviewRule represents a rule requiring approvals for the changes to the files matching its patterns
+type BranchProtectionReviewRule struct {
+	// semicolon separated list of glob patterns
+	FilePatterns      string `json:"file_patterns"`
+	RequiredApprovals int64  `json:"required_approvals"`
+	// teams whose members can approve the changes, anyone with write access can if empty
+	Teams []string `json:"teams"`
+}
+
 // CreateBranchProtectionOption options for creating a branch protection
 type CreateBranchProtectionOption struct {
 	// Deprecated: true
-	BranchName                    string   `json:"branch_name"`
-	RuleName                      string   `json:"rule_name"`
-	EnablePush                    bool     `json:"enable_push"`
-	EnablePushWhitelist           bool     `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       bool     `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             bool     `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             int64    `json:"required_approvals"`
-	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 bool     `json:"apply_to_admins"`
+	BranchName                    string                        `json:"branch_name"`
+	RuleName                      string                        `json:"rule_name"`
+	EnablePush                    bool                          `json:"enable_push"`
+	EnablePushWhitelist           bool                          `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string                      `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string                      `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       bool                          `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool                          `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string                      `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string                      `json:"merge_whitelist_teams"`
+	EnableStatusCheck             bool                          `json:"enable_status_check"`
+	StatusCheckContexts           []string                      `json:"status_check_contexts"`
+	RequiredApprovals             int64                         `json:"required_approvals"`
+	EnableApprovalsWhitelist      bool                          `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string                      `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string                      `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        bool                          `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool                          `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool                          `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool                          `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool                          `json:"ignore_stale_approvals"`
+	RequireSignedCommits          bool                          `json:"require_signed_commits"`
+	ProtectedFilePatterns         string                        `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       string                        `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 bool                          `json:"apply_to_admins"`
+	ReviewRules                   []*BranchProtectionReviewRule `json:"review_rules"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
 type EditBranchProtectionOption struct {
-	EnablePush                    *bool    `json:"enable_push"`
-	EnablePushWhitelist           *bool    `json:"enable_push_whitelist"`
-	PushWhitelistUsernames        []string `json:"push_whitelist_usernames"`
-	PushWhitelistTeams            []string `json:"push_whitelist_teams"`
-	PushWhitelistDeployKeys       *bool    `json:"push_whitelist_deploy_keys"`
-	EnableMergeWhitelist          *bool    `json:"enable_merge_whitelist"`
-	MergeWhitelistUsernames       []string `json:"merge_whitelist_usernames"`
-	MergeWhitelistTeams           []string `json:"merge_whitelist_teams"`
-	EnableStatusCheck             *bool    `json:"enable_status_check"`
-	StatusCheckContexts           []string `json:"status_check_contexts"`
-	RequiredApprovals             *int64   `json:"required_approvals"`
-	EnableApprovalsWhitelist      *bool    `json:"enable_approvals_whitelist"`
-	ApprovalsWhitelistUsernames   []string `json:"approvals_whitelist_username"`
-	ApprovalsWhitelistTeams       []string `json:"approvals_whitelist_teams"`
-	BlockOnRejectedReviews        *bool    `json:"block_on_rejected_reviews"`
-	BlockOnOfficialReviewRequests *bool    `json:"block_on_official_review_requests"`
-	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals"`
-	IgnoreStaleApprovals          *bool    `json:"ignore_stale_approvals"`
-	RequireSignedCommits          *bool    `json:"require_signed_commits"`
-	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
-	ApplyToAdmins                 *bool    `json:"apply_to_admins"`
+	EnablePush                    *bool                         `json:"enable_push"`
+	EnablePushWhitelist           *bool                         `json:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string                      `json:"push_whitelist_usernames"`
+	PushWhitelistTeams            []string                      `json:"push_whitelist_teams"`
+	PushWhitelistDeployKeys       *bool                         `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          *bool                         `json:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string                      `json:"merge_whitelist_usernames"`
+	MergeWhitelistTeams           []string                      `json:"merge_whitelist_teams"`
+	EnableStatusCheck             *bool                         `json:"enable_status_check"`
+	StatusCheckContexts           []string                      `json:"status_check_contexts"`
+	RequiredApprovals             *int64                        `json:"required_approvals"`
+	EnableApprovalsWhitelist      *bool                         `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string                      `json:"approvals_whitelist_username"`
+	ApprovalsWhitelistTeams       []string                      `json:"approvals_whitelist_teams"`
+	BlockOnRejectedReviews        *bool                         `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests *bool                         `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         *bool                         `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         *bool                         `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          *bool                         `json:"ignore_stale_approvals"`
+	RequireSignedCommits          *bool                         `json:"require_signed_commits"`
+	ProtectedFilePatterns         *string                       `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       *string                       `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 *bool                         `json:"apply_to_admins"`
+	ReviewRules                   []*BranchProtectionReviewRule `json:"review_rules"`
 }
@@ -0,0 +1,19 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// RepoDependency represents a dependency declared on the default branch of a repository
+type RepoDependency struct {
+	// Ecosystem is the package URL type of the dependency, e.g. "npm" or "golang"
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	// Manifest is the path of the manifest or lock file declaring the dependency
+	Manifest string `json:"manifest"`
+	IsDev    bool   `json:"is_dev"`
+	PURL     string `json:"purl"`
+	CommitID string `json:"commit_id"`
+	// PackageURL links to the package in this instance's registry, if the dependency resolves to one
+	PackageURL string `json:"package_url,omitempty"`
+}
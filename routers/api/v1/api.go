@@ -500,6 +500,19 @@ func reqAnyRepoReader() func(ctx *context.APIContext) {
 	}
 }
 
+// reqOrgUnitAccess user should have the access mode to the unit of the organization, or be a site admin
+func reqOrgUnitAccess(unitType unit.Type, accessMode perm.AccessMode) func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.IsUserSiteAdmin() {
+			return
+		}
+		if unitType.UnitGlobalDisabled() || ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unitType) < accessMode {
+			ctx.Error(http.StatusForbidden, "reqOrgUnitAccess", "user should have the permission to access this unit of the organization")
+			return
+		}
+	}
+}
+
 // reqOrgOwnership user should be an organization owner, or a site admin
 func reqOrgOwnership() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -1201,6 +1214,7 @@ func Routes() *web.Route {
 					m.Combo("").Get(repo.ListTrackedTimesByRepository)
 					m.Combo("/{timetrackingusername}").Get(repo.ListTrackedTimesByUser)
 				}, mustEnableIssues, reqToken())
+				m.Get("/time_report", mustEnableIssues, reqToken(), repo.GetTimeReport)
 				m.Group("/wiki", func() {
 					m.Combo("/page/{pageName}").
 						Get(repo.GetWikiPage).
@@ -1230,6 +1244,7 @@ func Routes() *web.Route {
 						m.Combo("").Get(repo.GetRelease).
 							Patch(reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.EditReleaseOption{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeReposAll, context.QuotaTargetRepo), repo.EditRelease).
 							Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteRelease)
+						m.Get("/checksums", repo.GetReleaseChecksums)
 						m.Group("/assets", func() {
 							m.Combo("").Get(repo.ListReleaseAttachments).
 								Post(reqToken(), reqRepoWriter(unit.TypeReleases), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeAssetsAttachmentsReleases, context.QuotaTargetRepo), repo.CreateReleaseAttachment)
@@ -1266,6 +1281,10 @@ func Routes() *web.Route {
 						m.Post("/update", reqToken(), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
 						m.Get("/files", repo.GetPullRequestFiles)
+						m.Combo("/viewed_files", reqToken()).Get(repo.GetPullViewedFiles).
+							Put(bind(api.UpdatePullReviewViewedFilesOptions{}), repo.UpdatePullViewedFiles)
+						m.Post("/suggestions", reqToken(), mustNotBeArchived, bind(api.ApplySuggestionsOptions{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.ApplyPullSuggestions)
+						m.Get("/mergeability", repo.GetPullRequestMergeability)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
@@ -1320,10 +1339,11 @@ func Routes() *web.Route {
 					m.Get("/trees/{sha}", repo.GetTree)
 					m.Get("/blobs/{sha}", repo.GetBlob)
 					m.Get("/tags/{sha}", repo.GetAnnotatedTag)
+					m.Get("/notes", repo.ListNotesRefs)
 					m.Group("/notes/{sha}", func() {
 						m.Get("", repo.GetNote)
-						m.Post("", reqToken(), reqRepoWriter(unit.TypeCode), bind(api.NoteOptions{}), repo.SetNote)
-						m.Delete("", reqToken(), reqRepoWriter(unit.TypeCode), repo.RemoveNote)
+						m.Post("", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, bind(api.NoteOptions{}), repo.SetNote)
+						m.Delete("", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.RemoveNote)
 					})
 				}, context.ReferencesGitRepo(true), reqRepoReader(unit.TypeCode))
 				m.Post("/diffpatch", reqRepoWriter(unit.TypeCode), reqToken(), bind(api.ApplyDiffPatchFileOptions{}), mustNotBeArchived, context.EnforceQuotaAPI(quota_model.LimitSubjectSizeReposAll, context.QuotaTargetRepo), repo.ApplyDiffPatch)
@@ -1347,9 +1367,13 @@ func Routes() *web.Route {
 					}, reqAdmin())
 				}, reqAnyRepoReader())
 				m.Get("/issue_templates", context.ReferencesGitRepo(), repo.GetIssueTemplates)
+				m.Get("/pull_request_templates", context.ReferencesGitRepo(), reqRepoReader(unit.TypePullRequests), repo.GetPullRequestTemplates)
+				m.Get("/issue_fields", reqRepoReader(unit.TypeIssues), repo.ListIssueCustomFields)
 				m.Get("/issue_config", context.ReferencesGitRepo(), repo.GetIssueConfig)
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
+				m.Get("/dependencies", reqRepoReader(unit.TypeCode), repo.ListDependencies)
+				m.Get("/sbom/{format}", reqRepoReader(unit.TypeCode), repo.GetSBOM)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
 				m.Group("/avatar", func() {
@@ -1363,7 +1387,7 @@ func Routes() *web.Route {
 					m.Post("/{branch}", mustNotBeArchived, reqRepoWriter(unit.TypeCode), repo.SyncForkBranch)
 				})
 
-				m.Get("/{ball_type:tarball|zipball|bundle}/*", reqRepoReader(unit.TypeCode), repo.DownloadArchive)
+				m.Get("/{ball_type:tarball|zipball|bundle|tar\\.zst|tar\\.xz}/*", reqRepoReader(unit.TypeCode), repo.DownloadArchive)
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
 
@@ -1385,6 +1409,7 @@ func Routes() *web.Route {
 					m.Combo("").Get(repo.ListIssues).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Post("/bulk", reqToken(), mustNotBeArchived, bind(api.BulkEditIssuesOption{}), repo.BulkEditIssues)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1425,6 +1450,8 @@ func Routes() *web.Route {
 								Delete(reqToken(), bind(api.DeleteLabelsOption{}), repo.ClearIssueLabels)
 							m.Delete("/{id}", reqToken(), bind(api.DeleteLabelsOption{}), repo.DeleteIssueLabel)
 						})
+						m.Put("/custom_fields/{id}", reqToken(), bind(api.SetIssueCustomFieldOption{}), repo.SetIssueCustomField)
+						m.Get("/form_values", repo.GetIssueFormValues)
 						m.Group("/times", func() {
 							m.Combo("").
 								Get(repo.ListTrackedTimes).
@@ -1465,12 +1492,18 @@ func Routes() *web.Route {
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
 							Delete(reqToken(), bind(api.IssueMeta{}), repo.RemoveIssueBlocking)
+						m.Combo("/sub_issues").
+							Get(repo.ListSubIssues).
+							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+						m.Get("/parent", repo.GetParentIssue)
 						m.Group("/pin", func() {
 							m.Combo("").
 								Post(reqToken(), reqAdmin(), repo.PinIssue).
 								Delete(reqToken(), reqAdmin(), repo.UnpinIssue)
 							m.Patch("/{position}", reqToken(), reqAdmin(), repo.MoveIssuePin)
 						})
+						m.Post("/transfer", reqToken(), mustNotBeArchived, bind(api.TransferIssueOption{}), repo.TransferIssue)
 					})
 				}, mustEnableIssuesOrPulls)
 				m.Group("/labels", func() {
@@ -1487,6 +1520,11 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Group("/projects/{id}/automations", func() {
+					m.Combo("").Get(repo.ListProjectAutomations).
+						Post(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.CreateProjectAutomationOption{}), repo.CreateProjectAutomation)
+					m.Delete("/{automation_id}", reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, repo.DeleteProjectAutomation)
+				}, reqRepoReader(unit.TypeProjects))
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 
@@ -1561,6 +1599,12 @@ func Routes() *web.Route {
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Group("/projects/{id}/automations", func() {
+				m.Combo("").Get(org.ListProjectAutomations).
+					Post(reqToken(), reqOrgUnitAccess(unit.TypeProjects, perm.AccessModeWrite), bind(api.CreateProjectAutomationOption{}), org.CreateProjectAutomation)
+				m.Delete("/{automation_id}", reqToken(), reqOrgUnitAccess(unit.TypeProjects, perm.AccessModeWrite), org.DeleteProjectAutomation)
+			}, reqOrgUnitAccess(unit.TypeProjects, perm.AccessModeRead))
+			m.Get("/time_report", reqToken(), org.GetTimeReport)
 
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
@@ -0,0 +1,111 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListProjectAutomations lists the automation rules of a project
+func ListProjectAutomations(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/automations organization orgListProjectAutomations
+	// ---
+	// summary: List the automation rules of an organization's project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListProjectAutomations(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// CreateProjectAutomation adds an automation rule to a project
+func CreateProjectAutomation(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/projects/{id}/automations organization orgCreateProjectAutomation
+	// ---
+	// summary: Add an automation rule to an organization's project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectAutomationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateProjectAutomation(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// DeleteProjectAutomation deletes an automation rule of a project
+func DeleteProjectAutomation(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/automations/{automation_id} organization orgDeleteProjectAutomation
+	// ---
+	// summary: Delete an automation rule of an organization's project
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteProjectAutomation(ctx, ctx.Org.Organization.ID, 0)
+}
@@ -6,6 +6,7 @@ package org
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"forgejo.org/models"
@@ -217,6 +218,15 @@ func CreateTeam(ctx *context.APIContext) {
 	//   "422":
 	//     "$ref": "#/responses/validationError"
 	form := web.GetForm(ctx).(*api.CreateTeamOption)
+	reviewAssignmentMode, ok := organization.ParseReviewAssignmentMode(form.ReviewAssignmentMode)
+	if !ok {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("unknown review_assignment_mode %q", form.ReviewAssignmentMode))
+		return
+	}
+	if form.ReviewAssignmentCount < 0 || form.ReviewAssignmentCount > organization.MaxReviewAssignmentCount {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("review_assignment_count must be between 1 and %d", organization.MaxReviewAssignmentCount))
+		return
+	}
 	p := perm.ParseAccessMode(form.Permission)
 	if p < perm.AccessModeAdmin && len(form.UnitsMap) > 0 {
 		p = unit_model.MinUnitAccessMode(convertUnitsMap(form.UnitsMap))
@@ -228,6 +238,8 @@ func CreateTeam(ctx *context.APIContext) {
 		IncludesAllRepositories: form.IncludesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
 		AccessMode:              p,
+		ReviewAssignmentMode:    reviewAssignmentMode,
+		ReviewAssignmentCount:   max(form.ReviewAssignmentCount, 1),
 	}
 
 	if team.AccessMode < perm.AccessModeAdmin {
@@ -284,6 +296,8 @@ func EditTeam(ctx *context.APIContext) {
 	//     "$ref": "#/responses/Team"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditTeamOption)
 	team := ctx.Org.Team
@@ -304,6 +318,23 @@ func EditTeam(ctx *context.APIContext) {
 		team.Description = *form.Description
 	}
 
+	if form.ReviewAssignmentMode != nil {
+		reviewAssignmentMode, ok := organization.ParseReviewAssignmentMode(*form.ReviewAssignmentMode)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("unknown review_assignment_mode %q", *form.ReviewAssignmentMode))
+			return
+		}
+		team.ReviewAssignmentMode = reviewAssignmentMode
+	}
+
+	if form.ReviewAssignmentCount != nil {
+		if *form.ReviewAssignmentCount < 1 || *form.ReviewAssignmentCount > organization.MaxReviewAssignmentCount {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("review_assignment_count must be between 1 and %d", organization.MaxReviewAssignmentCount))
+			return
+		}
+		team.ReviewAssignmentCount = *form.ReviewAssignmentCount
+	}
+
 	isAuthChanged := false
 	isIncludeAllChanged := false
 	if !team.IsOwnerTeam() && len(form.Permission) != 0 {
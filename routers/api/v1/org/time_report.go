@@ -0,0 +1,73 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// GetTimeReport sums up the times tracked on the issues of the repositories of an organization
+func GetTimeReport(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/time_report organization orgGetTimeReport
+	// ---
+	// summary: Sum up the times tracked on the issues of an organization's repositories
+	// description: Only the own times of the user are summed up for the repositories they can't write issues to.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: sum up the times by repository, milestone, label or user
+	//   type: string
+	//   enum: [repo, milestone, label, user]
+	// - name: user
+	//   in: query
+	//   description: only sum up the times of this user
+	//   type: string
+	// - name: milestone
+	//   in: query
+	//   description: only sum up the times of the issues of this milestone
+	//   type: integer
+	//   format: int64
+	// - name: label
+	//   in: query
+	//   description: only sum up the times of the issues with this label
+	//   type: integer
+	//   format: int64
+	// - name: since
+	//   in: query
+	//   description: Only sum up times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only sum up times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TimeReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	repos, err := issue_service.GetOwnerTimeReportRepos(ctx, ctx.Doer, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOwnerTimeReportRepos", err)
+		return
+	}
+	shared.GetTimeReport(ctx, repos)
+}
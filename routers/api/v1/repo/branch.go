@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
@@ -670,6 +671,11 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		}
 	}
 
+	reviewRules, ok := toReviewRules(ctx, form.ReviewRules)
+	if !ok {
+		return
+	}
+
 	protectBranch = &git_model.ProtectedBranch{
 		RepoID:                        ctx.Repo.Repository.ID,
 		RuleName:                      ruleName,
@@ -690,6 +696,7 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
 		ApplyToAdmins:                 form.ApplyToAdmins,
+		ReviewRules:                   reviewRules,
 	}
 
 	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -701,6 +708,10 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		ApprovalsTeamIDs: approvalsWhitelistTeams,
 	})
 	if err != nil {
+		if git_model.IsErrReviewRuleTeamNoAccess(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "Invalid review rule", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
@@ -881,6 +892,14 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.ApplyToAdmins = *form.ApplyToAdmins
 	}
 
+	if form.ReviewRules != nil {
+		reviewRules, ok := toReviewRules(ctx, form.ReviewRules)
+		if !ok {
+			return
+		}
+		protectBranch.ReviewRules = reviewRules
+	}
+
 	var whitelistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
@@ -976,6 +995,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ApprovalsTeamIDs: approvalsWhitelistTeams,
 	})
 	if err != nil {
+		if git_model.IsErrReviewRuleTeamNoAccess(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "Invalid review rule", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
@@ -1083,3 +1106,37 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// toReviewRules converts the review rules of a branch protection option, writing an error response if a rule is invalid
+func toReviewRules(ctx *context.APIContext, opts []*api.BranchProtectionReviewRule) ([]*git_model.ReviewRule, bool) {
+	rules := make([]*git_model.ReviewRule, 0, len(opts))
+	for _, opt := range opts {
+		if strings.TrimSpace(opt.FilePatterns) == "" || opt.RequiredApprovals < 1 {
+			ctx.Error(http.StatusUnprocessableEntity, "Invalid review rule", "a review rule needs file patterns and at least one required approval")
+			return nil, false
+		}
+		rule := &git_model.ReviewRule{FilePatterns: opt.FilePatterns, RequiredApprovals: opt.RequiredApprovals}
+		if err := rule.CheckFilePatterns(); err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "Invalid review rule", err)
+			return nil, false
+		}
+		if len(opt.Teams) > 0 {
+			if !ctx.Repo.Owner.IsOrganization() {
+				ctx.Error(http.StatusUnprocessableEntity, "Invalid review rule", "teams can only be set for repositories of organizations")
+				return nil, false
+			}
+			var err error
+			rule.TeamIDs, err = organization.GetTeamIDsByNames(ctx, ctx.Repo.Owner.ID, opt.Teams, false)
+			if err != nil {
+				if organization.IsErrTeamNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "Team does not exist", err)
+					return nil, false
+				}
+				ctx.Error(http.StatusInternalServerError, "GetTeamIDsByNames", err)
+				return nil, false
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, true
+}
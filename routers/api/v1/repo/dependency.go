@@ -0,0 +1,130 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/dependency"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	dependency_service "forgejo.org/services/dependency"
+)
+
+// ListDependencies lists the dependencies declared on the default branch of a repository
+func ListDependencies(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/dependencies repository repoListDependencies
+	// ---
+	// summary: List the dependencies declared in the manifests and lock files on the default branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ecosystem
+	//   in: query
+	//   description: only list the dependencies of an ecosystem
+	//   type: string
+	//   enum: [golang, npm, cargo, pypi, maven, composer]
+	// - name: q
+	//   in: query
+	//   description: keyword to filter the dependency names by
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoDependencyList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	deps, count, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+		Ecosystem:   ctx.FormTrim("ecosystem"),
+		Keyword:     ctx.FormTrim("q"),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindRepoDependencies", err)
+		return
+	}
+
+	links, err := dependency_service.GetPackageLinks(ctx, ctx.Doer, deps)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPackageLinks", err)
+		return
+	}
+
+	apiDeps := make([]*api.RepoDependency, 0, len(deps))
+	for _, dep := range deps {
+		apiDeps = append(apiDeps, convert.ToRepoDependency(dep, links[dep.ID]))
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeps)
+}
+
+// GetSBOM exports the dependencies of a repository as a software bill of materials
+func GetSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/sbom/{format} repository repoGetSBOM
+	// ---
+	// summary: Export the dependencies on the default branch as a software bill of materials
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: path
+	//   description: format of the document, SPDX 2.3 or CycloneDX 1.5 in JSON
+	//   type: string
+	//   enum: [spdx, cyclonedx]
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SBOM"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	format := dependency.SBOMFormat(ctx.Params(":format"))
+	if format != dependency.SBOMFormatSPDX && format != dependency.SBOMFormatCycloneDX {
+		ctx.NotFound()
+		return
+	}
+
+	doc, err := dependency_service.GenerateSBOM(ctx, ctx.Repo.Repository, format)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GenerateSBOM", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, doc)
+}
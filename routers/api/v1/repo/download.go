@@ -22,6 +22,10 @@ func DownloadArchive(ctx *context.APIContext) {
 		tp = git.ZIP
 	case "bundle":
 		tp = git.BUNDLE
+	case "tar.zst":
+		tp = git.TARZST
+	case "tar.xz":
+		tp = git.TARXZ
 	default:
 		ctx.Error(http.StatusBadRequest, "", fmt.Sprintf("Unknown archive type: %s", ballType))
 		return
@@ -366,6 +366,11 @@ func download(ctx *context.APIContext, archiveName string, archiver *repo_model.
 	case git.TARGZ:
 		// Per RFC6713.
 		contentType = "application/gzip"
+	case git.TARZST:
+		// Per RFC8878.
+		contentType = "application/zstd"
+	case git.TARXZ:
+		contentType = "application/x-xz"
 	}
 
 	ctx.ServeContent(fr, &context.ServeHeaderOptions{
@@ -389,6 +389,13 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: custom_field
+	//   in: query
+	//   description: Only show items which have the given value of a custom field, as "name:value". The values of user fields are user names. All of the given values must match.
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -408,6 +415,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -510,6 +519,16 @@ func ListIssues(ctx *context.APIContext) {
 		return
 	}
 
+	customFields, err := issue_service.ParseCustomFieldFilters(ctx, ctx.Repo.Repository, ctx.FormStrings("custom_field"))
+	if err != nil {
+		if issues_model.IsErrIssueCustomFieldNotExist(err) || issues_model.IsErrInvalidIssueCustomFieldValue(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "ParseCustomFieldFilters", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ParseCustomFieldFilters", err)
+		return
+	}
+
 	searchOpt := &issue_indexer.SearchOptions{
 		Paginator: &listOptions,
 		Keyword:   keyword,
@@ -551,6 +570,9 @@ func ListIssues(ctx *context.APIContext) {
 	if mentionedByID > 0 {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
+	for _, filter := range customFields {
+		searchOpt.CustomFields = append(searchOpt.CustomFields, issue_indexer.CustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
 
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
@@ -615,13 +637,15 @@ func GetIssue(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Issue"
+	//   "301":
+	//     description: the issue was transferred to another repository, the Location header is its new URL
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
 	issue, err := issues_model.GetIssueWithAttrsByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound()
+			redirectTransferredIssue(ctx, ctx.ParamsInt64(":index"))
 		} else {
 			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
 		}
@@ -634,6 +658,34 @@ func GetIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// redirectTransferredIssue redirects to the issue which was transferred away from the index of the repository,
+// if the doer can read it in its new repository
+func redirectTransferredIssue(ctx *context.APIContext, index int64) {
+	issue, err := issues_model.GetTransferredIssueByIndex(ctx, ctx.Repo.Repository.ID, index)
+	if err != nil {
+		if issues_model.IsErrIssueRedirectNotExist(err) || issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetTransferredIssueByIndex", err)
+		}
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadRepo", err)
+		return
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+	ctx.Redirect(issue.APIURL(ctx), http.StatusMovedPermanently)
+}
+
 // CreateIssue create an issue of a repository
 func CreateIssue(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/issues issue issueCreateIssue
@@ -677,21 +729,40 @@ func CreateIssue(ctx *context.APIContext) {
 		deadlineUnix = timeutil.TimeStamp(form.Deadline.Unix())
 	}
 
+	content := form.Body
+	var formValues *issues_model.IssueFormValues
+	if form.Template != "" {
+		var ok bool
+		if content, formValues, ok = renderTemplateForm(ctx, form.Template, form.FormValues); !ok {
+			return
+		}
+	}
+
 	issue := &issues_model.Issue{
 		RepoID:       ctx.Repo.Repository.ID,
 		Repo:         ctx.Repo.Repository,
 		Title:        form.Title,
 		PosterID:     ctx.Doer.ID,
 		Poster:       ctx.Doer,
-		Content:      form.Body,
+		Content:      content,
 		Ref:          form.Ref,
 		DeadlineUnix: deadlineUnix,
 	}
 
 	assigneeIDs := make([]int64, 0)
+	var customFields []*issue_service.CustomFieldValues
 	var err error
 	if ctx.Repo.CanWrite(unit.TypeIssues) {
 		issue.MilestoneID = form.Milestone
+		customFields, err = issue_service.ResolveCustomFieldValues(ctx, ctx.Repo.Repository, form.CustomFields)
+		if err != nil {
+			if issues_model.IsErrIssueCustomFieldNotExist(err) || issues_model.IsErrInvalidIssueCustomFieldValue(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "ResolveCustomFieldValues", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "ResolveCustomFieldValues", err)
+			}
+			return
+		}
 		assigneeIDs, err = issues_model.MakeIDsFromAPIAssigneesToAdd(ctx, form.Assignee, form.Assignees)
 		if err != nil {
 			if user_model.IsErrUserNotExist(err) {
@@ -725,7 +796,7 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
-	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs); err != nil {
+	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs, customFields, formValues); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
 			return
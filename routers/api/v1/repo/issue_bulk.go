@@ -0,0 +1,454 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// BulkEditIssues applies a list of operations to several issues and pull requests of a repository
+func BulkEditIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/bulk issue issueBulkEdit
+	// ---
+	// summary: Apply operations to several issues and pull requests of a repository
+	// description: The issues are selected either by index or by a query. Each issue is changed
+	//   in a transaction and reported separately, unless `atomic` is set, in which case no issue
+	//   is changed if any of them fails. At most as many issues as the maximum page size of the API
+	//   can be edited at once, larger selections are rejected.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/BulkEditIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BulkEditIssuesResult"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.BulkEditIssuesOption)
+
+	if len(form.Indexes) > 0 && form.Query != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", "indexes and query are mutually exclusive")
+		return
+	}
+
+	ops := make([]*issue_service.BulkOperation, 0, len(form.Operations))
+	for i, formOp := range form.Operations {
+		op := resolveBulkOperation(ctx, formOp)
+		if ctx.Written() {
+			return
+		}
+		if op.Type == issue_service.BulkTransfer && i != len(form.Operations)-1 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "a transfer must be the last operation")
+			return
+		}
+		ops = append(ops, op)
+	}
+
+	var issues []*issues_model.Issue
+	results := make([]*api.BulkIssueResult, 0, len(form.Indexes))
+	resultByIssueID := make(map[int64]*api.BulkIssueResult, len(form.Indexes))
+	switch {
+	case len(form.Indexes) > 0:
+		if len(form.Indexes) > setting.API.MaxResponseItems {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("at most %d issues can be edited at once", setting.API.MaxResponseItems))
+			return
+		}
+		seen := make(container.Set[int64], len(form.Indexes))
+		for _, index := range form.Indexes {
+			if !seen.Add(index) {
+				continue
+			}
+			issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, index)
+			if err != nil {
+				if !issues_model.IsErrIssueNotExist(err) {
+					ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+					return
+				}
+				results = append(results, &api.BulkIssueResult{Index: index, Status: "failed", Error: "issue does not exist"})
+				continue
+			}
+			issues = append(issues, issue)
+			results = append(results, &api.BulkIssueResult{Index: index})
+			resultByIssueID[issue.ID] = results[len(results)-1]
+		}
+	case form.Query != nil:
+		issues = searchBulkIssues(ctx, form.Query)
+		if ctx.Written() {
+			return
+		}
+		for _, issue := range issues {
+			results = append(results, &api.BulkIssueResult{Index: issue.Index})
+			resultByIssueID[issue.ID] = results[len(results)-1]
+		}
+	default:
+		ctx.Error(http.StatusUnprocessableEntity, "", "either indexes or query is required")
+		return
+	}
+
+	// issues the doer can't edit are reported as failed without being changed
+	editable := make([]*issues_model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+			editable = append(editable, issue)
+			continue
+		}
+		resultByIssueID[issue.ID].Status = "failed"
+		resultByIssueID[issue.ID].Error = "permission denied"
+	}
+
+	var bulkResults []*issue_service.BulkResult
+	if form.Atomic && len(editable) < len(results) {
+		// some issues already failed, nothing can be changed
+		for _, issue := range editable {
+			bulkResults = append(bulkResults, &issue_service.BulkResult{Issue: issue, Err: issue_service.ErrBulkRolledBack})
+		}
+	} else {
+		bulkResults = issue_service.ApplyBulkOperations(ctx, ctx.Doer, editable, ops, form.Atomic)
+	}
+
+	for _, bulkResult := range bulkResults {
+		result := resultByIssueID[bulkResult.Issue.ID]
+		switch {
+		case bulkResult.Err != nil:
+			result.Status = "failed"
+			result.Error = bulkResult.Err.Error()
+		case bulkResult.Changed:
+			result.Status = "changed"
+			if bulkResult.Issue.RepoID != ctx.Repo.Repository.ID {
+				result.TransferredTo = fmt.Sprintf("%s#%d", bulkResult.Issue.Repo.FullName(), bulkResult.Issue.Index)
+			}
+		default:
+			result.Status = "unchanged"
+		}
+	}
+
+	response := &api.BulkEditIssuesResult{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case "changed":
+			response.Changed++
+		case "failed":
+			response.Failed++
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// resolveBulkOperation checks an operation of a bulk edit and resolves the labels, milestone,
+// assignees and project column it refers to in the repository
+func resolveBulkOperation(ctx *context.APIContext, formOp *api.BulkIssueOperation) *issue_service.BulkOperation {
+	op := &issue_service.BulkOperation{Type: issue_service.BulkOperationType(formOp.Type)}
+
+	switch op.Type {
+	case issue_service.BulkAddLabels, issue_service.BulkRemoveLabels:
+		labels, err := resolveBulkLabels(ctx, formOp.Labels)
+		if err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return nil
+		} else if ctx.Written() {
+			return nil
+		}
+		op.Labels = labels
+
+	case issue_service.BulkClose, issue_service.BulkReopen:
+
+	case issue_service.BulkSetMilestone:
+		if formOp.Milestone > 0 {
+			milestone, err := issues_model.GetMilestoneByRepoID(ctx, ctx.Repo.Repository.ID, formOp.Milestone)
+			if err != nil {
+				if issues_model.IsErrMilestoneNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "", err)
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetMilestoneByRepoID", err)
+				}
+				return nil
+			}
+			op.MilestoneID = milestone.ID
+		}
+
+	case issue_service.BulkAddAssignees, issue_service.BulkRemoveAssignees:
+		if len(formOp.Assignees) == 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "assignees are required")
+			return nil
+		}
+		for _, name := range formOp.Assignees {
+			assignee, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					ctx.Error(http.StatusUnprocessableEntity, "", err)
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+				}
+				return nil
+			}
+			op.Assignees = append(op.Assignees, assignee)
+		}
+
+	case issue_service.BulkMoveProjectColumn:
+		if !ctx.Repo.CanRead(unit.TypeProjects) {
+			ctx.Error(http.StatusForbidden, "", "projects can not be accessed")
+			return nil
+		}
+		column, err := project_model.GetColumn(ctx, formOp.ProjectColumn)
+		if err != nil {
+			if project_model.IsErrProjectColumnNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+			}
+			return nil
+		}
+		project, err := project_model.GetProjectByID(ctx, column.ProjectID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+			return nil
+		}
+		if !project.CanBeAccessedByOwnerRepo(ctx.Repo.Repository.OwnerID, ctx.Repo.Repository) {
+			ctx.Error(http.StatusUnprocessableEntity, "", project_model.ErrProjectColumnNotExist{ColumnID: column.ID})
+			return nil
+		}
+		op.Column = column
+
+	case issue_service.BulkTransfer:
+		ownerName, repoName, _ := strings.Cut(formOp.Repository, "/")
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return nil
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+			return nil
+		}
+		if !perm.CanRead(unit.TypeIssues) {
+			ctx.Error(http.StatusUnprocessableEntity, "", repo_model.ErrRepoNotExist{OwnerName: ownerName, Name: repoName})
+			return nil
+		}
+		op.Repo = repo
+
+	default:
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("unknown operation %q", formOp.Type))
+		return nil
+	}
+
+	return op
+}
+
+// resolveBulkLabels returns the labels of the repository or of its owner given by ID or by name,
+// all of them must exist
+func resolveBulkLabels(ctx *context.APIContext, rawLabels []any) ([]*issues_model.Label, error) {
+	if len(rawLabels) == 0 {
+		return nil, errors.New("labels are required")
+	}
+
+	var (
+		labelIDs   []int64
+		labelNames []string
+	)
+	for _, label := range rawLabels {
+		rv := reflect.ValueOf(label)
+		switch rv.Kind() {
+		case reflect.Float64:
+			labelIDs = append(labelIDs, int64(rv.Float()))
+		case reflect.String:
+			labelNames = append(labelNames, rv.String())
+		default:
+			return nil, errors.New("a label must be an integer or a string")
+		}
+	}
+	if len(labelIDs) > 0 && len(labelNames) > 0 {
+		return nil, errors.New("labels should be an array of strings or integers")
+	}
+
+	if len(labelNames) > 0 {
+		repoLabelIDs, err := issues_model.GetLabelIDsInRepoByNames(ctx, ctx.Repo.Repository.ID, labelNames)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetLabelIDsInRepoByNames", err)
+			return nil, nil
+		}
+		labelIDs = append(labelIDs, repoLabelIDs...)
+		if ctx.Repo.Owner.IsOrganization() {
+			orgLabelIDs, err := issues_model.GetLabelIDsInOrgByNames(ctx, ctx.Repo.Owner.ID, labelNames)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetLabelIDsInOrgByNames", err)
+				return nil, nil
+			}
+			labelIDs = append(labelIDs, orgLabelIDs...)
+		}
+	}
+
+	labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetLabelsByIDs", err)
+		return nil, nil
+	}
+
+	found := make(container.Set[string], len(labels))
+	valid := make([]*issues_model.Label, 0, len(labels))
+	for _, label := range labels {
+		if label.RepoID != ctx.Repo.Repository.ID && (label.OrgID == 0 || label.OrgID != ctx.Repo.Owner.ID) {
+			continue
+		}
+		valid = append(valid, label)
+		found.Add(label.Name)
+		found.Add(strconv.FormatInt(label.ID, 10))
+	}
+	for _, id := range labelIDs {
+		if !found.Contains(strconv.FormatInt(id, 10)) {
+			return nil, issues_model.ErrLabelNotExist{LabelID: id}
+		}
+	}
+	for _, name := range labelNames {
+		if !found.Contains(name) {
+			return nil, fmt.Errorf("label does not exist [name: %s]", name)
+		}
+	}
+	return valid, nil
+}
+
+// searchBulkIssues returns the issues and pull requests of the repository matching the query of a bulk edit,
+// the same way as the issue list of the repository
+func searchBulkIssues(ctx *context.APIContext, query *api.BulkIssueQuery) []*issues_model.Issue {
+	opts := &issue_indexer.SearchOptions{
+		Paginator: &db.ListOptions{Page: 1, PageSize: setting.API.MaxResponseItems},
+		Keyword:   strings.TrimSpace(query.Keyword),
+		RepoIDs:   []int64{ctx.Repo.Repository.ID},
+		SortBy:    issue_indexer.SortByCreatedAsc,
+	}
+	if strings.IndexByte(opts.Keyword, 0) >= 0 {
+		opts.Keyword = ""
+	}
+
+	switch query.State {
+	case "closed":
+		opts.IsClosed = optional.Some(true)
+	case "all":
+	default:
+		opts.IsClosed = optional.Some(false)
+	}
+
+	switch query.Type {
+	case "pulls":
+		opts.IsPull = optional.Some(true)
+	case "issues":
+		opts.IsPull = optional.Some(false)
+	default:
+		canReadIssues := ctx.Repo.CanRead(unit.TypeIssues)
+		canReadPulls := ctx.Repo.CanRead(unit.TypePullRequests)
+		if !canReadIssues && canReadPulls {
+			opts.IsPull = optional.Some(true)
+		} else if canReadIssues && !canReadPulls {
+			opts.IsPull = optional.Some(false)
+		}
+	}
+	if opts.IsPull.Has() && !ctx.Repo.CanReadIssuesOrPulls(opts.IsPull.Value()) {
+		ctx.NotFound()
+		return nil
+	}
+
+	if len(query.Labels) > 0 {
+		labelIDs, err := issues_model.GetLabelIDsInRepoByNames(ctx, ctx.Repo.Repository.ID, query.Labels)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetLabelIDsInRepoByNames", err)
+			return nil
+		}
+		if len(labelIDs) == 0 {
+			return nil
+		}
+		opts.IncludedLabelIDs = labelIDs
+	}
+
+	if len(query.Milestones) > 0 {
+		for _, part := range query.Milestones {
+			// uses names and fall back to ids
+			milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, ctx.Repo.Repository.ID, part)
+			if err == nil {
+				opts.MilestoneIDs = append(opts.MilestoneIDs, milestone.ID)
+				continue
+			}
+			if !issues_model.IsErrMilestoneNotExist(err) {
+				ctx.Error(http.StatusInternalServerError, "GetMilestoneByRepoIDANDName", err)
+				return nil
+			}
+			id, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				continue
+			}
+			milestone, err = issues_model.GetMilestoneByRepoID(ctx, ctx.Repo.Repository.ID, id)
+			if err == nil {
+				opts.MilestoneIDs = append(opts.MilestoneIDs, milestone.ID)
+				continue
+			}
+			if !issues_model.IsErrMilestoneNotExist(err) {
+				ctx.Error(http.StatusInternalServerError, "GetMilestoneByRepoID", err)
+				return nil
+			}
+		}
+		if len(opts.MilestoneIDs) == 0 {
+			return nil
+		}
+	}
+
+	ids, total, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
+		return nil
+	}
+	if total > int64(setting.API.MaxResponseItems) {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("the query matches %d issues, at most %d issues can be edited at once", total, setting.API.MaxResponseItems))
+		return nil
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssuesByIDs", err)
+		return nil
+	}
+	return issues
+}
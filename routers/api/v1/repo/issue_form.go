@@ -0,0 +1,111 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/gitrepo"
+	issue_template "forgejo.org/modules/issue/template"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// GetIssueFormValues returns the values submitted through the form template an issue was created from
+func GetIssueFormValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/form_values issue issueGetFormValues
+	// ---
+	// summary: Get the values submitted through the form template an issue or pull request was created from
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFormValues"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	formValues, err := issues_model.GetIssueFormValues(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFormValues", err)
+		return
+	}
+	if formValues == nil {
+		ctx.NotFound()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.IssueFormValues{
+		Template: formValues.Template,
+		Fields:   formValues.Fields,
+	})
+}
+
+// renderTemplateForm renders the content of an issue or pull request created through the API from a form template
+// of the default branch, and returns the values to store with it
+func renderTemplateForm(ctx *context.APIContext, filename string, fields map[string][]string) (string, *issues_model.IssueFormValues, bool) {
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RepositoryFromContextOrOpen", err)
+		return "", nil, false
+	}
+	defer closer.Close()
+
+	template, err := issue_template.UnmarshalFromRepo(gitRepo, ctx.Repo.Repository.DefaultBranch, filename)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "UnmarshalFromRepo", err)
+		return "", nil, false
+	}
+	if template.Type() != api.IssueTemplateTypeYaml {
+		ctx.Error(http.StatusUnprocessableEntity, "", "the template is not a form")
+		return "", nil, false
+	}
+
+	values, err := issue_template.ValuesFromMap(template, fields)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "ValuesFromMap", err)
+		return "", nil, false
+	}
+	content, formValues, err := issue_service.RenderTemplateForm(template, values)
+	if err != nil {
+		if issue_template.IsErrInvalidFieldValue(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "RenderTemplateForm", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RenderTemplateForm", err)
+		}
+		return "", nil, false
+	}
+	return content, formValues, true
+}
@@ -10,10 +10,12 @@ import (
 
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/shared"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
@@ -218,6 +220,10 @@ func AddTime(ctx *context.APIContext) {
 
 	trackedTime, err := issues_model.AddTime(ctx, user, issue, form.Time, created)
 	if err != nil {
+		if issues_model.IsErrTrackedTimeLocked(err) {
+			ctx.Error(http.StatusForbidden, "AddTime", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "AddTime", err)
 		return
 	}
@@ -287,6 +293,8 @@ func ResetIssueTime(ctx *context.APIContext) {
 	if err != nil {
 		if db.IsErrNotExist(err) {
 			ctx.Error(http.StatusNotFound, "DeleteIssueUserTimes", err)
+		} else if issues_model.IsErrTrackedTimeLocked(err) {
+			ctx.Error(http.StatusForbidden, "DeleteIssueUserTimes", err)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "DeleteIssueUserTimes", err)
 		}
@@ -378,6 +386,10 @@ func DeleteTime(ctx *context.APIContext) {
 
 	err = issues_model.DeleteTime(ctx, time)
 	if err != nil {
+		if issues_model.IsErrTrackedTimeLocked(err) {
+			ctx.Error(http.StatusForbidden, "DeleteTime", err)
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, "DeleteTime", err)
 		return
 	}
@@ -635,3 +647,70 @@ func ListMyTrackedTimes(ctx *context.APIContext) {
 	ctx.SetTotalCountHeader(count)
 	ctx.JSON(http.StatusOK, convert.ToTrackedTimeList(ctx, ctx.Doer, trackedTimes))
 }
+
+// GetTimeReport sums up the times tracked on the issues of a repository
+func GetTimeReport(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/time_report repository repoGetTimeReport
+	// ---
+	// summary: Sum up the times tracked on the issues of a repository
+	// description: Only the own times of the user are summed up if they can't write issues.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: sum up the times by repository, milestone, label or user
+	//   type: string
+	//   enum: [repo, milestone, label, user]
+	// - name: user
+	//   in: query
+	//   description: only sum up the times of this user
+	//   type: string
+	// - name: milestone
+	//   in: query
+	//   description: only sum up the times of the issues of this milestone
+	//   type: integer
+	//   format: int64
+	// - name: label
+	//   in: query
+	//   description: only sum up the times of the issues with this label
+	//   type: integer
+	//   format: int64
+	// - name: since
+	//   in: query
+	//   description: Only sum up times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only sum up times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TimeReport"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !ctx.Repo.Repository.IsTimetrackerEnabled(ctx) {
+		ctx.Error(http.StatusBadRequest, "", "time tracking disabled")
+		return
+	}
+	shared.GetTimeReport(ctx, []*repo_model.Repository{ctx.Repo.Repository})
+}
@@ -14,6 +14,38 @@ import (
 	"forgejo.org/services/convert"
 )
 
+// ListNotesRefs List the notes refs of a repository
+func ListNotesRefs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/notes repository repoListNotesRefs
+	// ---
+	// summary: List the notes refs of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/StringSlice"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	refs, err := git.GetNotesRefs(ctx.Repo.GitRepo)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetNotesRefs", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, refs)
+}
+
 // GetNote Get a note corresponding to a single commit from a repository
 func GetNote(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/git/notes/{sha} repository repoGetNote
@@ -45,6 +77,10 @@ func GetNote(ctx *context.APIContext) {
 	//   in: query
 	//   description: include a list of affected files for every commit (disable for speedup, default 'true')
 	//   type: boolean
+	// - name: ref
+	//   in: query
+	//   description: name of the notes ref, defaults to "commits"
+	//   type: string
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Note"
@@ -58,10 +94,24 @@ func GetNote(ctx *context.APIContext) {
 		ctx.Error(http.StatusUnprocessableEntity, "no valid ref or sha", fmt.Sprintf("no valid ref or sha: %s", sha))
 		return
 	}
-	getNote(ctx, sha)
+	notesRef, ok := notesRefFromQuery(ctx)
+	if !ok {
+		return
+	}
+	getNote(ctx, notesRef, sha)
+}
+
+// notesRefFromQuery resolves the notes ref given by the "ref" query parameter.
+func notesRefFromQuery(ctx *context.APIContext) (string, bool) {
+	notesRef, err := git.NotesRefName(ctx.FormString("ref"))
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "NotesRefName", err)
+		return "", false
+	}
+	return notesRef, true
 }
 
-func getNote(ctx *context.APIContext, identifier string) {
+func getNote(ctx *context.APIContext, notesRef, identifier string) {
 	if ctx.Repo.GitRepo == nil {
 		ctx.InternalServerError(fmt.Errorf("no open git repo"))
 		return
@@ -78,7 +128,7 @@ func getNote(ctx *context.APIContext, identifier string) {
 	}
 
 	var note git.Note
-	if err := git.GetNote(ctx, ctx.Repo.GitRepo, commitID.String(), &note); err != nil {
+	if err := git.GetNoteInRef(ctx, ctx.Repo.GitRepo, notesRef, commitID.String(), &note); err != nil {
 		if git.IsErrNotExist(err) {
 			ctx.NotFound(identifier)
 			return
@@ -127,6 +177,10 @@ func SetNote(ctx *context.APIContext) {
 	//   description: a git ref or commit sha
 	//   type: string
 	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: name of the notes ref, defaults to "commits"
+	//   type: string
 	// - name: body
 	//   in: body
 	//   schema:
@@ -143,10 +197,14 @@ func SetNote(ctx *context.APIContext) {
 		ctx.Error(http.StatusUnprocessableEntity, "no valid ref or sha", fmt.Sprintf("no valid ref or sha: %s", sha))
 		return
 	}
+	notesRef, ok := notesRefFromQuery(ctx)
+	if !ok {
+		return
+	}
 
 	form := web.GetForm(ctx).(*api.NoteOptions)
 
-	err := git.SetNote(ctx, ctx.Repo.GitRepo, sha, form.Message, ctx.Doer.Name, ctx.Doer.GetEmail())
+	err := git.SetNoteInRef(ctx, ctx.Repo.GitRepo, notesRef, sha, form.Message, ctx.Doer.Name, ctx.Doer.GetEmail())
 	if err != nil {
 		if git.IsErrNotExist(err) {
 			ctx.NotFound(sha)
@@ -156,7 +214,7 @@ func SetNote(ctx *context.APIContext) {
 		return
 	}
 
-	getNote(ctx, sha)
+	getNote(ctx, notesRef, sha)
 }
 
 // RemoveNote Removes a note corresponding to a single commit from a repository
@@ -182,6 +240,10 @@ func RemoveNote(ctx *context.APIContext) {
 	//   description: a git ref or commit sha
 	//   type: string
 	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: name of the notes ref, defaults to "commits"
+	//   type: string
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
@@ -194,8 +256,12 @@ func RemoveNote(ctx *context.APIContext) {
 		ctx.Error(http.StatusUnprocessableEntity, "no valid ref or sha", fmt.Sprintf("no valid ref or sha: %s", sha))
 		return
 	}
+	notesRef, ok := notesRefFromQuery(ctx)
+	if !ok {
+		return
+	}
 
-	err := git.RemoveNote(ctx, ctx.Repo.GitRepo, sha)
+	err := git.RemoveNoteFromRef(ctx, ctx.Repo.GitRepo, notesRef, sha)
 	if err != nil {
 		if git.IsErrNotExist(err) {
 			ctx.NotFound(sha)
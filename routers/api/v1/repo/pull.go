@@ -505,6 +505,15 @@ func CreatePullRequest(ctx *context.APIContext) {
 		deadlineUnix = timeutil.TimeStamp(form.Deadline.Unix())
 	}
 
+	content := form.Body
+	var formValues *issues_model.IssueFormValues
+	if form.Template != "" {
+		var ok bool
+		if content, formValues, ok = renderTemplateForm(ctx, form.Template, form.FormValues); !ok {
+			return
+		}
+	}
+
 	prIssue := &issues_model.Issue{
 		RepoID:       repo.ID,
 		Title:        form.Title,
@@ -512,7 +521,7 @@ func CreatePullRequest(ctx *context.APIContext) {
 		Poster:       ctx.Doer,
 		MilestoneID:  milestoneID,
 		IsPull:       true,
-		Content:      form.Body,
+		Content:      content,
 		DeadlineUnix: deadlineUnix,
 	}
 	pr := &issues_model.PullRequest{
@@ -555,7 +564,7 @@ func CreatePullRequest(ctx *context.APIContext) {
 		}
 	}
 
-	if err := pull_service.NewPullRequest(ctx, repo, prIssue, labelIDs, []string{}, pr, assigneeIDs); err != nil {
+	if err := pull_service.NewPullRequest(ctx, repo, prIssue, labelIDs, []string{}, pr, assigneeIDs, formValues); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
 			return
@@ -847,6 +856,94 @@ func IsPullRequestMerged(ctx *context.APIContext) {
 	ctx.NotFound()
 }
 
+// GetPullRequestMergeability checks if a pull request can be merged regarding the protection of its base branch
+func GetPullRequestMergeability(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/mergeability repository repoGetPullRequestMergeability
+	// ---
+	// summary: Check if a pull request can be merged regarding the protection of its base branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestMergeability"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadBaseRepo", err)
+		return
+	}
+
+	mergeability := &api.PullRequestMergeability{
+		Mergeable:   pr.Mergeable(ctx),
+		Allowed:     true,
+		ReviewRules: []*api.PullRequestReviewRuleStatus{},
+	}
+	if _, err := pull_service.CheckPullBranchProtections(ctx, pr, false); err != nil {
+		if !models.IsErrDisallowedToMerge(err) {
+			ctx.Error(http.StatusInternalServerError, "CheckPullBranchProtections", err)
+			return
+		}
+		mergeability.Allowed = false
+		mergeability.Reason = err.(models.ErrDisallowedToMerge).Reason
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFirstMatchProtectedBranchRule", err)
+		return
+	}
+	if pb != nil {
+		statuses, err := pull_service.GetReviewRuleStatuses(ctx, pb, pr)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetReviewRuleStatuses", err)
+			return
+		}
+		for _, status := range statuses {
+			teams := make([]string, 0, len(status.Teams))
+			for _, team := range status.Teams {
+				teams = append(teams, team.Name)
+			}
+			mergeability.ReviewRules = append(mergeability.ReviewRules, &api.PullRequestReviewRuleStatus{
+				FilePatterns:      status.Rule.FilePatterns,
+				RequiredApprovals: status.Rule.RequiredApprovals,
+				GrantedApprovals:  status.GrantedApprovals,
+				Teams:             teams,
+				MatchedFiles:      status.MatchedFiles,
+				Satisfied:         status.IsSatisfied(),
+			})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, mergeability)
+}
+
 // MergePullRequest merges a PR given an index
 func MergePullRequest(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/merge repository repoMergePullRequest
@@ -946,6 +1043,8 @@ func MergePullRequest(ctx *context.APIContext) {
 			ctx.Error(http.StatusMethodNotAllowed, "PR is not ready to be merged", err)
 		} else if asymkey_service.IsErrWontSign(err) {
 			ctx.Error(http.StatusMethodNotAllowed, fmt.Sprintf("Protected branch %s requires signed commits but this merge would not be signed", pr.BaseBranch), err)
+		} else if errors.Is(err, pull_service.ErrMergeOutOfStackOrder) {
+			ctx.Error(http.StatusMethodNotAllowed, "PR is stacked on top of an open PR", "The pull requests of a stack must be merged in order")
 		} else {
 			ctx.InternalServerError(err)
 		}
@@ -4,22 +4,27 @@
 package repo
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"forgejo.org/models"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
 	access_model "forgejo.org/models/perm/access"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	issue_service "forgejo.org/services/issue"
 	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
 )
 
 // ListPullReviews lists all reviews of a pull request
@@ -1105,3 +1110,223 @@ func dismissReview(ctx *context.APIContext, msg string, isDismiss, dismissPriors
 	}
 	ctx.JSON(http.StatusOK, apiReview)
 }
+
+// ApplyPullSuggestions applies the suggestions of code comments in a single commit on the head branch
+func ApplyPullSuggestions(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/suggestions repository repoApplyPullSuggestions
+	// ---
+	// summary: Apply the suggestions of code comments of a pull request in a single commit on its head branch
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ApplySuggestionsOptions"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/FilesResponse"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/quotaExceeded"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	opts := web.GetForm(ctx).(*api.ApplySuggestionsOptions)
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	filesResponse, err := files_service.ApplySuggestions(ctx, ctx.Doer, pr, opts.CommentIDs, opts.Message)
+	if err != nil {
+		switch {
+		case issues_model.IsErrCommentNotExist(err):
+			ctx.NotFound(err)
+		case errors.Is(err, util.ErrPermissionDenied), models.IsErrUserCannotCommit(err), models.IsErrFilePathProtected(err):
+			ctx.Error(http.StatusForbidden, "ApplySuggestions", err)
+		case models.IsErrSHADoesNotMatch(err), models.IsErrCommitIDDoesNotMatch(err), git.IsErrPushOutOfDate(err):
+			ctx.Error(http.StatusConflict, "ApplySuggestions", err)
+		case git.IsErrPushRejected(err):
+			errPushRej := err.(*git.ErrPushRejected)
+			if len(errPushRej.Message) == 0 {
+				ctx.Error(http.StatusConflict, "ApplySuggestions", "PushRejected without remote error message")
+			} else {
+				ctx.Error(http.StatusConflict, "ApplySuggestions", "PushRejected with remote message: "+errPushRej.Message)
+			}
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "ApplySuggestions", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "ApplySuggestions", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, filesResponse)
+}
+
+// GetPullViewedFiles returns which changed files of a pull request the authenticated user has viewed
+func GetPullViewedFiles(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/viewed_files repository repoGetPullViewedFiles
+	// ---
+	// summary: Get which changed files of a pull request the authenticated user has viewed
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullReviewViewedFiles"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	viewedFiles, err := pull_service.GetViewedFiles(ctx, ctx.Repo.GitRepo, pr, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetViewedFiles", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toPullReviewViewedFiles(viewedFiles))
+}
+
+// UpdatePullViewedFiles marks changed files of a pull request as viewed or not viewed by the authenticated user
+func UpdatePullViewedFiles(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/pulls/{index}/viewed_files repository repoUpdatePullViewedFiles
+	// ---
+	// summary: Mark changed files of a pull request as viewed or not viewed by the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdatePullReviewViewedFilesOptions"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullReviewViewedFiles"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	opts := web.GetForm(ctx).(*api.UpdatePullReviewViewedFilesOptions)
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	headCommitID := opts.HeadCommitSHA
+	if headCommitID == "" {
+		if headCommitID, err = ctx.Repo.GitRepo.GetRefCommitID(pr.GetGitRefName()); err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetRefCommitID", err)
+			return
+		}
+	}
+
+	if err := pull_service.UpdateViewedFiles(ctx, ctx.Repo.GitRepo, pr, ctx.Doer, headCommitID, opts.Files); err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateViewedFiles", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateViewedFiles", err)
+		}
+		return
+	}
+
+	viewedFiles, err := pull_service.GetViewedFiles(ctx, ctx.Repo.GitRepo, pr, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetViewedFiles", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toPullReviewViewedFiles(viewedFiles))
+}
+
+func toPullReviewViewedFiles(viewedFiles *pull_service.ViewedFiles) *api.PullReviewViewedFiles {
+	apiViewedFiles := &api.PullReviewViewedFiles{
+		HeadCommitSHA: viewedFiles.HeadCommitID,
+		ViewedFiles:   viewedFiles.NumViewedFiles,
+		TotalFiles:    len(viewedFiles.Files),
+		Files:         make([]*api.PullReviewViewedFile, 0, len(viewedFiles.Files)),
+	}
+	for _, file := range viewedFiles.Files {
+		apiViewedFiles.Files = append(apiViewedFiles.Files, &api.PullReviewViewedFile{
+			Filename: file.Path,
+			State:    file.State.String(),
+		})
+	}
+	return apiViewedFiles
+}
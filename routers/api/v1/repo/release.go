@@ -69,6 +69,62 @@ func GetRelease(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToAPIRelease(ctx, ctx.Repo.Repository, release))
 }
 
+// GetReleaseChecksums get the checksums of the assets of a release
+func GetReleaseChecksums(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/{id}/checksums repository repoGetReleaseChecksums
+	// ---
+	// summary: Get the SHA-256 checksums of the assets of a release and their signature
+	// description: The checksums are signed with the signing key of the instance or of the repository,
+	//   see /repos/{owner}/{repo}/signing-key.gpg, if the signing rules of the instance allow it.
+	//   The signature is computed in the background when the assets change and is empty until then.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the release
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseChecksums"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	id := ctx.ParamsInt64(":id")
+	release, err := repo_model.GetReleaseForRepoByID(ctx, ctx.Repo.Repository.ID, id)
+	if err != nil && !repo_model.IsErrReleaseNotExist(err) {
+		ctx.Error(http.StatusInternalServerError, "GetReleaseForRepoByID", err)
+		return
+	}
+	if err != nil && repo_model.IsErrReleaseNotExist(err) || release.IsTag {
+		ctx.NotFound()
+		return
+	}
+
+	checksums, signature, err := release_service.GetReleaseChecksums(ctx, release)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReleaseChecksums", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ReleaseChecksums{
+		Checksums:       checksums,
+		Signature:       signature,
+		SignatureFormat: release_service.ChecksumsSignatureFormat(signature),
+	})
+}
+
 // GetLatestRelease gets the most recent non-prerelease, non-draft release of a repository, sorted by created_at
 func GetLatestRelease(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/releases/latest repository repoGetLatestRelease
@@ -20,6 +20,7 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/convert"
+	release_service "forgejo.org/services/release"
 )
 
 func checkReleaseMatchRepo(ctx *context.APIContext, releaseID int64) bool {
@@ -269,6 +270,7 @@ func CreateReleaseAttachment(ctx *context.APIContext) {
 			ctx.Error(http.StatusInternalServerError, "NewAttachment", err)
 			return
 		}
+		release_service.UpdateReleaseChecksums(releaseID)
 
 		ctx.JSON(http.StatusCreated, convert.ToAPIAttachment(ctx.Repo.Repository, attach))
 	} else if hasExternalURL {
@@ -397,6 +399,7 @@ func EditReleaseAttachment(ctx *context.APIContext) {
 		}
 		return
 	}
+	release_service.UpdateReleaseChecksums(releaseID)
 	ctx.JSON(http.StatusCreated, convert.ToAPIAttachment(ctx.Repo.Repository, attach))
 }
 
@@ -463,5 +466,6 @@ func DeleteReleaseAttachment(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteAttachment", err)
 		return
 	}
+	release_service.UpdateReleaseChecksums(releaseID)
 	ctx.Status(http.StatusNoContent)
 }
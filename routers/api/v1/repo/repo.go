@@ -6,6 +6,7 @@ package repo
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/issue"
+	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
 	wiki_service "forgejo.org/services/wiki"
 )
@@ -938,6 +940,7 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 					DefaultMergeStyle:             repo_model.MergeStyleMerge,
 					DefaultUpdateStyle:            repo_model.UpdateStyleMerge,
 					DefaultAllowMaintainerEdit:    false,
+					BlockMergeOutOfStackOrder:     false,
 				}
 			} else {
 				config = unit.PullRequestsConfig()
@@ -982,6 +985,27 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 			if opts.DefaultAllowMaintainerEdit != nil {
 				config.DefaultAllowMaintainerEdit = *opts.DefaultAllowMaintainerEdit
 			}
+			if opts.BlockMergeOutOfStackOrder != nil {
+				config.BlockMergeOutOfStackOrder = *opts.BlockMergeOutOfStackOrder
+			}
+			if opts.MergeMessageTemplates != nil {
+				mergeMessageTemplates := maps.Clone(config.MergeMessageTemplates)
+				if mergeMessageTemplates == nil {
+					mergeMessageTemplates = make(map[repo_model.MergeStyle]string, len(opts.MergeMessageTemplates))
+				}
+				for mergeStyle, template := range opts.MergeMessageTemplates {
+					if template = strings.TrimSpace(template); template != "" {
+						mergeMessageTemplates[repo_model.MergeStyle(mergeStyle)] = template
+					} else {
+						delete(mergeMessageTemplates, repo_model.MergeStyle(mergeStyle))
+					}
+				}
+				if err := pull_service.ValidateMergeMessageTemplates(mergeMessageTemplates); err != nil {
+					ctx.Error(http.StatusUnprocessableEntity, "ValidateMergeMessageTemplates", err)
+					return err
+				}
+				config.MergeMessageTemplates = mergeMessageTemplates
+			}
 
 			units = append(units, repo_model.RepoUnit{
 				RepoID: repo.ID,
@@ -1220,6 +1244,33 @@ func GetIssueTemplates(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, ret)
 }
 
+// GetPullRequestTemplates returns the pull request templates of a repository
+func GetPullRequestTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pull_request_templates repository repoGetPullRequestTemplates
+	// ---
+	// summary: Get available pull request templates for a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueTemplates"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	ret, _ := issue.GetPullRequestTemplatesFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
+	ctx.JSON(http.StatusOK, ret)
+}
+
 // GetIssueConfig returns the issue config for a repo
 func GetIssueConfig(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/issue_config repository repoGetIssueConfig
@@ -0,0 +1,99 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	project_service "forgejo.org/services/project"
+)
+
+// getProject returns the project of the url, it must belong to the repository or, if repoID is 0, to the owner
+func getProject(ctx *context.APIContext, ownerID, repoID int64) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetProjectByID", err)
+		}
+		return nil
+	}
+	if (repoID > 0 && project.RepoID != repoID) || (repoID == 0 && (project.OwnerID != ownerID || project.RepoID != 0)) {
+		ctx.NotFound()
+		return nil
+	}
+	return project
+}
+
+// ListProjectAutomations lists the automation rules of a project
+func ListProjectAutomations(ctx *context.APIContext, ownerID, repoID int64) {
+	project := getProject(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	automations, err := project_service.GetAutomations(ctx, project)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetAutomations", err)
+		return
+	}
+
+	result := make([]*api.ProjectAutomation, 0, len(automations))
+	for _, automation := range automations {
+		result = append(result, convert.ToProjectAutomation(automation.Automation, automation.Column))
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CreateProjectAutomation adds an automation rule to a project
+func CreateProjectAutomation(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm(ctx).(*api.CreateProjectAutomationOption)
+	project := getProject(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	automation, err := project_service.CreateAutomation(ctx, ctx.Doer, project, project_model.AutomationEvent(form.Event), form.LabelID, form.ColumnID)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateAutomation", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateAutomation", err)
+		}
+		return
+	}
+
+	column, err := project_model.GetColumn(ctx, automation.ColumnID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetColumn", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectAutomation(automation, column))
+}
+
+// DeleteProjectAutomation deletes an automation rule of a project
+func DeleteProjectAutomation(ctx *context.APIContext, ownerID, repoID int64) {
+	project := getProject(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteAutomationByID(ctx, project.ID, ctx.ParamsInt64(":automation_id")); err != nil {
+		if project_model.IsErrAutomationNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteAutomationByID", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
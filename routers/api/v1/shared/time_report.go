@@ -0,0 +1,71 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// GetTimeReport sums up the times tracked on the issues of the repositories, filtered and grouped by the query
+func GetTimeReport(ctx *context.APIContext, repos []*repo_model.Repository) {
+	opts := &issues_model.TimeReportOptions{
+		GroupBy:     issues_model.TimeReportGroupBy(ctx.FormString("group_by")),
+		MilestoneID: ctx.FormInt64("milestone"),
+		LabelID:     ctx.FormInt64("label"),
+	}
+	if opts.GroupBy == "" {
+		opts.GroupBy = issues_model.TimeReportGroupByRepo
+	} else if !opts.GroupBy.IsValid() {
+		ctx.Error(http.StatusUnprocessableEntity, "", util.NewInvalidArgumentErrorf("unknown group_by %q", opts.GroupBy))
+		return
+	}
+
+	var err error
+	if opts.Before, opts.Since, err = context.GetQueryBeforeSince(ctx.Base); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return
+	}
+
+	if username := ctx.FormTrim("user"); username != "" {
+		user, err := user_model.GetUserByName(ctx, username)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusNotFound, "GetUserByName", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+		opts.UserID = user.ID
+	}
+
+	if ctx.PublicOnly {
+		publicRepos := make([]*repo_model.Repository, 0, len(repos))
+		for _, repo := range repos {
+			if !repo.IsPrivate {
+				publicRepos = append(publicRepos, repo)
+			}
+		}
+		repos = publicRepos
+	}
+	if err := issue_service.SetTimeReportRepos(ctx, ctx.Doer, repos, opts); err != nil {
+		ctx.Error(http.StatusInternalServerError, "SetTimeReportRepos", err)
+		return
+	}
+
+	report, err := issues_model.GetTimeReport(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetTimeReport", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToTimeReport(report))
+}
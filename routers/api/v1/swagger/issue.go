@@ -56,6 +56,20 @@ type swaggerResponseLabelList struct {
 	Body []api.Label `json:"body"`
 }
 
+// BulkEditIssuesResult
+// swagger:response BulkEditIssuesResult
+type swaggerResponseBulkEditIssuesResult struct {
+	// in:body
+	Body api.BulkEditIssuesResult `json:"body"`
+}
+
+// IssueCustomFieldList
+// swagger:response IssueCustomFieldList
+type swaggerResponseIssueCustomFieldList struct {
+	// in:body
+	Body []api.IssueCustomField `json:"body"`
+}
+
 // Milestone
 // swagger:response Milestone
 type swaggerResponseMilestone struct {
@@ -84,6 +98,13 @@ type swaggerResponseTrackedTimeList struct {
 	Body []api.TrackedTime `json:"body"`
 }
 
+// TimeReport
+// swagger:response TimeReport
+type swaggerResponseTimeReport struct {
+	// in:body
+	Body api.TimeReport `json:"body"`
+}
+
 // IssueDeadline
 // swagger:response IssueDeadline
 type swaggerIssueDeadline struct {
@@ -98,6 +119,13 @@ type swaggerIssueTemplates struct {
 	Body []api.IssueTemplate `json:"body"`
 }
 
+// IssueFormValues
+// swagger:response IssueFormValues
+type swaggerIssueFormValues struct {
+	// in:body
+	Body api.IssueFormValues `json:"body"`
+}
+
 // StopWatch
 // swagger:response StopWatch
 type swaggerResponseStopWatch struct {
@@ -125,3 +153,17 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// ProjectAutomation
+// swagger:response ProjectAutomation
+type swaggerResponseProjectAutomation struct {
+	// in:body
+	Body api.ProjectAutomation `json:"body"`
+}
+
+// ProjectAutomationList
+// swagger:response ProjectAutomationList
+type swaggerResponseProjectAutomationList struct {
+	// in:body
+	Body []api.ProjectAutomation `json:"body"`
+}
@@ -55,6 +55,18 @@ type swaggerParameterBodies struct {
 	// in:body
 	IssueLabelsOption api.IssueLabelsOption
 
+	// in:body
+	SetIssueCustomFieldOption api.SetIssueCustomFieldOption
+
+	// in:body
+	CreateProjectAutomationOption api.CreateProjectAutomationOption
+
+	// in:body
+	BulkEditIssuesOption api.BulkEditIssuesOption
+
+	// in:body
+	TransferIssueOption api.TransferIssueOption
+
 	// in:body
 	DeleteLabelsOption api.DeleteLabelsOption
 
@@ -177,6 +189,12 @@ type swaggerParameterBodies struct {
 	// in:body
 	DismissPullReviewOptions api.DismissPullReviewOptions
 
+	// in:body
+	ApplySuggestionsOptions api.ApplySuggestionsOptions
+
+	// in:body
+	UpdatePullReviewViewedFilesOptions api.UpdatePullReviewViewedFilesOptions
+
 	// in:body
 	MigrateRepoOptions api.MigrateRepoOptions
 
@@ -133,6 +133,13 @@ type swaggerResponseRelease struct {
 	Body api.Release `json:"body"`
 }
 
+// ReleaseChecksums
+// swagger:response ReleaseChecksums
+type swaggerResponseReleaseChecksums struct {
+	// in:body
+	Body api.ReleaseChecksums `json:"body"`
+}
+
 // ReleaseList
 // swagger:response ReleaseList
 type swaggerResponseReleaseList struct {
@@ -168,6 +175,20 @@ type swaggerResponsePullReviewList struct {
 	Body []api.PullReview `json:"body"`
 }
 
+// PullRequestMergeability
+// swagger:response PullRequestMergeability
+type swaggerResponsePullRequestMergeability struct {
+	// in:body
+	Body api.PullRequestMergeability `json:"body"`
+}
+
+// PullReviewViewedFiles
+// swagger:response PullReviewViewedFiles
+type swaggerResponsePullReviewViewedFiles struct {
+	// in:body
+	Body api.PullReviewViewedFiles `json:"body"`
+}
+
 // PullComment
 // swagger:response PullReviewComment
 type swaggerPullReviewComment struct {
@@ -359,6 +380,20 @@ type swaggerLanguageStatistics struct {
 	Body map[string]int64 `json:"body"`
 }
 
+// RepoDependencyList
+// swagger:response RepoDependencyList
+type swaggerRepoDependencyList struct {
+	// in: body
+	Body []api.RepoDependency `json:"body"`
+}
+
+// SBOM is a software bill of materials in the SPDX or CycloneDX JSON format
+// swagger:response SBOM
+type swaggerSBOM struct {
+	// in: body
+	Body map[string]any `json:"body"`
+}
+
 // CombinedStatus
 // swagger:response CombinedStatus
 type swaggerCombinedStatus struct {
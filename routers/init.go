@@ -38,6 +38,7 @@ import (
 	"forgejo.org/services/auth/source/oauth2"
 	"forgejo.org/services/automerge"
 	"forgejo.org/services/cron"
+	dependency_service "forgejo.org/services/dependency"
 	feed_service "forgejo.org/services/feed"
 	indexer_service "forgejo.org/services/indexer"
 	"forgejo.org/services/mailer"
@@ -49,6 +50,7 @@ import (
 	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
 	"forgejo.org/services/repository/archiver"
+	secretscan_service "forgejo.org/services/secretscan"
 	"forgejo.org/services/task"
 	"forgejo.org/services/uinotification"
 	"forgejo.org/services/webhook"
@@ -152,6 +154,8 @@ func InitWebInstalled(ctx context.Context) {
 	mirror_service.InitSyncMirrors()
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
+	mustInitCtx(ctx, secretscan_service.Init)
+	mustInitCtx(ctx, dependency_service.Init)
 	mustInit(automerge.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
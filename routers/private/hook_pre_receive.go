@@ -52,6 +52,9 @@ type preReceiveContext struct {
 	isOverQuota bool
 
 	branchName string
+
+	pushPolicies  []*git_model.PushPolicy
+	gotPushPolicy bool
 }
 
 // CanWriteCode returns true if pusher can write code
@@ -174,6 +177,46 @@ func (ctx *preReceiveContext) quotaExceeded() {
 	})
 }
 
+// assertPushPolicy returns true if the pushed commits comply with the push policy of the repository
+func (ctx *preReceiveContext) assertPushPolicy(oldCommitID, newCommitID string, refFullName git.RefName) bool {
+	if ctx.opts.IsWiki || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	repo := ctx.Repo.Repository
+	if !ctx.gotPushPolicy {
+		var err error
+		ctx.pushPolicies, err = git_model.GetEffectivePushPolicies(ctx, repo.OwnerID, repo.ID)
+		if err != nil {
+			log.Error("Unable to get push policy for %-v Error: %v", repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		}
+		ctx.gotPushPolicy = true
+	}
+
+	for _, policy := range ctx.pushPolicies {
+		msg, err := checkPushPolicy(ctx, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, policy, oldCommitID, newCommitID, ctx.opts.PullRequestID != 0, ctx.env)
+		if err != nil {
+			log.Error("Unable to check push policy for commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check push policy for commits from %s to %s: %v", oldCommitID, newCommitID, err),
+			})
+			return false
+		}
+		if msg != "" {
+			log.Warn("Forbidden: Push to %s in %-v violates the push policy: %s", refFullName, repo, msg)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Push to %s rejected by the push policy of this repository: %s", refFullName.ShortName(), msg),
+			})
+			return false
+		}
+	}
+	return true
+}
+
 // HookPreReceive checks whether a individual commit is acceptable
 func HookPreReceive(ctx *gitea_context.PrivateContext) {
 	opts := web.GetForm(ctx).(*private.HookOptions)
@@ -242,6 +285,10 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	if !ctx.assertPushPolicy(oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
 	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branchName)
 	if err != nil {
 		log.Error("Unable to get protected branch: %s in %-v Error: %v", branchName, repo, err)
@@ -465,7 +512,7 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	}
 }
 
-func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) { //nolint:unparam
+func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	if !ctx.AssertCanWriteCode() {
 		return
 	}
@@ -500,6 +547,10 @@ func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 		return
 	}
 
+	if !ctx.assertPushPolicy(oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
 	// If the user is over quota, and the push isn't a tag deletion, deny it
 	if ctx.isOverQuota {
 		objectFormat := ctx.Repo.GetObjectFormat()
@@ -510,7 +561,7 @@ func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 	}
 }
 
-func preReceiveFor(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) { //nolint:unparam
+func preReceiveFor(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	if !ctx.AssertCreatePullRequest() {
 		return
 	}
@@ -548,6 +599,8 @@ func preReceiveFor(ctx *preReceiveContext, oldCommitID, newCommitID string, refF
 		})
 		return
 	}
+
+	ctx.assertPushPolicy(oldCommitID, newCommitID, refFullName)
 }
 
 func generateGitEnv(opts *private.HookOptions) (env []string) {
@@ -0,0 +1,289 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	git_model "forgejo.org/models/git"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/secretscan"
+	secretscan_service "forgejo.org/services/secretscan"
+)
+
+// This file contains the push policy checks for refs passed across in hooks
+
+// pushPolicySecretScanMaxSize is the largest file that is scanned for secrets
+const pushPolicySecretScanMaxSize = 1024 * 1024
+
+type pushPolicyChecker struct {
+	ctx    context.Context
+	repoID int64
+	repo   *git.Repository
+	policy *git_model.PushPolicy
+	env    []string
+	// skipMergeCommits disables the commit message, author and sign-off rules
+	// for merge commits, which are created by Forgejo when merging pull requests
+	skipMergeCommits bool
+}
+
+type pushPolicyFile struct {
+	path   string
+	blobID string
+}
+
+// checkPushPolicy checks the commits pushed between oldCommitID and newCommitID
+// against the policy. It returns a message for the pusher if the push violates the policy.
+func checkPushPolicy(ctx context.Context, repoID int64, repo *git.Repository, policy *git_model.PushPolicy, oldCommitID, newCommitID string, skipMergeCommits bool, env []string) (string, error) {
+	c := &pushPolicyChecker{
+		ctx:              ctx,
+		repoID:           repoID,
+		repo:             repo,
+		policy:           policy,
+		env:              env,
+		skipMergeCommits: skipMergeCommits,
+	}
+	return c.check(oldCommitID, newCommitID)
+}
+
+func (c *pushPolicyChecker) check(oldCommitID, newCommitID string) (string, error) {
+	commitIDs, err := c.newCommitIDs(oldCommitID, newCommitID)
+	if err != nil {
+		return "", err
+	}
+
+	var files []pushPolicyFile
+	seenBlobs := make(map[string]bool)
+	for _, commitID := range commitIDs {
+		msg, err := c.checkCommit(commitID)
+		if err != nil || msg != "" {
+			return msg, err
+		}
+
+		changed, err := c.changedFiles(commitID)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range changed {
+			if msg := c.checkPath(commitID, file.path); msg != "" {
+				return msg, nil
+			}
+			if !seenBlobs[file.blobID] {
+				seenBlobs[file.blobID] = true
+				files = append(files, file)
+			}
+		}
+	}
+
+	return c.checkContents(files)
+}
+
+// newCommitIDs lists the pushed commits, oldest first
+func (c *pushPolicyChecker) newCommitIDs(oldCommitID, newCommitID string) ([]string, error) {
+	cmd := git.NewCommand(c.ctx, "rev-list", "--reverse")
+	objectFormat, _ := c.repo.GetObjectFormat()
+	if oldCommitID == objectFormat.EmptyObjectID().String() {
+		// Only list the commits which are not reachable from any existing ref, see verifyCommits
+		cmd.AddDynamicArguments(newCommitID).AddArguments("--not", "--all")
+	} else {
+		cmd.AddDynamicArguments(oldCommitID + ".." + newCommitID)
+	}
+	stdout, _, err := cmd.RunStdString(&git.RunOpts{Dir: c.repo.Path, Env: c.env})
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(stdout), nil
+}
+
+func (c *pushPolicyChecker) checkCommit(commitID string) (string, error) {
+	policy := c.policy
+	if policy.CommitMessagePattern == "" && policy.AuthorEmailDomains == "" && !policy.RequireSignOff {
+		return "", nil
+	}
+
+	stdout, _, runErr := git.NewCommand(c.ctx, "cat-file", "commit").AddDynamicArguments(commitID).RunStdBytes(&git.RunOpts{Dir: c.repo.Path, Env: c.env})
+	if runErr != nil {
+		return "", runErr
+	}
+	commit, err := git.CommitFromReader(c.repo, git.MustIDFromString(commitID), bytes.NewReader(stdout))
+	if err != nil {
+		return "", err
+	}
+	if c.skipMergeCommits && commit.ParentCount() > 1 {
+		return "", nil
+	}
+
+	re, err := policy.GetCommitMessageRegexp()
+	if err != nil {
+		return "", err
+	}
+	if re != nil && !re.MatchString(commit.CommitMessage) {
+		return fmt.Sprintf("commit %s: message does not match the required pattern %s", commitID, policy.CommitMessagePattern), nil
+	}
+
+	email := strings.ToLower(commit.Author.Email)
+	if domains := policy.GetAuthorEmailDomains(); len(domains) > 0 {
+		_, domain, _ := strings.Cut(email, "@")
+		allowed := false
+		for _, d := range domains {
+			if domain == d {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("commit %s: author email %s is not in an allowed domain (%s)", commitID, commit.Author.Email, strings.Join(domains, ", ")), nil
+		}
+	}
+
+	if policy.RequireSignOff && !hasSignOff(commit.CommitMessage, email) {
+		return fmt.Sprintf("commit %s: missing \"Signed-off-by\" line for the author %s", commitID, commit.Author.Email), nil
+	}
+	return "", nil
+}
+
+// hasSignOff returns true if the message has a Signed-off-by trailer for the given email
+func hasSignOff(message, email string) bool {
+	for _, line := range strings.Split(message, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "Signed-off-by:")
+		if ok && strings.Contains(strings.ToLower(value), "<"+email+">") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFiles lists the files a commit adds or modifies, submodules are ignored
+func (c *pushPolicyChecker) changedFiles(commitID string) ([]pushPolicyFile, error) {
+	policy := c.policy
+	if policy.ForbiddenFilePatterns == "" && policy.ForbiddenExtensions == "" && policy.MaxFileSize <= 0 && !policy.DetectSecrets {
+		return nil, nil
+	}
+
+	// The output consists of ":<old mode> <new mode> <old blob> <new blob> <status>\0<path>\0" records
+	stdout, _, err := git.NewCommand(c.ctx, "diff-tree", "-r", "-z", "--root", "--no-commit-id", "--no-renames", "--diff-filter=AMT").
+		AddDynamicArguments(commitID).RunStdString(&git.RunOpts{Dir: c.repo.Path, Env: c.env})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []pushPolicyFile
+	fields := strings.Split(stdout, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		info := strings.Fields(fields[i])
+		if len(info) != 5 || info[1] == "160000" {
+			continue
+		}
+		files = append(files, pushPolicyFile{path: fields[i+1], blobID: info[3]})
+	}
+	return files, nil
+}
+
+func (c *pushPolicyChecker) checkPath(commitID, filePath string) string {
+	lpath := strings.ToLower(filePath)
+	for _, pattern := range c.policy.GetForbiddenFilePatterns() {
+		if pattern.Match(lpath) {
+			return fmt.Sprintf("commit %s: file %s matches a forbidden file pattern", commitID, filePath)
+		}
+	}
+	ext := path.Ext(lpath)
+	for _, forbidden := range c.policy.GetForbiddenExtensions() {
+		if ext == forbidden {
+			return fmt.Sprintf("commit %s: file %s has the forbidden extension %s", commitID, filePath, forbidden)
+		}
+	}
+	return ""
+}
+
+// checkContents checks the size and content of the pushed files
+func (c *pushPolicyChecker) checkContents(files []pushPolicyFile) (string, error) {
+	if len(files) == 0 || (c.policy.MaxFileSize <= 0 && !c.policy.DetectSecrets) {
+		return "", nil
+	}
+
+	var toScan []pushPolicyFile
+	err := c.catFileBatch(false, files, func(file pushPolicyFile, size int64, _ *bufio.Reader) error {
+		if c.policy.MaxFileSize > 0 && size > c.policy.MaxFileSize {
+			return pushPolicyViolation(fmt.Sprintf("file %s is %s, which exceeds the limit of %s", file.path, base.FileSize(size), base.FileSize(c.policy.MaxFileSize)))
+		}
+		if c.policy.DetectSecrets && size <= pushPolicySecretScanMaxSize {
+			toScan = append(toScan, file)
+		}
+		return nil
+	})
+	if err != nil || len(toScan) == 0 {
+		return violationMessage(err)
+	}
+
+	rules, err := secretscan_service.LoadRules(c.ctx, c.repoID)
+	if err != nil {
+		return "", err
+	}
+	err = c.catFileBatch(true, toScan, func(file pushPolicyFile, size int64, rd *bufio.Reader) error {
+		content := make([]byte, size+1) // the content is followed by a newline
+		if _, err := io.ReadFull(rd, content); err != nil {
+			return err
+		}
+		if findings := secretscan.Scan(rules, content[:size]); len(findings) > 0 {
+			f := findings[0]
+			return pushPolicyViolation(fmt.Sprintf("file %s contains a secret on line %d (%s: %s)", file.path, f.Line, f.Description, f.Redacted()))
+		}
+		return nil
+	})
+	return violationMessage(err)
+}
+
+// catFileBatch runs git cat-file in batch mode for the blobs of files and calls fn for each of them,
+// the content of the blob can be read from rd if withContent is set
+func (c *pushPolicyChecker) catFileBatch(withContent bool, files []pushPolicyFile, fn func(file pushPolicyFile, size int64, rd *bufio.Reader) error) error {
+	var stdin strings.Builder
+	for _, file := range files {
+		stdin.WriteString(file.blobID)
+		stdin.WriteByte('\n')
+	}
+
+	cmd := git.NewCommand(c.ctx, "cat-file", "--batch-check")
+	if withContent {
+		cmd = git.NewCommand(c.ctx, "cat-file", "--batch")
+	}
+	stdout, _, err := cmd.RunStdBytes(&git.RunOpts{Dir: c.repo.Path, Env: c.env, Stdin: strings.NewReader(stdin.String())})
+	if err != nil {
+		return err
+	}
+
+	rd := bufio.NewReader(bytes.NewReader(stdout))
+	for _, file := range files {
+		_, _, size, err := git.ReadBatchLine(rd)
+		if err != nil {
+			return err
+		}
+		if err := fn(file, size, rd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushPolicyViolation is returned by the batch callbacks to stop at the first violation
+type pushPolicyViolation string
+
+func (v pushPolicyViolation) Error() string {
+	return string(v)
+}
+
+func violationMessage(err error) (string, error) {
+	var v pushPolicyViolation
+	if errors.As(err, &v) {
+		return string(v), nil
+	}
+	return "", err
+}
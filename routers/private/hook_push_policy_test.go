@@ -0,0 +1,191 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/private"
+	gitea_context "forgejo.org/services/context"
+	"forgejo.org/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPushPolicy(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	gitRepo, err := git.OpenRepository(t.Context(), testReposDir+"repo1_hook_verification")
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	// The range adds b.txt, c.txt and d.txt in commits by abcde@gitea.com with the
+	// messages "main unVerify2", "verify" and "verify2"
+	const oldCommitID, newCommitID = "9779d17a04f1e2640583d35703c62460b2d86e0a", "d766f2917716d45be24bfa968b8409544941be32"
+
+	testCases := []struct {
+		name     string
+		policy   git_model.PushPolicy
+		violated string
+	}{
+		{"empty", git_model.PushPolicy{}, ""},
+		{"message", git_model.PushPolicy{CommitMessagePattern: `^verify`}, "commit 72920278f2f999e3005801e5d5b8ab8139d3641c: message does not match"},
+		{"message ok", git_model.PushPolicy{CommitMessagePattern: `(?i)verify`}, ""},
+		{"email", git_model.PushPolicy{AuthorEmailDomains: "forgejo.org; @codeberg.org"}, "author email abcde@gitea.com is not in an allowed domain (forgejo.org, codeberg.org)"},
+		{"email ok", git_model.PushPolicy{AuthorEmailDomains: "@Gitea.com"}, ""},
+		{"sign-off", git_model.PushPolicy{RequireSignOff: true}, "missing \"Signed-off-by\" line"},
+		{"pattern", git_model.PushPolicy{ForbiddenFilePatterns: "c.*"}, "file c.txt matches a forbidden file pattern"},
+		{"extension", git_model.PushPolicy{ForbiddenExtensions: ".exe;TXT"}, "file b.txt has the forbidden extension .txt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := checkPushPolicy(t.Context(), 1, gitRepo, &tc.policy, oldCommitID, newCommitID, false, nil)
+			require.NoError(t, err)
+			if tc.violated == "" {
+				assert.Empty(t, msg)
+			} else {
+				assert.Contains(t, msg, tc.violated)
+			}
+		})
+	}
+}
+
+func TestCheckPushPolicyCustomPatterns(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repoPath := t.TempDir()
+	require.NoError(t, git.InitRepository(t.Context(), repoPath, false, git.Sha1ObjectFormat.Name()))
+	commit := func(name, content string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o644))
+		require.NoError(t, git.AddChanges(repoPath, true))
+		require.NoError(t, git.CommitChanges(repoPath, git.CommitChangesOptions{
+			Committer: &git.Signature{Name: "User Two", Email: "user2@example.com", When: time.Now()},
+			Message:   "add " + name,
+		}))
+		commitID, _, err := git.NewCommand(t.Context(), "rev-parse", "HEAD").RunStdString(&git.RunOpts{Dir: repoPath})
+		require.NoError(t, err)
+		return strings.TrimSpace(commitID)
+	}
+	oldCommitID := commit("README.md", "# Test\n")
+	newCommitID := commit("deploy.conf", "host = example.com\nkey = forgejo-deploy-5f2b9c7e\n")
+
+	gitRepo, err := git.OpenRepository(t.Context(), repoPath)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	policy := &git_model.PushPolicy{MaxFileSize: 1024}
+	msg, err := checkPushPolicy(t.Context(), 1, gitRepo, policy, oldCommitID, newCommitID, false, nil)
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+
+	policy = &git_model.PushPolicy{DetectSecrets: true}
+	msg, err = checkPushPolicy(t.Context(), 1, gitRepo, policy, oldCommitID, newCommitID, false, nil)
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+
+	// a pattern of another repository does not apply
+	require.NoError(t, db.Insert(t.Context(), &secretscan_model.SecretScanPattern{RepoID: 2, Name: "Deploy key", Pattern: `forgejo-deploy-[0-9a-f]{8}`}))
+	msg, err = checkPushPolicy(t.Context(), 1, gitRepo, policy, oldCommitID, newCommitID, false, nil)
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+
+	require.NoError(t, db.Insert(t.Context(), &secretscan_model.SecretScanPattern{RepoID: 1, Name: "Deploy key", Pattern: `forgejo-deploy-[0-9a-f]{8}`}))
+	msg, err = checkPushPolicy(t.Context(), 1, gitRepo, policy, oldCommitID, newCommitID, false, nil)
+	require.NoError(t, err)
+	assert.Contains(t, msg, "file deploy.conf contains a secret on line 2 (Deploy key:")
+
+	t.Run("size", func(t *testing.T) {
+		largeCommitID := commit("large.bin", strings.Repeat("x", 2048))
+		policy := &git_model.PushPolicy{MaxFileSize: 1024, DetectSecrets: true}
+		msg, err := checkPushPolicy(t.Context(), 1, gitRepo, policy, newCommitID, largeCommitID, false, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "file large.bin is 2.0 KiB, which exceeds the limit of 1.0 KiB", msg)
+
+		policy.MaxFileSize = 4096
+		msg, err = checkPushPolicy(t.Context(), 1, gitRepo, policy, newCommitID, largeCommitID, false, nil)
+		require.NoError(t, err)
+		assert.Empty(t, msg)
+	})
+}
+
+func TestPreReceiveTagPushPolicy(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	gitRepo, err := git.OpenRepository(t.Context(), testReposDir+"repo1_hook_verification")
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	const oldCommitID, newCommitID = "9779d17a04f1e2640583d35703c62460b2d86e0a", "d766f2917716d45be24bfa968b8409544941be32"
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	preReceive := func(t *testing.T, policies ...*git_model.PushPolicy) *httptest.ResponseRecorder {
+		t.Helper()
+		privateCtx, resp := contexttest.MockPrivateContext(t, "/")
+		privateCtx.Repo = &gitea_context.Repository{Repository: repo, GitRepo: gitRepo}
+		ctx := &preReceiveContext{
+			PrivateContext:      privateCtx,
+			opts:                &private.HookOptions{UserID: repo.OwnerID},
+			canWriteCode:        true,
+			checkedCanWriteCode: true,
+			gotProtectedTags:    true,
+			pushPolicies:        policies,
+			gotPushPolicy:       policies != nil,
+		}
+		preReceiveTag(ctx, oldCommitID, newCommitID, git.RefNameFromTag("v1.0"))
+		return resp
+	}
+
+	resp := preReceive(t, &git_model.PushPolicy{CommitMessagePattern: `^verify`})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Contains(t, resp.Body.String(), "rejected by the push policy")
+
+	resp = preReceive(t, &git_model.PushPolicy{CommitMessagePattern: `(?i)verify`})
+	assert.Empty(t, resp.Body.String())
+
+	t.Run("Owner policy", func(t *testing.T) {
+		defer func() {
+			require.NoError(t, git_model.DeletePushPolicy(t.Context(), repo.OwnerID, 0))
+			require.NoError(t, git_model.DeletePushPolicy(t.Context(), 0, repo.ID))
+		}()
+
+		// the looser policy of the repository does not override the one of its owner
+		require.NoError(t, git_model.SetPushPolicy(t.Context(), &git_model.PushPolicy{OwnerID: repo.OwnerID, CommitMessagePattern: `^verify`}))
+		require.NoError(t, git_model.SetPushPolicy(t.Context(), &git_model.PushPolicy{RepoID: repo.ID, CommitMessagePattern: `(?i)verify`}))
+		resp := preReceive(t)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Contains(t, resp.Body.String(), "message does not match the required pattern ^verify")
+
+		// the rules of the repository apply as well
+		require.NoError(t, git_model.SetPushPolicy(t.Context(), &git_model.PushPolicy{OwnerID: repo.OwnerID, CommitMessagePattern: `(?i)verify`}))
+		require.NoError(t, git_model.SetPushPolicy(t.Context(), &git_model.PushPolicy{RepoID: repo.ID, ForbiddenExtensions: ".txt"}))
+		resp = preReceive(t)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Contains(t, resp.Body.String(), "has the forbidden extension .txt")
+
+		require.NoError(t, git_model.DeletePushPolicy(t.Context(), 0, repo.ID))
+		resp = preReceive(t)
+		assert.Empty(t, resp.Body.String())
+	})
+}
+
+func TestHasSignOff(t *testing.T) {
+	message := "Fix things\n\nSigned-off-by: Jane Doe <Jane@example.com>\n"
+	assert.True(t, hasSignOff(message, "jane@example.com"))
+	assert.False(t, hasSignOff(message, "john@example.com"))
+	assert.False(t, hasSignOff("Fix things", "jane@example.com"))
+}
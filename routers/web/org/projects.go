@@ -20,6 +20,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/templates"
 	"forgejo.org/modules/web"
+	shared_project "forgejo.org/routers/web/shared/project"
 	shared_user "forgejo.org/routers/web/shared/user"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -274,6 +275,11 @@ func RenderEditProject(ctx *context.Context) {
 	ctx.Data["card_type"] = p.CardType
 	ctx.Data["CancelLink"] = project_model.ProjectLinkForOrg(ctx.ContextUser, p.ID)
 
+	shared_project.PrepareAutomations(ctx, p)
+	if ctx.Written() {
+		return
+	}
+
 	ctx.HTML(http.StatusOK, tplProjectsNew)
 }
 
@@ -384,6 +390,26 @@ func ViewProject(ctx *context.Context) {
 		}
 	}
 
+	var allIssues issues_model.IssueList
+	for _, issuesList := range issuesMap {
+		allIssues = append(allIssues, issuesList...)
+	}
+	ctx.Data["IssueCustomFieldEntries"], err = issues_model.GetIssueCustomFieldEntriesByIssues(ctx, allIssues)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldEntriesByIssues", err)
+		return
+	}
+	ctx.Data["SubIssueProgressByIssue"], err = issues_model.GetSubIssueProgressByIssues(ctx, allIssues)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgressByIssues", err)
+		return
+	}
+
+	shared_project.PrepareView(ctx, project, columns, issuesMap, canWriteProjects(ctx))
+	if ctx.Written() {
+		return
+	}
+
 	project.RenderedContent = templates.RenderMarkdownToHtml(ctx, project.Description)
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 	ctx.Data["PageIsViewProjects"] = true
@@ -20,6 +20,7 @@ import (
 	unit_model "forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/validation"
@@ -165,6 +166,19 @@ func TeamsAction(ctx *context.Context) {
 			err = models.AddTeamMember(ctx, ctx.Org.Team, u.ID)
 		}
 
+		page = "team"
+	case "review_available", "review_unavailable":
+		uid := ctx.FormInt64("uid")
+		if uid != ctx.Doer.ID && !ctx.Org.IsOwner {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+		if !ctx.Org.Team.IsMember(ctx, uid) {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+
+		err = org_model.SetTeamMemberReviewAvailability(ctx, ctx.Org.Team.ID, uid, ctx.Params(":action") == "review_available")
 		page = "team"
 	case "remove_invite":
 		if !ctx.Org.IsOwner {
@@ -328,7 +342,10 @@ func NewTeamPost(ctx *context.Context) {
 		AccessMode:              p,
 		IncludesAllRepositories: includesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
+		ReviewAssignmentCount:   form.ReviewAssignmentCount,
 	}
+	// unknown modes are rejected by the binding of the form
+	t.ReviewAssignmentMode, _ = org_model.ParseReviewAssignmentMode(form.ReviewAssignmentMode)
 
 	units := make([]*org_model.TeamUnit, 0, len(unitPerms))
 	for tp, perm := range unitPerms {
@@ -395,6 +412,21 @@ func TeamMembers(ctx *context.Context) {
 	ctx.Data["Invites"] = invites
 	ctx.Data["IsEmailInviteEnabled"] = setting.MailService != nil
 
+	if ctx.Org.Team.HasReviewAssignment() {
+		teamUsers, err := org_model.GetTeamUsersByTeamID(ctx, ctx.Org.Team.ID)
+		if err != nil {
+			ctx.ServerError("GetTeamUsersByTeamID", err)
+			return
+		}
+		reviewUnavailableMembers := make(container.Set[int64])
+		for _, teamUser := range teamUsers {
+			if teamUser.IsReviewUnavailable {
+				reviewUnavailableMembers.Add(teamUser.UID)
+			}
+		}
+		ctx.Data["ReviewUnavailableMembers"] = reviewUnavailableMembers
+	}
+
 	ctx.HTML(http.StatusOK, tplTeamMembers)
 }
 
@@ -524,6 +556,9 @@ func EditTeamPost(ctx *context.Context) {
 	}
 
 	t.Description = form.Description
+	// unknown modes are rejected by the binding of the form
+	t.ReviewAssignmentMode, _ = org_model.ParseReviewAssignmentMode(form.ReviewAssignmentMode)
+	t.ReviewAssignmentCount = form.ReviewAssignmentCount
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplTeamNew)
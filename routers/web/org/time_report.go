@@ -0,0 +1,73 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/routers/web/shared/timetrack"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+const (
+	// tplTimeReport template for the time report of the repositories of an organization
+	tplTimeReport base.TplName = "org/time_report"
+)
+
+// TimeReport shows the sum of the times tracked on the issues of the repositories of an organization
+func TimeReport(ctx *context.Context) {
+	if !setting.Service.EnableTimetracking {
+		ctx.NotFound("TimeReport", nil)
+		return
+	}
+
+	org := ctx.Org.Organization
+	ctx.Data["Title"] = ctx.Tr("org.time_report")
+	ctx.Data["PageIsOrgTimeReport"] = true
+	ctx.Data["TimeReportLink"] = ctx.Org.OrgLink + "/times"
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	labels, err := issues_model.GetLabelsByOrgID(ctx, org.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByOrgID", err)
+		return
+	}
+	ctx.Data["TimeReportLabels"] = labels
+
+	repos, err := issue_service.GetOwnerTimeReportRepos(ctx, ctx.Doer, org.ID)
+	if err != nil {
+		ctx.ServerError("GetOwnerTimeReportRepos", err)
+		return
+	}
+	timetrack.Report(ctx, repos)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplTimeReport)
+}
+
+// TimeReportExport downloads the times tracked on the issues of the repositories of an organization
+func TimeReportExport(ctx *context.Context) {
+	if !setting.Service.EnableTimetracking {
+		ctx.NotFound("TimeReportExport", nil)
+		return
+	}
+
+	repos, err := issue_service.GetOwnerTimeReportRepos(ctx, ctx.Doer, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOwnerTimeReportRepos", err)
+		return
+	}
+	timetrack.Export(ctx, repos, ctx.Org.Organization.Name)
+}
@@ -18,6 +18,7 @@ import (
 	"forgejo.org/services/attachment"
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
+	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
 )
 
@@ -82,6 +83,9 @@ func DeleteAttachment(ctx *context.Context) {
 		ctx.Error(http.StatusInternalServerError, fmt.Sprintf("DeleteAttachment: %v", err))
 		return
 	}
+	if attach.ReleaseID != 0 {
+		release_service.UpdateReleaseChecksums(attach.ReleaseID)
+	}
 	ctx.JSON(http.StatusOK, map[string]string{
 		"uuid": attach.UUID,
 	})
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 
@@ -85,6 +86,7 @@ func Commits(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Commits"] = processGitCommits(ctx, commits)
+	loadCommitNotes(ctx, commits)
 
 	ctx.Data["Username"] = ctx.Repo.Owner.Name
 	ctx.Data["Reponame"] = ctx.Repo.Repository.Name
@@ -203,6 +205,7 @@ func SearchCommits(ctx *context.Context) {
 	}
 	ctx.Data["CommitCount"] = len(commits)
 	ctx.Data["Commits"] = processGitCommits(ctx, commits)
+	loadCommitNotes(ctx, commits)
 
 	ctx.Data["Keyword"] = query
 	if all {
@@ -268,6 +271,7 @@ func FileHistory(ctx *context.Context) {
 	}
 
 	ctx.Data["Commits"] = processGitCommits(ctx, commits)
+	loadCommitNotes(ctx, commits)
 
 	ctx.Data["Username"] = ctx.Repo.Owner.Name
 	ctx.Data["Reponame"] = ctx.Repo.Repository.Name
@@ -336,6 +340,8 @@ func Diff(ctx *context.Context) {
 		MaxLineCharacters:  setting.Git.MaxGitDiffLineCharacters,
 		MaxFiles:           maxFiles,
 		WhitespaceBehavior: gitdiff.GetWhitespaceFlag(ctx.Data["WhitespaceBehavior"].(string)),
+		IgnoreFormatting:   ctx.Data["WhitespaceBehavior"] == gitdiff.WhitespaceBehaviorIgnoreFormatting,
+		DetectMovedBlocks:  true,
 		FileOnly:           fileOnly,
 	}, files...)
 	if err != nil {
@@ -395,20 +401,29 @@ func Diff(ctx *context.Context) {
 		return
 	}
 
+	notesRef, err := git.NotesRefName(ctx.FormString("notes_ref"))
+	if err != nil {
+		ctx.NotFound("NotesRefName", err)
+		return
+	}
+	notesRefs, err := git.GetNotesRefs(ctx.Repo.GitRepo)
+	if err != nil {
+		ctx.ServerError("GetNotesRefs", err)
+		return
+	}
+	for i := range notesRefs {
+		notesRefs[i] = strings.TrimPrefix(notesRefs[i], git.NotesRefPrefix)
+	}
+	ctx.Data["NotesRef"] = strings.TrimPrefix(notesRef, git.NotesRefPrefix)
+	ctx.Data["NotesRefs"] = notesRefs
+
 	note := &git.Note{}
-	err = git.GetNote(ctx, ctx.Repo.GitRepo, commitID, note)
+	err = git.GetNoteInRef(ctx, ctx.Repo.GitRepo, notesRef, commitID, note)
 	if err == nil {
 		ctx.Data["NoteCommit"] = note.Commit
 		ctx.Data["NoteAuthor"] = user_model.ValidateCommitWithEmail(ctx, note.Commit)
-		ctx.Data["NoteRendered"], err = markup.RenderCommitMessage(&markup.RenderContext{
-			Links: markup.Links{
-				Base:       ctx.Repo.RepoLink,
-				BranchPath: path.Join("commit", util.PathEscapeSegments(commitID)),
-			},
-			Metas:   ctx.Repo.Repository.ComposeMetas(ctx),
-			GitRepo: ctx.Repo.GitRepo,
-			Ctx:     ctx,
-		}, template.HTMLEscapeString(string(charset.ToUTF8WithFallback(note.Message, charset.ConvertOpts{}))))
+		ctx.Data["NoteMessage"] = string(charset.ToUTF8WithFallback(note.Message, charset.ConvertOpts{}))
+		ctx.Data["NoteRendered"], err = renderCommitNote(ctx, commitID, note.Message)
 		if err != nil {
 			ctx.ServerError("RenderCommitMessage", err)
 			return
@@ -456,6 +471,44 @@ func RawDiff(ctx *context.Context) {
 	}
 }
 
+func renderCommitNote(ctx *context.Context, commitID string, message []byte) (string, error) {
+	return markup.RenderCommitMessage(&markup.RenderContext{
+		Links: markup.Links{
+			Base:       ctx.Repo.RepoLink,
+			BranchPath: path.Join("commit", util.PathEscapeSegments(commitID)),
+		},
+		Metas:   ctx.Repo.Repository.ComposeMetas(ctx),
+		GitRepo: ctx.Repo.GitRepo,
+		Ctx:     ctx,
+	}, template.HTMLEscapeString(string(charset.ToUTF8WithFallback(message, charset.ConvertOpts{}))))
+}
+
+// loadCommitNotes renders the git-notes of the default notes ref for the given
+// commits, so that they can be shown in the commits list.
+func loadCommitNotes(ctx *context.Context, commits []*git.Commit) {
+	commitIDs := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		commitIDs = append(commitIDs, commit.ID.String())
+	}
+
+	messages, err := git.GetNoteMessages(ctx, ctx.Repo.GitRepo, git.NotesRef, commitIDs)
+	if err != nil {
+		log.Error("GetNoteMessages: %v", err)
+		return
+	}
+
+	notes := make(map[string]string, len(messages))
+	for commitID, message := range messages {
+		rendered, err := renderCommitNote(ctx, commitID, message)
+		if err != nil {
+			log.Error("RenderCommitMessage: %v", err)
+			continue
+		}
+		notes[commitID] = rendered
+	}
+	ctx.Data["CommitNotes"] = notes
+}
+
 func processGitCommits(ctx *context.Context, gitCommits []*git.Commit) []*git_model.SignCommitWithStatuses {
 	commits := git_model.ConvertFromGitCommit(ctx, gitCommits, ctx.Repo.Repository)
 	if !ctx.Repo.CanRead(unit_model.TypeActions) {
@@ -470,28 +523,52 @@ func processGitCommits(ctx *context.Context, gitCommits []*git.Commit) []*git_mo
 	return commits
 }
 
+// commitNotesLink returns the link to the commit page showing the given notes ref.
+func commitNotesLink(ctx *context.Context, commitID, notesRef string) string {
+	link := fmt.Sprintf("%s/commit/%s", ctx.Repo.RepoLink, commitID)
+	if notesRef != git.NotesRef {
+		link += "?notes_ref=" + url.QueryEscape(strings.TrimPrefix(notesRef, git.NotesRefPrefix))
+	}
+	return link
+}
+
 func SetCommitNotes(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.CommitNotesForm)
 
 	commitID := ctx.Params(":sha")
 
-	err := git.SetNote(ctx, ctx.Repo.GitRepo, commitID, form.Notes, ctx.Doer.Name, ctx.Doer.GetEmail())
+	notesRef, err := git.NotesRefName(form.Ref)
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.diff.git-notes.invalid_ref", form.Ref))
+		ctx.Redirect(commitNotesLink(ctx, commitID, git.NotesRef))
+		return
+	}
+
+	err = git.SetNoteInRef(ctx, ctx.Repo.GitRepo, notesRef, commitID, form.Notes, ctx.Doer.Name, ctx.Doer.GetEmail())
 	if err != nil {
 		ctx.ServerError("SetNote", err)
 		return
 	}
 
-	ctx.Redirect(fmt.Sprintf("%s/commit/%s", ctx.Repo.Repository.HTMLURL(), commitID))
+	ctx.Redirect(commitNotesLink(ctx, commitID, notesRef))
 }
 
 func RemoveCommitNotes(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CommitNotesForm)
+
 	commitID := ctx.Params(":sha")
 
-	err := git.RemoveNote(ctx, ctx.Repo.GitRepo, commitID)
+	notesRef, err := git.NotesRefName(form.Ref)
+	if err != nil {
+		ctx.NotFound("NotesRefName", err)
+		return
+	}
+
+	err = git.RemoveNoteFromRef(ctx, ctx.Repo.GitRepo, notesRef, commitID)
 	if err != nil {
-		ctx.ServerError("RemoveNotes", err)
+		ctx.NotFoundOrServerError("RemoveNotes", git.IsErrNotExist, err)
 		return
 	}
 
-	ctx.Redirect(fmt.Sprintf("%s/commit/%s", ctx.Repo.Repository.HTMLURL(), commitID))
+	ctx.Redirect(commitNotesLink(ctx, commitID, notesRef))
 }
@@ -39,6 +39,7 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/gitdiff"
+	issue_service "forgejo.org/services/issue"
 )
 
 const (
@@ -630,6 +631,8 @@ func PrepareCompareDiff(
 			MaxLineCharacters:  setting.Git.MaxGitDiffLineCharacters,
 			MaxFiles:           maxFiles,
 			WhitespaceBehavior: whitespaceBehavior,
+			IgnoreFormatting:   ctx.Data["WhitespaceBehavior"] == gitdiff.WhitespaceBehaviorIgnoreFormatting,
+			DetectMovedBlocks:  true,
 			DirectComparison:   ci.DirectComparison,
 			FileOnly:           fileOnly,
 		}, ctx.FormStrings("files")...)
@@ -829,7 +832,18 @@ func CompareDiff(ctx *context.Context) {
 	ctx.Data["Title"] = "Comparing " + ctx.Data["Comparing"].(string)
 
 	ctx.Data["IsDiffCompare"] = true
-	_, templateErrs := setTemplateIfExists(ctx, pullRequestTemplateKey, pullRequestTemplateCandidates)
+	pullRequestTemplates, templateErrs := issue_service.GetPullRequestTemplatesFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
+	_, errs := setTemplateIfExists(ctx, pullRequestTemplateKey, pullRequestTemplateCandidates)
+	if len(errs) > 0 {
+		if templateErrs == nil {
+			templateErrs = make(map[string]error, len(errs))
+		}
+		for k, v := range errs {
+			templateErrs[k] = v
+		}
+	}
+	ctx.Data["PullRequestTemplates"] = pullRequestTemplates
+	ctx.Data["PullRequestTemplateLink"] = ctx.Link + "?expand=1&template="
 
 	if len(templateErrs) > 0 {
 		ctx.Flash.Warning(renderErrorOfTemplates(ctx, templateErrs), true)
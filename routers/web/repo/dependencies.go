@@ -0,0 +1,90 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/dependency"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+	dependency_service "forgejo.org/services/dependency"
+)
+
+const (
+	tplDependencies base.TplName = "repo/activity"
+)
+
+// Dependencies renders the dependencies declared on the default branch of the repository
+func Dependencies(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.activity.navbar.dependencies")
+	ctx.Data["PageIsActivity"] = true
+	ctx.Data["PageIsDependencies"] = true
+
+	ecosystem := ctx.FormTrim("ecosystem")
+	keyword := ctx.FormTrim("q")
+	page := max(ctx.FormInt("page"), 1)
+
+	deps, count, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.RepoSearchPagingNum},
+		RepoID:      ctx.Repo.Repository.ID,
+		Ecosystem:   ecosystem,
+		Keyword:     keyword,
+	})
+	if err != nil {
+		ctx.ServerError("FindRepoDependencies", err)
+		return
+	}
+
+	links, err := dependency_service.GetPackageLinks(ctx, ctx.Doer, deps)
+	if err != nil {
+		ctx.ServerError("GetPackageLinks", err)
+		return
+	}
+	for id, link := range links {
+		links[id] = setting.AppSubURL + "/" + link
+	}
+
+	ctx.Data["Dependencies"] = deps
+	ctx.Data["PackageLinks"] = links
+	ctx.Data["Ecosystem"] = ecosystem
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["Ecosystems"] = []dependency.Ecosystem{
+		dependency.EcosystemGo,
+		dependency.EcosystemNpm,
+		dependency.EcosystemCargo,
+		dependency.EcosystemPyPI,
+		dependency.EcosystemMaven,
+		dependency.EcosystemComposer,
+	}
+
+	pager := context.NewPagination(int(count), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamString("ecosystem", ecosystem)
+	pager.AddParamString("q", keyword)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDependencies)
+}
+
+// DependenciesSBOM downloads the dependencies of the repository as a software bill of materials
+func DependenciesSBOM(ctx *context.Context) {
+	format := dependency.SBOMFormat(ctx.Params(":format"))
+	if format != dependency.SBOMFormatSPDX && format != dependency.SBOMFormatCycloneDX {
+		ctx.NotFound("DependenciesSBOM", nil)
+		return
+	}
+
+	doc, err := dependency_service.GenerateSBOM(ctx, ctx.Repo.Repository, format)
+	if err != nil {
+		ctx.ServerError("GenerateSBOM", err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s.json"`, ctx.Repo.Repository.Name, format))
+	ctx.JSON(http.StatusOK, doc)
+}
@@ -56,6 +56,8 @@ import (
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 	issue_service "forgejo.org/services/issue"
+	incoming_service "forgejo.org/services/mailer/incoming"
+	"forgejo.org/services/mailer/token"
 	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
 
@@ -210,10 +212,23 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		mileIDs = []int64{milestoneID}
 	}
 
+	// custom_field=name:value, all of them must match
+	selectCustomFields := ctx.FormStrings("custom_field")
+	customFields, err := issue_service.ParseCustomFieldFilters(ctx, repo, selectCustomFields)
+	if err != nil {
+		if !issues_model.IsErrIssueCustomFieldNotExist(err) && !issues_model.IsErrInvalidIssueCustomFieldValue(err) {
+			ctx.ServerError("ParseCustomFieldFilters", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("invalid_data", strings.Join(selectCustomFields, ", ")), true)
+		selectCustomFields = nil
+	}
+
 	var issueStats *issues_model.IssueStats
 	statsOpts := &issues_model.IssuesOptions{
 		RepoIDs:           []int64{repo.ID},
 		LabelIDs:          labelIDs,
+		CustomFields:      customFields,
 		MilestoneIDs:      mileIDs,
 		ProjectID:         projectID,
 		AssigneeID:        assigneeID,
@@ -309,6 +324,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 			IsClosed:          isShowClosed,
 			IsPull:            isPullOption,
 			LabelIDs:          labelIDs,
+			CustomFields:      customFields,
 			SortType:          sortType,
 		})
 		if err != nil {
@@ -459,6 +475,9 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	ctx.Data["ClosedCount"] = issueStats.ClosedCount
 	ctx.Data["AllCount"] = issueStats.AllCount
 	linkStr := "?q=%s&type=%s&sort=%s&state=%s&labels=%s&milestone=%d&project=%d&assignee=%d&poster=%d&archived=%t"
+	for _, filter := range selectCustomFields {
+		linkStr += "&custom_field=" + strings.ReplaceAll(url.QueryEscape(filter), "%", "%%")
+	}
 	ctx.Data["AllStatesLink"] = fmt.Sprintf(linkStr,
 		url.QueryEscape(keyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "all", url.QueryEscape(selectLabels),
 		milestoneID, projectID, assigneeID, posterID, archived)
@@ -498,6 +517,9 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	pager.AddParam(ctx, "assignee", "AssigneeID")
 	pager.AddParam(ctx, "poster", "PosterID")
 	pager.AddParam(ctx, "archived", "ShowArchivedLabels")
+	for _, filter := range selectCustomFields {
+		pager.AddParamString("custom_field", filter)
+	}
 
 	ctx.Data["Page"] = pager
 }
@@ -780,6 +802,20 @@ func RetrieveRepoReviewers(ctx *context.Context, repo *repo_model.Repository, is
 			currentPullReviewers = append(currentPullReviewers, item)
 		}
 		ctx.Data["PullReviewers"] = currentPullReviewers
+
+		if !issue.IsClosed && issue.PullRequest != nil {
+			reviewerIDs := make([]int64, 0, len(currentPullReviewers))
+			for _, item := range currentPullReviewers {
+				if item.User != nil {
+					reviewerIDs = append(reviewerIDs, item.User.ID)
+				}
+			}
+			if progress, err := pull_service.GetReviewersProgress(ctx, ctx.Repo.GitRepo, issue.PullRequest, reviewerIDs); err != nil {
+				log.Error("GetReviewersProgress %-v: %v", issue.PullRequest, err)
+			} else {
+				ctx.Data["ReviewersProgress"] = progress
+			}
+		}
 	}
 
 	if canChooseReviewer && reviewersResult != nil {
@@ -953,6 +989,25 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 	return false, templateErrs
 }
 
+// setNewIssueEmailAddress sets the address the signed in user sends emails to in order to open issues in the
+// repository, if its incoming email is enabled
+func setNewIssueEmailAddress(ctx *context.Context) error {
+	incoming, err := repo_model.GetIncomingEmail(ctx, ctx.Repo.Repository.ID)
+	if err != nil || incoming == nil {
+		return err
+	}
+	payload, err := incoming_service.CreateNewIssuePayload(incoming)
+	if err != nil {
+		return err
+	}
+	newIssueToken, err := token.CreateToken(token.NewIssueHandlerType, ctx.Doer, payload)
+	if err != nil {
+		return err
+	}
+	ctx.Data["NewIssueEmailAddress"] = token.IncomingAddress(newIssueToken)
+	return nil
+}
+
 // NewIssue render creating issue page
 func NewIssue(ctx *context.Context) {
 	issueConfig, _ := issue_service.GetTemplateConfigFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
@@ -1009,6 +1064,13 @@ func NewIssue(ctx *context.Context) {
 	}
 	ctx.Data["Tags"] = tags
 
+	if setting.IncomingEmail.Enabled {
+		if err := setNewIssueEmailAddress(ctx); err != nil {
+			ctx.ServerError("setNewIssueEmailAddress", err)
+			return
+		}
+	}
+
 	_, templateErrs := issue_service.GetTemplatesFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
 	templateLoaded, errs := setTemplateIfExists(ctx, issueTemplateKey, IssueTemplateCandidates)
 	for k, v := range errs {
@@ -1057,6 +1119,37 @@ func renderErrorOfTemplates(ctx *context.Context, errs map[string]error) templat
 	return flashError
 }
 
+// templateFormErrorMessage returns the message shown for a value submitted through a form template which is not valid
+func templateFormErrorMessage(ctx *context.Context, err issue_template.ErrInvalidFieldValue) template.HTML {
+	label := err.Label
+	if label == "" {
+		label = err.ID
+	}
+	return ctx.Tr("repo.issues.form."+string(err.Reason), label)
+}
+
+// renderTemplateForm renders the values submitted through a form template to the content of an issue or
+// pull request, writing an error response if the template does not exist or a value is not valid
+func renderTemplateForm(ctx *context.Context, filename string) (*api.IssueTemplate, string, *issues_model.IssueFormValues) {
+	template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename)
+	if err != nil {
+		log.Debug("Unable to load the form template %q of %-v: %v", filename, ctx.Repo.Repository, err)
+		ctx.JSONError(ctx.Tr("repo.issues.form.template_not_found", filename))
+		return nil, "", nil
+	}
+	content, formValues, err := issue_service.RenderTemplateForm(template, ctx.Req.Form)
+	if err != nil {
+		var invalid issue_template.ErrInvalidFieldValue
+		if errors.As(err, &invalid) {
+			ctx.JSONError(templateFormErrorMessage(ctx, invalid))
+			return nil, "", nil
+		}
+		ctx.ServerError("RenderTemplateForm", err)
+		return nil, "", nil
+	}
+	return template, content, formValues
+}
+
 // NewIssueChooseTemplate render creating issue from template page
 func NewIssueChooseTemplate(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("repo.issues.new")
@@ -1243,9 +1336,24 @@ func NewIssuePost(ctx *context.Context) {
 	}
 
 	content := form.Content
+	var customFields []*issue_service.CustomFieldValues
+	var formValues *issues_model.IssueFormValues
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+		var template *api.IssueTemplate
+		template, content, formValues = renderTemplateForm(ctx, filename)
+		if ctx.Written() {
+			return
+		}
+		var err error
+		customFields, err = issue_service.GetTemplateCustomFieldValues(ctx, repo, template, ctx.Req.Form)
+		if err != nil {
+			var invalid issues_model.ErrInvalidIssueCustomFieldValue
+			if errors.As(err, &invalid) {
+				ctx.JSONError(ctx.Tr("repo.issues.custom_fields.invalid_value", invalid.Value, invalid.Field))
+				return
+			}
+			ctx.ServerError("GetTemplateCustomFieldValues", err)
+			return
 		}
 	}
 
@@ -1260,7 +1368,7 @@ func NewIssuePost(ctx *context.Context) {
 		Ref:         form.Ref,
 	}
 
-	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs); err != nil {
+	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs, customFields, formValues); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			if issue.IsPull {
 				ctx.JSONError(ctx.Tr("repo.pulls.blocked_by_user"))
@@ -1433,11 +1541,34 @@ func ViewIssue(ctx *context.Context) {
 
 	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
-		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound("GetIssueByIndex", err)
-		} else {
+		if !issues_model.IsErrIssueNotExist(err) {
 			ctx.ServerError("GetIssueByIndex", err)
+			return
+		}
+		// the issue may have been transferred to another repository
+		transferred, err := issues_model.GetTransferredIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				ctx.NotFound("GetTransferredIssueByIndex", err)
+			} else {
+				ctx.ServerError("GetTransferredIssueByIndex", err)
+			}
+			return
+		}
+		if err := transferred.LoadRepo(ctx); err != nil {
+			ctx.ServerError("LoadRepo", err)
+			return
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, transferred.Repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
 		}
+		if !perm.CanReadIssuesOrPulls(transferred.IsPull) {
+			ctx.NotFound("CanReadIssuesOrPulls", nil)
+			return
+		}
+		ctx.Redirect(transferred.Link(), http.StatusMovedPermanently)
 		return
 	}
 	if issue.Repo == nil {
@@ -1706,7 +1837,7 @@ func ViewIssue(ctx *context.Context) {
 			if comment.MilestoneID > 0 && comment.Milestone == nil {
 				comment.Milestone = ghostMilestone
 			}
-		} else if comment.Type == issues_model.CommentTypeProject {
+		} else if comment.Type == issues_model.CommentTypeProject || comment.Type == issues_model.CommentTypeProjectColumn {
 			if err = comment.LoadProject(ctx); err != nil {
 				ctx.ServerError("LoadProject", err)
 				return
@@ -1729,7 +1860,8 @@ func ViewIssue(ctx *context.Context) {
 				ctx.ServerError("LoadAssigneeUserAndTeam", err)
 				return
 			}
-		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency {
+		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency ||
+			comment.Type.IsSubIssue() {
 			if err = comment.LoadDepIssueDetails(ctx); err != nil {
 				if !issues_model.IsErrIssueNotExist(err) {
 					ctx.ServerError("LoadDepIssueDetails", err)
@@ -1938,7 +2070,7 @@ func ViewIssue(ctx *context.Context) {
 		}
 		ctx.Data["UpdateStyle"] = updateStyle
 
-		defaultMergeMessage, defaultMergeBody, err := pull_service.GetDefaultMergeMessage(ctx, ctx.Repo.GitRepo, pull, mergeStyle)
+		defaultMergeMessage, defaultMergeBody, err := pull_service.GetDefaultMergeMessage(ctx, ctx.Repo.GitRepo, pull, repo_model.MergeStyleMerge)
 		if err != nil {
 			ctx.ServerError("GetDefaultMergeMessage", err)
 			return
@@ -1946,6 +2078,14 @@ func ViewIssue(ctx *context.Context) {
 		ctx.Data["DefaultMergeMessage"] = defaultMergeMessage
 		ctx.Data["DefaultMergeBody"] = defaultMergeBody
 
+		defaultRebaseMergeMessage, defaultRebaseMergeBody, err := pull_service.GetDefaultMergeMessage(ctx, ctx.Repo.GitRepo, pull, repo_model.MergeStyleRebaseMerge)
+		if err != nil {
+			ctx.ServerError("GetDefaultRebaseMergeMessage", err)
+			return
+		}
+		ctx.Data["DefaultRebaseMergeMessage"] = defaultRebaseMergeMessage
+		ctx.Data["DefaultRebaseMergeBody"] = defaultRebaseMergeBody
+
 		defaultSquashMergeMessage, defaultSquashMergeBody, err := pull_service.GetDefaultMergeMessage(ctx, ctx.Repo.GitRepo, pull, repo_model.MergeStyleSquash)
 		if err != nil {
 			ctx.ServerError("GetDefaultSquashMergeMessage", err)
@@ -1972,6 +2112,13 @@ func ViewIssue(ctx *context.Context) {
 			ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
 			ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
 			ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
+			// the breakdown is informative only, merging is checked by CheckPullBranchProtections
+			if reviewRuleStatuses, err := pull_service.GetReviewRuleStatuses(ctx, pb, pull); err != nil {
+				log.Error("GetReviewRuleStatuses[%d]: %v", pull.ID, err)
+			} else {
+				ctx.Data["ReviewRuleStatuses"] = reviewRuleStatuses
+				ctx.Data["IsBlockedByReviewRules"] = pull_service.MergeBlockedByReviewRules(reviewRuleStatuses)
+			}
 			ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 			ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
 			ctx.Data["IsBlockedByChangedProtectedFiles"] = len(pull.ChangedProtectedFiles) != 0
@@ -2057,6 +2204,21 @@ func ViewIssue(ctx *context.Context) {
 		return
 	}
 
+	ctx.Data["IssueCustomFieldEntries"], err = issues_model.GetIssueCustomFieldEntries(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldEntries", err)
+		return
+	}
+
+	if !issue.IsPull {
+		prepareIssueViewSubIssues(ctx, issue)
+	} else {
+		prepareIssueViewPullStack(ctx, issue)
+	}
+	if ctx.Written() {
+		return
+	}
+
 	var pinAllowed bool
 	if !issue.IsPinned() {
 		pinAllowed, err = issues_model.IsNewPinAllowed(ctx, issue.RepoID, issue.IsPull)
@@ -3327,7 +3489,7 @@ func UpdateCommentContent(ctx *context.Context) {
 		Metas:   ctx.Repo.Repository.ComposeMetas(ctx),
 		GitRepo: ctx.Repo.GitRepo,
 		Ctx:     ctx,
-	}, comment.Content)
+	}, comment.RenderableContent())
 	if err != nil {
 		ctx.ServerError("RenderString", err)
 		return
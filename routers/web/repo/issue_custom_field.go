@@ -0,0 +1,56 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// UpdateIssueCustomField sets the values of a custom field on an issue
+func UpdateIssueCustomField(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	field, exist, err := db.GetByID[issues_model.IssueCustomField](ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldByID", err)
+		return
+	} else if !exist {
+		ctx.NotFound("GetIssueCustomFieldByID", nil)
+		return
+	}
+
+	if err := ctx.Req.ParseForm(); err != nil {
+		ctx.ServerError("ParseForm", err)
+		return
+	}
+
+	if err := issue_service.SetCustomFieldValues(ctx, issue, ctx.Doer, field, ctx.Req.PostForm["value"]); err != nil {
+		switch {
+		case issues_model.IsErrInvalidIssueCustomFieldValue(err):
+			invalid := err.(issues_model.ErrInvalidIssueCustomFieldValue)
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_fields.invalid_value", invalid.Value, invalid.Field))
+		case issues_model.IsErrIssueCustomFieldNotExist(err):
+			ctx.NotFound("SetCustomFieldValues", err)
+			return
+		default:
+			ctx.ServerError("SetCustomFieldValues", err)
+			return
+		}
+	}
+
+	ctx.Redirect(issue.Link())
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/references"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+// prepareIssueViewSubIssues sets the parent, the sub-issues and their progress for the sidebar of an issue
+func prepareIssueViewSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	parent, err := issue_service.GetParentIssue(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	subIssues, err := issue_service.GetSubIssues(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.ServerError("GetSubIssues", err)
+		return
+	}
+	progress, err := issues_model.GetSubIssueProgress(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgress", err)
+		return
+	}
+
+	ctx.Data["ParentIssue"] = parent
+	ctx.Data["SubIssues"] = subIssues
+	ctx.Data["SubIssueProgress"] = progress
+}
+
+// AddSubIssue makes the issue given by a reference a sub-issue of the current one
+func AddSubIssue(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SubIssueForm)
+	parent := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	refs := references.FindAllIssueReferences(strings.TrimSpace(form.SubIssue))
+	if len(refs) != 1 {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+		return
+	}
+	repo := ctx.Repo.Repository
+	if refs[0].Owner != "" && (refs[0].Owner != repo.OwnerName || refs[0].Name != repo.Name) {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, refs[0].Owner, refs[0].Name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+			} else {
+				ctx.ServerError("GetRepositoryByOwnerAndName", err)
+			}
+			return
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		}
+		if !perm.CanReadIssuesOrPulls(false) {
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+			return
+		}
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, refs[0].Index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+		} else {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return
+	}
+	subIssue.Repo = repo
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_circular"))
+		case issues_model.IsErrSubIssueOtherOwner(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_other_owner"))
+		case errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrPermissionDenied):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error", err.Error()))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(parent.Link())
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the current one
+func RemoveSubIssue(ctx *context.Context) {
+	parent := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("issue_id"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+		} else {
+			ctx.ServerError("GetIssueByID", err)
+		}
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueNotExist(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.issue_not_exist"))
+		case errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrPermissionDenied):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.remove_error", err.Error()))
+		default:
+			ctx.ServerError("RemoveSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(parent.Link())
+}
@@ -9,12 +9,17 @@ import (
 
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	"forgejo.org/routers/web/shared/timetrack"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
 
+const tplTimeReport base.TplName = "repo/activity"
+
 // AddTimeManually tracks time manually
 func AddTimeManually(c *context.Context) {
 	form := web.GetForm(c).(*forms.AddTimeManuallyForm)
@@ -78,6 +83,11 @@ func DeleteTime(c *context.Context) {
 	}
 
 	if err = issues_model.DeleteTime(c, t); err != nil {
+		if issues_model.IsErrTrackedTimeLocked(err) {
+			c.Flash.Error(c.Tr("repo.issues.time_locked"))
+			c.Redirect(issue.Link())
+			return
+		}
 		c.ServerError("DeleteTime", err)
 		return
 	}
@@ -85,3 +95,54 @@ func DeleteTime(c *context.Context) {
 	c.Flash.Success(c.Tr("repo.issues.del_time_history", util.SecToTime(t.Time)))
 	c.Redirect(issue.Link())
 }
+
+// TimeReport shows the sum of the times tracked on the issues of the repository
+func TimeReport(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.activity.navbar.time_report")
+	ctx.Data["PageIsActivity"] = true
+	ctx.Data["PageIsTimeReport"] = true
+	ctx.Data["TimeReportLink"] = ctx.Repo.RepoLink + "/activity/times"
+
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{
+		RepoID:   ctx.Repo.Repository.ID,
+		SortType: "furthestduedate",
+	})
+	if err != nil {
+		ctx.ServerError("GetMilestones", err)
+		return
+	}
+	ctx.Data["TimeReportMilestones"] = milestones
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByRepoID", err)
+		return
+	}
+	if ctx.Repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, ctx.Repo.Owner.ID, "", db.ListOptions{})
+		if err != nil {
+			ctx.ServerError("GetLabelsByOrgID", err)
+			return
+		}
+		labels = append(labels, orgLabels...)
+	}
+	ctx.Data["TimeReportLabels"] = labels
+
+	timetrack.Report(ctx, []*repo_model.Repository{ctx.Repo.Repository})
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplTimeReport)
+}
+
+// TimeReportExport downloads the times tracked on the issues of the repository
+func TimeReportExport(ctx *context.Context) {
+	timetrack.Export(ctx, []*repo_model.Repository{ctx.Repo.Repository}, ctx.Repo.Repository.Name)
+}
+
+// MustEnableTimetracking checks if time tracking is enabled in the repository
+func MustEnableTimetracking(ctx *context.Context) {
+	if !ctx.Repo.Repository.IsTimetrackerEnabled(ctx) {
+		ctx.NotFound("MustEnableTimetracking", nil)
+	}
+}
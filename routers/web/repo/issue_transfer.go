@@ -0,0 +1,58 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"strings"
+
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+// TransferIssue moves an issue to another repository, the doer must be able to write issues in both of them
+func TransferIssue(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.IssueTransferForm)
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ownerName, repoName, _ := strings.Cut(strings.TrimSpace(form.Repository), "/")
+	newRepo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.repository_not_exist"))
+		} else {
+			ctx.ServerError("GetRepositoryByOwnerAndName", err)
+		}
+		return
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, newRepo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(false) {
+		ctx.JSONError(ctx.Tr("repo.issues.transfer.repository_not_exist"))
+		return
+	}
+
+	if err := issue_service.TransferIssue(ctx, ctx.Doer, issue, newRepo); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrPermissionDenied) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.error", err.Error()))
+		} else {
+			ctx.ServerError("TransferIssue", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.issues.transfer.success", newRepo.FullName()))
+	ctx.JSONRedirect(issue.Link())
+}
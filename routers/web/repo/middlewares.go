@@ -12,6 +12,7 @@ import (
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/optional"
 	"forgejo.org/services/context"
+	"forgejo.org/services/gitdiff"
 	user_service "forgejo.org/services/user"
 )
 
@@ -71,7 +72,7 @@ func SetWhitespaceBehavior(ctx *context.Context) {
 	const defaultWhitespaceBehavior = "show-all"
 	whitespaceBehavior := ctx.FormString("whitespace")
 	switch whitespaceBehavior {
-	case "", "ignore-all", "ignore-eol", "ignore-change":
+	case "", "ignore-all", "ignore-eol", "ignore-change", gitdiff.WhitespaceBehaviorIgnoreFormatting:
 		break
 	default:
 		whitespaceBehavior = defaultWhitespaceBehavior
@@ -23,6 +23,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	shared_project "forgejo.org/routers/web/shared/project"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -252,6 +253,11 @@ func RenderEditProject(ctx *context.Context) {
 	ctx.Data["redirect"] = ctx.FormString("redirect")
 	ctx.Data["CancelLink"] = project_model.ProjectLinkForRepo(ctx.Repo.Repository, p.ID)
 
+	shared_project.PrepareAutomations(ctx, p)
+	if ctx.Written() {
+		return
+	}
+
 	ctx.HTML(http.StatusOK, tplProjectsNew)
 }
 
@@ -361,8 +367,28 @@ func ViewProject(ctx *context.Context) {
 			}
 		}
 	}
+	var allIssues issues_model.IssueList
+	for _, issuesList := range issuesMap {
+		allIssues = append(allIssues, issuesList...)
+	}
+	ctx.Data["IssueCustomFieldEntries"], err = issues_model.GetIssueCustomFieldEntriesByIssues(ctx, allIssues)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldEntriesByIssues", err)
+		return
+	}
+	ctx.Data["SubIssueProgressByIssue"], err = issues_model.GetSubIssueProgressByIssues(ctx, allIssues)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgressByIssues", err)
+		return
+	}
+
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 
+	shared_project.PrepareView(ctx, project, columns, issuesMap, ctx.Repo.CanWrite(unit.TypeProjects) && !ctx.Repo.Repository.IsArchived)
+	if ctx.Written() {
+		return
+	}
+
 	project.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
@@ -31,7 +31,6 @@ import (
 	"forgejo.org/modules/emoji"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
-	issue_template "forgejo.org/modules/issue/template"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
@@ -765,6 +764,10 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 	if pull.IsFilesConflicted() {
 		ctx.Data["IsPullFilesConflicted"] = true
 		ctx.Data["ConflictedFiles"] = pull.ConflictedFiles
+		if ctx.Data["CanResolveConflicts"], err = pull_service.CanResolveConflicts(ctx, pull, ctx.Doer); err != nil {
+			ctx.ServerError("CanResolveConflicts", err)
+			return nil
+		}
 	}
 
 	ctx.Data["NumCommits"] = len(compareInfo.Commits)
@@ -966,6 +969,8 @@ func viewPullFiles(ctx *context.Context, specifiedStartCommit, specifiedEndCommi
 		MaxLineCharacters:  setting.Git.MaxGitDiffLineCharacters,
 		MaxFiles:           maxFiles,
 		WhitespaceBehavior: gitdiff.GetWhitespaceFlag(ctx.Data["WhitespaceBehavior"].(string)),
+		IgnoreFormatting:   ctx.Data["WhitespaceBehavior"] == gitdiff.WhitespaceBehaviorIgnoreFormatting,
+		DetectMovedBlocks:  true,
 		FileOnly:           fileOnly,
 	}
 
@@ -1256,6 +1261,8 @@ func MergePullRequest(ctx *context.Context) {
 			ctx.JSONError(err.Error()) // has no translation ...
 		case errors.Is(err, pull_service.ErrDependenciesLeft):
 			ctx.JSONError(ctx.Tr("repo.issues.dependency.pr_close_blocked"))
+		case errors.Is(err, pull_service.ErrMergeOutOfStackOrder):
+			ctx.JSONError(ctx.Tr("repo.pulls.stack.merge_blocked"))
 		default:
 			ctx.ServerError("WebCheck", err)
 		}
@@ -1497,9 +1504,11 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	content := form.Content
+	var formValues *issues_model.IssueFormValues
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+		_, content, formValues = renderTemplateForm(ctx, filename)
+		if ctx.Written() {
+			return
 		}
 	}
 
@@ -1527,7 +1536,7 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	// FIXME: check error in the case two people send pull request at almost same time, give nice error prompt
 	// instead of 500.
 
-	if err := pull_service.NewPullRequest(ctx, repo, pullIssue, labelIDs, attachments, pullRequest, assigneeIDs); err != nil {
+	if err := pull_service.NewPullRequest(ctx, repo, pullIssue, labelIDs, attachments, pullRequest, assigneeIDs, formValues); err != nil {
 		switch {
 		case errors.Is(err, user_model.ErrBlockedByUser):
 			ctx.JSONError(ctx.Tr("repo.pulls.blocked_by_user"))
@@ -1684,7 +1693,7 @@ func CleanUpPullRequest(ctx *context.Context) {
 func deleteBranch(ctx *context.Context, pr *issues_model.PullRequest, gitRepo *git.Repository) {
 	fullBranchName := pr.HeadRepo.FullName() + ":" + pr.HeadBranch
 
-	if err := pull_service.RetargetChildrenOnMerge(ctx, ctx.Doer, pr); err != nil {
+	if err := pull_service.RetargetChildrenOnMerge(ctx, ctx.Doer, pr, ""); err != nil {
 		ctx.Flash.Error(ctx.Tr("repo.branch.deletion_failed", fullBranchName))
 		return
 	}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+)
+
+const tplPullConflicts base.TplName = "repo/pulls/conflicts"
+
+// ViewPullConflicts shows the conflicts of the merge of the base branch into the head branch of a pull request,
+// to resolve them in the browser
+func ViewPullConflicts(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pull := issue.PullRequest
+
+	canResolve, err := pull_service.CanResolveConflicts(ctx, pull, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("CanResolveConflicts", err)
+		return
+	}
+	if !canResolve {
+		ctx.NotFound("CanResolveConflicts", nil)
+		return
+	}
+
+	if prInfo := PrepareViewPullInfo(ctx, issue); ctx.Written() {
+		return
+	} else if prInfo == nil {
+		ctx.NotFound("ViewPullConflicts", nil)
+		return
+	}
+
+	conflicts, err := pull_service.GetMergeConflicts(ctx, pull, ctx.Doer)
+	if err != nil {
+		if pull_service.IsErrConflictNotResolvable(err) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.not_resolvable", err.(pull_service.ErrConflictNotResolvable).Path))
+			ctx.Redirect(issue.Link())
+			return
+		}
+		ctx.ServerError("GetMergeConflicts", err)
+		return
+	}
+	if len(conflicts.Files) == 0 {
+		ctx.Flash.Info(ctx.Tr("repo.pulls.conflicts.none"))
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	ctx.Data["Conflicts"] = conflicts
+	ctx.Data["DefaultMessage"] = fmt.Sprintf("Merge branch '%s' into %s", pull.BaseBranch, pull.HeadBranch)
+	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
+
+	PrepareBranchList(ctx)
+	if ctx.Written() {
+		return
+	}
+	getBranchData(ctx, issue)
+	ctx.HTML(http.StatusOK, tplPullConflicts)
+}
+
+// ResolvePullConflicts commits the resolution of the conflicts of a pull request to its head branch
+func ResolvePullConflicts(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	conflictsLink := issue.Link() + "/conflicts"
+
+	opts := &pull_service.ResolveConflictsOptions{
+		HeadCommitID: ctx.FormString("head_commit_id"),
+		BaseCommitID: ctx.FormString("base_commit_id"),
+		Message:      ctx.FormString("message"),
+		Files:        make(map[string]*pull_service.ConflictResolution),
+	}
+	for i := 0; ; i++ {
+		path := ctx.FormString(fmt.Sprintf("path-%d", i))
+		if path == "" {
+			break
+		}
+		resolution := &pull_service.ConflictResolution{
+			Edited:  ctx.FormBool(fmt.Sprintf("edit-%d", i)),
+			Content: ctx.FormString(fmt.Sprintf("content-%d", i)),
+		}
+		for j := 0; j < ctx.FormInt(fmt.Sprintf("conflicts-%d", i)); j++ {
+			resolution.Sides = append(resolution.Sides, pull_service.ConflictSide(ctx.FormString(fmt.Sprintf("side-%d-%d", i, j))))
+		}
+		opts.Files[path] = resolution
+	}
+
+	if err := pull_service.ResolveMergeConflicts(ctx, issue.PullRequest, ctx.Doer, opts); err != nil {
+		switch {
+		case pull_service.IsErrConflictUnresolved(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.unresolved", err.(pull_service.ErrConflictUnresolved).Path))
+		case pull_service.IsErrConflictNotResolvable(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.not_resolvable", err.(pull_service.ErrConflictNotResolvable).Path))
+			ctx.Redirect(issue.Link())
+			return
+		case models.IsErrSHADoesNotMatch(err), git.IsErrPushOutOfDate(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.outdated"))
+		case git.IsErrPushRejected(err):
+			ctx.Flash.Error(ctx.Tr("repo.editor.push_rejected_no_message"))
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.NotFound("ResolveMergeConflicts", err)
+			return
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.none"))
+			ctx.Redirect(issue.Link())
+			return
+		default:
+			ctx.ServerError("ResolveMergeConflicts", err)
+			return
+		}
+		ctx.Redirect(conflictsLink)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.conflicts.resolved"))
+	ctx.Redirect(issue.Link())
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/services/context"
+	"forgejo.org/services/gitdiff"
+)
+
+const tplPullRangeDiff base.TplName = "repo/pulls/range_diff"
+
+// ViewPullRangeDiff shows the range-diff between two versions of the head of a pull request, e.g. before
+// and after a force push. Without a range, the version of the last review of the signed-in user is
+// compared with the current head.
+func ViewPullRangeDiff(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pull := issue.PullRequest
+	gitRepo := ctx.Repo.GitRepo
+
+	oldCommitID, newCommitID := ctx.Params("shaFrom"), ctx.Params("shaTo")
+	if oldCommitID == "" {
+		if !ctx.IsSigned {
+			ctx.NotFound("ViewPullRangeDiff", nil)
+			return
+		}
+		review, err := issues_model.GetReviewByIssueIDAndUserID(ctx, issue.ID, ctx.Doer.ID)
+		if err != nil && !issues_model.IsErrReviewNotExist(err) {
+			ctx.ServerError("GetReviewByIssueIDAndUserID", err)
+			return
+		}
+		if review == nil || review.CommitID == "" {
+			ctx.Flash.Info(ctx.Tr("repo.pulls.range_diff.no_review"))
+			ctx.Redirect(issue.Link() + "/files")
+			return
+		}
+		oldCommitID = review.CommitID
+	}
+	if newCommitID == "" {
+		var err error
+		newCommitID, err = gitRepo.GetRefCommitID(pull.GetGitRefName())
+		if err != nil {
+			ctx.ServerError("GetRefCommitID", err)
+			return
+		}
+	}
+
+	// both versions are compared with the current base branch, so that the commits a rebase picked up
+	// from the base branch are not part of the new version
+	baseCommitID := pull.MergeBase
+	if !pull.HasMerged {
+		if commitID, err := gitRepo.GetRefCommitID(git.BranchPrefix + pull.BaseBranch); err == nil {
+			baseCommitID = commitID
+		}
+	}
+
+	if prInfo := PrepareViewPullInfo(ctx, issue); ctx.Written() {
+		return
+	} else if prInfo == nil {
+		ctx.NotFound("ViewPullRangeDiff", nil)
+		return
+	}
+
+	rangeDiff, err := gitdiff.GetRangeDiff(ctx, gitRepo, baseCommitID, oldCommitID, newCommitID)
+	if err != nil {
+		switch {
+		case git.IsErrNotExist(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.range_diff.commit_not_exist"))
+		case gitdiff.IsErrRangeDiffTooLarge(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.range_diff.too_large", gitdiff.MaxRangeDiffCommits))
+		default:
+			ctx.ServerError("GetRangeDiff", err)
+			return
+		}
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	ctx.Data["RangeDiff"] = rangeDiff
+	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+	ctx.Data["IsIssuePoster"] = ctx.IsSigned && issue.IsPoster(ctx.Doer.ID)
+
+	PrepareBranchList(ctx)
+	if ctx.Written() {
+		return
+	}
+	getBranchData(ctx, issue)
+	ctx.HTML(http.StatusOK, tplPullRangeDiff)
+}
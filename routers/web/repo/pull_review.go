@@ -8,17 +8,21 @@ import (
 	"fmt"
 	"net/http"
 
+	"forgejo.org/models"
 	issues_model "forgejo.org/models/issues"
-	pull_model "forgejo.org/models/pull"
+	access_model "forgejo.org/models/perm/access"
 	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
 	"forgejo.org/services/context/upload"
 	"forgejo.org/services/forms"
 	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
 )
 
 const (
@@ -211,6 +215,23 @@ func renderConversation(ctx *context.Context, comment *issues_model.Comment, ori
 		return
 	}
 	ctx.Data["AfterCommitID"] = pullHeadCommitID
+
+	pull := comment.Issue.PullRequest
+	if origin == "diff" && ctx.Doer != nil && !pull.HasMerged {
+		if err := pull.LoadHeadRepo(ctx); err != nil {
+			ctx.ServerError("LoadHeadRepo", err)
+			return
+		}
+		if pull.HeadRepo != nil {
+			headRepoPerm, err := access_model.GetUserRepoPermission(ctx, pull.HeadRepo, ctx.Doer)
+			if err != nil {
+				ctx.ServerError("GetUserRepoPermission", err)
+				return
+			}
+			ctx.Data["HeadBranchIsEditable"] = pull.HeadRepo.CanEnableEditor() && issues_model.CanMaintainerWriteToBranch(ctx, headRepoPerm, pull.HeadBranch, ctx.Doer)
+		}
+	}
+
 	switch origin {
 	case "diff":
 		ctx.HTML(http.StatusOK, tplDiffConversation)
@@ -292,7 +313,6 @@ func DismissReview(ctx *context.Context) {
 }
 
 // viewedFilesUpdate Struct to parse the body of a request to update the reviewed files of a PR
-// If you want to implement an API to update the review, simply move this struct into modules.
 type viewedFilesUpdate struct {
 	Files         map[string]bool `json:"files"`
 	HeadCommitSHA string          `json:"headCommitSHA"`
@@ -319,17 +339,55 @@ func UpdateViewedFiles(ctx *context.Context) {
 		data.HeadCommitSHA = pull.HeadCommitID
 	}
 
-	updatedFiles := make(map[string]pull_model.ViewedState, len(data.Files))
-	for file, viewed := range data.Files {
-		// Only unviewed and viewed are possible, has-changed can not be set from the outside
-		state := pull_model.Unviewed
-		if viewed {
-			state = pull_model.Viewed
+	if err := pull_service.UpdateViewedFiles(ctx, ctx.Repo.GitRepo, pull, ctx.Doer, data.HeadCommitSHA, data.Files); err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.Resp.WriteHeader(http.StatusBadRequest)
+			return
 		}
-		updatedFiles[file] = state
+		ctx.ServerError("UpdateViewedFiles", err)
+	}
+}
+
+// ApplySuggestions commits the suggestions of the selected code comments to the head branch of a pull request
+func ApplySuggestions(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	filesLink := issue.Link() + "/files"
+
+	if err := ctx.Req.ParseForm(); err != nil {
+		ctx.ServerError("ParseForm", err)
+		return
+	}
+	commentIDs, err := base.StringsToInt64s(ctx.Req.Form["comment_ids"])
+	if err != nil || len(commentIDs) == 0 {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.none_selected"))
+		ctx.Redirect(filesLink)
+		return
 	}
 
-	if err := pull_model.UpdateReviewState(ctx, ctx.Doer.ID, pull.ID, data.HeadCommitSHA, updatedFiles); err != nil {
-		ctx.ServerError("UpdateReview", err)
+	if _, err := files_service.ApplySuggestions(ctx, ctx.Doer, issue.PullRequest, commentIDs, ctx.FormString("message")); err != nil {
+		switch {
+		case issues_model.IsErrCommentNotExist(err):
+			ctx.NotFound("ApplySuggestions", err)
+			return
+		case files_service.IsErrSuggestionNotApplicable(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.not_applicable"))
+		case models.IsErrSHADoesNotMatch(err), models.IsErrCommitIDDoesNotMatch(err), git.IsErrPushOutOfDate(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.outdated"))
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.Flash.Error(ctx.Tr("repo.editor.cannot_commit_to_protected_branch", issue.PullRequest.HeadBranch))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.suggestion.not_applicable"))
+		default:
+			ctx.ServerError("ApplySuggestions", err)
+			return
+		}
+		ctx.Redirect(filesLink)
+		return
 	}
+
+	ctx.Flash.Success(ctx.TrN(len(commentIDs), "repo.pulls.suggestion.applied_1", "repo.pulls.suggestion.applied_n", len(commentIDs)))
+	ctx.Redirect(filesLink)
 }
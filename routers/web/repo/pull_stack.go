@@ -0,0 +1,44 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+)
+
+// prepareIssueViewPullStack sets the stack of pull requests a pull request belongs to for the sidebar,
+// and the open pull request it is stacked on if it blocks the merge
+func prepareIssueViewPullStack(ctx *context.Context, issue *issues_model.Issue) {
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		ctx.ServerError("LoadPullRequest", err)
+		return
+	}
+	if issue.PullRequest.HasMerged || issue.IsClosed {
+		return
+	}
+
+	stack, err := pull_service.GetPullRequestStack(ctx, issue.PullRequest)
+	if err != nil {
+		ctx.ServerError("GetPullRequestStack", err)
+		return
+	}
+	if len(stack) > 1 {
+		ctx.Data["PullRequestStack"] = stack
+	}
+
+	parent, err := pull_service.GetBlockingStackParent(ctx, issue.PullRequest)
+	if err != nil {
+		ctx.ServerError("GetBlockingStackParent", err)
+		return
+	}
+	if parent != nil {
+		if err := parent.LoadIssue(ctx); err != nil {
+			ctx.ServerError("LoadIssue", err)
+			return
+		}
+		ctx.Data["StackParent"] = parent
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
+	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
 	commitstatus_service "forgejo.org/services/repository/commitstatus"
@@ -437,25 +438,19 @@ func RedirectDownload(ctx *context.Context) {
 		ctx.ServerError("RedirectDownload", err)
 		return
 	}
+	var release *repo_model.Release
 	if len(releases) == 1 {
-		release := releases[0]
-		att, err := repo_model.GetAttachmentByReleaseIDFileName(ctx, release.ID, fileName)
-		if err != nil {
-			ctx.Error(http.StatusNotFound)
-			return
-		}
-		if att != nil {
-			ServeAttachment(ctx, att.UUID)
-			return
-		}
+		release = releases[0]
 	} else if len(releases) == 0 && vTag == "latest" {
 		// GitHub supports the alias "latest" for the latest release
 		// We only fetch the latest release if the tag is "latest" and no release with the tag "latest" exists
-		release, err := repo_model.GetLatestReleaseByRepoID(ctx, ctx.Repo.Repository.ID)
+		release, err = repo_model.GetLatestReleaseByRepoID(ctx, ctx.Repo.Repository.ID)
 		if err != nil {
 			ctx.Error(http.StatusNotFound)
 			return
 		}
+	}
+	if release != nil {
 		att, err := repo_model.GetAttachmentByReleaseIDFileName(ctx, release.ID, fileName)
 		if err != nil {
 			ctx.Error(http.StatusNotFound)
@@ -465,10 +460,48 @@ func RedirectDownload(ctx *context.Context) {
 			ServeAttachment(ctx, att.UUID)
 			return
 		}
+		// an asset named like the checksums file takes precedence over the generated one
+		if fileName == repo_model.ReleaseChecksumsFileName {
+			serveReleaseChecksums(ctx, release)
+			return
+		}
+		if strings.HasPrefix(fileName, repo_model.ReleaseChecksumsFileName+".") {
+			serveReleaseChecksumsSignature(ctx, release, fileName)
+			return
+		}
 	}
 	ctx.Error(http.StatusNotFound)
 }
 
+// serveReleaseChecksums serves the generated file listing the checksums of the assets of a release
+func serveReleaseChecksums(ctx *context.Context, release *repo_model.Release) {
+	checksums, _, err := release_service.GetReleaseChecksums(ctx, release)
+	if err != nil {
+		ctx.ServerError("GetReleaseChecksums", err)
+		return
+	}
+	if checksums == "" {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
+	ctx.PlainText(http.StatusOK, checksums)
+}
+
+// serveReleaseChecksumsSignature serves the detached signature of the checksums of the assets of a release,
+// which is named after the format of the signature
+func serveReleaseChecksumsSignature(ctx *context.Context, release *repo_model.Release, fileName string) {
+	_, signature, err := release_service.GetReleaseChecksums(ctx, release)
+	if err != nil {
+		ctx.ServerError("GetReleaseChecksums", err)
+		return
+	}
+	if signature == "" || repo_model.ReleaseChecksumsSignatureFileName(signature) != fileName {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
+	ctx.PlainText(http.StatusOK, signature)
+}
+
 // Download an archive of a repository
 func Download(ctx *context.Context) {
 	uri := ctx.Params("*")
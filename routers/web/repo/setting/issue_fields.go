@@ -0,0 +1,241 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const (
+	tplRepoIssueFields base.TplName = "repo/settings/issue_fields"
+	tplOrgIssueFields  base.TplName = "org/settings/issue_fields"
+)
+
+type issueFieldsCtx struct {
+	OwnerID      int64
+	RepoID       int64
+	Template     base.TplName
+	RedirectLink string
+}
+
+func getIssueFieldsCtx(ctx *context.Context) (*issueFieldsCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &issueFieldsCtx{
+			RepoID:       ctx.Repo.Repository.ID,
+			Template:     tplRepoIssueFields,
+			RedirectLink: ctx.Repo.RepoLink + "/settings/issue_fields",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &issueFieldsCtx{
+			OwnerID:      ctx.ContextUser.ID,
+			Template:     tplOrgIssueFields,
+			RedirectLink: ctx.Org.OrgLink + "/settings/issue_fields",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set issue fields context")
+}
+
+// IssueFields renders the custom issue fields of a repository or organization
+func IssueFields(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.issue_fields")
+	ctx.Data["PageIsSettingsIssueFields"] = true
+	ctx.Data["IssueCustomFieldTypes"] = issues_model.IssueCustomFieldTypes
+
+	fCtx, err := getIssueFieldsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getIssueFieldsCtx", err)
+		return
+	}
+
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, fCtx.OwnerID, fCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldsForRepo", err)
+		return
+	}
+	ctx.Data["IssueFields"] = fields
+
+	if fCtx.RepoID > 0 && ctx.Repo.Owner.IsOrganization() {
+		orgFields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, ctx.Repo.Owner.ID, 0)
+		if err != nil {
+			ctx.ServerError("GetIssueCustomFieldsForRepo", err)
+			return
+		}
+		ctx.Data["OrgIssueFields"] = orgFields
+	}
+
+	ctx.HTML(http.StatusOK, fCtx.Template)
+}
+
+// isIssueFieldNameTaken checks whether another field of the same scope, or of the organization
+// of the repository, already uses the name, as fields are referred to by name in issue forms
+func isIssueFieldNameTaken(ctx *context.Context, fCtx *issueFieldsCtx, name string, excludeID int64) (bool, error) {
+	ownerID := fCtx.OwnerID
+	if fCtx.RepoID > 0 {
+		ownerID = ctx.Repo.Repository.OwnerID
+	}
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, ownerID, fCtx.RepoID)
+	if err != nil {
+		return false, err
+	}
+	for _, field := range fields {
+		if field.ID != excludeID && strings.EqualFold(field.Name, name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IssueFieldsPost adds a custom issue field to a repository or organization
+func IssueFieldsPost(ctx *context.Context) {
+	fCtx, err := getIssueFieldsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getIssueFieldsCtx", err)
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.IssueCustomFieldForm)
+	field := &issues_model.IssueCustomField{
+		OwnerID:     fCtx.OwnerID,
+		RepoID:      fCtx.RepoID,
+		Name:        strings.TrimSpace(form.Name),
+		Description: strings.TrimSpace(form.Description),
+		Type:        issues_model.IssueCustomFieldType(form.Type),
+		Options:     strings.TrimSpace(form.Options),
+		Sort:        form.Sort,
+	}
+	if !field.Type.IsValid() {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_fields.type_invalid"))
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+	if !field.Type.HasOptions() {
+		field.Options = ""
+	} else if len(field.GetOptions()) == 0 {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_fields.options_required"))
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+
+	if taken, err := isIssueFieldNameTaken(ctx, fCtx, field.Name, 0); err != nil {
+		ctx.ServerError("isIssueFieldNameTaken", err)
+		return
+	} else if taken {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_fields.name_taken", field.Name))
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+
+	if err := issues_model.NewIssueCustomField(ctx, field); err != nil {
+		ctx.ServerError("NewIssueCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_fields.add_success", field.Name))
+	ctx.Redirect(fCtx.RedirectLink)
+}
+
+// IssueFieldsEditPost updates a custom issue field of a repository or organization, its type can not be changed
+func IssueFieldsEditPost(ctx *context.Context) {
+	fCtx, err := getIssueFieldsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getIssueFieldsCtx", err)
+		return
+	}
+
+	field, err := issues_model.GetIssueCustomFieldByID(ctx, fCtx.OwnerID, fCtx.RepoID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetIssueCustomFieldByID", err)
+		} else {
+			ctx.ServerError("GetIssueCustomFieldByID", err)
+		}
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.IssueCustomFieldForm)
+	field.Name = strings.TrimSpace(form.Name)
+	field.Description = strings.TrimSpace(form.Description)
+	field.Sort = form.Sort
+	if field.Type.HasOptions() {
+		field.Options = strings.TrimSpace(form.Options)
+		if len(field.GetOptions()) == 0 {
+			ctx.Flash.Error(ctx.Tr("repo.settings.issue_fields.options_required"))
+			ctx.Redirect(fCtx.RedirectLink)
+			return
+		}
+	}
+
+	if taken, err := isIssueFieldNameTaken(ctx, fCtx, field.Name, field.ID); err != nil {
+		ctx.ServerError("isIssueFieldNameTaken", err)
+		return
+	} else if taken {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_fields.name_taken", field.Name))
+		ctx.Redirect(fCtx.RedirectLink)
+		return
+	}
+
+	if err := issues_model.UpdateIssueCustomField(ctx, field); err != nil {
+		ctx.ServerError("UpdateIssueCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_fields.edit_success", field.Name))
+	ctx.Redirect(fCtx.RedirectLink)
+}
+
+// IssueFieldsDelete deletes a custom issue field of a repository or organization, along with its values
+func IssueFieldsDelete(ctx *context.Context) {
+	fCtx, err := getIssueFieldsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getIssueFieldsCtx", err)
+		return
+	}
+
+	field, err := issues_model.GetIssueCustomFieldByID(ctx, fCtx.OwnerID, fCtx.RepoID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetIssueCustomFieldByID", err)
+		} else {
+			ctx.ServerError("GetIssueCustomFieldByID", err)
+		}
+		return
+	}
+
+	if err := issue_service.DeleteCustomField(ctx, field); err != nil {
+		ctx.ServerError("DeleteCustomField", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_fields.delete_success", field.Name))
+	ctx.JSONRedirect(fCtx.RedirectLink)
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -84,8 +86,9 @@ func SettingsProtectedBranch(c *context.Context) {
 	contexts, _ := git_model.FindRepoRecentCommitStatusContexts(c, c.Repo.Repository.ID, 7*24*time.Hour) // Find last week status check contexts
 	c.Data["recent_status_checks"] = contexts
 
+	var teams []*organization.Team
 	if c.Repo.Owner.IsOrganization() {
-		teams, err := organization.OrgFromUser(c.Repo.Owner).TeamsWithAccessToRepo(c, c.Repo.Repository.ID, perm.AccessModeRead)
+		teams, err = organization.OrgFromUser(c.Repo.Owner).TeamsWithAccessToRepo(c, c.Repo.Repository.ID, perm.AccessModeRead)
 		if err != nil {
 			c.ServerError("Repo.Owner.TeamsWithAccessToRepo", err)
 			return
@@ -95,6 +98,7 @@ func SettingsProtectedBranch(c *context.Context) {
 		c.Data["merge_whitelist_teams"] = strings.Join(base.Int64sToStrings(rule.MergeWhitelistTeamIDs), ",")
 		c.Data["approvals_whitelist_teams"] = strings.Join(base.Int64sToStrings(rule.ApprovalsWhitelistTeamIDs), ",")
 	}
+	c.Data["review_rules"] = formatReviewRules(rule.ReviewRules, teams)
 
 	c.Data["Rule"] = rule
 	c.HTML(http.StatusOK, tplProtectedBranch)
@@ -229,6 +233,16 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			approvalsWhitelistTeams, _ = base.StringsToInt64s(strings.Split(f.ApprovalsWhitelistTeams, ","))
 		}
 	}
+	protectBranch.ReviewRules, err = parseReviewRules(ctx, f.ReviewRules)
+	if err != nil {
+		if errInvalid, ok := err.(errInvalidReviewRule); ok {
+			ctx.Flash.Error(ctx.Tr("repo.settings.protect_invalid_review_rule", string(errInvalid)))
+			ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, url.QueryEscape(protectBranch.RuleName)))
+			return
+		}
+		ctx.ServerError("parseReviewRules", err)
+		return
+	}
 	protectBranch.BlockOnRejectedReviews = f.BlockOnRejectedReviews
 	protectBranch.BlockOnOfficialReviewRequests = f.BlockOnOfficialReviewRequests
 	protectBranch.DismissStaleApprovals = f.DismissStaleApprovals
@@ -248,6 +262,11 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ApprovalsTeamIDs: approvalsWhitelistTeams,
 	})
 	if err != nil {
+		if errNoAccess, ok := err.(git_model.ErrReviewRuleTeamNoAccess); ok {
+			ctx.Flash.Error(ctx.Tr("repo.settings.protect_review_rule_team_no_access", errNoAccess.FilePatterns))
+			ctx.Redirect(fmt.Sprintf("%s/settings/branches/edit?rule_name=%s", ctx.Repo.RepoLink, url.QueryEscape(protectBranch.RuleName)))
+			return
+		}
 		ctx.ServerError("UpdateProtectBranch", err)
 		return
 	}
@@ -269,6 +288,73 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/settings/branches?rule_name=%s", ctx.Repo.RepoLink, protectBranch.RuleName))
 }
 
+// errInvalidReviewRule is the line of an invalid review rule
+type errInvalidReviewRule string
+
+func (err errInvalidReviewRule) Error() string {
+	return fmt.Sprintf("invalid review rule: %q", string(err))
+}
+
+// parseReviewRules parses the review rules of a protected branch, one rule per line: the file patterns
+// separated by semicolons, the number of required approvals and optionally the names of the teams whose
+// members can approve, separated by commas
+func parseReviewRules(ctx *context.Context, text string) ([]*git_model.ReviewRule, error) {
+	var rules []*git_model.ReviewRule
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 3 || len(fields) < 2 {
+			return nil, errInvalidReviewRule(line)
+		}
+
+		requiredApprovals, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || requiredApprovals < 1 {
+			return nil, errInvalidReviewRule(line)
+		}
+		rule := &git_model.ReviewRule{FilePatterns: fields[0], RequiredApprovals: requiredApprovals}
+		if rule.CheckFilePatterns() != nil {
+			return nil, errInvalidReviewRule(line)
+		}
+
+		if len(fields) == 3 {
+			if !ctx.Repo.Owner.IsOrganization() {
+				return nil, errInvalidReviewRule(line)
+			}
+			rule.TeamIDs, err = organization.GetTeamIDsByNames(ctx, ctx.Repo.Owner.ID, strings.Split(fields[2], ","), false)
+			if err != nil {
+				if organization.IsErrTeamNotExist(err) {
+					return nil, errInvalidReviewRule(line)
+				}
+				return nil, err
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// formatReviewRules formats the review rules of a protected branch the way parseReviewRules parses them
+func formatReviewRules(rules []*git_model.ReviewRule, teams []*organization.Team) string {
+	lines := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		line := fmt.Sprintf("%s %d", rule.FilePatterns, rule.RequiredApprovals)
+		teamNames := make([]string, 0, len(rule.TeamIDs))
+		for _, team := range teams {
+			if slices.Contains(rule.TeamIDs, team.ID) {
+				teamNames = append(teamNames, team.Name)
+			}
+		}
+		if len(teamNames) > 0 {
+			line += " " + strings.Join(teamNames, ",")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // DeleteProtectedBranchRulePost delete protected branch rule by id
 func DeleteProtectedBranchRulePost(ctx *context.Context) {
 	ruleID := ctx.ParamsInt64("id")
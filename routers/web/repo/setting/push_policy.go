@@ -0,0 +1,148 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	git_model "forgejo.org/models/git"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplRepoPushPolicy base.TplName = "repo/settings/push_policy"
+	tplOrgPushPolicy  base.TplName = "org/settings/push_policy"
+)
+
+type pushPolicyCtx struct {
+	OwnerID      int64
+	RepoID       int64
+	IsRepo       bool
+	Template     base.TplName
+	RedirectLink string
+}
+
+func getPushPolicyCtx(ctx *context.Context) (*pushPolicyCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &pushPolicyCtx{
+			RepoID:       ctx.Repo.Repository.ID,
+			IsRepo:       true,
+			Template:     tplRepoPushPolicy,
+			RedirectLink: ctx.Repo.RepoLink + "/settings/push_policy",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &pushPolicyCtx{
+			OwnerID:      ctx.ContextUser.ID,
+			Template:     tplOrgPushPolicy,
+			RedirectLink: ctx.Org.OrgLink + "/settings/push_policy",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set push policy context")
+}
+
+// PushPolicy renders the push policy settings of a repository or organization
+func PushPolicy(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.push_policy")
+	ctx.Data["PageIsSettingsPushPolicy"] = true
+
+	pCtx, err := getPushPolicyCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getPushPolicyCtx", err)
+		return
+	}
+
+	policy, err := git_model.GetPushPolicy(ctx, pCtx.OwnerID, pCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetPushPolicy", err)
+		return
+	}
+	ctx.Data["HasOwnPushPolicy"] = policy != nil
+
+	if pCtx.IsRepo && ctx.Repo.Owner.IsOrganization() {
+		// The rules of the organization apply in addition to the ones of the repository
+		ownerPolicy, err := git_model.GetPushPolicy(ctx, ctx.Repo.Owner.ID, 0)
+		if err != nil {
+			ctx.ServerError("GetPushPolicy", err)
+			return
+		}
+		ctx.Data["IsInheritedPushPolicy"] = ownerPolicy != nil && !ownerPolicy.IsEmpty()
+	}
+	if policy == nil {
+		policy = &git_model.PushPolicy{}
+	}
+	ctx.Data["PushPolicy"] = policy
+
+	ctx.HTML(http.StatusOK, pCtx.Template)
+}
+
+// PushPolicyPost updates the push policy of a repository or organization
+func PushPolicyPost(ctx *context.Context) {
+	pCtx, err := getPushPolicyCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getPushPolicyCtx", err)
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(pCtx.RedirectLink)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PushPolicyForm)
+	policy := &git_model.PushPolicy{
+		OwnerID:               pCtx.OwnerID,
+		RepoID:                pCtx.RepoID,
+		CommitMessagePattern:  strings.TrimSpace(form.CommitMessagePattern),
+		AuthorEmailDomains:    strings.TrimSpace(form.AuthorEmailDomains),
+		MaxFileSize:           max(form.MaxFileSize, 0),
+		ForbiddenFilePatterns: strings.TrimSpace(form.ForbiddenFilePatterns),
+		ForbiddenExtensions:   strings.TrimSpace(form.ForbiddenExtensions),
+		DetectSecrets:         form.DetectSecrets,
+		RequireSignOff:        form.RequireSignOff,
+	}
+	if _, err := regexp.Compile(policy.CommitMessagePattern); err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.settings.push_policy.commit_message_pattern_invalid", err.Error()))
+		ctx.Redirect(pCtx.RedirectLink)
+		return
+	}
+
+	if err := git_model.SetPushPolicy(ctx, policy); err != nil {
+		ctx.ServerError("SetPushPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.push_policy.update_success"))
+	ctx.Redirect(pCtx.RedirectLink)
+}
+
+// PushPolicyDelete removes the push policy of a repository or organization
+func PushPolicyDelete(ctx *context.Context) {
+	pCtx, err := getPushPolicyCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getPushPolicyCtx", err)
+		return
+	}
+
+	if err := git_model.DeletePushPolicy(ctx, pCtx.OwnerID, pCtx.RepoID); err != nil {
+		ctx.ServerError("DeletePushPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.push_policy.delete_success"))
+	ctx.Redirect(pCtx.RedirectLink)
+}
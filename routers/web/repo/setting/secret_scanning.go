@@ -0,0 +1,174 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/db"
+	secretscan_model "forgejo.org/models/secretscan"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/secretscan"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplRepoSecretScanning  base.TplName = "repo/settings/secret_scanning"
+	tplAdminSecretScanning base.TplName = "admin/secret_scanning"
+)
+
+type secretScanningCtx struct {
+	RepoID       int64
+	IsRepo       bool
+	Template     base.TplName
+	RedirectLink string
+}
+
+func getSecretScanningCtx(ctx *context.Context) (*secretScanningCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &secretScanningCtx{
+			RepoID:       ctx.Repo.Repository.ID,
+			IsRepo:       true,
+			Template:     tplRepoSecretScanning,
+			RedirectLink: ctx.Repo.RepoLink + "/settings/secret_scanning",
+		}, nil
+	}
+
+	if ctx.Data["PageIsAdmin"] == true {
+		return &secretScanningCtx{
+			Template:     tplAdminSecretScanning,
+			RedirectLink: setting.AppSubURL + "/admin/secret_scanning",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set secret scanning context")
+}
+
+// SecretScanning renders the secret scanning alerts and custom patterns of a repository,
+// or the global custom patterns for site administrators
+func SecretScanning(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.secret_scanning")
+	ctx.Data["PageIsSettingsSecretScanning"] = true
+	ctx.Data["SecretScanningEnabled"] = setting.SecretScanning.Enabled
+
+	sCtx, err := getSecretScanningCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getSecretScanningCtx", err)
+		return
+	}
+
+	if sCtx.IsRepo {
+		isResolved := ctx.FormString("state") == "resolved"
+		ctx.Data["IsShowResolved"] = isResolved
+
+		page := max(ctx.FormInt("page"), 1)
+		alerts, count, err := db.FindAndCount[secretscan_model.SecretScanAlert](ctx, secretscan_model.FindAlertsOptions{
+			ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+			RepoID:      sCtx.RepoID,
+			IsResolved:  optional.Some(isResolved),
+		})
+		if err != nil {
+			ctx.ServerError("FindAlerts", err)
+			return
+		}
+		ctx.Data["Alerts"] = alerts
+
+		pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+		pager.AddParamString("state", ctx.FormString("state"))
+		ctx.Data["Page"] = pager
+	}
+
+	patterns, err := db.Find[secretscan_model.SecretScanPattern](ctx, secretscan_model.FindPatternsOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      sCtx.RepoID,
+	})
+	if err != nil {
+		ctx.ServerError("FindPatterns", err)
+		return
+	}
+	ctx.Data["Patterns"] = patterns
+	ctx.Data["BuiltinRules"] = secretscan.BuiltinRules()
+
+	ctx.HTML(http.StatusOK, sCtx.Template)
+}
+
+// SecretScanningResolveAlert marks a secret scanning alert of a repository as resolved
+func SecretScanningResolveAlert(ctx *context.Context) {
+	alert, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetAlertByID", err)
+		} else {
+			ctx.ServerError("GetAlertByID", err)
+		}
+		return
+	}
+
+	if err := secretscan_model.ResolveAlert(ctx, alert, ctx.Doer.ID); err != nil {
+		ctx.ServerError("ResolveAlert", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.resolve_success"))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/secret_scanning")
+}
+
+// SecretScanningPatternPost adds a custom secret scanning pattern
+func SecretScanningPatternPost(ctx *context.Context) {
+	sCtx, err := getSecretScanningCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getSecretScanningCtx", err)
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(sCtx.RedirectLink)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.SecretScanPatternForm)
+	pattern := &secretscan_model.SecretScanPattern{
+		RepoID:  sCtx.RepoID,
+		Name:    strings.TrimSpace(form.Name),
+		Pattern: form.Pattern,
+	}
+	if _, err := pattern.ToRule(); err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.settings.secret_scanning.pattern_invalid", err.Error()))
+		ctx.Redirect(sCtx.RedirectLink)
+		return
+	}
+
+	if err := db.Insert(ctx, pattern); err != nil {
+		ctx.ServerError("Insert", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.pattern_add_success"))
+	ctx.Redirect(sCtx.RedirectLink)
+}
+
+// SecretScanningPatternDelete deletes a custom secret scanning pattern
+func SecretScanningPatternDelete(ctx *context.Context) {
+	sCtx, err := getSecretScanningCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getSecretScanningCtx", err)
+		return
+	}
+
+	if err := secretscan_model.DeletePattern(ctx, sCtx.RepoID, ctx.ParamsInt64(":id")); err != nil {
+		ctx.ServerError("DeletePattern", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.pattern_delete_success"))
+	ctx.JSONRedirect(sCtx.RedirectLink)
+}
@@ -36,8 +36,10 @@ import (
 	"forgejo.org/services/context"
 	"forgejo.org/services/federation"
 	"forgejo.org/services/forms"
+	"forgejo.org/services/mailer/token"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
+	pull_service "forgejo.org/services/pull"
 	repo_service "forgejo.org/services/repository"
 	wiki_service "forgejo.org/services/wiki"
 )
@@ -92,12 +94,26 @@ func SettingsCtxData(ctx *context.Context) {
 	}
 	ctx.Data["PushMirrors"] = pushMirrors
 	ctx.Data["CanUseSSHMirroring"] = git.HasSSHExecutable
+
+	if setting.IncomingEmail.Enabled {
+		incomingEmail, err := repo_model.GetIncomingEmail(ctx, ctx.Repo.Repository.ID)
+		if err != nil {
+			ctx.ServerError("GetIncomingEmail", err)
+			return
+		}
+		ctx.Data["IncomingEmailEnabled"] = true
+		ctx.Data["IncomingEmail"] = incomingEmail
+		if incomingEmail != nil {
+			ctx.Data["IncomingEmailAddress"] = token.IncomingAddress(token.NewIssueTokenPrefix + incomingEmail.Token)
+		}
+	}
 }
 
 // Units show a repositorys unit settings page
 func Units(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("repo.settings.units.units")
 	ctx.Data["PageIsRepoSettingsUnits"] = true
+	ctx.Data["MergeMessageTemplateVariables"] = pull_service.MergeMessageTemplateVariables
 
 	ctx.HTML(http.StatusOK, tplSettingsUnits)
 }
@@ -250,6 +266,23 @@ func UnitsPost(ctx *context.Context) {
 	}
 
 	if form.EnablePulls && !unit_model.TypePullRequests.UnitGlobalDisabled() {
+		mergeMessageTemplates := map[repo_model.MergeStyle]string{}
+		for mergeStyle, template := range map[repo_model.MergeStyle]string{
+			repo_model.MergeStyleMerge:       form.PullsMergeMessageTemplateMerge,
+			repo_model.MergeStyleRebase:      form.PullsMergeMessageTemplateRebase,
+			repo_model.MergeStyleRebaseMerge: form.PullsMergeMessageTemplateRebaseMerge,
+			repo_model.MergeStyleSquash:      form.PullsMergeMessageTemplateSquash,
+		} {
+			if template = strings.TrimSpace(template); template != "" {
+				mergeMessageTemplates[mergeStyle] = template
+			}
+		}
+		if err := pull_service.ValidateMergeMessageTemplates(mergeMessageTemplates); err != nil {
+			ctx.Flash.Error(ctx.Tr("repo.settings.pulls.merge_message_template_invalid", err.Error()))
+			ctx.Redirect(repo.Link() + "/settings/units")
+			return
+		}
+
 		units = append(units, repo_model.RepoUnit{
 			RepoID: repo.ID,
 			Type:   unit_model.TypePullRequests,
@@ -267,6 +300,8 @@ func UnitsPost(ctx *context.Context) {
 				DefaultMergeStyle:             repo_model.MergeStyle(form.PullsDefaultMergeStyle),
 				DefaultUpdateStyle:            repo_model.UpdateStyle(form.PullsDefaultUpdateStyle),
 				DefaultAllowMaintainerEdit:    form.DefaultAllowMaintainerEdit,
+				BlockMergeOutOfStackOrder:     form.PullsBlockMergeOutOfStackOrder,
+				MergeMessageTemplates:         mergeMessageTemplates,
 			},
 		})
 	} else if !unit_model.TypePullRequests.UnitGlobalDisabled() {
@@ -737,6 +772,47 @@ func SettingsPost(ctx *context.Context) {
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
 		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
 
+	case "incoming_email":
+		if !setting.IncomingEmail.Enabled {
+			ctx.NotFound("", nil)
+			return
+		}
+
+		if form.EnableIncomingEmail {
+			if _, err := repo_model.SetIncomingEmail(ctx, repo.ID, form.EnableServiceDesk); err != nil {
+				ctx.ServerError("SetIncomingEmail", err)
+				return
+			}
+		} else if err := repo_model.DeleteIncomingEmail(ctx, repo.ID); err != nil {
+			ctx.ServerError("DeleteIncomingEmail", err)
+			return
+		}
+		log.Trace("Repository incoming email settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
+	case "incoming_email_reset":
+		if !setting.IncomingEmail.Enabled {
+			ctx.NotFound("", nil)
+			return
+		}
+
+		incomingEmail, err := repo_model.GetIncomingEmail(ctx, repo.ID)
+		if err != nil {
+			ctx.ServerError("GetIncomingEmail", err)
+			return
+		}
+		if incomingEmail != nil {
+			if err := repo_model.ResetIncomingEmailToken(ctx, incomingEmail); err != nil {
+				ctx.ServerError("ResetIncomingEmailToken", err)
+				return
+			}
+		}
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.incoming_email.reset_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
 	case "admin":
 		if !ctx.Doer.IsAdmin {
 			ctx.Error(http.StatusForbidden)
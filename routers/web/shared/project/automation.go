@@ -0,0 +1,80 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	project_service "forgejo.org/services/project"
+)
+
+// PrepareAutomations sets the automation rules of a project and the choices for new ones on its edit page
+func PrepareAutomations(ctx *context.Context, project *project_model.Project) {
+	automations, err := project_service.GetAutomations(ctx, project)
+	if err != nil {
+		ctx.ServerError("GetAutomations", err)
+		return
+	}
+	columns, err := project.GetColumns(ctx)
+	if err != nil {
+		ctx.ServerError("GetColumns", err)
+		return
+	}
+	labels, err := project_service.GetProjectLabels(ctx, project)
+	if err != nil {
+		ctx.ServerError("GetProjectLabels", err)
+		return
+	}
+
+	ctx.Data["Automations"] = automations
+	ctx.Data["AutomationEvents"] = project_model.AutomationEvents
+	ctx.Data["AutomationColumns"] = columns
+	ctx.Data["AutomationLabels"] = labels
+	ctx.Data["AutomationLink"] = project.Link(ctx) + "/automations"
+}
+
+// AddAutomation adds an automation rule to a project
+func AddAutomation(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectAutomationForm)
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	defer ctx.Redirect(project.Link(ctx) + "/edit")
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		return
+	}
+
+	if _, err := project_service.CreateAutomation(ctx, ctx.Doer, project, project_model.AutomationEvent(form.Event), form.LabelID, form.ColumnID); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("repo.projects.automation.add_error", err.Error()))
+			return
+		}
+		ctx.ServerError("CreateAutomation", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.projects.automation.add_success"))
+}
+
+// DeleteAutomation deletes an automation rule of a project
+func DeleteAutomation(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteAutomationByID(ctx, project.ID, ctx.ParamsInt64(":automationID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteAutomationByID", project_model.IsErrAutomationNotExist, err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.projects.automation.delete_success"))
+	ctx.Redirect(project.Link(ctx) + "/edit")
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	project_service "forgejo.org/services/project"
+)
+
+func getProject(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound("CanBeAccessedByOwnerRepo", nil)
+		return nil
+	}
+	return project
+}
+
+// PrepareView sets the data of the view of a project selected in the query: the board, the table or the timeline.
+// The attributes of the issues of the columns must be loaded.
+func PrepareView(ctx *context.Context, project *project_model.Project, columns project_model.ColumnList, issuesMap map[int64]issues_model.IssueList, canWrite bool) {
+	view := project_service.ParseView(ctx.FormString("view"))
+	ctx.Data["ProjectView"] = view
+
+	switch view {
+	case project_service.ViewTable:
+		opts := project_service.ParseTableOptions(ctx.FormStrings("fields"), ctx.FormString("group"), ctx.FormString("sort"))
+		ctx.Data["TableOptions"] = opts
+		ctx.Data["TableFields"] = project_service.TableFields
+		ctx.Data["TableGroupBys"] = project_service.TableGroupBys
+		ctx.Data["TableSortBys"] = project_service.TableSortBys
+		ctx.Data["TableGroups"] = project_service.BuildTable(columns, issuesMap, opts)
+		if canWrite {
+			editable, err := getEditableIssues(ctx, ctx.Doer, issuesMap)
+			if err != nil {
+				ctx.ServerError("getEditableIssues", err)
+				return
+			}
+			ctx.Data["EditableIssues"] = editable
+		}
+	case project_service.ViewTimeline:
+		var issues issues_model.IssueList
+		for _, column := range columns {
+			issues = append(issues, issuesMap[column.ID]...)
+		}
+		ctx.Data["Timeline"] = project_service.BuildTimeline(issues)
+	}
+
+	if canWrite {
+		labels, err := project_service.GetProjectLabels(ctx, project)
+		if err != nil {
+			ctx.ServerError("GetProjectLabels", err)
+			return
+		}
+		ctx.Data["ProjectLabels"] = labels
+		ctx.Data["AddItemsLimit"] = project_service.AddItemsLimit
+	}
+}
+
+// getEditableIssues returns the IDs of the issues whose title and deadline the user can change
+func getEditableIssues(ctx *context.Context, doer *user_model.User, issuesMap map[int64]issues_model.IssueList) (map[int64]bool, error) {
+	editable := make(map[int64]bool)
+	perms := make(map[int64]access_model.Permission)
+	for _, issues := range issuesMap {
+		if _, err := issues.LoadRepositories(ctx); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			perm, ok := perms[issue.RepoID]
+			if !ok {
+				var err error
+				if perm, err = access_model.GetUserRepoPermission(ctx, issue.Repo, doer); err != nil {
+					return nil, err
+				}
+				perms[issue.RepoID] = perm
+			}
+			editable[issue.ID] = !issue.Repo.IsArchived && perm.CanWriteIssuesOrPulls(issue.IsPull)
+		}
+	}
+	return editable, nil
+}
+
+// AddItems adds the issues and pull requests matching a search to a project
+func AddItems(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectAddItemsForm)
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	opts := project_service.AddItemsOptions{
+		Keyword:  strings.TrimSpace(form.Keyword),
+		IsClosed: optional.Some(false),
+	}
+	switch form.Type {
+	case "issues":
+		opts.IsPull = optional.Some(false)
+	case "pulls":
+		opts.IsPull = optional.Some(true)
+	}
+	switch form.State {
+	case "closed":
+		opts.IsClosed = optional.Some(true)
+	case "all":
+		opts.IsClosed = optional.None[bool]()
+	}
+	if form.LabelID > 0 {
+		opts.LabelIDs = []int64{form.LabelID}
+	}
+
+	added, err := project_service.AddItems(ctx, ctx.Doer, project, opts)
+	if err != nil {
+		ctx.ServerError("AddItems", err)
+		return
+	}
+	if added == 0 {
+		ctx.JSONError(ctx.Tr("repo.projects.add_items.none"))
+		return
+	}
+	ctx.Flash.Success(ctx.TrN(added, "repo.projects.add_items.added_1", "repo.projects.add_items.added_n", added))
+	ctx.JSONRedirect(project.Link(ctx))
+}
+
+// EditItem changes the column, the title or the deadline of an issue or pull request of a project
+func EditItem(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectItemForm)
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+
+	issue, err := issues_model.GetIssueByID(ctx, ctx.ParamsInt64(":issueID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if err := issue.LoadProject(ctx); err != nil {
+		ctx.ServerError("LoadProject", err)
+		return
+	}
+	if issue.Project == nil || issue.Project.ID != project.ID {
+		ctx.NotFound("IssueNotInProject", nil)
+		return
+	}
+
+	if form.Field == "column" {
+		columnID, _ := strconv.ParseInt(form.Value, 10, 64)
+		column, err := project_model.GetColumn(ctx, columnID)
+		if err != nil && !project_model.IsErrProjectColumnNotExist(err) {
+			ctx.ServerError("GetColumn", err)
+			return
+		}
+		if column == nil || column.ProjectID != project.ID {
+			ctx.JSONError(ctx.Tr("repo.projects.item.column_not_exist"))
+			return
+		}
+		if err := issues_model.MoveIssueToProjectColumn(ctx, issue, ctx.Doer, column); err != nil {
+			ctx.ServerError("MoveIssueToProjectColumn", err)
+			return
+		}
+		ctx.JSONOK()
+		return
+	}
+
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.ServerError("LoadRepo", err)
+		return
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	if issue.Repo.IsArchived || !perm.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.JSONError(ctx.Tr("repo.projects.item.no_permission"))
+		return
+	}
+
+	switch form.Field {
+	case "title":
+		title := strings.TrimSpace(form.Value)
+		if title == "" {
+			ctx.JSONError(ctx.Tr("repo.projects.item.title_empty"))
+			return
+		}
+		if err := issue_service.ChangeTitle(ctx, issue, ctx.Doer, title); err != nil {
+			if errors.Is(err, user_model.ErrBlockedByUser) {
+				ctx.JSONError(ctx.Tr("repo.projects.item.no_permission"))
+				return
+			}
+			ctx.ServerError("ChangeTitle", err)
+			return
+		}
+	case "deadline":
+		var deadlineUnix timeutil.TimeStamp
+		if form.Value != "" {
+			deadline, err := time.ParseInLocation("2006-01-02", form.Value, time.Local)
+			if err != nil {
+				ctx.JSONError(ctx.Tr("repo.projects.item.invalid_deadline"))
+				return
+			}
+			deadlineUnix = timeutil.TimeStamp(deadline.Add(24*time.Hour - time.Second).Unix())
+		}
+		if err := issues_model.UpdateIssueDeadline(ctx, issue, deadlineUnix, ctx.Doer); err != nil {
+			ctx.ServerError("UpdateIssueDeadline", err)
+			return
+		}
+	}
+	ctx.JSONOK()
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package timetrack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// parseReportOptions returns the options of the time report selected in the query, the dates are days of the form
+// 2006-01-02 and the before day is included
+func parseReportOptions(ctx *context.Context, repos []*repo_model.Repository) *issues_model.TimeReportOptions {
+	opts := &issues_model.TimeReportOptions{
+		GroupBy:     issues_model.TimeReportGroupBy(ctx.FormString("group_by")),
+		MilestoneID: ctx.FormInt64("milestone"),
+		LabelID:     ctx.FormInt64("label"),
+	}
+	if !opts.GroupBy.IsValid() {
+		opts.GroupBy = issues_model.TimeReportGroupByRepo
+		if len(repos) == 1 {
+			opts.GroupBy = issues_model.TimeReportGroupByUser
+		}
+	}
+
+	query := make(url.Values)
+	if opts.MilestoneID > 0 {
+		query.Set("milestone", strconv.FormatInt(opts.MilestoneID, 10))
+	}
+	if opts.LabelID > 0 {
+		query.Set("label", strconv.FormatInt(opts.LabelID, 10))
+	}
+	if since, err := time.ParseInLocation("2006-01-02", ctx.FormString("since"), time.Local); err == nil {
+		opts.Since = since.Unix()
+		query.Set("since", since.Format("2006-01-02"))
+	}
+	if before, err := time.ParseInLocation("2006-01-02", ctx.FormString("before"), time.Local); err == nil {
+		opts.Before = before.AddDate(0, 0, 1).Unix()
+		query.Set("before", before.Format("2006-01-02"))
+	}
+
+	if username := ctx.FormTrim("user"); username != "" {
+		user, err := user_model.GetUserByName(ctx, username)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			ctx.ServerError("GetUserByName", err)
+			return nil
+		}
+		if user == nil {
+			ctx.Flash.Error(ctx.Tr("form.user_not_exist"), true)
+		} else {
+			opts.UserID = user.ID
+			query.Set("user", user.Name)
+		}
+	}
+	ctx.Data["TimeReportQuery"] = query
+
+	if err := issue_service.SetTimeReportRepos(ctx, ctx.Doer, repos, opts); err != nil {
+		ctx.ServerError("SetTimeReportRepos", err)
+		return nil
+	}
+	return opts
+}
+
+// Report shows the sum of the times tracked on the issues of the repositories, filtered and grouped by the query
+func Report(ctx *context.Context, repos []*repo_model.Repository) {
+	opts := parseReportOptions(ctx, repos)
+	if ctx.Written() {
+		return
+	}
+
+	report, err := issues_model.GetTimeReport(ctx, opts)
+	if err != nil {
+		ctx.ServerError("GetTimeReport", err)
+		return
+	}
+	ctx.Data["TimeReport"] = report
+	ctx.Data["TimeReportGroupBys"] = issues_model.TimeReportGroupBys
+	ctx.Data["TimeReportHasRepos"] = len(repos) > 1
+	ctx.Data["TimeReportOwnTimesOnly"] = len(opts.RepoIDs) == 0 && len(opts.OwnTimesRepoIDs) > 0
+}
+
+// Export downloads the times tracked on the issues of the repositories, filtered by the query, as CSV or JSON
+func Export(ctx *context.Context, repos []*repo_model.Repository, name string) {
+	format := ctx.FormString("format")
+	if format != "csv" && format != "json" {
+		ctx.NotFound("Export", nil)
+		return
+	}
+
+	opts := parseReportOptions(ctx, repos)
+	if ctx.Written() {
+		return
+	}
+	times, err := issues_model.GetTimeReportItems(ctx, opts)
+	if err != nil {
+		ctx.ServerError("GetTimeReportItems", err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-times.%s"`, name, format))
+	if format == "json" {
+		items := make([]*api.TimeReportItem, 0, len(times))
+		for _, t := range times {
+			items = append(items, convert.ToTimeReportItem(t))
+		}
+		ctx.JSON(http.StatusOK, items)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := issue_service.WriteTimeReportCSV(ctx.Resp, times); err != nil {
+		log.Error("WriteTimeReportCSV: %v", err)
+	}
+}
@@ -683,6 +683,24 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		ctx.Data["State"] = "open"
 	}
 
+	savedSearches, err := db.Find[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchesOptions{
+		ListOptions:     db.ListOptionsAll,
+		VisibleToUserID: ctx.Doer.ID,
+		IsPull:          optional.Some(isPullList),
+	})
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(savedSearches).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["SavedSearches"] = savedSearches
+	ctx.Data["SavedSearchQuery"] = issue_service.SanitizeSavedSearchQuery(ctx.Req.URL.Query())
+	ctx.Data["SavedSearchOrg"] = org
+	ctx.Data["SavedSearchTeam"] = team
+
 	pager := context.NewPagination(shownIssues, setting.UI.IssuePagingNum, page, 5)
 	pager.AddParam(ctx, "q", "Keyword")
 	pager.AddParam(ctx, "type", "ViewType")
@@ -0,0 +1,170 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const (
+	tplSettingsSavedSearches base.TplName = "user/settings/saved_searches"
+)
+
+// SavedSearches renders the searches saved by the user or shared with the user
+func SavedSearches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.saved_searches")
+	ctx.Data["PageIsSettingsSavedSearches"] = true
+
+	searches, err := db.Find[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchesOptions{
+		ListOptions:     db.ListOptionsAll,
+		VisibleToUserID: ctx.Doer.ID,
+	})
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(searches).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	subscriptions, err := issues_model.GetSavedSearchSubscriptions(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchSubscriptions", err)
+		return
+	}
+
+	ctx.Data["SavedSearches"] = searches
+	ctx.Data["SavedSearchSubscriptions"] = subscriptions
+	ctx.HTML(http.StatusOK, tplSettingsSavedSearches)
+}
+
+// SavedSearchesPost saves a search of the issue or pull request overview
+func SavedSearchesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchForm)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(setting.AppSubURL + "/user/settings/saved_searches")
+		return
+	}
+
+	query, err := url.ParseQuery(form.Query)
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("settings.saved_searches.invalid_query"))
+		ctx.Redirect(setting.AppSubURL + "/user/settings/saved_searches")
+		return
+	}
+
+	search := &issues_model.SavedSearch{
+		UserID: ctx.Doer.ID,
+		IsPull: form.IsPull,
+		Name:   strings.TrimSpace(form.Name),
+		Query:  issue_service.SanitizeSavedSearchQuery(query),
+	}
+	if form.OrgID > 0 {
+		isMember, err := organization.IsOrganizationMember(ctx, form.OrgID, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("IsOrganizationMember", err)
+			return
+		} else if !isMember {
+			ctx.NotFound("IsOrganizationMember", nil)
+			return
+		}
+		search.OrgID = form.OrgID
+		search.IsShared = form.IsShared
+
+		if form.TeamID > 0 {
+			team, err := organization.GetTeamByID(ctx, form.TeamID)
+			if err != nil {
+				ctx.NotFoundOrServerError("GetTeamByID", organization.IsErrTeamNotExist, err)
+				return
+			} else if team.OrgID != search.OrgID {
+				ctx.NotFound("GetTeamByID", nil)
+				return
+			}
+			search.TeamID = team.ID
+		}
+	}
+
+	if err := issues_model.CreateSavedSearch(ctx, search); err != nil {
+		ctx.ServerError("CreateSavedSearch", err)
+		return
+	}
+	if err := search.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.add_success", search.Name))
+	ctx.Redirect(search.Link())
+}
+
+func getVisibleSavedSearch(ctx *context.Context) *issues_model.SavedSearch {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetSavedSearchByID", issues_model.IsErrSavedSearchNotExist, err)
+		return nil
+	}
+	if visible, err := search.IsVisibleTo(ctx, ctx.Doer.ID); err != nil {
+		ctx.ServerError("IsVisibleTo", err)
+		return nil
+	} else if !visible {
+		ctx.NotFound("IsVisibleTo", nil)
+		return nil
+	}
+	return search
+}
+
+// SavedSearchSubscriptionPost changes the subscription of the user to a saved search
+func SavedSearchSubscriptionPost(ctx *context.Context) {
+	search := getVisibleSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	mode := issues_model.SavedSearchSubscriptionMode(ctx.FormInt("mode"))
+	if !mode.IsValid() {
+		ctx.Error(http.StatusBadRequest, "invalid subscription mode")
+		return
+	}
+
+	if err := issues_model.SetSavedSearchSubscription(ctx, search.ID, ctx.Doer.ID, mode); err != nil {
+		ctx.ServerError("SetSavedSearchSubscription", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.subscription_success", search.Name))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/saved_searches")
+}
+
+// SavedSearchDelete deletes a search saved by the user
+func SavedSearchDelete(ctx *context.Context) {
+	search := getVisibleSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if search.UserID != ctx.Doer.ID {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	if err := issues_model.DeleteSavedSearch(ctx, search); err != nil {
+		ctx.ServerError("DeleteSavedSearch", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.saved_searches.delete_success", search.Name))
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/saved_searches")
+}
@@ -425,6 +425,30 @@ func registerRoutes(m *web.Route) {
 		})
 	}
 
+	addSettingsPushPolicyRoutes := func() {
+		m.Group("/push_policy", func() {
+			m.Combo("").Get(repo_setting.PushPolicy).
+				Post(web.Bind(forms.PushPolicyForm{}), repo_setting.PushPolicyPost)
+			m.Post("/delete", repo_setting.PushPolicyDelete)
+		})
+	}
+
+	addSettingsIssueFieldsRoutes := func() {
+		m.Group("/issue_fields", func() {
+			m.Combo("").Get(repo_setting.IssueFields).
+				Post(web.Bind(forms.IssueCustomFieldForm{}), repo_setting.IssueFieldsPost)
+			m.Post("/{id}/edit", web.Bind(forms.IssueCustomFieldForm{}), repo_setting.IssueFieldsEditPost)
+			m.Post("/{id}/delete", repo_setting.IssueFieldsDelete)
+		})
+	}
+
+	addSettingsSecretScanningPatternsRoutes := func() {
+		m.Group("/patterns", func() {
+			m.Post("", web.Bind(forms.SecretScanPatternForm{}), repo_setting.SecretScanningPatternPost)
+			m.Post("/{id}/delete", repo_setting.SecretScanningPatternDelete)
+		})
+	}
+
 	addSettingsRunnersRoutes := func() {
 		m.Group("/runners", func() {
 			m.Get("", repo_setting.Runners)
@@ -640,6 +664,12 @@ func registerRoutes(m *web.Route) {
 			m.Get("", user_setting.BlockedUsers)
 			m.Post("/unblock", user_setting.UnblockUser)
 		})
+		m.Group("/saved_searches", func() {
+			m.Combo("").Get(user_setting.SavedSearches).
+				Post(web.Bind(forms.SavedSearchForm{}), user_setting.SavedSearchesPost)
+			m.Post("/{id}/subscription", user_setting.SavedSearchSubscriptionPost)
+			m.Post("/{id}/delete", user_setting.SavedSearchDelete)
+		})
 		m.Get("/storage_overview", user_setting.StorageOverview)
 	}, reqSignIn, ctxDataSet("PageIsUserSettings", true, "EnablePackages", setting.Packages.Enabled, "EnableQuota", setting.Quota.Enabled))
 
@@ -775,6 +805,11 @@ func registerRoutes(m *web.Route) {
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
 		})
+
+		m.Group("/secret_scanning", func() {
+			m.Get("", repo_setting.SecretScanning)
+			addSettingsSecretScanningPatternsRoutes()
+		})
 	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
 	// ***** END: Admin *****
 
@@ -856,6 +891,8 @@ func registerRoutes(m *web.Route) {
 			m.Get("/milestones/{team}", reqMilestonesDashboardPageEnabled, user.Milestones)
 			m.Post("/members/action/{action}", org.MembersAction)
 			m.Get("/teams", org.Teams)
+			m.Get("/times", org.TimeReport)
+			m.Get("/times/export", org.TimeReportExport)
 		}, context.OrgAssignment(true, false, true))
 
 		m.Group("/{org}", func() {
@@ -913,6 +950,10 @@ func registerRoutes(m *web.Route) {
 					m.Post("/initialize", web.Bind(forms.InitializeLabelsForm{}), org.InitializeLabels)
 				})
 
+				addSettingsIssueFieldsRoutes()
+
+				addSettingsPushPolicyRoutes()
+
 				m.Group("/actions", func() {
 					m.Get("", org_setting.RedirectToDefaultSetting)
 					addSettingsRunnersRoutes()
@@ -999,6 +1040,10 @@ func registerRoutes(m *web.Route) {
 					m.Get("/edit", org.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), org.EditProjectPost)
 					m.Post("/{action:open|close}", org.ChangeProjectStatus)
+					m.Post("/automations", web.Bind(forms.ProjectAutomationForm{}), project.AddAutomation)
+					m.Post("/automations/{automationID}/delete", project.DeleteAutomation)
+					m.Post("/items", web.Bind(forms.ProjectAddItemsForm{}), project.AddItems)
+					m.Post("/items/{issueID}", web.Bind(forms.ProjectItemForm{}), project.EditItem)
 
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), org.EditProjectColumn)
@@ -1063,6 +1108,15 @@ func registerRoutes(m *web.Route) {
 				m.Post("/{id}", web.Bind(forms.ProtectTagForm{}), context.RepoMustNotBeArchived(), repo_setting.EditProtectedTagPost)
 			})
 
+			addSettingsPushPolicyRoutes()
+			addSettingsIssueFieldsRoutes()
+
+			m.Group("/secret_scanning", func() {
+				m.Get("", repo_setting.SecretScanning)
+				m.Post("/alerts/{id}/resolve", repo_setting.SecretScanningResolveAlert)
+				addSettingsSecretScanningPatternsRoutes()
+			})
+
 			m.Group("/hooks/git", func() {
 				m.Get("", repo_setting.GitHooks)
 				m.Combo("/{name}").Get(repo_setting.GitHooksEdit).
@@ -1171,6 +1225,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", web.Bind(structs.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+				m.Post("/custom_fields/{id}", repo.UpdateIssueCustomField)
 				m.Post("/watch", repo.IssueWatch)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
@@ -1191,6 +1246,11 @@ func registerRoutes(m *web.Route) {
 				m.Post("/reactions/{action}", web.Bind(forms.ReactionForm{}), repo.ChangeIssueReaction)
 				m.Post("/lock", reqRepoIssuesOrPullsWriter, web.Bind(forms.IssueLockForm{}), repo.LockIssue)
 				m.Post("/unlock", reqRepoIssuesOrPullsWriter, repo.UnlockIssue)
+				m.Post("/transfer", reqRepoIssuesOrPullsWriter, web.Bind(forms.IssueTransferForm{}), repo.TransferIssue)
+				m.Group("/sub_issues", func() {
+					m.Post("/add", web.Bind(forms.SubIssueForm{}), repo.AddSubIssue)
+					m.Post("/remove", repo.RemoveSubIssue)
+				}, reqRepoIssuesOrPullsWriter)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
 			}, context.RepoMustNotBeArchived())
 			m.Group("/{index}", func() {
@@ -1377,6 +1437,10 @@ func registerRoutes(m *web.Route) {
 					m.Get("/edit", repo.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), repo.EditProjectPost)
 					m.Post("/{action:open|close}", repo.ChangeProjectStatus)
+					m.Post("/automations", web.Bind(forms.ProjectAutomationForm{}), project.AddAutomation)
+					m.Post("/automations/{automationID}/delete", project.DeleteAutomation)
+					m.Post("/items", web.Bind(forms.ProjectAddItemsForm{}), project.AddItems)
+					m.Post("/items/{issueID}", web.Bind(forms.ProjectItemForm{}), project.EditItem)
 
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), repo.EditProjectColumn)
@@ -1457,6 +1521,14 @@ func registerRoutes(m *web.Route) {
 				m.Get("", repo.RecentCommits)
 				m.Get("/data", repo.CodeFrequencyData)
 			}, repo.MustBeNotEmpty, context.RequireRepoReaderOr(unit.TypeCode))
+			m.Group("/dependencies", func() {
+				m.Get("", repo.Dependencies)
+				m.Get("/sbom/{format}", repo.DependenciesSBOM)
+			}, repo.MustBeNotEmpty, context.RequireRepoReaderOr(unit.TypeCode))
+			m.Group("/times", func() {
+				m.Get("", repo.TimeReport)
+				m.Get("/export", repo.TimeReportExport)
+			}, reqSignIn, reqRepoIssueReader, repo.MustEnableTimetracking)
 		}, context.RepoRef(), context.RequireRepoReaderOr(unit.TypeCode, unit.TypePullRequests, unit.TypeIssues, unit.TypeReleases))
 
 		m.Group("/activity_author_data", func() {
@@ -1509,6 +1581,11 @@ func registerRoutes(m *web.Route) {
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
+			m.Combo("/conflicts", context.RepoMustNotBeArchived()).Get(repo.ViewPullConflicts).
+				Post(context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.ResolvePullConflicts)
+			m.Get("/range-diff", repo.ViewPullRangeDiff)
+			m.Get("/range-diff/{shaFrom:[a-f0-9]{4,64}}..{shaTo:[a-f0-9]{4,64}}", repo.ViewPullRangeDiff)
+			m.Post("/suggestions/apply", context.RepoMustNotBeArchived(), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.ApplySuggestions)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), context.RepoRef(), repo.CleanUpPullRequest)
 			m.Group("/files", func() {
@@ -1568,7 +1645,7 @@ func registerRoutes(m *web.Route) {
 			m.Get("/commit/{sha:([a-f0-9]{4,64})$}/load-branches-and-tags", repo.LoadBranchesAndTags)
 			m.Group("/commit/{sha:([a-f0-9]{4,64})$}/notes", func() {
 				m.Post("", web.Bind(forms.CommitNotesForm{}), repo.SetCommitNotes)
-				m.Post("/remove", repo.RemoveCommitNotes)
+				m.Post("/remove", web.Bind(forms.CommitNotesForm{}), repo.RemoveCommitNotes)
 			}, reqSignIn, reqRepoCodeWriter)
 			m.Get("/cherry-pick/{sha:([a-f0-9]{4,64})$}", repo.SetEditorconfigIfExists, repo.CherryPick)
 		}, repo.MustBeNotEmpty, context.RepoRef(), reqRepoCodeReader)
@@ -4,8 +4,10 @@
 package asymkey
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	asymkey_model "forgejo.org/models/asymkey"
@@ -396,3 +398,64 @@ Loop:
 	}
 	return true, signingKey, signer, nil
 }
+
+// SignRelease determines if we should sign the checksums of the assets of a release
+func SignRelease(ctx context.Context, repo *repo_model.Repository, u *user_model.User) (bool, string, error) {
+	rules := signingModeFromStrings(setting.Repository.Signing.Releases)
+	signingKey, _ := SigningKey(ctx, repo.RepoPath())
+	if signingKey == "" {
+		return false, "", &ErrWontSign{noKey}
+	}
+
+Loop:
+	for _, rule := range rules {
+		switch rule {
+		case never:
+			return false, "", &ErrWontSign{never}
+		case always:
+			break Loop
+		case pubkey:
+			hasPubKey, err := asymkey_model.HasAsymKeyByUID(ctx, u.ID)
+			if err != nil {
+				return false, "", err
+			}
+			if !hasPubKey {
+				return false, "", &ErrWontSign{pubkey}
+			}
+		case twofa:
+			hasTwoFactor, err := auth.HasTwoFactorByUID(ctx, u.ID)
+			if err != nil {
+				return false, "", err
+			}
+			if !hasTwoFactor {
+				return false, "", &ErrWontSign{twofa}
+			}
+		}
+	}
+	return true, signingKey, nil
+}
+
+// DetachedSign signs content with a signing key returned by SigningKey and returns the armored detached
+// signature: an OpenPGP signature, or an SSH signature in the "file" namespace if the signing format is ssh
+func DetachedSign(ctx context.Context, repoPath, signingKey, content string) (string, error) {
+	// use the same home directory as git, whose keyring and configuration are used to sign commits
+	env := append(os.Environ(), "HOME="+git.HomeDir())
+
+	var signature, stderr string
+	var err error
+	if setting.Repository.Signing.Format == "ssh" {
+		// Can ignore the error here as it means that gpg.ssh.program is not set
+		sshKeygenPath, _, _ := git.NewCommand(ctx, "config", "--get", "gpg.ssh.program").RunStdString(&git.RunOpts{Dir: repoPath})
+		signature, stderr, err = process.GetManager().ExecDirEnvStdIn(ctx, -1, repoPath,
+			"ssh-keygen -Y sign", env, strings.NewReader(content),
+			cmp.Or(strings.TrimSpace(sshKeygenPath), "ssh-keygen"), "-Y", "sign", "-n", "file", "-f", signingKey)
+	} else {
+		signature, stderr, err = process.GetManager().ExecDirEnvStdIn(ctx, -1, repoPath,
+			"gpg --detach-sign", env, strings.NewReader(content),
+			"gpg", "--batch", "--armor", "--detach-sign", "--local-user", signingKey)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to sign with %s: %s, %w", signingKey, stderr, err)
+	}
+	return signature, nil
+}
@@ -6,6 +6,8 @@ package attachment
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 
@@ -27,11 +29,13 @@ func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.R
 
 	err := db.WithTx(ctx, func(ctx context.Context) error {
 		attach.UUID = uuid.New().String()
-		size, err := storage.Attachments.Save(attach.RelativePath(), file, size)
+		hash := sha256.New()
+		size, err := storage.Attachments.Save(attach.RelativePath(), io.TeeReader(file, hash), size)
 		if err != nil {
 			return fmt.Errorf("Create: %w", err)
 		}
 		attach.Size = size
+		attach.SHA256 = hex.EncodeToString(hash.Sum(nil))
 
 		eng := db.GetEngine(ctx)
 		if attach.NoAutoTime {
@@ -78,3 +82,24 @@ func UploadAttachment(ctx context.Context, file io.Reader, allowedTypes string,
 
 	return NewAttachment(ctx, attach, io.MultiReader(bytes.NewReader(buf), file), fileSize)
 }
+
+// UpdateAttachmentSHA256 computes and stores the SHA-256 checksum of an attachment uploaded before checksums
+// were stored at upload time
+func UpdateAttachmentSHA256(ctx context.Context, attach *repo_model.Attachment) error {
+	if attach.ExternalURL != "" {
+		return nil
+	}
+
+	file, err := storage.Attachments.Open(attach.RelativePath())
+	if err != nil {
+		return fmt.Errorf("open attachment %s: %w", attach.UUID, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("read attachment %s: %w", attach.UUID, err)
+	}
+	attach.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return repo_model.UpdateAttachmentByUUID(ctx, attach, "sha256")
+}
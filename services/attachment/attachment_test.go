@@ -4,6 +4,8 @@
 package attachment
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -45,4 +47,8 @@ func TestUploadAttachment(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, user.ID, attachment.UploaderID)
 	assert.Equal(t, int64(0), attachment.DownloadCount)
+
+	content, err := os.ReadFile(fPath)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256(content)), attachment.SHA256)
 }
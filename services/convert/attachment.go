@@ -47,6 +47,7 @@ func toAttachment(repo *repo_model.Repository, a *repo_model.Attachment, getDown
 		UUID:          a.UUID,
 		DownloadURL:   getDownloadURL(repo, a), // for web request json and api request json, return different download urls
 		Type:          typeName,
+		SHA256:        a.SHA256,
 	}
 }
 
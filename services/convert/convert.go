@@ -148,6 +148,15 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 	mergeWhitelistTeams := getWhitelistEntities(teamReaders, bp.MergeWhitelistTeamIDs)
 	approvalsWhitelistTeams := getWhitelistEntities(teamReaders, bp.ApprovalsWhitelistTeamIDs)
 
+	reviewRules := make([]*api.BranchProtectionReviewRule, 0, len(bp.ReviewRules))
+	for _, rule := range bp.ReviewRules {
+		reviewRules = append(reviewRules, &api.BranchProtectionReviewRule{
+			FilePatterns:      rule.FilePatterns,
+			RequiredApprovals: rule.RequiredApprovals,
+			Teams:             getWhitelistEntities(teamReaders, rule.TeamIDs),
+		})
+	}
+
 	branchName := ""
 	if !git_model.IsRuleNameSpecial(bp.RuleName) {
 		branchName = bp.RuleName
@@ -179,6 +188,7 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		ApplyToAdmins:                 bp.ApplyToAdmins,
+		ReviewRules:                   reviewRules,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -368,6 +378,8 @@ func ToTeams(ctx context.Context, teams []*organization.Team, loadOrgs bool) ([]
 			Permission:              t.AccessMode.String(),
 			Units:                   t.GetUnitNames(),
 			UnitsMap:                t.GetUnitsMap(),
+			ReviewAssignmentMode:    t.ReviewAssignmentMode.String(),
+			ReviewAssignmentCount:   t.ReviewAssignmentCount,
 		}
 
 		if loadOrgs {
@@ -115,6 +115,12 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		apiIssue.Deadline = issue.DeadlineUnix.AsTimePtr()
 	}
 
+	customFields, err := issues_model.GetIssueCustomFieldEntries(ctx, issue)
+	if err != nil {
+		return &api.Issue{}
+	}
+	apiIssue.CustomFields = ToIssueCustomFieldValues(customFields)
+
 	return apiIssue
 }
 
@@ -205,6 +211,46 @@ func ToTrackedTimeList(ctx context.Context, doer *user_model.User, tl issues_mod
 	return result
 }
 
+// ToTimeReport converts TimeReport to API format
+func ToTimeReport(report *issues_model.TimeReport) *api.TimeReport {
+	result := &api.TimeReport{
+		GroupBy: string(report.GroupBy),
+		Total:   report.Total,
+		Entries: make([]*api.TimeReportEntry, 0, len(report.Entries)),
+	}
+	for _, entry := range report.Entries {
+		result.Entries = append(result.Entries, &api.TimeReportEntry{
+			ID:   entry.ID,
+			Name: entry.Name(),
+			Time: entry.Time,
+		})
+	}
+	return result
+}
+
+// ToTimeReportItem converts a TrackedTime of a time report to API format, its report attributes must be loaded
+func ToTimeReportItem(t *issues_model.TrackedTime) *api.TimeReportItem {
+	item := &api.TimeReportItem{
+		ID:      t.ID,
+		Created: t.Created,
+		Time:    t.Time,
+	}
+	if t.User != nil {
+		item.UserName = t.User.Name
+	}
+	if t.Issue != nil {
+		item.IssueIndex = t.Issue.Index
+		item.IssueTitle = t.Issue.Title
+		if t.Issue.Repo != nil {
+			item.Repository = t.Issue.Repo.FullName()
+		}
+		if t.Issue.Milestone != nil {
+			item.Milestone = t.Issue.Milestone.Name
+		}
+	}
+	return item
+}
+
 // ToLabel converts Label to API format
 func ToLabel(label *issues_model.Label, repo *repo_model.Repository, org *user_model.User) *api.Label {
 	result := &api.Label{
@@ -0,0 +1,52 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+)
+
+// ToIssueCustomField converts an issues_model.IssueCustomField to API format
+func ToIssueCustomField(field *issues_model.IssueCustomField) *api.IssueCustomField {
+	options := field.GetOptions()
+	if options == nil {
+		options = []string{}
+	}
+	return &api.IssueCustomField{
+		ID:          field.ID,
+		Name:        field.Name,
+		Description: field.Description,
+		Type:        string(field.Type),
+		Options:     options,
+		IsOrgField:  field.BelongsToOrg(),
+	}
+}
+
+// ToIssueCustomFieldList converts a list of custom issue fields to API format
+func ToIssueCustomFieldList(fields []*issues_model.IssueCustomField) []*api.IssueCustomField {
+	result := make([]*api.IssueCustomField, len(fields))
+	for i := range fields {
+		result[i] = ToIssueCustomField(fields[i])
+	}
+	return result
+}
+
+// ToIssueCustomFieldValues converts the custom fields set on an issue to API format, fields without a value are omitted
+func ToIssueCustomFieldValues(entries []*issues_model.IssueCustomFieldEntry) []*api.IssueCustomFieldValue {
+	result := make([]*api.IssueCustomFieldValue, 0, len(entries))
+	for _, entry := range entries {
+		values := entry.DisplayValues()
+		if len(values) == 0 {
+			continue
+		}
+		result = append(result, &api.IssueCustomFieldValue{
+			FieldID: entry.Field.ID,
+			Name:    entry.Field.Name,
+			Type:    string(entry.Field.Type),
+			Values:  values,
+		})
+	}
+	return result
+}
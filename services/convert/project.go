@@ -0,0 +1,24 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+)
+
+// ToProjectAutomation converts a project_model.Automation and its column, if it still exists, to API format
+func ToProjectAutomation(automation *project_model.Automation, column *project_model.Column) *api.ProjectAutomation {
+	result := &api.ProjectAutomation{
+		ID:       automation.ID,
+		Event:    string(automation.Event),
+		LabelID:  automation.LabelID,
+		ColumnID: automation.ColumnID,
+		Created:  automation.CreatedUnix.AsTime(),
+	}
+	if column != nil {
+		result.ColumnTitle = column.Title
+	}
+	return result
+}
@@ -31,5 +31,6 @@ func ToAPIRelease(ctx context.Context, repo *repo_model.Repository, r *repo_mode
 		Publisher:            ToUser(ctx, r.Publisher, nil),
 		Attachments:          ToAPIAttachments(repo, r.Attachments),
 		ArchiveDownloadCount: r.ArchiveDownloadCount,
+		ChecksumsURL:         r.ChecksumsURL(),
 	}
 }
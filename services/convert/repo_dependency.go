@@ -0,0 +1,28 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+)
+
+// ToRepoDependency converts a repository dependency to its API format,
+// packagePath is the path of the package in the registry it resolves to
+func ToRepoDependency(dep *repo_model.RepoDependency, packagePath string) *api.RepoDependency {
+	result := &api.RepoDependency{
+		Ecosystem: dep.Ecosystem,
+		Name:      dep.Name,
+		Version:   dep.Version,
+		Manifest:  dep.Manifest,
+		IsDev:     dep.IsDev,
+		PURL:      dep.ToDependency().PURL(),
+		CommitID:  dep.CommitID,
+	}
+	if packagePath != "" {
+		result.PackageURL = setting.AppURL + packagePath
+	}
+	return result
+}
@@ -103,6 +103,8 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 	defaultMergeStyle := repo_model.MergeStyleMerge
 	defaultUpdateStyle := repo_model.UpdateStyleMerge
 	defaultAllowMaintainerEdit := false
+	blockMergeOutOfStackOrder := false
+	var mergeMessageTemplates map[string]string
 	if unit, err := repo.GetUnit(ctx, unit_model.TypePullRequests); err == nil {
 		config := unit.PullRequestsConfig()
 		hasPullRequests = true
@@ -117,6 +119,13 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		defaultMergeStyle = config.GetDefaultMergeStyle()
 		defaultUpdateStyle = config.GetDefaultUpdateStyle()
 		defaultAllowMaintainerEdit = config.DefaultAllowMaintainerEdit
+		blockMergeOutOfStackOrder = config.BlockMergeOutOfStackOrder
+		if len(config.MergeMessageTemplates) > 0 {
+			mergeMessageTemplates = make(map[string]string, len(config.MergeMessageTemplates))
+			for mergeStyle, template := range config.MergeMessageTemplates {
+				mergeMessageTemplates[string(mergeStyle)] = template
+			}
+		}
 	}
 	hasProjects := false
 	if _, err := repo.GetUnit(ctx, unit_model.TypeProjects); err == nil {
@@ -235,6 +244,8 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		DefaultMergeStyle:             string(defaultMergeStyle),
 		DefaultUpdateStyle:            string(defaultUpdateStyle),
 		DefaultAllowMaintainerEdit:    defaultAllowMaintainerEdit,
+		BlockMergeOutOfStackOrder:     blockMergeOutOfStackOrder,
+		MergeMessageTemplates:         mergeMessageTemplates,
 		AvatarURL:                     repo.AvatarLink(ctx),
 		Internal:                      !repo.IsPrivate && repo.Owner.Visibility == api.VisibleTypePrivate,
 		MirrorInterval:                mirrorInterval,
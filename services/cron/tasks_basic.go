@@ -14,6 +14,7 @@ import (
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/setting"
 	"forgejo.org/services/auth"
+	issue_service "forgejo.org/services/issue"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
@@ -156,6 +157,16 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerNotifySavedSearchSubscribers() {
+	RegisterTaskFatal("notify_saved_searches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.NotifySavedSearchSubscribers(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -169,6 +180,7 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerNotifySavedSearchSubscribers()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
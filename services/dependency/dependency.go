@@ -0,0 +1,230 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/dependency"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/process"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// maxManifestSize is the largest manifest or lock file which is parsed
+const maxManifestSize = 10 * 1024 * 1024
+
+// dependencyQueue holds the IDs of the repositories whose dependencies need to be updated
+var dependencyQueue *queue.WorkerPoolQueue[int64]
+
+// Init starts the queue updating the dependencies of repositories
+func Init(ctx context.Context) error {
+	handler := func(items ...int64) []int64 {
+		for _, repoID := range items {
+			if err := updateDependencies(ctx, repoID); err != nil {
+				log.Error("Updating the dependencies of repo %d failed: %v", repoID, err)
+			}
+		}
+		return nil
+	}
+
+	dependencyQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "repo_dependencies", handler)
+	if dependencyQueue == nil {
+		return errors.New("unable to create repo_dependencies queue")
+	}
+	go graceful.GetManager().RunWithCancel(dependencyQueue)
+	return nil
+}
+
+// UpdateRepoDependencies queues the repository for its dependencies to be read from the default branch
+func UpdateRepoDependencies(repo *repo_model.Repository) {
+	if dependencyQueue == nil {
+		return
+	}
+	if err := dependencyQueue.Push(repo.ID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			log.Error("Unable to queue the dependencies update of repo %d: %v", repo.ID, err)
+		}
+	}
+}
+
+type manifestFile struct {
+	path   string
+	blobID string
+}
+
+func updateDependencies(ctx context.Context, repoID int64) error {
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Dependencies: repo %d", repoID))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if repo.IsEmpty || repo.DefaultBranch == "" {
+		return repo_model.UpdateRepoDependencies(ctx, repo.ID, "", nil)
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return repo_model.UpdateRepoDependencies(ctx, repo.ID, "", nil)
+		}
+		return err
+	}
+
+	manifests, err := listManifests(ctx, gitRepo, commitID)
+	if err != nil {
+		return err
+	}
+
+	var deps []*repo_model.RepoDependency
+	err = readManifests(ctx, gitRepo, manifests, func(manifest manifestFile, content []byte) {
+		parsed, err := dependency.Parse(manifest.path, content)
+		if err != nil {
+			log.Debug("Unable to parse %s at %s in %-v: %v", manifest.path, commitID, repo, err)
+			return
+		}
+		for _, dep := range parsed {
+			deps = append(deps, &repo_model.RepoDependency{
+				Manifest:  manifest.path,
+				Ecosystem: string(dep.Ecosystem),
+				Name:      dep.Name,
+				Version:   dep.Version,
+				IsDev:     dep.IsDev,
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return repo_model.UpdateRepoDependencies(ctx, repo.ID, commitID, deps)
+}
+
+// isVendored returns true for paths inside directories holding copies of dependencies
+func isVendored(filePath string) bool {
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if dir == "vendor" || dir == "node_modules" || dir == "third_party" {
+			return true
+		}
+	}
+	return false
+}
+
+// listManifests lists the manifest and lock files of the commit which are small enough to be parsed
+func listManifests(ctx context.Context, gitRepo *git.Repository, commitID string) ([]manifestFile, error) {
+	// The output consists of "<mode> <type> <object> <size>\t<path>\0" records
+	stdout, _, runErr := git.NewCommand(ctx, "ls-tree", "-r", "-l", "-z", "--full-tree").AddDynamicArguments(commitID).RunStdString(&git.RunOpts{Dir: gitRepo.Path})
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	var manifests []manifestFile
+	for _, record := range strings.Split(stdout, "\x00") {
+		info, filePath, ok := strings.Cut(record, "\t")
+		if !ok || !dependency.IsManifest(filePath) || isVendored(filePath) {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		if size, err := strconv.ParseInt(fields[3], 10, 64); err != nil || size > maxManifestSize {
+			continue
+		}
+		manifests = append(manifests, manifestFile{path: filePath, blobID: fields[2]})
+	}
+	return manifests, nil
+}
+
+// readManifests calls fn with the content of each manifest
+func readManifests(ctx context.Context, gitRepo *git.Repository, manifests []manifestFile, fn func(manifest manifestFile, content []byte)) error {
+	if len(manifests) == 0 {
+		return nil
+	}
+
+	wr, rd, cancel, err := gitRepo.CatFileBatch(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for _, manifest := range manifests {
+		if _, err := wr.Write([]byte(manifest.blobID + "\n")); err != nil {
+			return err
+		}
+		_, _, size, err := git.ReadBatchLine(rd)
+		if err != nil {
+			return err
+		}
+		content := make([]byte, size)
+		if _, err := io.ReadFull(rd, content); err != nil {
+			return err
+		}
+		if _, err := rd.Discard(1); err != nil {
+			return err
+		}
+		fn(manifest, content)
+	}
+	return nil
+}
+
+// SBOMToolName is the name of the tool recorded in generated SBOMs
+const SBOMToolName = "Forgejo"
+
+// GenerateSBOM creates a software bill of materials in the format for the dependencies on the default branch
+func GenerateSBOM(ctx context.Context, repo *repo_model.Repository, format dependency.SBOMFormat) (any, error) {
+	records, err := db.Find[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject := &dependency.SBOMSubject{
+		Name:        repo.FullName(),
+		Link:        repo.HTMLURL(),
+		ToolName:    SBOMToolName,
+		ToolVersion: setting.AppVer,
+		Created:     time.Now(),
+	}
+	deps := make([]*dependency.Dependency, 0, len(records))
+	for _, record := range records {
+		subject.Version = record.CommitID
+		deps = append(deps, record.ToDependency())
+	}
+
+	switch format {
+	case dependency.SBOMFormatSPDX:
+		return dependency.NewSPDXDocument(subject, deps), nil
+	case dependency.SBOMFormatCycloneDX:
+		return dependency.NewCycloneDXDocument(subject, deps), nil
+	}
+	return nil, util.NewInvalidArgumentErrorf("unsupported SBOM format %q", format)
+}
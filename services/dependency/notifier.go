@@ -0,0 +1,39 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"context"
+
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/repository"
+	notify_service "forgejo.org/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&dependencyNotifier{})
+}
+
+type dependencyNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &dependencyNotifier{}
+
+func (n *dependencyNotifier) PushCommits(ctx context.Context, _ *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, _ *repository.PushCommits) {
+	if opts.RefFullName.IsBranch() && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		UpdateRepoDependencies(repo)
+	}
+}
+
+func (n *dependencyNotifier) SyncPushCommits(ctx context.Context, _ *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, _ *repository.PushCommits) {
+	if opts.RefFullName.IsBranch() && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		UpdateRepoDependencies(repo)
+	}
+}
+
+func (n *dependencyNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
+	UpdateRepoDependencies(repo)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"forgejo.org/models/organization"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/dependency"
+	"forgejo.org/modules/setting"
+)
+
+// registryPackage returns the type and name a dependency has in the package registry
+func registryPackage(dep *repo_model.RepoDependency) (packages_model.Type, string, bool) {
+	switch dependency.Ecosystem(dep.Ecosystem) {
+	case dependency.EcosystemGo:
+		return packages_model.TypeGo, dep.Name, true
+	case dependency.EcosystemNpm:
+		return packages_model.TypeNpm, dep.Name, true
+	case dependency.EcosystemCargo:
+		return packages_model.TypeCargo, dep.Name, true
+	case dependency.EcosystemPyPI:
+		return packages_model.TypePyPI, dependency.NormalizePyPIName(dep.Name), true
+	case dependency.EcosystemMaven:
+		// Maven packages are stored as "groupId-artifactId"
+		return packages_model.TypeMaven, strings.Replace(dep.Name, ":", "-", 1), true
+	case dependency.EcosystemComposer:
+		return packages_model.TypeComposer, dep.Name, true
+	}
+	return "", "", false
+}
+
+// GetPackageLinks returns the paths, relative to the application URL, of the packages in this
+// instance's registry the dependencies resolve to, keyed by dependency ID. Only packages visible
+// to the doer are linked.
+func GetPackageLinks(ctx context.Context, doer *user_model.User, deps []*repo_model.RepoDependency) (map[int64]string, error) {
+	links := make(map[int64]string)
+	if !setting.Packages.Enabled || len(deps) == 0 {
+		return links, nil
+	}
+
+	names := make(map[packages_model.Type][]string)
+	versions := make(map[packages_model.Type][]string)
+	for _, dep := range deps {
+		if typ, name, ok := registryPackage(dep); ok {
+			names[typ] = append(names[typ], name)
+			if dep.Version != "" {
+				versions[typ] = append(versions[typ], dep.Version)
+			}
+		}
+	}
+
+	type packageKey struct {
+		typ       packages_model.Type
+		lowerName string
+	}
+	type versionKey struct {
+		packageID    int64
+		lowerVersion string
+	}
+	packages := make(map[packageKey]*packages_model.Package)
+	packageVersions := make(container.Set[versionKey])
+	owners := make(map[int64]*user_model.User)
+	for typ, typeNames := range names {
+		found, err := packages_model.GetPackagesByNames(ctx, typ, typeNames)
+		if err != nil {
+			return nil, err
+		}
+		var packageIDs []int64
+		for _, p := range found {
+			owner, ok := owners[p.OwnerID]
+			if !ok {
+				owner, err = user_model.GetUserByID(ctx, p.OwnerID)
+				if err != nil {
+					if user_model.IsErrUserNotExist(err) {
+						continue
+					}
+					return nil, err
+				}
+				owners[p.OwnerID] = owner
+			}
+			if !organization.HasOrgOrUserVisible(ctx, owner, doer) {
+				continue
+			}
+			// Packages with the same name may exist for several owners, prefer the first one found
+			key := packageKey{typ: p.Type, lowerName: p.LowerName}
+			if _, ok := packages[key]; !ok {
+				packages[key] = p
+				packageIDs = append(packageIDs, p.ID)
+			}
+		}
+
+		if len(packageIDs) == 0 || len(versions[typ]) == 0 {
+			continue
+		}
+		pvs, err := packages_model.GetVersionsByPackageIDsAndVersions(ctx, packageIDs, versions[typ])
+		if err != nil {
+			return nil, err
+		}
+		for _, pv := range pvs {
+			packageVersions.Add(versionKey{packageID: pv.PackageID, lowerVersion: pv.LowerVersion})
+		}
+	}
+
+	for _, dep := range deps {
+		typ, name, ok := registryPackage(dep)
+		if !ok {
+			continue
+		}
+		p, ok := packages[packageKey{typ: typ, lowerName: strings.ToLower(name)}]
+		if !ok {
+			continue
+		}
+		link := fmt.Sprintf("%s/-/packages/%s/%s", url.PathEscape(owners[p.OwnerID].Name), string(p.Type), url.PathEscape(p.LowerName))
+		if lowerVersion := strings.ToLower(dep.Version); packageVersions.Contains(versionKey{packageID: p.ID, lowerVersion: lowerVersion}) {
+			link += "/" + url.PathEscape(lowerVersion)
+		}
+		links[dep.ID] = link
+	}
+	return links, nil
+}
@@ -62,11 +62,13 @@ func (f *UpdateOrgSettingForm) Validate(req *http.Request, errs binding.Errors)
 
 // CreateTeamForm form for creating team
 type CreateTeamForm struct {
-	TeamName         string `binding:"Required;AlphaDashDot;MaxSize(255)"`
-	Description      string `binding:"MaxSize(255)"`
-	Permission       string
-	RepoAccess       string
-	CanCreateOrgRepo bool
+	TeamName              string `binding:"Required;AlphaDashDot;MaxSize(255)"`
+	Description           string `binding:"MaxSize(255)"`
+	Permission            string
+	RepoAccess            string
+	CanCreateOrgRepo      bool
+	ReviewAssignmentMode  string `binding:"In(,none,round-robin,load-balance)"`
+	ReviewAssignmentCount int    `binding:"Range(1,10)"`
 }
 
 // Validate validates the fields
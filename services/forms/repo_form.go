@@ -151,6 +151,10 @@ type RepoSettingForm struct {
 	// Signing Settings
 	TrustModel string
 
+	// Incoming email settings
+	EnableIncomingEmail bool
+	EnableServiceDesk   bool
+
 	// Admin settings
 	EnableHealthCheck  bool
 	RequestReindexType string
@@ -194,6 +198,11 @@ type RepoUnitSettingForm struct {
 	PullsAllowRebaseUpdate                bool
 	DefaultDeleteBranchAfterMerge         bool
 	DefaultAllowMaintainerEdit            bool
+	PullsBlockMergeOutOfStackOrder        bool
+	PullsMergeMessageTemplateMerge        string
+	PullsMergeMessageTemplateRebase       string
+	PullsMergeMessageTemplateRebaseMerge  string
+	PullsMergeMessageTemplateSquash       string
 	EnableTimetracker                     bool
 	AllowOnlyContributorsToTrackTime      bool
 	EnableIssueDependencies               bool
@@ -226,6 +235,7 @@ type ProtectBranchForm struct {
 	EnableStatusCheck             bool
 	StatusCheckContexts           string
 	RequiredApprovals             int64
+	ReviewRules                   string
 	EnableApprovalsWhitelist      bool
 	ApprovalsWhitelistUsers       string
 	ApprovalsWhitelistTeams       string
@@ -246,6 +256,50 @@ func (f *ProtectBranchForm) Validate(req *http.Request, errs binding.Errors) bin
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// PushPolicyForm form for changing the push policy of a repository or organization
+type PushPolicyForm struct {
+	CommitMessagePattern  string `binding:"MaxSize(1024)"`
+	AuthorEmailDomains    string
+	MaxFileSize           int64
+	ForbiddenFilePatterns string
+	ForbiddenExtensions   string
+	DetectSecrets         bool
+	RequireSignOff        bool
+}
+
+// Validate validates the fields
+func (f *PushPolicyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SecretScanPatternForm form for adding a custom secret scanning pattern
+type SecretScanPatternForm struct {
+	Name    string `binding:"Required;MaxSize(255)"`
+	Pattern string `binding:"Required;MaxSize(1024)"`
+}
+
+// Validate validates the fields
+func (f *SecretScanPatternForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// IssueCustomFieldForm form for adding or editing a custom issue field
+type IssueCustomFieldForm struct {
+	Name        string `binding:"Required;MaxSize(255)"`
+	Description string
+	Type        string // only used when adding a field
+	Options     string
+	Sort        int64
+}
+
+// Validate validates the fields
+func (f *IssueCustomFieldForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 //  __      __      ___.   .__                   __
 // /  \    /  \ ____\_ |__ |  |__   ____   ____ |  | __
 // \   \/\/   // __ \| __ \|  |  \ /  _ \ /  _ \|  |/ /
@@ -385,6 +439,28 @@ func (i IssueLockForm) HasValidReason() bool {
 	return false
 }
 
+// IssueTransferForm form for transferring an issue to another repository
+type IssueTransferForm struct {
+	Repository string
+}
+
+// Validate validates the fields
+func (f *IssueTransferForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SubIssueForm form for adding a sub-issue, given by a reference like #1 or owner/repo#1
+type SubIssueForm struct {
+	SubIssue string
+}
+
+// Validate validates the fields
+func (f *SubIssueForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateProjectForm form for creating a project
 type CreateProjectForm struct {
 	Title        string `binding:"Required;MaxSize(100)"`
@@ -400,6 +476,45 @@ type EditProjectColumnForm struct {
 	Color   string `binding:"MaxSize(7)"`
 }
 
+// ProjectAutomationForm is a form for adding an automation rule to a project
+type ProjectAutomationForm struct {
+	Event    string `binding:"Required"`
+	LabelID  int64
+	ColumnID int64 `binding:"Required"`
+}
+
+// Validate validates the fields
+func (f *ProjectAutomationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ProjectAddItemsForm is a form for adding the issues and pull requests matching a search to a project
+type ProjectAddItemsForm struct {
+	Keyword string `form:"q"`
+	Type    string
+	State   string
+	LabelID int64
+}
+
+// Validate validates the fields
+func (f *ProjectAddItemsForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ProjectItemForm is a form for changing a field of an issue or pull request from the table view of a project
+type ProjectItemForm struct {
+	Field string `binding:"Required;In(column,title,deadline)"`
+	Value string
+}
+
+// Validate validates the fields
+func (f *ProjectItemForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateMilestoneForm form for creating milestone
 type CreateMilestoneForm struct {
 	Title    string `binding:"Required;MaxSize(50)"`
@@ -742,4 +857,5 @@ type SaveTopicForm struct {
 
 type CommitNotesForm struct {
 	Notes string
+	Ref   string
 }
@@ -454,3 +454,19 @@ func (f *PackageSettingForm) Validate(req *http.Request, errs binding.Errors) bi
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// SavedSearchForm form for saving a search of the issue or pull request overview
+type SavedSearchForm struct {
+	Name     string `binding:"Required;MaxSize(255)"`
+	Query    string
+	IsPull   bool
+	OrgID    int64 `form:"org_id"`
+	TeamID   int64 `form:"team_id"`
+	IsShared bool
+}
+
+// Validate validates the fields
+func (f *SavedSearchForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"slices"
+	"strings"
+
+	"forgejo.org/modules/highlight"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// WhitespaceBehaviorIgnoreFormatting is the whitespace behavior which marks the changes which only reformat
+// the code, including the whitespace-only changes, except in the languages where the whitespace is significant
+const WhitespaceBehaviorIgnoreFormatting = "ignore-formatting"
+
+// whitespaceSensitiveLexers are the lexers of the languages whose layout is significant,
+// for which a change of the line breaks or of the indentation is never only a formatting change
+var whitespaceSensitiveLexers = []string{
+	"CoffeeScript",
+	"Elm",
+	"Haskell",
+	"Makefile",
+	"Nim",
+	"Python",
+	"Python 2",
+	"Sass",
+	"YAML",
+}
+
+// MarkFormattingOnlyChanges marks the blocks of changed lines which only change the formatting of the code,
+// like the line breaks of a reflowed statement or the spacing around operators. The code is tokenized with
+// the lexer of the language of each file, so that the whitespace in string literals is kept significant.
+func (diff *Diff) MarkFormattingOnlyChanges() {
+	for _, file := range diff.Files {
+		if file.IsBin || file.IsLFSFile || file.IsSubmodule {
+			continue
+		}
+		lexer := highlight.GetLexer(file.Name, file.Language)
+		if slices.Contains(whitespaceSensitiveLexers, lexer.Config().Name) {
+			continue
+		}
+
+		for _, section := range file.Sections {
+			for start := 0; start < len(section.Lines); {
+				end := start
+				for end < len(section.Lines) && (section.Lines[end].Type == DiffLineAdd || section.Lines[end].Type == DiffLineDel) {
+					end++
+				}
+				if end == start {
+					start++
+					continue
+				}
+				if isFormattingOnlyChange(lexer, section.Lines[start:end]) {
+					for _, line := range section.Lines[start:end] {
+						line.IsFormattingOnly = true
+					}
+				}
+				start = end
+			}
+		}
+	}
+}
+
+// isFormattingOnlyChange returns true if the deleted and the added lines of a block of changed lines
+// have the same significant tokens
+func isFormattingOnlyChange(lexer chroma.Lexer, lines []*DiffLine) bool {
+	var oldCode, newCode strings.Builder
+	for _, line := range lines {
+		if line.Type == DiffLineDel {
+			oldCode.WriteString(line.Content[1:])
+			oldCode.WriteByte('\n')
+		} else {
+			newCode.WriteString(line.Content[1:])
+			newCode.WriteByte('\n')
+		}
+	}
+
+	oldTokens, ok := significantTokens(lexer, oldCode.String())
+	if !ok {
+		return false
+	}
+	newTokens, ok := significantTokens(lexer, newCode.String())
+	if !ok {
+		return false
+	}
+	return slices.Equal(oldTokens, newTokens)
+}
+
+// significantTokens returns the tokens of code without the whitespace between them
+func significantTokens(lexer chroma.Lexer, code string) ([]string, bool) {
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return nil, false
+	}
+
+	var tokens []string
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		if token.Type.InCategory(chroma.LiteralString) {
+			// the whitespace of string literals is part of their value
+			tokens = append(tokens, token.Value)
+			continue
+		}
+		tokens = append(tokens, strings.Fields(token.Value)...)
+	}
+	return tokens, true
+}
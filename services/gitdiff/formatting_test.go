@@ -0,0 +1,126 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkFormattingOnlyChanges(t *testing.T) {
+	parse := func(t *testing.T, patch string) *Diff {
+		diff, err := ParsePatch(db.DefaultContext, setting.Git.MaxGitDiffLines, setting.Git.MaxGitDiffLineCharacters, setting.Git.MaxGitDiffFiles, strings.NewReader(patch), "")
+		require.NoError(t, err)
+		diff.MarkFormattingOnlyChanges()
+		return diff
+	}
+
+	t.Run("Reflowed statement", func(t *testing.T) {
+		diff := parse(t, `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,5 @@
+ package a
+-var x = call(a,b)
++var x = call(
++	a, b,
++)
+ // end
+`)
+		lines := diff.Files[0].Sections[0].Lines
+		// a trailing comma is a token, so the added lines are not only a formatting change
+		for _, line := range lines {
+			assert.False(t, line.IsFormattingOnly)
+		}
+
+		diff = parse(t, `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,5 @@
+ package a
+-var x = call(a,b)
++var x = call(
++	a, b)
+ // end
+`)
+		lines = diff.Files[0].Sections[0].Lines
+		assert.False(t, lines[1].IsFormattingOnly)
+		for _, line := range lines[2:5] {
+			assert.True(t, line.IsFormattingOnly)
+			assert.Equal(t, "formatting-code", line.GetHTMLDiffLineClass())
+		}
+		assert.False(t, lines[5].IsFormattingOnly)
+	})
+
+	t.Run("String literal", func(t *testing.T) {
+		diff := parse(t, `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,2 @@
+-var s = "a  b"
++var s = "a b"
+ // end
+`)
+		for _, line := range diff.Files[0].Sections[0].Lines {
+			assert.False(t, line.IsFormattingOnly)
+		}
+	})
+
+	t.Run("Whitespace sensitive language", func(t *testing.T) {
+		diff := parse(t, `diff --git a/a.py b/a.py
+--- a/a.py
++++ b/a.py
+@@ -1,2 +1,2 @@
+-if a:  b()
++if a: b()
+ # end
+`)
+		for _, line := range diff.Files[0].Sections[0].Lines {
+			assert.False(t, line.IsFormattingOnly)
+		}
+	})
+
+	t.Run("Indentation", func(t *testing.T) {
+		diff := parse(t, `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+ if a {
+-b()
++	b()
+ }
+`)
+		lines := diff.Files[0].Sections[0].Lines
+		assert.True(t, lines[2].IsFormattingOnly)
+		assert.True(t, lines[3].IsFormattingOnly)
+
+		// the indentation of Python is significant, the change is not hidden as a whitespace change
+		diff = parse(t, `diff --git a/a.py b/a.py
+--- a/a.py
++++ b/a.py
+@@ -1,3 +1,3 @@
+ if a:
+     b()
+-c()
++    c()
+`)
+		for _, line := range diff.Files[0].Sections[0].Lines {
+			assert.False(t, line.IsFormattingOnly)
+		}
+		assert.Equal(t, 1, diff.Files[0].Addition)
+		assert.Equal(t, 1, diff.Files[0].Deletion)
+	})
+}
+
+func TestGetWhitespaceFlagIgnoreFormatting(t *testing.T) {
+	// git must not drop the whitespace changes, they are compared per file
+	assert.Empty(t, GetWhitespaceFlag(WhitespaceBehaviorIgnoreFormatting))
+	assert.Equal(t, "-w", GetWhitespaceFlag("ignore-all")[0])
+}
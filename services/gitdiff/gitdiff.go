@@ -14,6 +14,7 @@ import (
 	"html/template"
 	"io"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -81,6 +82,10 @@ type DiffLine struct {
 	Content       string
 	Conversations []issues_model.CodeConversation
 	SectionInfo   *DiffLineSectionInfo
+	// Move is set if the line is part of a block of lines moved within the diff
+	Move *DiffLineMove
+	// IsFormattingOnly is set if the line is part of a change which only reformats the code
+	IsFormattingOnly bool
 }
 
 // DiffLineSectionInfo represents diff line section meta data
@@ -115,6 +120,17 @@ func (d *DiffLine) GetHTMLDiffLineType() string {
 	return "same"
 }
 
+// GetHTMLDiffLineClass returns the CSS classes of the line for HTML, in addition to its diff line type
+func (d *DiffLine) GetHTMLDiffLineClass() string {
+	switch {
+	case d.Move != nil:
+		return "moved-code"
+	case d.IsFormattingOnly:
+		return "formatting-code"
+	}
+	return ""
+}
+
 // CanComment returns whether a line can get commented
 func (d *DiffLine) CanComment() bool {
 	return len(d.Conversations) == 0 && d.Type != DiffLineSection
@@ -318,14 +334,14 @@ func (diffSection *DiffSection) GetComputedInlineDiffFor(diffLine *DiffLine, loc
 		return getLineContent(diffLine.Content[1:], locale)
 	case DiffLineAdd:
 		compareDiffLine = diffSection.GetLine(DiffLineDel, diffLine.RightIdx)
-		if compareDiffLine == nil {
+		if compareDiffLine == nil || diffLine.Move != nil || compareDiffLine.Move != nil {
 			return DiffInlineWithHighlightCode(diffSection.FileName, language, diffLine.Content[1:], locale)
 		}
 		diff1 = compareDiffLine.Content
 		diff2 = diffLine.Content
 	case DiffLineDel:
 		compareDiffLine = diffSection.GetLine(DiffLineAdd, diffLine.LeftIdx)
-		if compareDiffLine == nil {
+		if compareDiffLine == nil || diffLine.Move != nil || compareDiffLine.Move != nil {
 			return DiffInlineWithHighlightCode(diffSection.FileName, language, diffLine.Content[1:], locale)
 		}
 		diff1 = diffLine.Content
@@ -1084,8 +1100,12 @@ type DiffOptions struct {
 	MaxLineCharacters  int
 	MaxFiles           int
 	WhitespaceBehavior git.TrustedCmdArgs
-	DirectComparison   bool
-	FileOnly           bool
+	// IgnoreFormatting marks the changes which only reformat the code, see WhitespaceBehaviorIgnoreFormatting
+	IgnoreFormatting bool
+	// DetectMovedBlocks marks the blocks of lines moved within the diff, see Diff.DetectMovedBlocks
+	DetectMovedBlocks bool
+	DirectComparison  bool
+	FileOnly          bool
 }
 
 // GetDiffSimple builds a Diff between two commits of a repository.
@@ -1237,6 +1257,13 @@ func GetDiffFull(ctx context.Context, gitRepo *git.Repository, opts *DiffOptions
 		}
 	}
 
+	if opts.IgnoreFormatting {
+		diff.MarkFormattingOnlyChanges()
+	}
+	if opts.DetectMovedBlocks {
+		diff.DetectMovedBlocks()
+	}
+
 	if opts.FileOnly {
 		return diff, nil
 	}
@@ -1317,29 +1344,26 @@ func SyncAndGetUserSpecificDiff(ctx context.Context, userID int64, pull *issues_
 		log.Error("Could not get changed files between %s and %s for pull request %d in repo with path %s. Assuming no changes. Error: %w", review.CommitSHA, latestCommit, pull.Index, gitRepo.Path, err)
 	}
 
-	filesChangedSinceLastDiff := make(map[string]pull_model.ViewedState)
-outer:
-	for _, diffFile := range diff.Files {
-		fileViewedState := review.UpdatedFiles[diffFile.GetDiffFileName()]
-
-		// Check whether it was previously detected that the file has changed since the last review
-		if fileViewedState == pull_model.HasChanged {
-			diffFile.HasChangedSinceLastReview = true
-			continue
+	// The viewed content of the files is compared with their current content
+	var afterCommit *git.Commit
+	if len(review.ViewedBlobs) > 0 {
+		if afterCommit, err = gitRepo.GetCommit(latestCommit); err != nil {
+			log.Error("Could not get commit %s for pull request %d in repo with path %s. Assuming no changes. Error: %v", latestCommit, pull.Index, gitRepo.Path, err)
 		}
+	}
 
+	filesChangedSinceLastDiff := make(map[string]pull_model.ViewedState)
+	for _, diffFile := range diff.Files {
 		filename := diffFile.GetDiffFileName()
 
-		// Check explicitly whether the file has changed since the last review
-		for _, changedFile := range changedFiles {
-			diffFile.HasChangedSinceLastReview = filename == changedFile
-			if diffFile.HasChangedSinceLastReview {
+		switch review.GetViewedState(filename, GetBlobID(afterCommit, filename), slices.Contains(changedFiles, filename)) {
+		case pull_model.HasChanged:
+			// We don't want to check if the file is viewed here as that would fold the file, which is in this case unwanted
+			diffFile.HasChangedSinceLastReview = true
+			if _, ok := review.ViewedBlobs[filename]; !ok && review.UpdatedFiles[filename] != pull_model.HasChanged {
 				filesChangedSinceLastDiff[filename] = pull_model.HasChanged
-				continue outer // We don't want to check if the file is viewed here as that would fold the file, which is in this case unwanted
 			}
-		}
-		// Check whether the file has already been viewed
-		if fileViewedState == pull_model.Viewed {
+		case pull_model.Viewed:
 			diffFile.IsViewed = true
 			diff.NumViewedFiles++
 		}
@@ -1349,7 +1373,7 @@ outer:
 	// This has the benefit that the "Has Changed" attribute will be present as long as the user does not explicitly mark this file as viewed, so it will even survive a page reload after marking another file as viewed.
 	// On the other hand, this means that even if a commit reverting an unseen change is committed, the file will still be seen as changed.
 	if len(filesChangedSinceLastDiff) > 0 {
-		err := pull_model.UpdateReviewState(ctx, review.UserID, review.PullID, review.CommitSHA, filesChangedSinceLastDiff)
+		err := pull_model.UpdateReviewState(ctx, review.UserID, review.PullID, review.CommitSHA, filesChangedSinceLastDiff, nil)
 		if err != nil {
 			log.Warn("Could not update review for user %d, pull %d, commit %s and the changed files %v: %v", review.UserID, review.PullID, review.CommitSHA, filesChangedSinceLastDiff, err)
 			return nil, err
@@ -1359,6 +1383,18 @@ outer:
 	return diff, nil
 }
 
+// GetBlobID returns the ID of the blob of the file at path in commit, or an empty string if it does not exist
+func GetBlobID(commit *git.Commit, path string) string {
+	if commit == nil {
+		return ""
+	}
+	entry, err := commit.GetTreeEntryByPath(path)
+	if err != nil || entry.IsDir() {
+		return ""
+	}
+	return entry.ID.String()
+}
+
 // CommentAsDiff returns c.Patch as *Diff
 func CommentAsDiff(ctx context.Context, c *issues_model.Comment) (*Diff, error) {
 	diff, err := ParsePatch(ctx, setting.Git.MaxGitDiffLines,
@@ -1401,6 +1437,9 @@ func GetWhitespaceFlag(whitespaceBehavior string) git.TrustedCmdArgs {
 		"ignore-change": {"-b"},
 		"ignore-eol":    {"--ignore-space-at-eol"},
 		"show-all":      nil,
+
+		// the whitespace is compared per file by MarkFormattingOnlyChanges, as it is significant in some languages
+		WhitespaceBehaviorIgnoreFormatting: nil,
 	}
 	if flag, ok := whitespaceFlags[whitespaceBehavior]; ok {
 		return flag
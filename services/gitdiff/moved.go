@@ -0,0 +1,130 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"cmp"
+	"unicode"
+)
+
+const (
+	// minMovedBlockAlnumCount is the minimum number of alphanumeric characters of a block of lines to be
+	// detected as moved, like for git diff --color-moved
+	minMovedBlockAlnumCount = 20
+	// maxMovedLineCandidates is the maximum number of deleted lines a moved block is searched from for each
+	// added line, so that very common lines like closing braces do not make the detection quadratic
+	maxMovedLineCandidates = 100
+	// maxMovedDetectionLines is the maximum number of deleted and added lines of a diff for the moved blocks
+	// to be detected
+	maxMovedDetectionLines = 10000
+)
+
+// DiffLineMove describes where a line of a block of lines moved within a diff was moved from or to
+type DiffLineMove struct {
+	// BlockID identifies the moved block, it is shared by the deleted and the added lines of the block
+	BlockID int
+	// FileName is the name of the file of the counterpart of the line
+	FileName string
+	// LineIdx is the line number of the counterpart of the line: the new line number of a deleted line,
+	// the old line number of an added line
+	LineIdx int
+}
+
+// movedLineCandidate is a deleted or added line of a diff with the file it belongs to
+type movedLineCandidate struct {
+	file *DiffFile
+	line *DiffLine
+}
+
+// follows returns true if c is the line right after prev in the same file
+func (c *movedLineCandidate) follows(prev *movedLineCandidate) bool {
+	if c.file != prev.file {
+		return false
+	}
+	if c.line.Type == DiffLineDel {
+		return c.line.LeftIdx == prev.line.LeftIdx+1
+	}
+	return c.line.RightIdx == prev.line.RightIdx+1
+}
+
+// DetectMovedBlocks finds the blocks of lines which have been deleted and added unchanged elsewhere in the
+// diff, possibly in another file, and marks their lines as moved. Nothing is detected in diffs with more than
+// maxMovedDetectionLines deleted and added lines.
+func (diff *Diff) DetectMovedBlocks() {
+	var deleted, added []*movedLineCandidate
+	for _, file := range diff.Files {
+		if file.IsBin || file.IsLFSFile || file.IsSubmodule {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.Move != nil || line.IsFormattingOnly {
+					continue
+				}
+				switch line.Type {
+				case DiffLineDel:
+					deleted = append(deleted, &movedLineCandidate{file: file, line: line})
+				case DiffLineAdd:
+					added = append(added, &movedLineCandidate{file: file, line: line})
+				}
+			}
+		}
+	}
+	if len(deleted) == 0 || len(added) == 0 || len(deleted)+len(added) > maxMovedDetectionLines {
+		return
+	}
+
+	deletedByContent := make(map[string][]int, len(deleted))
+	for i, candidate := range deleted {
+		content := candidate.line.Content[1:]
+		if len(deletedByContent[content]) < maxMovedLineCandidates {
+			deletedByContent[content] = append(deletedByContent[content], i)
+		}
+	}
+
+	blockID := 0
+	for i := 0; i < len(added); {
+		bestStart, bestLength := 0, 0
+		for _, start := range deletedByContent[added[i].line.Content[1:]] {
+			length := 0
+			for i+length < len(added) && start+length < len(deleted) {
+				del, add := deleted[start+length], added[i+length]
+				if del.line.Move != nil || del.line.Content[1:] != add.line.Content[1:] ||
+					(length > 0 && (!del.follows(deleted[start+length-1]) || !add.follows(added[i+length-1]))) {
+					break
+				}
+				length++
+			}
+			if length > bestLength {
+				bestStart, bestLength = start, length
+			}
+		}
+
+		if bestLength == 0 || movedBlockAlnumCount(added[i:i+bestLength]) < minMovedBlockAlnumCount {
+			i++
+			continue
+		}
+
+		blockID++
+		for j := 0; j < bestLength; j++ {
+			del, add := deleted[bestStart+j], added[i+j]
+			del.line.Move = &DiffLineMove{BlockID: blockID, FileName: add.file.Name, LineIdx: add.line.RightIdx}
+			add.line.Move = &DiffLineMove{BlockID: blockID, FileName: cmp.Or(del.file.OldName, del.file.Name), LineIdx: del.line.LeftIdx}
+		}
+		i += bestLength
+	}
+}
+
+// movedBlockAlnumCount returns the number of alphanumeric characters of a block of lines
+func movedBlockAlnumCount(block []*movedLineCandidate) int {
+	count := 0
+	for _, candidate := range block {
+		for _, r := range candidate.line.Content[1:] {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectMovedBlocks(t *testing.T) {
+	diff, err := ParsePatch(db.DefaultContext, setting.Git.MaxGitDiffLines, setting.Git.MaxGitDiffLineCharacters, setting.Git.MaxGitDiffFiles, strings.NewReader(`diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,6 +1,3 @@
+ package a
+-
+-func moved() string {
+-	return "this function has been moved"
+-}
+ // end
+diff --git a/b.go b/b.go
+--- a/b.go
++++ b/b.go
+@@ -1,2 +1,7 @@
+ package a
++
++func moved() string {
++	return "this function has been moved"
++}
++}
+ // end
+`), "")
+	require.NoError(t, err)
+	diff.DetectMovedBlocks()
+
+	deleted := diff.Files[0].Sections[0].Lines
+	added := diff.Files[1].Sections[0].Lines
+	require.Len(t, deleted, 7)
+	require.Len(t, added, 8)
+
+	// the empty line and the lines of the function have been moved as one block
+	for i := 2; i <= 5; i++ {
+		if assert.NotNil(t, deleted[i].Move, "deleted line %d", i) {
+			assert.Equal(t, &DiffLineMove{BlockID: 1, FileName: "b.go", LineIdx: i}, deleted[i].Move)
+		}
+		if assert.NotNil(t, added[i].Move, "added line %d", i) {
+			assert.Equal(t, &DiffLineMove{BlockID: 1, FileName: "a.go", LineIdx: i}, added[i].Move)
+		}
+		assert.Equal(t, "moved-code", added[i].GetHTMLDiffLineClass())
+	}
+	// the second closing brace has not been moved
+	assert.Nil(t, added[6].Move)
+	assert.Empty(t, added[6].GetHTMLDiffLineClass())
+
+	t.Run("Short block", func(t *testing.T) {
+		diff, err := ParsePatch(db.DefaultContext, setting.Git.MaxGitDiffLines, setting.Git.MaxGitDiffLineCharacters, setting.Git.MaxGitDiffFiles, strings.NewReader(`diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+-}
+ x := 1
++}
+ // end
+`), "")
+		require.NoError(t, err)
+		diff.DetectMovedBlocks()
+
+		for _, line := range diff.Files[0].Sections[0].Lines {
+			assert.Nil(t, line.Move)
+		}
+	})
+	t.Run("Large diff", func(t *testing.T) {
+		deleted := make([]*DiffLine, 0, maxMovedDetectionLines/2+1)
+		added := make([]*DiffLine, 0, maxMovedDetectionLines/2+1)
+		for i := 1; i <= maxMovedDetectionLines/2+1; i++ {
+			content := fmt.Sprintf("fmt.Println(\"moved line number %d\")", i)
+			deleted = append(deleted, &DiffLine{Type: DiffLineDel, LeftIdx: i, Content: "-" + content})
+			added = append(added, &DiffLine{Type: DiffLineAdd, RightIdx: i, Content: "+" + content})
+		}
+		diff := &Diff{Files: []*DiffFile{
+			{Name: "a.go", Sections: []*DiffSection{{Lines: deleted}}},
+			{Name: "b.go", Sections: []*DiffSection{{Lines: added}}},
+		}}
+		diff.DetectMovedBlocks()
+
+		assert.Nil(t, deleted[0].Move)
+		assert.Nil(t, added[0].Move)
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// MaxRangeDiffCommits is the maximum number of commits of each series of a range-diff
+const MaxRangeDiffCommits = 250
+
+// RangeDiffStatus represents how a commit of the old series relates to a commit of the new series
+type RangeDiffStatus string
+
+// RangeDiffStatus possible values, as printed by git range-diff
+const (
+	RangeDiffStatusEqual    RangeDiffStatus = "="
+	RangeDiffStatusModified RangeDiffStatus = "!"
+	RangeDiffStatusRemoved  RangeDiffStatus = "<"
+	RangeDiffStatusAdded    RangeDiffStatus = ">"
+)
+
+// RangeDiffPair is a commit of the old series paired with a commit of the new series.
+// One of the commits is missing if the commit was removed or added.
+type RangeDiffPair struct {
+	Status      RangeDiffStatus
+	OldIndex    int // 1-based position in the old series, 0 if the commit was added
+	OldCommitID string
+	NewIndex    int // 1-based position in the new series, 0 if the commit was removed
+	NewCommitID string
+	Subject     string
+	// Lines is the diff between the patches of the paired commits, each line starting with the marker of
+	// the outer diff followed by the line of the patch
+	Lines []*DiffLine
+}
+
+// IsEqual returns true if both commits introduce the same changes
+func (p *RangeDiffPair) IsEqual() bool {
+	return p.Status == RangeDiffStatusEqual
+}
+
+// IsModified returns true if the changes of the commits differ
+func (p *RangeDiffPair) IsModified() bool {
+	return p.Status == RangeDiffStatusModified
+}
+
+// IsRemoved returns true if the commit of the old series has no counterpart in the new series
+func (p *RangeDiffPair) IsRemoved() bool {
+	return p.Status == RangeDiffStatusRemoved
+}
+
+// IsAdded returns true if the commit of the new series has no counterpart in the old series
+func (p *RangeDiffPair) IsAdded() bool {
+	return p.Status == RangeDiffStatusAdded
+}
+
+// RangeDiff is the comparison of two series of commits based on the same commit, like the head of a
+// pull request before and after a rebase
+type RangeDiff struct {
+	BaseCommitID string
+	OldCommitID  string
+	NewCommitID  string
+	Pairs        []*RangeDiffPair
+}
+
+// ErrRangeDiffTooLarge represents an error if a series of a range-diff has too many commits
+type ErrRangeDiffTooLarge struct {
+	NumCommits int
+}
+
+// IsErrRangeDiffTooLarge checks if an error is a ErrRangeDiffTooLarge.
+func IsErrRangeDiffTooLarge(err error) bool {
+	_, ok := err.(ErrRangeDiffTooLarge)
+	return ok
+}
+
+func (err ErrRangeDiffTooLarge) Error() string {
+	return fmt.Sprintf("too many commits for a range-diff [num_commits: %d, max: %d]", err.NumCommits, MaxRangeDiffCommits)
+}
+
+func (err ErrRangeDiffTooLarge) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// GetRangeDiff compares the commits between baseCommitID and oldCommitID with the commits between
+// baseCommitID and newCommitID, pairing the commits which introduce similar changes
+func GetRangeDiff(ctx context.Context, gitRepo *git.Repository, baseCommitID, oldCommitID, newCommitID string) (*RangeDiff, error) {
+	rangeDiff := &RangeDiff{}
+	for _, commitID := range []*string{&baseCommitID, &oldCommitID, &newCommitID} {
+		commit, err := gitRepo.GetCommit(*commitID)
+		if err != nil {
+			return nil, err
+		}
+		*commitID = commit.ID.String()
+	}
+	rangeDiff.BaseCommitID, rangeDiff.OldCommitID, rangeDiff.NewCommitID = baseCommitID, oldCommitID, newCommitID
+
+	oldCommitIDs, err := getRangeDiffSeries(ctx, gitRepo, baseCommitID, oldCommitID)
+	if err != nil {
+		return nil, err
+	}
+	newCommitIDs, err := getRangeDiffSeries(ctx, gitRepo, baseCommitID, newCommitID)
+	if err != nil {
+		return nil, err
+	}
+	if len(oldCommitIDs) == 0 && len(newCommitIDs) == 0 {
+		return rangeDiff, nil
+	}
+
+	stdout, _, err := git.NewCommand(ctx, "range-diff", "--no-color").
+		AddDynamicArguments(baseCommitID, oldCommitID, newCommitID).
+		RunStdString(&git.RunOpts{
+			Dir:     gitRepo.Path,
+			Timeout: time.Duration(setting.Git.Timeout.Default) * time.Second,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("git range-diff %s %s %s: %w", baseCommitID, oldCommitID, newCommitID, err)
+	}
+
+	rangeDiff.Pairs, err = parseRangeDiff(stdout, oldCommitIDs, newCommitIDs)
+	if err != nil {
+		return nil, err
+	}
+	return rangeDiff, nil
+}
+
+// getRangeDiffSeries returns the IDs of the commits between base and head, from the oldest to the newest
+func getRangeDiffSeries(ctx context.Context, gitRepo *git.Repository, baseCommitID, headCommitID string) ([]string, error) {
+	stdout, _, err := git.NewCommand(ctx, "rev-list", "--reverse").
+		AddDynamicArguments(baseCommitID + ".." + headCommitID).
+		RunStdString(&git.RunOpts{Dir: gitRepo.Path})
+	if err != nil {
+		return nil, err
+	}
+	commitIDs := strings.Fields(stdout)
+	if len(commitIDs) > MaxRangeDiffCommits {
+		return nil, ErrRangeDiffTooLarge{NumCommits: len(commitIDs)}
+	}
+	return commitIDs, nil
+}
+
+// rangeDiffPairPattern matches the header of a pair, e.g. "1:  0123abc ! 1:  4567def Commit subject".
+// The positions are padded to the width of the largest one, e.g. " 1:  0123abc <  -:  ------- Commit subject".
+var rangeDiffPairPattern = regexp.MustCompile(`^\s*(-|\d+):\s+\S+ ([=!<>])\s+(-|\d+):\s+\S+ (.*)$`)
+
+// parseRangeDiff parses the output of git range-diff. The abbreviated commit IDs of the output are
+// replaced with the full IDs of the commits of both series.
+func parseRangeDiff(output string, oldCommitIDs, newCommitIDs []string) ([]*RangeDiffPair, error) {
+	var pairs []*RangeDiffPair
+	var current *RangeDiffPair
+
+	for _, line := range strings.Split(output, "\n") {
+		// the diff of the patches is indented by four spaces, the padding of the headers is shorter
+		isPatchLine := strings.HasPrefix(line, "    ")
+		if m := rangeDiffPairPattern.FindStringSubmatch(line); m != nil && !isPatchLine {
+			current = &RangeDiffPair{Status: RangeDiffStatus(m[2]), Subject: m[4]}
+			var err error
+			if current.OldIndex, current.OldCommitID, err = rangeDiffCommit(m[1], oldCommitIDs); err != nil {
+				return nil, err
+			}
+			if current.NewIndex, current.NewCommitID, err = rangeDiffCommit(m[3], newCommitIDs); err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, current)
+			continue
+		}
+
+		if current == nil || !isPatchLine {
+			continue
+		}
+		line = line[4:]
+		diffLine := &DiffLine{Type: DiffLinePlain, Content: line}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			diffLine.Type = DiffLineSection
+		case strings.HasPrefix(line, "+"):
+			diffLine.Type = DiffLineAdd
+		case strings.HasPrefix(line, "-"):
+			diffLine.Type = DiffLineDel
+		}
+		current.Lines = append(current.Lines, diffLine)
+	}
+	return pairs, nil
+}
+
+// rangeDiffCommit returns the position and the ID of a commit of a series from its position as printed by git range-diff
+func rangeDiffCommit(position string, commitIDs []string) (int, string, error) {
+	if position == "-" {
+		return 0, "", nil
+	}
+	index, err := strconv.Atoi(position)
+	if err != nil {
+		return 0, "", err
+	}
+	if index < 1 || index > len(commitIDs) {
+		return 0, "", fmt.Errorf("unexpected commit position %d in a series of %d commits", index, len(commitIDs))
+	}
+	return index, commitIDs[index-1], nil
+}
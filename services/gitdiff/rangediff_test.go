@@ -0,0 +1,162 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/modules/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRangeDiff(t *testing.T) {
+	output := strings.Join([]string{
+		"1:  6a405a0 = 1:  6a405a0 add b",
+		"2:  f64b895 ! 2:  56fbd4a add g",
+		"    @@ Metadata",
+		"     Author: a <a@b>",
+		"     ",
+		"      ## Commit message ##",
+		"    -    add g",
+		"    +    add g, v2",
+		"     ",
+		"      ## g (new) ##",
+		"     @@",
+		"     +c",
+		"    -+line3",
+		"    ++line3 changed",
+		"3:  0123456 < -:  ------- removed",
+		"-:  ------- > 3:  89abcde added",
+		"",
+	}, "\n")
+	oldCommitIDs := []string{"6a405a0a", "f64b895a", "0123456a"}
+	newCommitIDs := []string{"6a405a0a", "56fbd4aa", "89abcdea"}
+
+	pairs, err := parseRangeDiff(output, oldCommitIDs, newCommitIDs)
+	require.NoError(t, err)
+	require.Len(t, pairs, 4)
+
+	assert.True(t, pairs[0].IsEqual())
+	assert.Equal(t, 1, pairs[0].OldIndex)
+	assert.Equal(t, "6a405a0a", pairs[0].OldCommitID)
+	assert.Equal(t, "6a405a0a", pairs[0].NewCommitID)
+	assert.Equal(t, "add b", pairs[0].Subject)
+	assert.Empty(t, pairs[0].Lines)
+
+	assert.True(t, pairs[1].IsModified())
+	assert.Equal(t, "f64b895a", pairs[1].OldCommitID)
+	assert.Equal(t, "56fbd4aa", pairs[1].NewCommitID)
+	require.Len(t, pairs[1].Lines, 12)
+	assert.Equal(t, &DiffLine{Type: DiffLineSection, Content: "@@ Metadata"}, pairs[1].Lines[0])
+	assert.Equal(t, &DiffLine{Type: DiffLineDel, Content: "-    add g"}, pairs[1].Lines[4])
+	assert.Equal(t, &DiffLine{Type: DiffLineAdd, Content: "+    add g, v2"}, pairs[1].Lines[5])
+	assert.Equal(t, &DiffLine{Type: DiffLinePlain, Content: " @@"}, pairs[1].Lines[8])
+	assert.Equal(t, &DiffLine{Type: DiffLineAdd, Content: "++line3 changed"}, pairs[1].Lines[11])
+
+	assert.True(t, pairs[2].IsRemoved())
+	assert.Equal(t, 3, pairs[2].OldIndex)
+	assert.Equal(t, 0, pairs[2].NewIndex)
+	assert.Empty(t, pairs[2].NewCommitID)
+
+	assert.True(t, pairs[3].IsAdded())
+	assert.Equal(t, 0, pairs[3].OldIndex)
+	assert.Equal(t, "89abcdea", pairs[3].NewCommitID)
+	assert.Equal(t, "added", pairs[3].Subject)
+
+	t.Run("Unknown commit", func(t *testing.T) {
+		_, err := parseRangeDiff(output, oldCommitIDs[:1], newCommitIDs)
+		require.Error(t, err)
+	})
+}
+
+func TestGetRangeDiffLongSeries(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, git.InitRepository(t.Context(), repoPath, false, git.Sha1ObjectFormat.Name()))
+
+	run := func(cmd *git.Command) string {
+		stdout, _, err := cmd.RunStdString(&git.RunOpts{Dir: repoPath})
+		require.NoError(t, err)
+		return strings.TrimSpace(stdout)
+	}
+	commit := func(name, content, message string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o644))
+		require.NoError(t, git.AddChanges(repoPath, true))
+		require.NoError(t, git.CommitChanges(repoPath, git.CommitChangesOptions{
+			Committer: &git.Signature{Name: "User Two", Email: "user2@example.com", When: time.Now()},
+			Message:   message,
+		}))
+		return run(git.NewCommand(t.Context(), "rev-parse", "HEAD"))
+	}
+
+	baseCommitID := commit("README.md", "base\n", "base")
+
+	// the old series has 11 commits, so git pads the positions of the range-diff to two characters
+	run(git.NewCommand(t.Context(), "checkout", "-q", "-b").AddDynamicArguments("old", baseCommitID))
+	oldCommitIDs := make([]string, 0, 11)
+	for i := 1; i <= 11; i++ {
+		oldCommitIDs = append(oldCommitIDs, commit(fmt.Sprintf("c%d.txt", i), fmt.Sprintf("line %d\n", i), fmt.Sprintf("c%d", i)))
+	}
+
+	// the new series drops c1, rewords c5 and adds c12
+	run(git.NewCommand(t.Context(), "checkout", "-q", "-b").AddDynamicArguments("new", baseCommitID))
+	newCommitIDs := make([]string, 0, 11)
+	for i := 2; i <= 12; i++ {
+		message := fmt.Sprintf("c%d", i)
+		if i == 5 {
+			message = "c5\n\nExplain c5"
+		}
+		newCommitIDs = append(newCommitIDs, commit(fmt.Sprintf("c%d.txt", i), fmt.Sprintf("line %d\n", i), message))
+	}
+
+	gitRepo, err := git.OpenRepository(t.Context(), repoPath)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	rangeDiff, err := GetRangeDiff(t.Context(), gitRepo, baseCommitID, "old", "new")
+	require.NoError(t, err)
+	assert.Equal(t, oldCommitIDs[10], rangeDiff.OldCommitID)
+	assert.Equal(t, newCommitIDs[10], rangeDiff.NewCommitID)
+	require.Len(t, rangeDiff.Pairs, 12)
+
+	pairs := make(map[string]*RangeDiffPair, len(rangeDiff.Pairs))
+	for _, pair := range rangeDiff.Pairs {
+		pairs[pair.Subject] = pair
+	}
+
+	if assert.Contains(t, pairs, "c1") {
+		assert.True(t, pairs["c1"].IsRemoved())
+		assert.Equal(t, 1, pairs["c1"].OldIndex)
+		assert.Equal(t, oldCommitIDs[0], pairs["c1"].OldCommitID)
+		assert.Equal(t, 0, pairs["c1"].NewIndex)
+	}
+	if assert.Contains(t, pairs, "c5") {
+		assert.True(t, pairs["c5"].IsModified())
+		assert.Equal(t, 5, pairs["c5"].OldIndex)
+		assert.Equal(t, oldCommitIDs[4], pairs["c5"].OldCommitID)
+		assert.Equal(t, 4, pairs["c5"].NewIndex)
+		assert.Equal(t, newCommitIDs[3], pairs["c5"].NewCommitID)
+		assert.Contains(t, pairs["c5"].Lines, &DiffLine{Type: DiffLineAdd, Content: "+    Explain c5"})
+	}
+	if assert.Contains(t, pairs, "c11") {
+		assert.True(t, pairs["c11"].IsEqual())
+		assert.Equal(t, 11, pairs["c11"].OldIndex)
+		assert.Equal(t, oldCommitIDs[10], pairs["c11"].OldCommitID)
+		assert.Equal(t, 10, pairs["c11"].NewIndex)
+		assert.Equal(t, newCommitIDs[9], pairs["c11"].NewCommitID)
+		assert.Empty(t, pairs["c11"].Lines)
+	}
+	if assert.Contains(t, pairs, "c12") {
+		assert.True(t, pairs["c12"].IsAdded())
+		assert.Equal(t, 0, pairs["c12"].OldIndex)
+		assert.Equal(t, 11, pairs["c12"].NewIndex)
+		assert.Equal(t, newCommitIDs[10], pairs["c12"].NewCommitID)
+	}
+}
@@ -153,6 +153,14 @@ func (r *indexerNotifier) IssueClearLabels(ctx context.Context, doer *user_model
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
 
+func (r *indexerNotifier) IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.IssueCustomField, oldValues []string) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
+func (r *indexerNotifier) IssueTransferred(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
 func (r *indexerNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 	if err := pr.LoadIssue(ctx); err != nil {
 		log.Error("LoadIssue: %v", err)
@@ -227,6 +227,19 @@ func TeamReviewRequest(ctx context.Context, issue *issues_model.Issue, doer *use
 		return nil, nil
 	}
 
+	if reviewer.HasReviewAssignment() {
+		notifiers, err := assignTeamReviewers(ctx, issue, doer, reviewer)
+		if err != nil {
+			return nil, err
+		}
+		for _, notifier := range notifiers {
+			if notifier.Comment != nil && doer.ID != notifier.Reviewer.ID {
+				notify_service.PullRequestReviewRequest(ctx, doer, issue, notifier.Reviewer, true, notifier.Comment)
+			}
+		}
+		return comment, nil
+	}
+
 	return comment, teamReviewRequestNotify(ctx, issue, doer, reviewer, isAdd, comment)
 }
 
@@ -244,6 +257,11 @@ func ReviewRequestNotify(ctx context.Context, issue *issues_model.Issue, doer *u
 
 // teamReviewRequestNotify notify all user in this team
 func teamReviewRequestNotify(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, reviewer *organization.Team, isAdd bool, comment *issues_model.Comment) error {
+	// the members picked by the review assignment of the team are notified of their own review requests
+	if reviewer.HasReviewAssignment() {
+		return nil
+	}
+
 	// notify all user in this team
 	if err := comment.LoadIssue(ctx); err != nil {
 		return err
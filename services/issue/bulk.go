@@ -0,0 +1,286 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// BulkOperationType is the kind of change applied by a bulk operation
+type BulkOperationType string
+
+const (
+	BulkAddLabels         BulkOperationType = "add_labels"
+	BulkRemoveLabels      BulkOperationType = "remove_labels"
+	BulkClose             BulkOperationType = "close"
+	BulkReopen            BulkOperationType = "reopen"
+	BulkSetMilestone      BulkOperationType = "set_milestone"
+	BulkAddAssignees      BulkOperationType = "add_assignees"
+	BulkRemoveAssignees   BulkOperationType = "remove_assignees"
+	BulkMoveProjectColumn BulkOperationType = "move_project_column"
+	BulkTransfer          BulkOperationType = "transfer"
+)
+
+// IsValid returns true if the operation type is known
+func (t BulkOperationType) IsValid() bool {
+	switch t {
+	case BulkAddLabels, BulkRemoveLabels, BulkClose, BulkReopen, BulkSetMilestone,
+		BulkAddAssignees, BulkRemoveAssignees, BulkMoveProjectColumn, BulkTransfer:
+		return true
+	}
+	return false
+}
+
+// BulkOperation is a change applied to every issue of a bulk edit.
+// The labels, milestone, assignees and column must already have been resolved for the repository of the issues,
+// so a transfer must be the last operation.
+type BulkOperation struct {
+	Type        BulkOperationType
+	Labels      []*issues_model.Label
+	MilestoneID int64
+	Assignees   []*user_model.User
+	Column      *project_model.Column
+	Repo        *repo_model.Repository // the repository to transfer the issues to
+}
+
+// BulkResult is the outcome of a bulk edit for one issue
+type BulkResult struct {
+	Issue   *issues_model.Issue
+	Changed bool
+	Err     error
+}
+
+// ErrBulkRolledBack is reported for the issues of an atomic bulk edit whose changes were rolled back because another issue failed
+var ErrBulkRolledBack = errors.New("rolled back because another issue failed")
+
+// ApplyBulkOperations applies the operations in order to each issue. The changes of an issue are applied in a transaction,
+// so an issue is either fully changed or left as is. If atomic is set, all the issues are changed in a single transaction
+// and the first failure rolls back the changes of all the issues.
+// The notifications are sent once the changes are committed, as if the issues had been changed one by one.
+func ApplyBulkOperations(ctx context.Context, doer *user_model.User, issues []*issues_model.Issue, ops []*BulkOperation, atomic bool) []*BulkResult {
+	results := make([]*BulkResult, len(issues))
+	notifications := make([][]func(context.Context), len(issues))
+
+	applyIssue := func(ctx context.Context, i int) error {
+		results[i] = &BulkResult{Issue: issues[i]}
+		return db.WithTx(ctx, func(ctx context.Context) error {
+			notifications[i] = notifications[i][:0]
+			results[i].Changed = false
+			for _, op := range ops {
+				changed, notify, err := applyBulkOperation(ctx, doer, issues[i], op)
+				if err != nil {
+					return fmt.Errorf("%s: %w", op.Type, err)
+				}
+				if changed {
+					results[i].Changed = true
+				}
+				if notify != nil {
+					notifications[i] = append(notifications[i], notify)
+				}
+			}
+			return nil
+		})
+	}
+
+	if atomic {
+		err := db.WithTx(ctx, func(ctx context.Context) error {
+			for i := range issues {
+				if err := applyIssue(ctx, i); err != nil {
+					results[i].Err = err
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for i, result := range results {
+				if result == nil {
+					results[i] = &BulkResult{Issue: issues[i], Err: ErrBulkRolledBack}
+				} else if result.Err == nil {
+					result.Err = ErrBulkRolledBack
+				}
+				results[i].Changed = false
+			}
+			return results
+		}
+	} else {
+		for i := range issues {
+			if err := applyIssue(ctx, i); err != nil {
+				results[i].Err = err
+				results[i].Changed = false
+				notifications[i] = nil
+			}
+		}
+	}
+
+	for _, notifyIssue := range notifications {
+		for _, notify := range notifyIssue {
+			notify(ctx)
+		}
+	}
+	return results
+}
+
+// applyBulkOperation applies an operation to an issue and returns whether the issue changed,
+// along with the notification to send once the change is committed
+func applyBulkOperation(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, op *BulkOperation) (bool, func(context.Context), error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, nil, err
+	}
+
+	switch op.Type {
+	case BulkAddLabels:
+		added := make([]*issues_model.Label, 0, len(op.Labels))
+		for _, label := range op.Labels {
+			if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+				added = append(added, label)
+			}
+		}
+		if len(added) == 0 {
+			return false, nil, nil
+		}
+		if err := issues_model.NewIssueLabels(ctx, issue, added, doer); err != nil {
+			return false, nil, err
+		}
+		return true, func(ctx context.Context) {
+			notify_service.IssueChangeLabels(ctx, doer, issue, added, nil)
+		}, nil
+
+	case BulkRemoveLabels:
+		removed := make([]*issues_model.Label, 0, len(op.Labels))
+		for _, label := range op.Labels {
+			if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+				continue
+			}
+			if err := issues_model.DeleteIssueLabel(ctx, issue, label, doer); err != nil {
+				return false, nil, err
+			}
+			removed = append(removed, label)
+		}
+		if len(removed) == 0 {
+			return false, nil, nil
+		}
+		return true, func(ctx context.Context) {
+			notify_service.IssueChangeLabels(ctx, doer, issue, nil, removed)
+		}, nil
+
+	case BulkClose, BulkReopen:
+		isClosed := op.Type == BulkClose
+		if issue.IsClosed == isClosed {
+			return false, nil, nil
+		}
+		if issue.IsPull {
+			if err := issue.LoadPullRequest(ctx); err != nil {
+				return false, nil, err
+			}
+			if issue.PullRequest.HasMerged {
+				return false, nil, util.NewInvalidArgumentErrorf("the state of a merged pull request can not be changed")
+			}
+		}
+		comment, err := issues_model.ChangeIssueStatus(ctx, issue, doer, isClosed)
+		if err != nil {
+			return false, nil, err
+		}
+		if isClosed {
+			if err := issues_model.FinishIssueStopwatchIfPossible(ctx, doer, issue); err != nil {
+				return false, nil, err
+			}
+		}
+		return true, func(ctx context.Context) {
+			notify_service.IssueChangeStatus(ctx, doer, "", issue, comment, isClosed)
+		}, nil
+
+	case BulkSetMilestone:
+		oldMilestoneID := issue.MilestoneID
+		if oldMilestoneID == op.MilestoneID {
+			return false, nil, nil
+		}
+		issue.MilestoneID = op.MilestoneID
+		if err := changeMilestoneAssign(ctx, doer, issue, oldMilestoneID); err != nil {
+			issue.MilestoneID = oldMilestoneID
+			return false, nil, err
+		}
+		return true, func(ctx context.Context) {
+			notify_service.IssueChangeMilestone(ctx, doer, issue, oldMilestoneID)
+		}, nil
+
+	case BulkAddAssignees, BulkRemoveAssignees:
+		add := op.Type == BulkAddAssignees
+		var notifications []func(context.Context)
+		for _, assignee := range op.Assignees {
+			isAssigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, assignee)
+			if err != nil {
+				return false, nil, err
+			}
+			if isAssigned == add {
+				continue
+			}
+			if add {
+				valid, err := access_model.CanBeAssigned(ctx, assignee, issue.Repo, issue.IsPull)
+				if err != nil {
+					return false, nil, err
+				}
+				if !valid {
+					return false, nil, repo_model.ErrUserDoesNotHaveAccessToRepo{UserID: assignee.ID, RepoName: issue.Repo.Name}
+				}
+			}
+			removed, comment, err := issues_model.ToggleIssueAssignee(ctx, issue, doer, assignee.ID)
+			if err != nil {
+				return false, nil, err
+			}
+			notifications = append(notifications, func(ctx context.Context) {
+				notify_service.IssueChangeAssignee(ctx, doer, issue, assignee, removed, comment)
+			})
+		}
+		if len(notifications) == 0 {
+			return false, nil, nil
+		}
+		return true, func(ctx context.Context) {
+			for _, notify := range notifications {
+				notify(ctx)
+			}
+		}, nil
+
+	case BulkMoveProjectColumn:
+		issue.Project = nil
+		if err := issue.LoadProject(ctx); err != nil {
+			return false, nil, err
+		}
+		if issue.Project == nil || issue.Project.ID != op.Column.ProjectID {
+			if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, op.Column.ProjectID, op.Column.ID); err != nil {
+				return false, nil, err
+			}
+			return true, nil, nil
+		}
+		if issue.ProjectColumnID(ctx) == op.Column.ID {
+			return false, nil, nil
+		}
+		if err := issues_model.MoveIssueToProjectColumn(ctx, issue, doer, op.Column); err != nil {
+			return false, nil, err
+		}
+		return true, nil, nil
+
+	case BulkTransfer:
+		oldRepo, oldIndex, err := transferIssue(ctx, doer, issue, op.Repo)
+		if err != nil {
+			return false, nil, err
+		}
+		return true, func(ctx context.Context) {
+			notify_service.IssueTransferred(ctx, doer, issue, oldRepo, oldIndex)
+		}, nil
+	}
+
+	return false, nil, util.NewInvalidArgumentErrorf("unknown bulk operation %q", op.Type)
+}
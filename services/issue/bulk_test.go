@@ -0,0 +1,98 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBulkOperations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+	issues := []*issues_model.Issue{
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}),
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5}), // already has the label, and is closed
+	}
+	ops := []*BulkOperation{
+		{Type: BulkAddLabels, Labels: []*issues_model.Label{label}},
+		{Type: BulkClose},
+	}
+
+	results := ApplyBulkOperations(db.DefaultContext, doer, issues, ops, false)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.True(t, results[0].Changed)
+	require.NoError(t, results[1].Err)
+	assert.False(t, results[1].Changed)
+
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}).IsClosed)
+}
+
+func TestApplyBulkOperationsMoveProjectColumn(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	column := unittest.AssertExistsAndLoadBean(t, &project_model.Column{ID: 2})
+	issues := []*issues_model.Issue{
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}), // in the "To Do" column of project 1
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3}), // already in the "In Progress" column
+	}
+	ops := []*BulkOperation{{Type: BulkMoveProjectColumn, Column: column}}
+
+	results := ApplyBulkOperations(db.DefaultContext, doer, issues, ops, false)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.True(t, results[0].Changed)
+	require.NoError(t, results[1].Err)
+	assert.False(t, results[1].Changed)
+
+	unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 2})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+		Type:      issues_model.CommentTypeProjectColumn,
+		IssueID:   1,
+		PosterID:  doer.ID,
+		ProjectID: 1,
+		OldTitle:  "To Do",
+		NewTitle:  "In Progress",
+	})
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{Type: issues_model.CommentTypeProjectColumn, IssueID: 3})
+}
+
+func TestApplyBulkOperationsAtomic(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+	issues := []*issues_model.Issue{
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}),
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5}),
+	}
+	ops := []*BulkOperation{
+		{Type: BulkAddLabels, Labels: []*issues_model.Label{label}},
+		{Type: BulkSetMilestone, MilestoneID: 4}, // milestone of another repository
+	}
+
+	results := ApplyBulkOperations(db.DefaultContext, doer, issues, ops, true)
+	require.Len(t, results, 2)
+	require.Error(t, results[0].Err)
+	assert.NotErrorIs(t, results[0].Err, ErrBulkRolledBack)
+	assert.False(t, results[0].Changed)
+	require.ErrorIs(t, results[1].Err, ErrBulkRolledBack)
+	assert.False(t, results[1].Changed)
+
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+	assert.EqualValues(t, 0, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}).MilestoneID)
+}
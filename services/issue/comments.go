@@ -73,6 +73,31 @@ func CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_m
 	return comment, nil
 }
 
+// CreateExternalReporterComment comments on an issue on behalf of its external reporter, the comment is posted by
+// the ghost user. The mentions of the comment are ignored, the reporter isn't a user.
+func CreateExternalReporterComment(ctx context.Context, reporter *issues_model.ExternalReporter, issue *issues_model.Issue, content string, attachments []string) (*issues_model.Comment, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	doer := user_model.NewGhostUser()
+	comment, err := issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
+		Type:           issues_model.CommentTypeComment,
+		Doer:           doer,
+		Repo:           issue.Repo,
+		Issue:          issue,
+		Content:        content,
+		Attachments:    attachments,
+		OriginalAuthor: reporter.DisplayName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.CreateIssueComment(ctx, doer, issue.Repo, issue, comment, nil)
+
+	return comment, nil
+}
+
 // UpdateComment updates information of comment.
 func UpdateComment(ctx context.Context, c *issues_model.Comment, contentVersion int, doer *user_model.User, oldContent string) error {
 	if err := c.LoadReview(ctx); err != nil {
@@ -85,6 +85,11 @@ func issueAddTime(ctx context.Context, issue *issues_model.Issue, doer *user_mod
 	}
 
 	_, err := issues_model.AddTime(ctx, doer, issue, amount, time)
+	if issues_model.IsErrTrackedTimeLocked(err) {
+		// the commit is older than the lock period, its time can't be tracked anymore
+		log.Debug("Ignoring the time logged by a commit on the locked period of issue %d", issue.ID)
+		return nil
+	}
 	return err
 }
 
@@ -0,0 +1,161 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	notify_service "forgejo.org/services/notify"
+)
+
+// CustomFieldValues are the validated values of a custom field, ready to be stored
+type CustomFieldValues struct {
+	Field  *issues_model.IssueCustomField
+	Values []string
+}
+
+// ResolveCustomFieldValues validates values given by the name of the custom field they are set on.
+// The values of user fields are user names.
+func ResolveCustomFieldValues(ctx context.Context, repo *repo_model.Repository, byName map[string][]string) ([]*CustomFieldValues, error) {
+	if len(byName) == 0 {
+		return nil, nil
+	}
+
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*CustomFieldValues, 0, len(byName))
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		field := findCustomField(fields, name)
+		if field == nil {
+			return nil, issues_model.ErrIssueCustomFieldNotExist{Name: name}
+		}
+		values, err := resolveCustomFieldValues(ctx, field, byName[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &CustomFieldValues{Field: field, Values: values})
+	}
+	return result, nil
+}
+
+// ParseCustomFieldFilters parses the filters of an issue search on custom fields, each given as "name:value".
+// The values of user fields are user names.
+func ParseCustomFieldFilters(ctx context.Context, repo *repo_model.Repository, filters []string) ([]issues_model.IssueCustomFieldFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]issues_model.IssueCustomFieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		name, value, ok := strings.Cut(filter, ":")
+		if !ok {
+			return nil, issues_model.ErrInvalidIssueCustomFieldValue{Field: filter}
+		}
+		field := findCustomField(fields, strings.TrimSpace(name))
+		if field == nil {
+			return nil, issues_model.ErrIssueCustomFieldNotExist{Name: name}
+		}
+		values, err := resolveCustomFieldValues(ctx, field, []string{value})
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, issues_model.ErrInvalidIssueCustomFieldValue{Field: field.Name, Value: value}
+		}
+		result = append(result, issues_model.IssueCustomFieldFilter{FieldID: field.ID, Value: values[0]})
+	}
+	return result, nil
+}
+
+func findCustomField(fields []*issues_model.IssueCustomField, name string) *issues_model.IssueCustomField {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return field
+		}
+	}
+	return nil
+}
+
+// resolveCustomFieldValues replaces user names by IDs for user fields and normalizes the values
+func resolveCustomFieldValues(ctx context.Context, field *issues_model.IssueCustomField, values []string) ([]string, error) {
+	if field.Type == issues_model.IssueCustomFieldTypeUser {
+		ids := make([]string, 0, len(values))
+		for _, name := range values {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			u, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					return nil, issues_model.ErrInvalidIssueCustomFieldValue{Field: field.Name, Value: name}
+				}
+				return nil, err
+			}
+			ids = append(ids, strconv.FormatInt(u.ID, 10))
+		}
+		values = ids
+	}
+	return field.NormalizeValues(values)
+}
+
+// SetCustomFieldValues sets the values of a custom field on an issue, an empty list clears the field.
+// The values of user fields are user names.
+func SetCustomFieldValues(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, field *issues_model.IssueCustomField, values []string) error {
+	values, err := resolveCustomFieldValues(ctx, field, values)
+	if err != nil {
+		return err
+	}
+
+	entries, err := issues_model.GetIssueCustomFieldEntries(ctx, issue)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(entries, func(e *issues_model.IssueCustomFieldEntry) bool {
+		return e.Field.ID == field.ID
+	})
+	if idx < 0 {
+		// The field belongs to another repository or organization
+		return issues_model.ErrIssueCustomFieldNotExist{ID: field.ID, Name: field.Name}
+	}
+	if slices.Equal(entries[idx].Values, values) {
+		return nil
+	}
+	oldValues := entries[idx].DisplayValues()
+
+	if err := issues_model.SetIssueCustomFieldValues(ctx, issue.ID, field, values); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeCustomField(ctx, doer, issue, field, oldValues)
+	return nil
+}
+
+// DeleteCustomField deletes a custom field along with its values and queues the issues which had a value
+// for it to be indexed again, so that they are no longer found by the values of the field
+func DeleteCustomField(ctx context.Context, field *issues_model.IssueCustomField) error {
+	issueIDs, err := issues_model.DeleteIssueCustomField(ctx, field)
+	if err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, issueID)
+	}
+	return nil
+}
@@ -23,14 +23,30 @@ import (
 	notify_service "forgejo.org/services/notify"
 )
 
-// NewIssue creates new issue with labels for repository.
-func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, assigneeIDs []int64) error {
+// NewIssue creates new issue with labels, custom field values and the values of the form template
+// it was created from for repository.
+func NewIssue(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, assigneeIDs []int64, customFields []*CustomFieldValues, formValues *issues_model.IssueFormValues) error {
 	// Check if the user is not blocked by the repo's owner.
 	if user_model.IsBlocked(ctx, repo.OwnerID, issue.PosterID) {
 		return user_model.ErrBlockedByUser
 	}
 
-	if err := issues_model.NewIssue(ctx, repo, issue, labelIDs, uuids); err != nil {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := issues_model.NewIssue(ctx, repo, issue, labelIDs, uuids); err != nil {
+			return err
+		}
+
+		for _, cf := range customFields {
+			if len(cf.Values) == 0 {
+				continue
+			}
+			if err := issues_model.SetIssueCustomFieldValues(ctx, issue.ID, cf.Field, cf.Values); err != nil {
+				return err
+			}
+		}
+
+		return SaveTemplateFormValues(ctx, issue, formValues)
+	}); err != nil {
 		return err
 	}
 
@@ -298,6 +314,12 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.IssueWatch{IssueID: issue.ID},
 		&issues_model.Stopwatch{IssueID: issue.ID},
 		&issues_model.TrackedTime{IssueID: issue.ID},
+		&issues_model.IssueCustomFieldValue{IssueID: issue.ID},
+		&issues_model.IssueRedirect{IssueID: issue.ID},
+		&issues_model.IssueFormValues{IssueID: issue.ID},
+		&issues_model.ExternalReporter{IssueID: issue.ID},
+		&issues_model.SubIssue{IssueID: issue.ID},
+		&issues_model.SubIssue{ParentID: issue.ID},
 		&project_model.ProjectIssue{IssueID: issue.ID},
 		&repo_model.Attachment{IssueID: issue.ID},
 		&issues_model.PullRequest{IssueID: issue.ID},
@@ -11,6 +11,7 @@ import (
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,3 +86,22 @@ func TestIssue_DeleteIssue(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, left)
 }
+
+func TestNewIssueFormValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue := &issues_model.Issue{RepoID: repo.ID, Repo: repo, Title: "Bug", PosterID: user.ID, Poster: user}
+	formValues := &issues_model.IssueFormValues{
+		Template: ".forgejo/issue_template/bug.yaml",
+		Fields:   []*api.IssueFormFieldValue{{ID: "version", Type: api.IssueFormFieldTypeInput, Label: "Version", Values: []string{"1.0"}}},
+	}
+	require.NoError(t, NewIssue(db.DefaultContext, repo, issue, nil, nil, nil, nil, formValues))
+
+	stored, err := issues_model.GetIssueFormValues(db.DefaultContext, issue.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, ".forgejo/issue_template/bug.yaml", stored.Template)
+	assert.Equal(t, formValues.Fields, stored.Fields)
+}
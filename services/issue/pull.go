@@ -147,6 +147,15 @@ func PullRequestCodeOwnersReview(ctx context.Context, issue *issues_model.Issue,
 			IsAdd:      true,
 			ReviewTeam: t,
 		})
+		if comment == nil {
+			continue
+		}
+		assigned, err := assignTeamReviewers(ctx, issue, issue.Poster, t)
+		if err != nil {
+			log.Warn("Failed to assign reviewers of team: %s to PR review: %s#%d, error: %s", t.Name, pr.BaseRepo.Name, pr.ID, err)
+			return nil, err
+		}
+		notifiers = append(notifiers, assigned...)
 	}
 
 	return notifiers, nil
@@ -0,0 +1,134 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+)
+
+// assignTeamReviewers requests reviews from the members of a team picked by the review assignment of the team,
+// when the team is requested to review a pull request. The members who already reviewed the pull request or
+// whose review is already requested, who blocked or are blocked by the poster, or who are unavailable are
+// skipped. The members are picked and the member picked last is recorded in one transaction, so that concurrent
+// requests pick the members in turn.
+func assignTeamReviewers(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, team *org_model.Team) (notifiers []*ReviewRequestNotifier, err error) {
+	if !team.HasReviewAssignment() {
+		return nil, nil
+	}
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		notifiers, err = requestTeamReviewers(ctx, issue, doer, team)
+		return err
+	})
+	return notifiers, err
+}
+
+func requestTeamReviewers(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, team *org_model.Team) ([]*ReviewRequestNotifier, error) {
+	lastReviewAssigneeID, err := org_model.LockTeamLastReviewAssignee(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	team.LastReviewAssigneeID = lastReviewAssigneeID
+
+	if err := issue.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	teamUsers, err := org_model.GetTeamUsersByTeamID(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := issues_model.GetReviewsByIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	involved := make(container.Set[int64], len(reviews)+1)
+	involved.Add(issue.PosterID)
+	for _, review := range reviews {
+		involved.Add(review.ReviewerID)
+	}
+
+	candidateIDs := make([]int64, 0, len(teamUsers))
+	for _, teamUser := range teamUsers {
+		if !teamUser.IsReviewUnavailable && !involved.Contains(teamUser.UID) {
+			candidateIDs = append(candidateIDs, teamUser.UID)
+		}
+	}
+	users, err := user_model.GetUsersByIDs(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*user_model.User, 0, len(users))
+	for _, user := range users {
+		if !user.IsActive || user.ProhibitLogin ||
+			user_model.IsBlockedMultiple(ctx, []int64{issue.PosterID, issue.Repo.OwnerID, doer.ID}, user.ID) ||
+			user_model.IsBlockedMultiple(ctx, []int64{user.ID}, issue.PosterID) {
+			continue
+		}
+		candidates = append(candidates, user)
+	}
+
+	var pendingReviewRequests map[int64]int64
+	if team.ReviewAssignmentMode == org_model.ReviewAssignmentLoadBalance {
+		candidateIDs = make([]int64, 0, len(candidates))
+		for _, candidate := range candidates {
+			candidateIDs = append(candidateIDs, candidate.ID)
+		}
+		if pendingReviewRequests, err = issues_model.CountPendingReviewRequests(ctx, candidateIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	reviewers := pickTeamReviewers(team, candidates, pendingReviewRequests)
+	if len(reviewers) == 0 {
+		return nil, nil
+	}
+
+	notifiers := make([]*ReviewRequestNotifier, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		comment, err := issues_model.AddReviewRequest(ctx, issue, reviewer, doer)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, &ReviewRequestNotifier{
+			Comment:  comment,
+			IsAdd:    true,
+			Reviewer: reviewer,
+		})
+	}
+	return notifiers, org_model.UpdateTeamLastReviewAssignee(ctx, team.ID, reviewers[len(reviewers)-1].ID)
+}
+
+// pickTeamReviewers picks reviewers among candidate members of a team according to the review assignment of the
+// team. Both modes go through the members in turn, starting after the member picked last, and the load-balanced
+// mode picks the members with the fewest pending review requests first.
+func pickTeamReviewers(team *org_model.Team, candidates []*user_model.User, pendingReviewRequests map[int64]int64) []*user_model.User {
+	count := min(max(team.ReviewAssignmentCount, 1), org_model.MaxReviewAssignmentCount)
+
+	sorted := slices.SortedFunc(slices.Values(candidates), func(a, b *user_model.User) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	next, _ := slices.BinarySearchFunc(sorted, team.LastReviewAssigneeID+1, func(user *user_model.User, id int64) int {
+		return cmp.Compare(user.ID, id)
+	})
+	ordered := slices.Concat(sorted[next:], sorted[:next])
+
+	if team.ReviewAssignmentMode == org_model.ReviewAssignmentLoadBalance {
+		slices.SortStableFunc(ordered, func(a, b *user_model.User) int {
+			return cmp.Compare(pendingReviewRequests[a.ID], pendingReviewRequests[b.ID])
+		})
+	}
+
+	return ordered[:min(count, len(ordered))]
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickTeamReviewers(t *testing.T) {
+	candidates := []*user_model.User{{ID: 5}, {ID: 2}, {ID: 8}, {ID: 4}}
+	ids := func(users []*user_model.User) []int64 {
+		result := make([]int64, 0, len(users))
+		for _, user := range users {
+			result = append(result, user.ID)
+		}
+		return result
+	}
+
+	t.Run("Round robin", func(t *testing.T) {
+		team := &org_model.Team{ReviewAssignmentMode: org_model.ReviewAssignmentRoundRobin, ReviewAssignmentCount: 2}
+		assert.Equal(t, []int64{2, 4}, ids(pickTeamReviewers(team, candidates, nil)))
+
+		team.LastReviewAssigneeID = 4
+		assert.Equal(t, []int64{5, 8}, ids(pickTeamReviewers(team, candidates, nil)))
+
+		team.LastReviewAssigneeID = 6
+		assert.Equal(t, []int64{8, 2}, ids(pickTeamReviewers(team, candidates, nil)))
+
+		team.LastReviewAssigneeID = 8
+		assert.Equal(t, []int64{2, 4}, ids(pickTeamReviewers(team, candidates, nil)))
+	})
+
+	t.Run("Load balance", func(t *testing.T) {
+		team := &org_model.Team{ReviewAssignmentMode: org_model.ReviewAssignmentLoadBalance, ReviewAssignmentCount: 2, LastReviewAssigneeID: 4}
+		pending := map[int64]int64{2: 1, 4: 0, 5: 3, 8: 1}
+		assert.Equal(t, []int64{4, 8}, ids(pickTeamReviewers(team, candidates, pending)))
+
+		assert.Equal(t, []int64{5, 8}, ids(pickTeamReviewers(team, candidates, nil)))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		team := &org_model.Team{ReviewAssignmentMode: org_model.ReviewAssignmentRoundRobin}
+		assert.Equal(t, []int64{2}, ids(pickTeamReviewers(team, candidates, nil)))
+
+		team.ReviewAssignmentCount = 20
+		assert.Equal(t, []int64{2, 4, 5, 8}, ids(pickTeamReviewers(team, candidates, nil)))
+
+		assert.Empty(t, pickTeamReviewers(team, nil, nil))
+	})
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	activities_model "forgejo.org/models/activities"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// savedSearchQueryKeys are the parameters of the overview page kept in saved searches
+var savedSearchQueryKeys = []string{"type", "state", "labels", "sort", "q"}
+
+// savedSearchIndexerDelay leaves some time to the indexer, which is updated asynchronously,
+// before checking whether the updated issues match saved searches
+const savedSearchIndexerDelay = time.Minute
+
+// savedSearchNotifyLimit is the maximum number of issues notified per subscription and run
+const savedSearchNotifyLimit = 50
+
+// SanitizeSavedSearchQuery keeps the parameters of the overview page query which define the search
+func SanitizeSavedSearchQuery(values url.Values) string {
+	query := make(url.Values, len(savedSearchQueryKeys))
+	for _, key := range savedSearchQueryKeys {
+		if value := strings.TrimSpace(values.Get(key)); value != "" {
+			query.Set(key, value)
+		}
+	}
+	return query.Encode()
+}
+
+// SavedSearchOptions builds the indexer search options of a saved search, limited to the repositories the user can access.
+// It applies the filters the same way as the issue and pull request overview pages.
+func SavedSearchOptions(ctx context.Context, search *issues_model.SavedSearch, doer *user_model.User) (*issue_indexer.SearchOptions, error) {
+	if err := search.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	query, err := url.ParseQuery(search.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	unitType := unit.TypeIssues
+	if search.IsPull {
+		unitType = unit.TypePullRequests
+	}
+
+	sortType := query.Get("sort")
+	if sortType == "" {
+		sortType = "recentupdate"
+	}
+	opts := &issues_model.IssuesOptions{
+		IsPull:     optional.Some(search.IsPull),
+		SortType:   sortType,
+		IsArchived: optional.Some(false),
+		IsClosed:   optional.Some(query.Get("state") == "closed"),
+		Org:        search.Org,
+		Team:       search.Team,
+		User:       doer,
+	}
+
+	repoOpts := &repo_model.SearchRepoOptions{
+		Actor:       doer,
+		OwnerID:     doer.ID,
+		Private:     true,
+		Collaborate: optional.None[bool](),
+		UnitType:    unitType,
+		Archived:    optional.Some(false),
+	}
+	if search.Org != nil {
+		repoOpts.OwnerID = search.Org.ID
+	}
+	if search.Team != nil {
+		repoOpts.TeamID = search.Team.ID
+	}
+	opts.RepoIDs, _, err = repo_model.SearchRepositoryIDs(ctx, repoOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.RepoIDs) == 0 {
+		// no repos found, don't let the indexer return all repos
+		opts.RepoIDs = []int64{0}
+	}
+
+	viewType := query.Get("type")
+	if viewType == "" {
+		if search.Org != nil {
+			viewType = "your_repositories"
+		} else {
+			viewType = "created_by"
+		}
+	}
+	switch viewType {
+	case "assigned":
+		opts.AssigneeID = doer.ID
+	case "mentioned":
+		opts.MentionedID = doer.ID
+	case "review_requested":
+		opts.ReviewRequestedID = doer.ID
+	case "reviewed_by":
+		opts.ReviewedID = doer.ID
+	case "your_repositories":
+	default:
+		opts.PosterID = doer.ID
+	}
+	if search.Org == nil && viewType != "your_repositories" {
+		// as on the dashboard of the user, issues of all public repositories can match
+		opts.AllPublic = true
+	}
+
+	if labels := query.Get("labels"); labels != "" && labels != "0" {
+		if opts.LabelIDs, err = base.StringsToInt64s(strings.Split(labels, ",")); err != nil {
+			return nil, err
+		}
+	}
+
+	return issue_indexer.ToSearchOptions(strings.TrimSpace(query.Get("q")), opts), nil
+}
+
+// NotifySavedSearchSubscribers creates notifications for the issues which started to match
+// the saved searches users subscribed to since the last check
+func NotifySavedSearchSubscribers(ctx context.Context) error {
+	until := timeutil.TimeStamp(time.Now().Add(-savedSearchIndexerDelay).Unix())
+	return db.Iterate(ctx, builder.Neq{"mode": issues_model.SavedSearchSubscriptionNone}, func(ctx context.Context, sub *issues_model.SavedSearchSubscription) error {
+		if err := notifySavedSearchSubscriber(ctx, sub, until); err != nil {
+			log.Error("notifySavedSearchSubscriber [subscription: %d]: %v", sub.ID, err)
+		}
+		return nil
+	})
+}
+
+func notifySavedSearchSubscriber(ctx context.Context, sub *issues_model.SavedSearchSubscription, until timeutil.TimeStamp) error {
+	if sub.LastCheckedUnix >= until {
+		return nil
+	}
+
+	search, err := issues_model.GetSavedSearchByID(ctx, sub.SavedSearchID)
+	if err != nil {
+		return err
+	}
+	subscriber, err := user_model.GetUserByID(ctx, sub.UserID)
+	if err != nil {
+		return err
+	}
+	if !subscriber.IsActive || subscriber.ProhibitLogin {
+		return nil
+	}
+	if visible, err := search.IsVisibleTo(ctx, subscriber.ID); err != nil {
+		return err
+	} else if !visible {
+		// the subscriber left the organization the search is shared with
+		return nil
+	}
+
+	opts, err := SavedSearchOptions(ctx, search, subscriber)
+	if err != nil {
+		return err
+	}
+	opts = opts.Copy(func(o *issue_indexer.SearchOptions) {
+		o.UpdatedAfterUnix = optional.Some(int64(sub.LastCheckedUnix) + 1)
+		o.UpdatedBeforeUnix = optional.Some(int64(until))
+		o.SortBy = issue_indexer.SortByUpdatedAsc
+		o.Paginator = &db.ListOptions{Page: 1, PageSize: savedSearchNotifyLimit}
+	})
+	issueIDs, _, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		return err
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+	if err != nil {
+		return err
+	}
+
+	checked := until
+	if len(issueIDs) == savedSearchNotifyLimit && len(issues) > 0 {
+		// More issues might have been updated at the same time as the last one, but not fit in the
+		// limit. Either all the issues updated at that time are notified, or none of them.
+		last := issues[len(issues)-1].UpdatedUnix
+		if issues[0].UpdatedUnix == last {
+			issueIDs, _, err = issue_indexer.SearchIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) {
+				o.UpdatedAfterUnix = optional.Some(int64(last))
+				o.UpdatedBeforeUnix = optional.Some(int64(last))
+				o.Paginator = &db.ListOptions{ListAll: true}
+			}))
+			if err != nil {
+				return err
+			}
+			if issues, err = issues_model.GetIssuesByIDs(ctx, issueIDs, true); err != nil {
+				return err
+			}
+			checked = last
+		} else {
+			for len(issues) > 0 && issues[len(issues)-1].UpdatedUnix == last {
+				issues = issues[:len(issues)-1]
+			}
+			// continue with the issues updated at the time of the last one on the next run
+			checked = last - 1
+		}
+	}
+
+	for _, issue := range issues {
+		isNew := issue.CreatedUnix > sub.LastCheckedUnix
+		if isNew && issue.PosterID == subscriber.ID {
+			continue
+		}
+		if !isNew && sub.Mode == issues_model.SavedSearchSubscriptionNew {
+			continue
+		}
+		if err := activities_model.CreateOrUpdateIssueNotifications(ctx, issue.ID, 0, issue.PosterID, subscriber.ID); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Error("CreateOrUpdateIssueNotifications [issue: %d, user: %d]: %v", issue.ID, subscriber.ID, err)
+		}
+	}
+
+	return issues_model.UpdateSavedSearchSubscriptionChecked(ctx, sub, checked)
+}
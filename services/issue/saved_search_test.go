@@ -0,0 +1,89 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	activities_model "forgejo.org/models/activities"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSavedSearchQuery(t *testing.T) {
+	values := url.Values{
+		"type":   {"assigned"},
+		"state":  {"closed"},
+		"labels": {"1,-2"},
+		"q":      {" security "},
+		"page":   {"3"},
+		"sort":   {""},
+	}
+	assert.Equal(t, "labels=1%2C-2&q=security&state=closed&type=assigned", SanitizeSavedSearchQuery(values))
+	assert.Empty(t, SanitizeSavedSearchQuery(url.Values{"page": {"2"}}))
+}
+
+func TestNotifySavedSearchSubscriberLimit(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	search := &issues_model.SavedSearch{UserID: 2, Name: "Open issues", Query: "type=your_repositories"}
+	require.NoError(t, db.Insert(db.DefaultContext, search))
+	base := timeutil.TimeStamp(time.Now().Add(-time.Hour).Unix())
+	sub := &issues_model.SavedSearchSubscription{
+		SavedSearchID:   search.ID,
+		UserID:          2,
+		Mode:            issues_model.SavedSearchSubscriptionUpdated,
+		LastCheckedUnix: base - 1,
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, sub))
+
+	// more issues than the limit are updated at the same time, then the limit falls
+	// between the issues updated at another time
+	var issueIDs []int64
+	createIssues := func(count int, updated timeutil.TimeStamp) {
+		for range count {
+			issue := &issues_model.Issue{
+				RepoID:      1,
+				Index:       int64(1000 + len(issueIDs)),
+				PosterID:    4,
+				Title:       fmt.Sprintf("issue %d", len(issueIDs)),
+				CreatedUnix: updated,
+				UpdatedUnix: updated,
+			}
+			_, err := db.GetEngine(db.DefaultContext).NoAutoTime().Insert(issue)
+			require.NoError(t, err)
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	createIssues(savedSearchNotifyLimit+10, base)
+	createIssues(savedSearchNotifyLimit-5, base+10)
+	createIssues(10, base+20)
+
+	until := timeutil.TimeStamp(time.Now().Add(-savedSearchIndexerDelay).Unix())
+	notified := func() int {
+		count, err := db.GetEngine(db.DefaultContext).Where("user_id = ?", 2).In("issue_id", issueIDs).Count(new(activities_model.Notification))
+		require.NoError(t, err)
+		return int(count)
+	}
+
+	require.NoError(t, notifySavedSearchSubscriber(db.DefaultContext, sub, until))
+	assert.Equal(t, savedSearchNotifyLimit+10, notified())
+	assert.Equal(t, base, sub.LastCheckedUnix)
+
+	require.NoError(t, notifySavedSearchSubscriber(db.DefaultContext, sub, until))
+	assert.Equal(t, 2*savedSearchNotifyLimit+5, notified())
+	assert.Equal(t, base+19, sub.LastCheckedUnix)
+
+	require.NoError(t, notifySavedSearchSubscriber(db.DefaultContext, sub, until))
+	assert.Equal(t, 2*savedSearchNotifyLimit+15, notified())
+	assert.Equal(t, until, sub.LastCheckedUnix)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+)
+
+// AddSubIssue makes an issue a sub-issue of the parent, the doer must be able to write issues in both repositories
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := checkSubIssuePermission(ctx, doer, parent, issue); err != nil {
+		return err
+	}
+	return issues_model.AddSubIssue(ctx, doer, parent, issue)
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the parent, the doer must be able to write issues
+// in both repositories
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := checkSubIssuePermission(ctx, doer, parent, issue); err != nil {
+		return err
+	}
+	return issues_model.RemoveSubIssue(ctx, doer, parent, issue)
+}
+
+func checkSubIssuePermission(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if parent.IsPull || issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can not have or be sub-issues")
+	}
+	for _, i := range []*issues_model.Issue{parent, issue} {
+		if err := i.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if i.Repo.IsArchived {
+			return util.NewInvalidArgumentErrorf("%s is archived", i.Repo.FullName())
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, i.Repo, doer)
+		if err != nil {
+			return err
+		}
+		if !perm.CanWrite(unit.TypeIssues) {
+			return util.NewPermissionDeniedErrorf("issues of %s can not be written", i.Repo.FullName())
+		}
+	}
+	return nil
+}
+
+// GetSubIssues returns the sub-issues of an issue which the doer can read
+func GetSubIssues(ctx context.Context, doer *user_model.User, parent *issues_model.Issue) (issues_model.IssueList, error) {
+	subIssues, err := issues_model.GetSubIssues(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+	return filterReadableIssues(ctx, doer, subIssues)
+}
+
+// GetParentIssue returns the parent of an issue, or nil if it has none or the doer can't read it
+func GetParentIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (*issues_model.Issue, error) {
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	readable, err := filterReadableIssues(ctx, doer, issues_model.IssueList{parent})
+	if err != nil || len(readable) == 0 {
+		return nil, err
+	}
+	return parent, nil
+}
+
+func filterReadableIssues(ctx context.Context, doer *user_model.User, issues issues_model.IssueList) (issues_model.IssueList, error) {
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	canRead := make(map[int64]bool)
+	readable := make(issues_model.IssueList, 0, len(issues))
+	for _, issue := range issues {
+		ok, has := canRead[issue.RepoID]
+		if !has {
+			perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, doer)
+			if err != nil {
+				return nil, err
+			}
+			ok = perm.CanRead(unit.TypeIssues)
+			canRead[issue.RepoID] = ok
+		}
+		if ok {
+			readable = append(readable, issue)
+		}
+	}
+	return readable, nil
+}
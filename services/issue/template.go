@@ -4,12 +4,14 @@
 package issue
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/url"
 	"path"
 	"strings"
 
+	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/repo"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/issue/template"
@@ -33,6 +35,19 @@ var templateDirCandidates = []string{
 	".gitlab/issue_template",
 }
 
+// pullRequestTemplateDirCandidates pull request templates directory
+var pullRequestTemplateDirCandidates = []string{
+	"PULL_REQUEST_TEMPLATE",
+	"pull_request_template",
+	".forgejo/PULL_REQUEST_TEMPLATE",
+	".forgejo/pull_request_template",
+	".gitea/PULL_REQUEST_TEMPLATE",
+	".gitea/pull_request_template",
+	".github/PULL_REQUEST_TEMPLATE",
+	".github/pull_request_template",
+	".gitlab/merge_request_templates",
+}
+
 var templateConfigCandidates = []string{
 	".forgejo/ISSUE_TEMPLATE/config",
 	".forgejo/issue_template/config",
@@ -114,6 +129,16 @@ func IsTemplateConfig(path string) bool {
 // GetTemplatesFromDefaultBranch checks for issue templates in the repo's default branch,
 // returns valid templates and the errors of invalid template files.
 func GetTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository) ([]*api.IssueTemplate, map[string]error) {
+	return getTemplatesFromDefaultBranch(repo, gitRepo, templateDirCandidates)
+}
+
+// GetPullRequestTemplatesFromDefaultBranch checks for pull request templates in the directories of the repo's
+// default branch, returns valid templates and the errors of invalid template files.
+func GetPullRequestTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository) ([]*api.IssueTemplate, map[string]error) {
+	return getTemplatesFromDefaultBranch(repo, gitRepo, pullRequestTemplateDirCandidates)
+}
+
+func getTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository, dirCandidates []string) ([]*api.IssueTemplate, map[string]error) {
 	var issueTemplates []*api.IssueTemplate
 
 	if repo.IsEmpty {
@@ -126,7 +151,7 @@ func GetTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repositor
 	}
 
 	invalidFiles := map[string]error{}
-	for _, dirName := range templateDirCandidates {
+	for _, dirName := range dirCandidates {
 		tree, err := commit.SubTree(dirName)
 		if err != nil {
 			log.Debug("get sub tree of %s: %v", dirName, err)
@@ -189,3 +214,47 @@ func HasTemplatesOrContactLinks(repo *repo.Repository, gitRepo *git.Repository)
 	issueConfig, _ := GetTemplateConfigFromDefaultBranch(repo, gitRepo)
 	return len(issueConfig.ContactLinks) > 0
 }
+
+// GetTemplateCustomFieldValues validates the values submitted through an issue form for the custom fields it sets.
+// Form fields which refer to a custom field that does not exist in the repository are ignored.
+func GetTemplateCustomFieldValues(ctx context.Context, repo *repo.Repository, it *api.IssueTemplate, values url.Values) ([]*CustomFieldValues, error) {
+	submitted := template.CustomFieldValues(it, values)
+	if len(submitted) == 0 {
+		return nil, nil
+	}
+
+	fields, err := issues_model.GetIssueCustomFieldsForRepo(ctx, repo.OwnerID, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	for name := range submitted {
+		if findCustomField(fields, name) == nil {
+			log.Debug("Issue form %s refers to the unknown custom field %q", it.FileName, name)
+			delete(submitted, name)
+		}
+	}
+	return ResolveCustomFieldValues(ctx, repo, submitted)
+}
+
+// RenderTemplateForm checks the values submitted through a form template and renders them to the content of
+// the issue or pull request. It also returns the values to store with the issue, see SaveTemplateFormValues.
+func RenderTemplateForm(it *api.IssueTemplate, values url.Values) (string, *issues_model.IssueFormValues, error) {
+	if err := template.ValidateValues(it, values); err != nil {
+		return "", nil, err
+	}
+	formValues := &issues_model.IssueFormValues{
+		Template: it.FileName,
+		Fields:   template.FieldValues(it, values),
+	}
+	return template.RenderToMarkdown(it, values), formValues, nil
+}
+
+// SaveTemplateFormValues stores the form values of an issue created from a form template,
+// in the transaction creating the issue
+func SaveTemplateFormValues(ctx context.Context, issue *issues_model.Issue, formValues *issues_model.IssueFormValues) error {
+	if formValues == nil {
+		return nil
+	}
+	formValues.IssueID = issue.ID
+	return issues_model.CreateIssueFormValues(ctx, formValues)
+}
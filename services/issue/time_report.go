@@ -0,0 +1,105 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+)
+
+// SetTimeReportRepos sets the repositories of a time report: the doer sees all the times tracked on the issues
+// of the repositories they can write issues to, and only their own times on the issues of the others.
+// The repositories without time tracking are ignored.
+func SetTimeReportRepos(ctx context.Context, doer *user_model.User, repos []*repo_model.Repository, opts *issues_model.TimeReportOptions) error {
+	opts.DoerID = doer.ID
+	for _, repo := range repos {
+		if !repo.IsTimetrackerEnabled(ctx) {
+			continue
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return err
+		}
+		if doer.IsAdmin || perm.CanWrite(unit.TypeIssues) {
+			opts.RepoIDs = append(opts.RepoIDs, repo.ID)
+		} else if perm.CanRead(unit.TypeIssues) {
+			opts.OwnTimesRepoIDs = append(opts.OwnTimesRepoIDs, repo.ID)
+		}
+	}
+	return nil
+}
+
+// GetOwnerTimeReportRepos returns the repositories of an owner whose issues the doer can read
+func GetOwnerTimeReportRepos(ctx context.Context, doer *user_model.User, ownerID int64) ([]*repo_model.Repository, error) {
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, &repo_model.SearchRepoOptions{
+		Actor:       doer,
+		OwnerID:     ownerID,
+		Private:     true,
+		Collaborate: optional.None[bool](),
+		UnitType:    unit.TypeIssues,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reposMap, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]*repo_model.Repository, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		if repo, ok := reposMap[id]; ok {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+// TimeReportCSVHeader are the columns of an exported time report
+var TimeReportCSVHeader = []string{"id", "created", "user", "repository", "issue", "title", "milestone", "seconds", "hours"}
+
+// WriteTimeReportCSV writes the tracked times of a time report as CSV, their report attributes must be loaded
+func WriteTimeReportCSV(w io.Writer, times issues_model.TrackedTimeList) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(TimeReportCSVHeader); err != nil {
+		return err
+	}
+	for _, t := range times {
+		var repoName, title, milestone string
+		var index int64
+		if t.Issue != nil {
+			index, title = t.Issue.Index, t.Issue.Title
+			if t.Issue.Repo != nil {
+				repoName = t.Issue.Repo.FullName()
+			}
+			if t.Issue.Milestone != nil {
+				milestone = t.Issue.Milestone.Name
+			}
+		}
+		if err := writer.Write([]string{
+			strconv.FormatInt(t.ID, 10),
+			t.Created.Format(time.RFC3339),
+			t.User.Name,
+			repoName,
+			strconv.FormatInt(index, 10),
+			title,
+			milestone,
+			strconv.FormatInt(t.Time, 10),
+			strconv.FormatFloat(float64(t.Time)/3600, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
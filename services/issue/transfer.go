@@ -0,0 +1,60 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// TransferIssue moves an issue to another repository, the doer must be able to write issues in both repositories.
+// Links and references to the former location of the issue keep leading to it.
+func TransferIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, newRepo *repo_model.Repository) error {
+	oldRepo, oldIndex, err := transferIssue(ctx, doer, issue, newRepo)
+	if err != nil {
+		return err
+	}
+
+	notify_service.IssueTransferred(ctx, doer, issue, oldRepo, oldIndex)
+	return nil
+}
+
+func transferIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, newRepo *repo_model.Repository) (*repo_model.Repository, int64, error) {
+	if issue.IsPull {
+		return nil, 0, util.NewInvalidArgumentErrorf("pull requests can not be transferred")
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, 0, err
+	}
+	if issue.RepoID == newRepo.ID {
+		return nil, 0, util.NewInvalidArgumentErrorf("the issue already belongs to %s", newRepo.FullName())
+	}
+	if newRepo.IsArchived {
+		return nil, 0, util.NewInvalidArgumentErrorf("%s is archived", newRepo.FullName())
+	}
+
+	for _, repo := range []*repo_model.Repository{issue.Repo, newRepo} {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !perm.CanWrite(unit.TypeIssues) {
+			return nil, 0, util.NewPermissionDeniedErrorf("issues of %s can not be written", repo.FullName())
+		}
+	}
+
+	oldRepo := issue.Repo
+	oldIndex := issue.Index
+	if err := issues_model.TransferIssue(ctx, issue, doer, newRepo); err != nil {
+		return nil, 0, err
+	}
+	return oldRepo, oldIndex, nil
+}
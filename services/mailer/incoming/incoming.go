@@ -247,6 +247,16 @@ loop:
 					return nil
 				}
 
+				if handler, key, ok := findAddressHandler(t); ok {
+					if err := handler.Handle(ctx, getContentFromMailReader(env), key); err != nil {
+						return fmt.Errorf("could not handle message: %w", err)
+					}
+
+					handledSet.AddNum(msg.SeqNum)
+
+					return nil
+				}
+
 				handlerType, user, payload, err := token.ExtractToken(ctx, t)
 				if err != nil {
 					if _, ok := err.(*token.ErrToken); ok {
@@ -364,6 +374,9 @@ func searchTokenInAddresses(addresses []*net_mail.Address) string {
 }
 
 type MailContent struct {
+	Subject     string
+	FromAddress string
+	FromName    string
 	Content     string
 	Attachments []*Attachment
 }
@@ -403,10 +416,16 @@ func getContentFromMailReader(env *enmime.Envelope) *MailContent {
 		})
 	}
 
-	return &MailContent{
+	content := &MailContent{
+		Subject:     strings.TrimSpace(env.GetHeader("Subject")),
 		Content:     reply.FromText(env.Text),
 		Attachments: slices.Concat(attachments, inlineAttachments, otherParts),
 	}
+	if from, err := env.AddressList("From"); err == nil && len(from) > 0 {
+		content.FromAddress = from[0].Address
+		content.FromName = from[0].Name
+	}
+	return content
 }
 
 // constructFilename interprets the mime part as an (inline) attachment and returns its filename
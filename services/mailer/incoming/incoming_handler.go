@@ -7,10 +7,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	issues_model "forgejo.org/models/issues"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
@@ -18,6 +20,7 @@ import (
 	attachment_service "forgejo.org/services/attachment"
 	"forgejo.org/services/context/upload"
 	issue_service "forgejo.org/services/issue"
+	"forgejo.org/services/mailer"
 	incoming_payload "forgejo.org/services/mailer/incoming/payload"
 	"forgejo.org/services/mailer/token"
 	pull_service "forgejo.org/services/pull"
@@ -30,6 +33,7 @@ type MailHandler interface {
 var handlers = map[token.HandlerType]MailHandler{
 	token.ReplyHandlerType:       &ReplyHandler{},
 	token.UnsubscribeHandlerType: &UnsubscribeHandler{},
+	token.NewIssueHandlerType:    &UserNewIssueHandler{},
 }
 
 // ReplyHandler handles incoming emails to create a reply from them
@@ -84,23 +88,9 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 
 	log.Trace("incoming mail related to %T", ref)
 
-	attachmentIDs := make([]string, 0, len(content.Attachments))
-	if setting.Attachment.Enabled {
-		for _, attachment := range content.Attachments {
-			a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
-				Name:       attachment.Name,
-				UploaderID: doer.ID,
-				RepoID:     issue.Repo.ID,
-			})
-			if err != nil {
-				if upload.IsErrFileTypeForbidden(err) {
-					log.Info("Skipping disallowed attachment type: %s", attachment.Name)
-					continue
-				}
-				return err
-			}
-			attachmentIDs = append(attachmentIDs, a.UUID)
-		}
+	attachmentIDs, err := uploadAttachments(ctx, content, doer, issue.Repo)
+	if err != nil {
+		return err
 	}
 
 	if content.Content == "" && len(attachmentIDs) == 0 {
@@ -124,6 +114,7 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 				return fmt.Errorf("CreateIssueComment failed: %w", err)
 			}
 		case issues_model.CommentTypeCode:
+			// the reply keeps the review, file and line of the thread, so that it shows up in it
 			_, err := pull_service.CreateCodeComment(
 				ctx,
 				doer,
@@ -149,6 +140,31 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 	return nil
 }
 
+// uploadAttachments stores the attachments of an email in a repository, the disallowed types are skipped.
+// It returns their UUIDs.
+func uploadAttachments(ctx context.Context, content *MailContent, doer *user_model.User, repo *repo_model.Repository) ([]string, error) {
+	attachmentIDs := make([]string, 0, len(content.Attachments))
+	if !setting.Attachment.Enabled {
+		return attachmentIDs, nil
+	}
+	for _, attachment := range content.Attachments {
+		a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
+			Name:       attachment.Name,
+			UploaderID: doer.ID,
+			RepoID:     repo.ID,
+		})
+		if err != nil {
+			if upload.IsErrFileTypeForbidden(err) {
+				log.Info("Skipping disallowed attachment type: %s", attachment.Name)
+				continue
+			}
+			return nil, err
+		}
+		attachmentIDs = append(attachmentIDs, a.UUID)
+	}
+	return attachmentIDs, nil
+}
+
 // UnsubscribeHandler handles unwatching issues/pulls
 type UnsubscribeHandler struct{}
 
@@ -185,3 +201,211 @@ func (h *UnsubscribeHandler) Handle(ctx context.Context, _ *MailContent, doer *u
 		return fmt.Errorf("unsupported unsubscribe reference: %v", ref)
 	}
 }
+
+// AddressHandler handles the emails sent to an incoming address which is not bound to a user,
+// the key is the part of its token after the prefix
+type AddressHandler interface {
+	Handle(ctx context.Context, content *MailContent, key string) error
+}
+
+var addressHandlers = map[string]AddressHandler{
+	token.NewIssueTokenPrefix:    &NewIssueHandler{},
+	token.ServiceDeskTokenPrefix: &ServiceDeskReplyHandler{},
+}
+
+// findAddressHandler returns the handler of an incoming address token, false if it's a token bound to a user
+func findAddressHandler(t string) (AddressHandler, string, bool) {
+	for prefix, handler := range addressHandlers {
+		if key, ok := strings.CutPrefix(strings.ToLower(t), prefix); ok {
+			return handler, key, true
+		}
+	}
+	return nil, "", false
+}
+
+// NewIssueHandler opens an issue from an email sent to the incoming address of a repository with a service desk.
+// The address may be public and the sender can't be trusted to be a user: the issue is opened on behalf of the
+// sender as an external reporter, who gets the comments on the issue by email. Without service desk, users open
+// issues with their own address, see UserNewIssueHandler.
+type NewIssueHandler struct{}
+
+func (h *NewIssueHandler) Handle(ctx context.Context, content *MailContent, key string) error {
+	incoming, err := repo_model.GetIncomingEmailByToken(ctx, key)
+	if err != nil {
+		return err
+	}
+	if incoming == nil {
+		log.Info("Unknown incoming email address of repository")
+		return nil
+	}
+	if !incoming.EnableServiceDesk {
+		// the sender address is set by the sender, it can't identify a user
+		log.Debug("incoming email address of repository %d has no service desk", incoming.RepoID)
+		return nil
+	}
+	if content.FromAddress == "" || (setting.MailService != nil && strings.EqualFold(content.FromAddress, setting.MailService.FromEmail)) {
+		log.Debug("incoming mail has no sender or is sent by the instance")
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, incoming.RepoID)
+	if err != nil {
+		return err
+	}
+	if repo.IsArchived || !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		log.Debug("can't open issue in repository %d", repo.ID)
+		return nil
+	}
+
+	reporter := &issues_model.ExternalReporter{
+		Email: content.FromAddress,
+		Name:  content.FromName,
+	}
+	issue, err := newIssueFromMail(ctx, repo, user_model.NewGhostUser(), reporter.DisplayName(), content)
+	if err != nil || issue == nil {
+		return err
+	}
+
+	reporter.IssueID = issue.ID
+	if err := issues_model.CreateExternalReporter(ctx, reporter); err != nil {
+		return err
+	}
+	if err := mailer.MailExternalReporter(ctx, reporter, issue, nil); err != nil {
+		log.Error("MailExternalReporter: %v", err)
+	}
+	return nil
+}
+
+// UserNewIssueHandler opens an issue from an email sent by a user to their own incoming address for a
+// repository, see CreateNewIssuePayload. The address identifies the user, who must be able to read the issues.
+type UserNewIssueHandler struct{}
+
+func (h *UserNewIssueHandler) Handle(ctx context.Context, content *MailContent, doer *user_model.User, payload []byte) error {
+	if doer == nil {
+		return util.NewInvalidArgumentErrorf("doer can't be nil")
+	}
+
+	var repoID int64
+	var incomingToken string
+	if err := util.UnpackData(payload, &repoID, &incomingToken); err != nil {
+		return err
+	}
+
+	incoming, err := repo_model.GetIncomingEmail(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if incoming == nil || incoming.Token != incomingToken {
+		// the incoming email of the repository was disabled or its address was changed
+		log.Debug("incoming email address of repository %d has changed", repoID)
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if repo.IsArchived || !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		log.Debug("can't open issue in repository %d", repo.ID)
+		return nil
+	}
+	if !doer.IsActive || doer.ProhibitLogin {
+		log.Debug("incoming mail sender can't sign in")
+		return nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanRead(unit.TypeIssues) {
+		log.Debug("can't read issues")
+		return nil
+	}
+
+	_, err = newIssueFromMail(ctx, repo, doer, "", content)
+	return err
+}
+
+// CreateNewIssuePayload returns the payload of the token of the incoming address a user sends emails to in order
+// to open issues in a repository. The address stops working when the address of the repository is changed.
+func CreateNewIssuePayload(incoming *repo_model.IncomingEmail) ([]byte, error) {
+	return util.PackData(incoming.RepoID, incoming.Token)
+}
+
+// newIssueFromMail opens an issue from the subject, the content and the attachments of an email,
+// nil if the email is empty
+func newIssueFromMail(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, originalAuthor string, content *MailContent) (*issues_model.Issue, error) {
+	if content.Subject == "" && content.Content == "" && len(content.Attachments) == 0 {
+		log.Trace("incoming mail has no subject, no content and no attachment")
+		return nil, nil
+	}
+
+	attachmentIDs, err := uploadAttachments(ctx, content, doer, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:         repo.ID,
+		Repo:           repo,
+		Title:          content.Subject,
+		PosterID:       doer.ID,
+		Poster:         doer,
+		Content:        content.Content,
+		OriginalAuthor: originalAuthor,
+	}
+	if issue.Title == "" {
+		issue.Title = content.FromAddress
+	}
+	if err := issue_service.NewIssue(ctx, repo, issue, nil, attachmentIDs, nil, nil, nil); err != nil {
+		return nil, fmt.Errorf("NewIssue failed: %w", err)
+	}
+	return issue, nil
+}
+
+// ServiceDeskReplyHandler comments on an issue on behalf of its external reporter, who replied to an email about it
+type ServiceDeskReplyHandler struct{}
+
+func (h *ServiceDeskReplyHandler) Handle(ctx context.Context, content *MailContent, key string) error {
+	reporter, err := issues_model.GetExternalReporterByToken(ctx, key)
+	if err != nil {
+		return err
+	}
+	if reporter == nil {
+		log.Info("Unknown incoming email address of external reporter")
+		return nil
+	}
+
+	issue, err := issues_model.GetIssueByID(ctx, reporter.IssueID)
+	if err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	incoming, err := repo_model.GetIncomingEmail(ctx, issue.RepoID)
+	if err != nil {
+		return err
+	}
+	if incoming == nil || !incoming.EnableServiceDesk || issue.Repo.IsArchived || issue.IsLocked {
+		log.Debug("external reporter can't comment on issue %d", issue.ID)
+		return nil
+	}
+
+	doer := user_model.NewGhostUser()
+	attachmentIDs, err := uploadAttachments(ctx, content, doer, issue.Repo)
+	if err != nil {
+		return err
+	}
+
+	if content.Content == "" && len(attachmentIDs) == 0 {
+		log.Trace("incoming mail has no content and no attachment")
+		return nil
+	}
+
+	if _, err := issue_service.CreateExternalReporterComment(ctx, reporter, issue, content.Content, attachmentIDs); err != nil {
+		return fmt.Errorf("CreateExternalReporterComment failed: %w", err)
+	}
+	return nil
+}
@@ -90,6 +90,37 @@ func TestIsAutomaticReply(t *testing.T) {
 	}
 }
 
+func TestFindAddressHandler(t *testing.T) {
+	handler, key, ok := findAddressHandler("issue-0123abcd")
+	assert.True(t, ok)
+	assert.IsType(t, &NewIssueHandler{}, handler)
+	assert.Equal(t, "0123abcd", key)
+
+	handler, key, ok = findAddressHandler("Desk-0123ABCD")
+	assert.True(t, ok)
+	assert.IsType(t, &ServiceDeskReplyHandler{}, handler)
+	assert.Equal(t, "0123abcd", key)
+
+	_, _, ok = findAddressHandler("AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA")
+	assert.False(t, ok)
+}
+
+func TestGetSenderFromMailReader(t *testing.T) {
+	mailString := "From: Jane Doe <jane@example.com>\r\n" +
+		"Subject: =?utf-8?q?Printer_=C3=A9rror?=\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"mail content\r\n"
+
+	env, err := enmime.ReadEnvelope(strings.NewReader(mailString))
+	require.NoError(t, err)
+	content := getContentFromMailReader(env)
+	assert.Equal(t, "Printer érror", content.Subject)
+	assert.Equal(t, "jane@example.com", content.FromAddress)
+	assert.Equal(t, "Jane Doe", content.FromName)
+	assert.Equal(t, "mail content", content.Content)
+}
+
 func TestGetContentFromMailReader(t *testing.T) {
 	mailString := "Content-Type: multipart/mixed; boundary=message-boundary\r\n" +
 		"\r\n" +
@@ -263,11 +263,16 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 	}
 	fallback = prefix + fallbackMailSubject(ctx.Issue)
 
+	// the first comment of each thread of the review, a reply to the email can go to each of them
+	var reviewThreads []*issues_model.Comment
 	if ctx.Comment != nil && ctx.Comment.Review != nil {
 		reviewComments = make([]*issues_model.Comment, 0, 10)
 		for _, lines := range ctx.Comment.Review.CodeComments {
 			for _, comments := range lines {
 				reviewComments = append(reviewComments, comments...)
+				if len(comments) > 0 {
+					reviewThreads = append(reviewThreads, comments[0])
+				}
 			}
 		}
 	}
@@ -291,6 +296,8 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 		"ReviewComments":  reviewComments,
 		"Language":        locale.Language(),
 		"CanReply":        setting.IncomingEmail.Enabled && commentType != issues_model.CommentTypePullRequestPush,
+		// ThreadReplyAddresses are the addresses to reply to each thread of a review, by ID of their first comment
+		"ThreadReplyAddresses": map[int64]string(nil),
 	}
 
 	var mailSubject bytes.Buffer
@@ -307,10 +314,18 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 
 	mailMeta["Subject"] = subject
 
-	var mailBody bytes.Buffer
-
-	if err := bodyTemplates.ExecuteTemplate(&mailBody, tplName, mailMeta); err != nil {
-		log.Error("ExecuteTemplate [%s]: %v", tplName+"/body", err)
+	renderBody := func() string {
+		var mailBody bytes.Buffer
+		if err := bodyTemplates.ExecuteTemplate(&mailBody, tplName, mailMeta); err != nil {
+			log.Error("ExecuteTemplate [%s]: %v", tplName+"/body", err)
+		}
+		return mailBody.String()
+	}
+	// the reply addresses of the threads are bound to each recipient, and so is the body showing them
+	threadReplies := setting.IncomingEmail.Enabled && len(reviewThreads) > 1
+	var mailBody string
+	if !threadReplies {
+		mailBody = renderBody()
 	}
 
 	// Make sure to compose independent messages to avoid leaking user emails
@@ -319,7 +334,10 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 
 	var replyPayload []byte
 	if ctx.Comment != nil {
-		if ctx.Comment.Type.HasMailReplySupport() {
+		if len(reviewThreads) == 1 {
+			// a reply to a review with a single thread goes to that thread
+			replyPayload, err = incoming_payload.CreateReferencePayload(reviewThreads[0])
+		} else if ctx.Comment.Type.HasMailReplySupport() {
 			replyPayload, err = incoming_payload.CreateReferencePayload(ctx.Comment)
 		}
 	} else {
@@ -336,12 +354,22 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 
 	msgs := make([]*Message, 0, len(recipients))
 	for _, recipient := range recipients {
+		body := mailBody
+		if threadReplies {
+			threadReplyAddresses, err := createThreadReplyAddresses(recipient, reviewThreads)
+			if err != nil {
+				log.Error("createThreadReplyAddresses failed: %v", err)
+			}
+			mailMeta["ThreadReplyAddresses"] = threadReplyAddresses
+			body = renderBody()
+		}
+
 		msg := NewMessageFrom(
 			recipient.Email,
 			fromDisplayName(ctx.Doer),
 			setting.MailService.FromEmail,
 			subject,
-			mailBody.String(),
+			body,
 		)
 		msg.Info = fmt.Sprintf("Subject: %s, %s", subject, info)
 
@@ -387,6 +415,24 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 	return msgs, nil
 }
 
+// createThreadReplyAddresses returns the addresses a recipient replies to in order to comment on each thread of
+// a review, by ID of the first comment of the thread
+func createThreadReplyAddresses(recipient *user_model.User, threads []*issues_model.Comment) (map[int64]string, error) {
+	addresses := make(map[int64]string, len(threads))
+	for _, thread := range threads {
+		payload, err := incoming_payload.CreateReferencePayload(thread)
+		if err != nil {
+			return nil, err
+		}
+		replyToken, err := token.CreateToken(token.ReplyHandlerType, recipient, payload)
+		if err != nil {
+			return nil, err
+		}
+		addresses[thread.ID] = token.IncomingAddress(replyToken)
+	}
+	return addresses, nil
+}
+
 func createReference(issue *issues_model.Issue, comment *issues_model.Comment, actionType activities_model.ActionType) string {
 	var path string
 	if issue.IsPull {
@@ -0,0 +1,106 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplSecretScanAlerts   base.TplName = "notify/secret_scan_alerts"
+	tplAccessTokenRevoked base.TplName = "notify/access_token_revoked"
+)
+
+// MailSecretScanAlerts notifies the administrators of a repository about secrets found in pushed content
+func MailSecretScanAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*secretscan_model.SecretScanAlert) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	recipients, err := access_model.GetRepoAdmins(ctx, repo)
+	if err != nil {
+		log.Error("GetRepoAdmins: %v", err)
+		return
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, user := range recipients {
+		if !user.IsActive || user.IsOrganization() {
+			continue
+		}
+		langMap[user.Language] = append(langMap[user.Language], user)
+	}
+
+	for lang, tos := range langMap {
+		if err := mailSecretScanAlertsPerLang(lang, tos, repo, alerts); err != nil {
+			log.Error("mailSecretScanAlertsPerLang: %v", err)
+		}
+	}
+}
+
+func mailSecretScanAlertsPerLang(lang string, tos []*user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.SecretScanAlert) error {
+	locale := translation.NewLocale(lang)
+	subject := locale.TrString("mail.secret_scan.subject", repo.FullName())
+	data := map[string]any{
+		"locale":   locale,
+		"Subject":  subject,
+		"Repo":     repo.FullName(),
+		"Link":     repo.HTMLURL() + "/settings/secret_scanning",
+		"Alerts":   alerts,
+		"Language": locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplSecretScanAlerts), data); err != nil {
+		return err
+	}
+
+	msgs := make([]*Message, 0, len(tos))
+	for _, to := range tos {
+		msg := NewMessage(to.EmailTo(), subject, content.String())
+		msg.Info = fmt.Sprintf("UID: %d, secret scanning alerts for repo %d", to.ID, repo.ID)
+		msgs = append(msgs, msg)
+	}
+	SendAsync(msgs...)
+	return nil
+}
+
+// MailAccessTokenRevoked informs a user that one of their access tokens was revoked because it was pushed to a repository
+func MailAccessTokenRevoked(ctx context.Context, u *user_model.User, tokenName string, repo *repo_model.Repository) {
+	if setting.MailService == nil || !u.IsActive {
+		return
+	}
+	locale := translation.NewLocale(u.Language)
+
+	data := map[string]any{
+		"locale":      locale,
+		"DisplayName": u.DisplayName(),
+		"TokenName":   tokenName,
+		"Repo":        repo.FullName(),
+		"Link":        setting.AppURL + "user/settings/applications",
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplAccessTokenRevoked), data); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", tplAccessTokenRevoked, err)
+		return
+	}
+
+	msg := NewMessage(u.EmailTo(), locale.TrString("mail.access_token_revoked.subject"), content.String())
+	msg.Info = fmt.Sprintf("UID: %d, access token revoked notification", u.ID)
+	SendAsync(msg)
+}
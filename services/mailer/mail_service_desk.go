@@ -0,0 +1,101 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	activities_model "forgejo.org/models/activities"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+	"forgejo.org/services/mailer/token"
+)
+
+const (
+	tplServiceDeskMail base.TplName = "issue/service_desk"
+)
+
+// MailExternalReporter sends a comment on an issue to the external reporter of the issue, or the confirmation
+// that the issue was opened if the comment is nil. The reporter can reply to the email to comment on the issue.
+func MailExternalReporter(ctx context.Context, reporter *issues_model.ExternalReporter, issue *issues_model.Issue, comment *issues_model.Comment) error {
+	if setting.MailService == nil || !setting.IncomingEmail.Enabled {
+		return nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale("")
+	subject := fallbackMailSubject(issue)
+	mailMeta := map[string]any{
+		"locale":   locale,
+		"Reporter": reporter,
+		"Issue":    issue,
+		"Language": locale.Language(),
+	}
+	fromName := setting.AppName
+	if comment != nil {
+		if err := comment.LoadPoster(ctx); err != nil {
+			return err
+		}
+		body, err := markdown.RenderString(&markup.RenderContext{
+			Ctx: ctx,
+			Links: markup.Links{
+				AbsolutePrefix: true,
+				Base:           issue.Repo.HTMLURL(),
+			},
+			Metas: issue.Repo.ComposeMetas(ctx),
+		}, comment.Content)
+		if err != nil {
+			return err
+		}
+		subject = "Re: " + subject
+		mailMeta["Comment"] = comment
+		mailMeta["Doer"] = comment.Poster
+		mailMeta["Body"] = body
+		fromName = fromDisplayName(comment.Poster)
+	}
+	mailMeta["Subject"] = subject
+
+	var mailBody bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplServiceDeskMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplServiceDeskMail)+"/body", err)
+		return err
+	}
+
+	replyAddress := token.IncomingAddress(token.ServiceDeskTokenPrefix + reporter.Token)
+	reference := createReference(issue, nil, activities_model.ActionType(0))
+
+	msg := NewMessageFrom(reporter.Email, fromName, setting.MailService.FromEmail, subject, mailBody.String())
+	msg.Info = fmt.Sprintf("Subject: %s, service desk of issue %d", subject, issue.ID)
+	msg.ReplyTo = replyAddress
+	msg.SetHeader("Message-ID", createReference(issue, comment, activities_model.ActionType(0)))
+	if comment != nil {
+		msg.SetHeader("In-Reply-To", reference)
+	}
+	msg.SetHeader("References", reference, fmt.Sprintf("<reply-%s%s@%s>", token.ServiceDeskTokenPrefix, reporter.Token, setting.Domain))
+
+	SendAsync(msg)
+
+	return nil
+}
+
+func mailExternalReporterComment(ctx context.Context, issue *issues_model.Issue, comment *issues_model.Comment) error {
+	// the comments of the reporter are not sent back to them
+	if comment.Type != issues_model.CommentTypeComment || comment.OriginalAuthor != "" {
+		return nil
+	}
+	reporter, err := issues_model.GetExternalReporterByIssueID(ctx, issue.ID)
+	if err != nil || reporter == nil {
+		return err
+	}
+	return MailExternalReporter(ctx, reporter, issue, comment)
+}
@@ -24,6 +24,8 @@ import (
 	"forgejo.org/modules/markup"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/test"
+	incoming_payload "forgejo.org/services/mailer/incoming/payload"
+	"forgejo.org/services/mailer/token"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -117,6 +119,87 @@ func TestComposeIssueCommentMessage(t *testing.T) {
 	assert.Contains(t, string(b), fmt.Sprintf(`href="%s"`, issue.HTMLURL()))
 }
 
+func TestComposeReviewThreadReplies(t *testing.T) {
+	defer MockMailSettings(nil)()
+	doer, _, _, _ := prepareMailerTest(t)
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, true)()
+
+	subjectTemplates = texttmpl.Must(texttmpl.New("issue/default").Parse(subjectTpl))
+	bodyTemplates = template.Must(template.New("issue/default").Parse(`{{range .ReviewComments}}{{.ID}}={{index $.ThreadReplyAddresses .ID}};{{end}}`))
+
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	require.NoError(t, pull.LoadRepo(db.DefaultContext))
+	recipient := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	firstThread := &issues_model.Comment{ID: 4, Type: issues_model.CommentTypeCode, TreePath: "README.md", Line: 4}
+	reply := &issues_model.Comment{ID: 5, Type: issues_model.CommentTypeCode, TreePath: "README.md", Line: 4}
+	secondThread := &issues_model.Comment{ID: 6, Type: issues_model.CommentTypeCode, TreePath: "README.md", Line: -8}
+	review := &issues_model.Review{
+		ID:   10,
+		Type: issues_model.ReviewTypeComment,
+		CodeComments: issues_model.CodeComments{
+			"README.md": {
+				4:  {firstThread, reply},
+				-8: {secondThread},
+			},
+		},
+	}
+	reviewComment := &issues_model.Comment{ID: 7, Type: issues_model.CommentTypeReview, ReviewID: review.ID, Review: review}
+
+	compose := func(t *testing.T) (string, string) {
+		t.Helper()
+		msg := testComposeIssueCommentMessage(t, &mailCommentContext{
+			Context: t.Context(),
+			Issue:   pull, Doer: doer, ActionType: activities_model.ActionCommentPull,
+			Comment: reviewComment,
+		}, []*user_model.User{recipient}, false, "review")
+		return msg.ToMessage().GetHeader("Reply-To")[0], msg.Body
+	}
+	tokenRegex := regexp.MustCompile(`incoming\+([^@;]+)@localhost`)
+	extractPayload := func(t *testing.T, address string) []byte {
+		t.Helper()
+		matches := tokenRegex.FindStringSubmatch(address)
+		require.Len(t, matches, 2)
+		handlerType, user, payload, err := token.ExtractToken(db.DefaultContext, matches[1])
+		require.NoError(t, err)
+		assert.Equal(t, token.ReplyHandlerType, handlerType)
+		assert.Equal(t, recipient.ID, user.ID)
+		return payload
+	}
+
+	t.Run("Several threads", func(t *testing.T) {
+		replyTo, body := compose(t)
+		// the email itself replies to the review
+		reviewPayload, err := incoming_payload.CreateReferencePayload(reviewComment)
+		require.NoError(t, err)
+		assert.Equal(t, reviewPayload, extractPayload(t, replyTo))
+
+		// each thread has its own address
+		addresses := make(map[string]string)
+		for _, part := range strings.Split(strings.TrimSuffix(body, ";"), ";") {
+			id, address, _ := strings.Cut(part, "=")
+			addresses[id] = address
+		}
+		assert.Empty(t, addresses["5"])
+		for id, thread := range map[string]*issues_model.Comment{"4": firstThread, "6": secondThread} {
+			threadPayload, err := incoming_payload.CreateReferencePayload(thread)
+			require.NoError(t, err)
+			assert.Equal(t, threadPayload, extractPayload(t, addresses[id]), id)
+		}
+	})
+
+	t.Run("Single thread", func(t *testing.T) {
+		review.CodeComments = issues_model.CodeComments{"README.md": {4: {firstThread, reply}}}
+
+		replyTo, body := compose(t)
+		// the email replies to the thread
+		threadPayload, err := incoming_payload.CreateReferencePayload(firstThread)
+		require.NoError(t, err)
+		assert.Equal(t, threadPayload, extractPayload(t, replyTo))
+		assert.Equal(t, "4=;5=;", body)
+	})
+}
+
 func TestComposeIssueMessage(t *testing.T) {
 	defer MockMailSettings(nil)()
 	doer, _, issue, _ := prepareMailerTest(t)
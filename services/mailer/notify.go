@@ -47,6 +47,9 @@ func (m *mailNotifier) CreateIssueComment(ctx context.Context, doer *user_model.
 	if err := MailParticipantsComment(ctx, comment, act, issue, mentions); err != nil {
 		log.Error("MailParticipantsComment: %v", err)
 	}
+	if err := mailExternalReporterComment(ctx, issue, comment); err != nil {
+		log.Error("mailExternalReporterComment: %v", err)
+	}
 }
 
 func (m *mailNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
@@ -0,0 +1,24 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package token
+
+import (
+	"strings"
+
+	"forgejo.org/modules/setting"
+)
+
+// The prefixes of the tokens of the incoming email addresses which are not bound to a user. These tokens can't be
+// mistaken for the ones created by CreateToken, which are encoded in upper case base32.
+const (
+	// NewIssueTokenPrefix is the prefix of the address of a repository, which opens an issue for each email
+	NewIssueTokenPrefix = "issue-"
+	// ServiceDeskTokenPrefix is the prefix of the address the external reporter of an issue replies to
+	ServiceDeskTokenPrefix = "desk-"
+)
+
+// IncomingAddress returns the incoming email address of a token
+func IncomingAddress(token string) string {
+	return strings.Replace(setting.IncomingEmail.ReplyToAddress, setting.IncomingEmail.TokenPlaceholder, token, 1)
+}
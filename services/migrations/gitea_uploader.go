@@ -6,6 +6,8 @@ package migrations
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -346,8 +348,10 @@ func (g *GiteaLocalUploader) CreateReleases(releases ...*base.Release) error {
 				if rc == nil {
 					return nil
 				}
-				_, err = storage.Attachments.Save(attach.RelativePath(), rc, int64(*asset.Size))
+				hash := sha256.New()
+				_, err = storage.Attachments.Save(attach.RelativePath(), io.TeeReader(rc, hash), int64(*asset.Size))
 				rc.Close()
+				attach.SHA256 = hex.EncodeToString(hash.Sum(nil))
 				return err
 			}()
 			if err != nil {
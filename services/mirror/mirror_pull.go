@@ -518,6 +518,11 @@ func SyncPullMirror(ctx context.Context, repoID int64) bool {
 			continue
 		}
 
+		// git-notes are mirrored, but they are not commits anybody pushed
+		if result.refName.IsNotes() {
+			continue
+		}
+
 		// Create reference
 		if result.oldCommitID == gitShortEmptySha {
 			commitID, err := gitRepo.GetRefCommitID(result.refName.String())
@@ -28,6 +28,25 @@ import (
 
 var stripExitStatus = regexp.MustCompile(`exit status \d+ - `)
 
+// pushMirrorNotesRefspec makes push mirrors carry git-notes along with branches and tags.
+const pushMirrorNotesRefspec = "+" + git.NotesRefPrefix + "*:" + git.NotesRefPrefix + "*"
+
+// ensurePushMirrorNotesRefspec adds the git-notes refspec to push mirror remotes
+// which were created before notes were mirrored.
+func ensurePushMirrorNotesRefspec(ctx context.Context, path, remoteName string) error {
+	stdout, _, err := git.NewCommand(ctx, "config", "--get-all").AddDynamicArguments("remote." + remoteName + ".push").RunStdString(&git.RunOpts{Dir: path})
+	if err != nil {
+		return err
+	}
+	for _, refspec := range strings.Split(stdout, "\n") {
+		if strings.TrimSpace(refspec) == pushMirrorNotesRefspec {
+			return nil
+		}
+	}
+	_, _, err = git.NewCommand(ctx, "config", "--add").AddDynamicArguments("remote."+remoteName+".push", pushMirrorNotesRefspec).RunStdString(&git.RunOpts{Dir: path})
+	return err
+}
+
 // AddPushMirrorRemote registers the push mirror remote.
 var AddPushMirrorRemote = addPushMirrorRemote
 
@@ -48,6 +67,9 @@ func addPushMirrorRemote(ctx context.Context, m *repo_model.PushMirror, addr str
 		if _, _, err := git.NewCommand(ctx, "config", "--add").AddDynamicArguments("remote."+m.RemoteName+".push", "+refs/tags/*:refs/tags/*").RunStdString(&git.RunOpts{Dir: path}); err != nil {
 			return err
 		}
+		if _, _, err := git.NewCommand(ctx, "config", "--add").AddDynamicArguments("remote."+m.RemoteName+".push", pushMirrorNotesRefspec).RunStdString(&git.RunOpts{Dir: path}); err != nil {
+			return err
+		}
 		return nil
 	}
 
@@ -168,6 +190,11 @@ func runPushSync(ctx context.Context, m *repo_model.PushMirror) error {
 			}
 		}
 
+		if err := ensurePushMirrorNotesRefspec(ctx, path, m.RemoteName); err != nil {
+			log.Error("Unable to configure notes refspec for %s mirror[%d] remote %s: %v", path, m.ID, m.RemoteName, err)
+			return errors.New("Unexpected error")
+		}
+
 		log.Trace("Pushing %s mirror[%d] remote %s", path, m.ID, m.RemoteName)
 
 		// OpenSSH isn't very intuitive when you want to specify a specific keypair.
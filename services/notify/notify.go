@@ -250,6 +250,20 @@ func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues
 	}
 }
 
+// IssueChangeCustomField notifies a change of the values of a custom field to notifiers
+func IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.IssueCustomField, oldValues []string) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeCustomField(ctx, doer, issue, field, oldValues)
+	}
+}
+
+// IssueTransferred notifies the transfer of an issue to another repository to notifiers
+func IssueTransferred(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+	for _, notifier := range notifiers {
+		notifier.IssueTransferred(ctx, doer, issue, oldRepo, oldIndex)
+	}
+}
+
 // CreateRepository notifies create repository to notifiers
 func CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -142,6 +142,14 @@ func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.Use
 	addedLabels, removedLabels []*issues_model.Label) {
 }
 
+// IssueChangeCustomField places a place holder function
+func (*NullNotifier) IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.IssueCustomField, oldValues []string) {
+}
+
+// IssueTransferred places a place holder function
+func (*NullNotifier) IssueTransferred(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+}
+
 // CreateRepository places a place holder function
 func (*NullNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 }
@@ -9,6 +9,8 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -52,6 +54,19 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
 
+	if err := git_model.DeletePushPolicy(ctx, org.ID, 0); err != nil {
+		return fmt.Errorf("DeletePushPolicy: %w", err)
+	}
+
+	// The organization has no repositories left, so no issue has a value for its fields
+	if err := db.DeleteBeans(ctx, &issues_model.IssueCustomField{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteBeans: %w", err)
+	}
+
+	if err := issues_model.DeleteSavedSearchesOfOrg(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchesOfOrg: %w", err)
+	}
+
 	if err := commiter.Commit(); err != nil {
 		return err
 	}
@@ -0,0 +1,180 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+)
+
+// AutomationInfo is an automation rule with its column and label
+type AutomationInfo struct {
+	*project_model.Automation
+	Column *project_model.Column
+	// Label is nil if the rule is not restricted to a label, or if its label has been deleted
+	Label *issues_model.Label
+}
+
+// GetAutomations returns the automation rules of a project with their columns and labels
+func GetAutomations(ctx context.Context, project *project_model.Project) ([]*AutomationInfo, error) {
+	automations, err := project_model.GetAutomationsByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := project.GetColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := GetProjectLabels(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*AutomationInfo, 0, len(automations))
+	for _, automation := range automations {
+		info := &AutomationInfo{Automation: automation}
+		for _, column := range columns {
+			if column.ID == automation.ColumnID {
+				info.Column = column
+				break
+			}
+		}
+		for _, label := range labels {
+			if label.ID == automation.LabelID {
+				info.Label = label
+				break
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// GetProjectLabels returns the labels which can be set on the items of a project:
+// the labels of its repository and of the organization owning it
+func GetProjectLabels(ctx context.Context, project *project_model.Project) ([]*issues_model.Label, error) {
+	ownerID := project.OwnerID
+	var labels []*issues_model.Label
+	if project.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, project.RepoID)
+		if err != nil {
+			return nil, err
+		}
+		if labels, err = issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{}); err != nil {
+			return nil, err
+		}
+		ownerID = repo.OwnerID
+	}
+
+	owner, err := user_model.GetUserByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, owner.ID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, orgLabels...)
+	}
+	return labels, nil
+}
+
+// CreateAutomation adds an automation rule to a project, its label must be one of GetProjectLabels
+func CreateAutomation(ctx context.Context, doer *user_model.User, project *project_model.Project, event project_model.AutomationEvent, labelID, columnID int64) (*project_model.Automation, error) {
+	if labelID > 0 && event.SupportsLabel() {
+		labels, err := GetProjectLabels(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, label := range labels {
+			if label.ID == labelID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, util.NewInvalidArgumentErrorf("label %d can't be used in the project %d", labelID, project.ID)
+		}
+	}
+
+	automation := &project_model.Automation{
+		ProjectID: project.ID,
+		Event:     event,
+		LabelID:   labelID,
+		ColumnID:  columnID,
+		CreatorID: doer.ID,
+	}
+	if err := project_model.NewAutomation(ctx, automation); err != nil {
+		return nil, err
+	}
+	return automation, nil
+}
+
+// applyAutomations moves an issue or pull request on its project according to the first rule of the project
+// triggered by the event. The events which can add items apply the first matching rule of the projects it may
+// be added to if it is not on a project yet.
+func applyAutomations(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent, labels []*issues_model.Label) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+	if issue.Project == nil && !event.CanAddItems() {
+		return nil
+	}
+	if labels == nil && event.SupportsLabel() {
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		labels = issue.Labels
+	}
+
+	opts := project_model.FindAutomationsOptions{Event: event}
+	if issue.Project != nil {
+		opts.ProjectID = issue.Project.ID
+	} else {
+		opts.RepoID = issue.RepoID
+		opts.OwnerID = issue.Repo.OwnerID
+	}
+	automations, err := project_model.FindAutomations(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, automation := range automations {
+		if automation.LabelID > 0 && !hasLabel(labels, automation.LabelID) {
+			continue
+		}
+		column, err := project_model.GetColumn(ctx, automation.ColumnID)
+		if err != nil {
+			return err
+		}
+
+		log.Trace("Project automation %d moves issue %d to column %d", automation.ID, issue.ID, column.ID)
+		if issue.Project == nil {
+			return issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, column.ProjectID, column.ID)
+		}
+		return issues_model.MoveIssueToProjectColumn(ctx, issue, doer, column)
+	}
+	return nil
+}
+
+func hasLabel(labels []*issues_model.Label, labelID int64) bool {
+	for _, label := range labels {
+		if label.ID == labelID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+)
+
+// AddItemsLimit is the maximum number of issues and pull requests added to a project at once
+const AddItemsLimit = 100
+
+// AddItemsOptions selects the issues and pull requests to add to a project
+type AddItemsOptions struct {
+	Keyword  string
+	IsPull   optional.Option[bool]
+	IsClosed optional.Option[bool]
+	LabelIDs []int64
+}
+
+// AddItems adds the issues and pull requests matching the options which are not on a project yet to the default column
+// of a project. They are searched in the repository of the project, or in all the repositories of the owner of the project.
+// Only the items the doer can change are added, at most AddItemsLimit of them. It returns the number of added items.
+func AddItems(ctx context.Context, doer *user_model.User, project *project_model.Project, opts AddItemsOptions) (int, error) {
+	var repoIDs []int64
+	if project.RepoID > 0 {
+		repoIDs = []int64{project.RepoID}
+	} else {
+		repoOpts := &repo_model.SearchRepoOptions{
+			Actor:       doer,
+			OwnerID:     project.OwnerID,
+			Private:     true,
+			Collaborate: optional.None[bool](),
+			Archived:    optional.Some(false),
+		}
+		if opts.IsPull.Has() {
+			repoOpts.UnitType = unit.TypeIssues
+			if opts.IsPull.Value() {
+				repoOpts.UnitType = unit.TypePullRequests
+			}
+		}
+		var err error
+		if repoIDs, _, err = repo_model.SearchRepositoryIDs(ctx, repoOpts); err != nil {
+			return 0, err
+		}
+		if len(repoIDs) == 0 {
+			return 0, nil
+		}
+	}
+
+	searchOpts := issue_indexer.ToSearchOptions(opts.Keyword, &issues_model.IssuesOptions{
+		RepoIDs:   repoIDs,
+		IsPull:    opts.IsPull,
+		IsClosed:  opts.IsClosed,
+		LabelIDs:  opts.LabelIDs,
+		ProjectID: db.NoConditionID,
+		SortType:  "oldest",
+	})
+	searchOpts.Paginator = &db.ListOptions{Page: 1, PageSize: AddItemsLimit}
+	ids, _, err := issue_indexer.SearchIssues(ctx, searchOpts)
+	if err != nil {
+		return 0, err
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return 0, err
+	}
+
+	perms := make(map[int64]access_model.Permission)
+	added := 0
+	for _, issue := range issues {
+		perm, ok := perms[issue.RepoID]
+		if !ok {
+			if perm, err = access_model.GetUserRepoPermission(ctx, issue.Repo, doer); err != nil {
+				return added, err
+			}
+			perms[issue.RepoID] = perm
+		}
+		if issue.Repo.IsArchived || !perm.CanWriteIssuesOrPulls(issue.IsPull) {
+			continue
+		}
+		// the indexer may be outdated
+		if err := issue.LoadProject(ctx); err != nil {
+			return added, err
+		}
+		if issue.Project != nil {
+			continue
+		}
+		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, project.ID, 0); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/references"
+	notify_service "forgejo.org/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&projectNotifier{})
+}
+
+type projectNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &projectNotifier{}
+
+func (n *projectNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	if err := applyAutomations(ctx, issue.Poster, issue, project_model.AutomationEventItemOpened, nil); err != nil {
+		log.Error("applyAutomations[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, _ string, issue *issues_model.Issue, _ *issues_model.Comment, closeOrReopen bool) {
+	event := project_model.AutomationEventItemReopened
+	if closeOrReopen {
+		event = project_model.AutomationEventItemClosed
+	}
+	if err := applyAutomations(ctx, doer, issue, event, nil); err != nil {
+		log.Error("applyAutomations[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, _ []*issues_model.Label) {
+	if len(addedLabels) == 0 {
+		return
+	}
+	if err := applyAutomations(ctx, doer, issue, project_model.AutomationEventItemLabeled, addedLabels); err != nil {
+		log.Error("applyAutomations[%d]: %v", issue.ID, err)
+	}
+}
+
+func (n *projectNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, _ []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	doer := pr.Issue.Poster
+	if err := applyAutomations(ctx, doer, pr.Issue, project_model.AutomationEventItemOpened, nil); err != nil {
+		log.Error("applyAutomations[%d]: %v", pr.Issue.ID, err)
+	}
+
+	refs, err := pr.ResolveCrossReferences(ctx)
+	if err != nil {
+		log.Error("ResolveCrossReferences[%d]: %v", pr.ID, err)
+		return
+	}
+	for _, ref := range refs {
+		if ref.RefAction != references.XRefActionCloses {
+			continue
+		}
+		if err := ref.LoadIssue(ctx); err != nil {
+			log.Error("LoadIssue: %v", err)
+			continue
+		}
+		if err := applyAutomations(ctx, doer, ref.Issue, project_model.AutomationEventPullRequestOpened, nil); err != nil {
+			log.Error("applyAutomations[%d]: %v", ref.Issue.ID, err)
+		}
+	}
+}
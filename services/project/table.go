@@ -0,0 +1,290 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"sort"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+)
+
+// View is a way to display the issues and pull requests of a project
+type View string
+
+const (
+	// ViewBoard shows the items as cards in the columns of the project
+	ViewBoard View = "board"
+	// ViewTable shows the items as rows of a table
+	ViewTable View = "table"
+	// ViewTimeline shows the items on a timeline according to their deadlines
+	ViewTimeline View = "timeline"
+)
+
+// Views are all the ways to display a project
+var Views = []View{ViewBoard, ViewTable, ViewTimeline}
+
+// ParseView returns the view of the given name, the board if it is unknown
+func ParseView(name string) View {
+	for _, view := range Views {
+		if string(view) == name {
+			return view
+		}
+	}
+	return ViewBoard
+}
+
+// TableField is an optional field shown in the table view
+type TableField string
+
+const (
+	TableFieldAssignees   TableField = "assignees"
+	TableFieldLabels      TableField = "labels"
+	TableFieldMilestone   TableField = "milestone"
+	TableFieldDeadline    TableField = "deadline"
+	TableFieldTrackedTime TableField = "tracked_time"
+)
+
+// TableFields are all the optional fields of the table view
+var TableFields = []TableField{
+	TableFieldAssignees,
+	TableFieldLabels,
+	TableFieldMilestone,
+	TableFieldDeadline,
+	TableFieldTrackedTime,
+}
+
+// DefaultTableFields are the fields shown when none are selected
+var DefaultTableFields = []TableField{
+	TableFieldAssignees,
+	TableFieldLabels,
+	TableFieldMilestone,
+	TableFieldDeadline,
+}
+
+// TableGroupBy is the attribute the rows of the table view are grouped by
+type TableGroupBy string
+
+const (
+	TableGroupByNone      TableGroupBy = ""
+	TableGroupByColumn    TableGroupBy = "column"
+	TableGroupByMilestone TableGroupBy = "milestone"
+	TableGroupByAssignee  TableGroupBy = "assignee"
+	TableGroupByLabel     TableGroupBy = "label"
+)
+
+// TableGroupBys are all the ways to group the rows of the table view
+var TableGroupBys = []TableGroupBy{
+	TableGroupByNone,
+	TableGroupByColumn,
+	TableGroupByMilestone,
+	TableGroupByAssignee,
+	TableGroupByLabel,
+}
+
+// TableSortBy is the order of the rows of the table view
+type TableSortBy string
+
+const (
+	// TableSortByPosition keeps the order of the columns and of the cards in them
+	TableSortByPosition    TableSortBy = ""
+	TableSortByTitle       TableSortBy = "title"
+	TableSortByNewest      TableSortBy = "newest"
+	TableSortByOldest      TableSortBy = "oldest"
+	TableSortByDeadline    TableSortBy = "deadline"
+	TableSortByTrackedTime TableSortBy = "tracked_time"
+)
+
+// TableSortBys are all the orders of the rows of the table view
+var TableSortBys = []TableSortBy{
+	TableSortByPosition,
+	TableSortByTitle,
+	TableSortByNewest,
+	TableSortByOldest,
+	TableSortByDeadline,
+	TableSortByTrackedTime,
+}
+
+// TableOptions are the fields, grouping and order of the table view
+type TableOptions struct {
+	Fields  []TableField
+	GroupBy TableGroupBy
+	SortBy  TableSortBy
+}
+
+// ParseTableOptions returns the table options of a query, unknown values are ignored
+func ParseTableOptions(fields []string, groupBy, sortBy string) TableOptions {
+	opts := TableOptions{}
+	for _, name := range fields {
+		for _, field := range TableFields {
+			if string(field) == name && !opts.HasField(field) {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+	}
+	if len(opts.Fields) == 0 {
+		opts.Fields = DefaultTableFields
+	}
+	for _, group := range TableGroupBys {
+		if string(group) == groupBy {
+			opts.GroupBy = group
+		}
+	}
+	for _, order := range TableSortBys {
+		if string(order) == sortBy {
+			opts.SortBy = order
+		}
+	}
+	return opts
+}
+
+// HasField returns true if the field is shown
+func (opts TableOptions) HasField(field TableField) bool {
+	for _, f := range opts.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// TableRow is an issue or pull request of the table view with the column it is in
+type TableRow struct {
+	Issue  *issues_model.Issue
+	Column *project_model.Column
+}
+
+// TableGroup is a group of rows of the table view. At most one of the attributes the rows are grouped by is set,
+// none of them for the rows without it or if the rows are not grouped.
+type TableGroup struct {
+	Column    *project_model.Column
+	Milestone *issues_model.Milestone
+	Assignee  *user_model.User
+	Label     *issues_model.Label
+	Rows      []*TableRow
+}
+
+// BuildTable groups and sorts the issues of the columns of a project, their attributes must be loaded.
+// When grouped by assignee or label, an issue is in the group of each of its assignees or labels.
+func BuildTable(columns project_model.ColumnList, issuesMap map[int64]issues_model.IssueList, opts TableOptions) []*TableGroup {
+	rows := make([]*TableRow, 0, len(columns)*5)
+	for _, column := range columns {
+		for _, issue := range issuesMap[column.ID] {
+			rows = append(rows, &TableRow{Issue: issue, Column: column})
+		}
+	}
+	sortTableRows(rows, opts.SortBy)
+
+	if opts.GroupBy == TableGroupByNone {
+		return []*TableGroup{{Rows: rows}}
+	}
+
+	groups := make([]*TableGroup, 0, 10)
+	groupsByKey := make(map[int64]*TableGroup)
+	var noneGroup *TableGroup
+	addRow := func(key int64, newGroup func() *TableGroup, row *TableRow) {
+		if key == 0 {
+			if noneGroup == nil {
+				noneGroup = &TableGroup{}
+			}
+			noneGroup.Rows = append(noneGroup.Rows, row)
+			return
+		}
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = newGroup()
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	if opts.GroupBy == TableGroupByColumn {
+		// keep the empty columns and their order
+		for _, column := range columns {
+			group := &TableGroup{Column: column}
+			groupsByKey[column.ID] = group
+			groups = append(groups, group)
+		}
+	}
+
+	for _, row := range rows {
+		issue := row.Issue
+		switch opts.GroupBy {
+		case TableGroupByColumn:
+			addRow(row.Column.ID, nil, row)
+		case TableGroupByMilestone:
+			addRow(issue.MilestoneID, func() *TableGroup { return &TableGroup{Milestone: issue.Milestone} }, row)
+		case TableGroupByAssignee:
+			if len(issue.Assignees) == 0 {
+				addRow(0, nil, row)
+			}
+			for _, assignee := range issue.Assignees {
+				addRow(assignee.ID, func() *TableGroup { return &TableGroup{Assignee: assignee} }, row)
+			}
+		case TableGroupByLabel:
+			if len(issue.Labels) == 0 {
+				addRow(0, nil, row)
+			}
+			for _, label := range issue.Labels {
+				addRow(label.ID, func() *TableGroup { return &TableGroup{Label: label} }, row)
+			}
+		}
+	}
+
+	if opts.GroupBy != TableGroupByColumn {
+		sort.SliceStable(groups, func(i, j int) bool {
+			return strings.ToLower(groups[i].name()) < strings.ToLower(groups[j].name())
+		})
+	}
+	if noneGroup != nil {
+		groups = append(groups, noneGroup)
+	}
+	return groups
+}
+
+func (group *TableGroup) name() string {
+	switch {
+	case group.Column != nil:
+		return group.Column.Title
+	case group.Milestone != nil:
+		return group.Milestone.Name
+	case group.Assignee != nil:
+		return group.Assignee.Name
+	case group.Label != nil:
+		return group.Label.Name
+	}
+	return ""
+}
+
+func sortTableRows(rows []*TableRow, sortBy TableSortBy) {
+	var less func(a, b *issues_model.Issue) bool
+	switch sortBy {
+	case TableSortByTitle:
+		less = func(a, b *issues_model.Issue) bool {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+	case TableSortByNewest:
+		less = func(a, b *issues_model.Issue) bool { return a.CreatedUnix > b.CreatedUnix }
+	case TableSortByOldest:
+		less = func(a, b *issues_model.Issue) bool { return a.CreatedUnix < b.CreatedUnix }
+	case TableSortByDeadline:
+		// the items without deadline come last
+		less = func(a, b *issues_model.Issue) bool {
+			if a.DeadlineUnix == 0 || b.DeadlineUnix == 0 {
+				return b.DeadlineUnix == 0 && a.DeadlineUnix != 0
+			}
+			return a.DeadlineUnix < b.DeadlineUnix
+		}
+	case TableSortByTrackedTime:
+		less = func(a, b *issues_model.Issue) bool { return a.TotalTrackedTime > b.TotalTrackedTime }
+	default:
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return less(rows[i].Issue, rows[j].Issue)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTableOptions(t *testing.T) {
+	opts := ParseTableOptions(nil, "unknown", "unknown")
+	assert.Equal(t, DefaultTableFields, opts.Fields)
+	assert.Equal(t, TableGroupByNone, opts.GroupBy)
+	assert.Equal(t, TableSortByPosition, opts.SortBy)
+
+	opts = ParseTableOptions([]string{"tracked_time", "unknown", "labels", "tracked_time"}, "label", "deadline")
+	assert.Equal(t, []TableField{TableFieldTrackedTime, TableFieldLabels}, opts.Fields)
+	assert.True(t, opts.HasField(TableFieldLabels))
+	assert.False(t, opts.HasField(TableFieldAssignees))
+	assert.Equal(t, TableGroupByLabel, opts.GroupBy)
+	assert.Equal(t, TableSortByDeadline, opts.SortBy)
+}
+
+func TestBuildTable(t *testing.T) {
+	todo := &project_model.Column{ID: 1, Title: "To Do"}
+	done := &project_model.Column{ID: 2, Title: "Done"}
+	empty := &project_model.Column{ID: 3, Title: "Empty"}
+	bug := &issues_model.Label{ID: 1, Name: "bug"}
+	feature := &issues_model.Label{ID: 2, Name: "Feature"}
+	user := &user_model.User{ID: 1, Name: "user"}
+
+	first := &issues_model.Issue{ID: 1, Title: "b", DeadlineUnix: 200, Labels: []*issues_model.Label{feature, bug}}
+	second := &issues_model.Issue{ID: 2, Title: "a", Assignees: []*user_model.User{user}}
+	third := &issues_model.Issue{ID: 3, Title: "c", DeadlineUnix: 100, Labels: []*issues_model.Label{bug}}
+	columns := project_model.ColumnList{todo, done, empty}
+	issuesMap := map[int64]issues_model.IssueList{
+		todo.ID: {first, second},
+		done.ID: {third},
+	}
+	issueIDs := func(group *TableGroup) []int64 {
+		ids := make([]int64, 0, len(group.Rows))
+		for _, row := range group.Rows {
+			ids = append(ids, row.Issue.ID)
+		}
+		return ids
+	}
+
+	groups := BuildTable(columns, issuesMap, TableOptions{})
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, []int64{1, 2, 3}, issueIDs(groups[0]))
+		assert.Equal(t, done, groups[0].Rows[2].Column)
+	}
+
+	groups = BuildTable(columns, issuesMap, TableOptions{SortBy: TableSortByDeadline})
+	assert.Equal(t, []int64{3, 1, 2}, issueIDs(groups[0]))
+	groups = BuildTable(columns, issuesMap, TableOptions{SortBy: TableSortByTitle})
+	assert.Equal(t, []int64{2, 1, 3}, issueIDs(groups[0]))
+
+	groups = BuildTable(columns, issuesMap, TableOptions{GroupBy: TableGroupByColumn})
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, todo, groups[0].Column)
+		assert.Equal(t, []int64{1, 2}, issueIDs(groups[0]))
+		assert.Equal(t, []int64{3}, issueIDs(groups[1]))
+		assert.Equal(t, empty, groups[2].Column)
+		assert.Empty(t, groups[2].Rows)
+	}
+
+	// an issue is in the group of each of its labels, the issues without label come last
+	groups = BuildTable(columns, issuesMap, TableOptions{GroupBy: TableGroupByLabel})
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, bug, groups[0].Label)
+		assert.Equal(t, []int64{1, 3}, issueIDs(groups[0]))
+		assert.Equal(t, feature, groups[1].Label)
+		assert.Equal(t, []int64{1}, issueIDs(groups[1]))
+		assert.Nil(t, groups[2].Label)
+		assert.Equal(t, []int64{2}, issueIDs(groups[2]))
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	day := int64(24 * 3600)
+	start := timeutil.TimeStamp(time.Date(2025, 1, 10, 0, 0, 0, 0, time.Local).Unix())
+	milestone := &issues_model.Milestone{ID: 1, DeadlineUnix: start.Add(40 * day)}
+	milestone.AfterLoad()
+
+	late := &issues_model.Issue{ID: 1, CreatedUnix: start, DeadlineUnix: start.Add(60 * day)}
+	early := &issues_model.Issue{ID: 2, CreatedUnix: start.Add(10 * day), DeadlineUnix: start.Add(20 * day)}
+	inMilestone := &issues_model.Issue{ID: 3, CreatedUnix: start.Add(30 * day), MilestoneID: 1, Milestone: milestone}
+	unscheduled := &issues_model.Issue{ID: 4, CreatedUnix: start}
+
+	timeline := BuildTimeline(issues_model.IssueList{late, early, inMilestone, unscheduled})
+	assert.Equal(t, start, timeline.Start)
+	assert.Equal(t, start.Add(60*day), timeline.End)
+	assert.Equal(t, issues_model.IssueList{unscheduled}, timeline.Unscheduled)
+	if assert.Len(t, timeline.Items, 3) {
+		assert.Equal(t, early, timeline.Items[0].Issue)
+		assert.Equal(t, inMilestone, timeline.Items[1].Issue)
+		assert.True(t, timeline.Items[1].FromMilestone)
+		assert.Equal(t, late, timeline.Items[2].Issue)
+
+		assert.InDelta(t, 0, timeline.Items[2].Offset, 0.01)
+		assert.InDelta(t, 98.04, timeline.Items[2].Width, 0.01)
+		assert.InDelta(t, 16.34, timeline.Items[0].Offset, 0.01)
+	}
+	// the first days of February and March
+	assert.Len(t, timeline.Marks, 2)
+
+	assert.Empty(t, BuildTimeline(issues_model.IssueList{unscheduled}).Items)
+}
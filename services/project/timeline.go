@@ -0,0 +1,102 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"sort"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/timeutil"
+)
+
+// timelineMaxMarks is the maximum number of dates marked on a timeline
+const timelineMaxMarks = 12
+
+// TimelineItem is an issue or pull request on a timeline, from its creation to its deadline,
+// or to the deadline of its milestone if it has none
+type TimelineItem struct {
+	Issue         *issues_model.Issue
+	Start         timeutil.TimeStamp
+	End           timeutil.TimeStamp
+	FromMilestone bool // the end is the deadline of the milestone
+	// Offset and Width are the position of the item on the timeline, in percent
+	Offset float64
+	Width  float64
+}
+
+// TimelineMark is a date marked on a timeline
+type TimelineMark struct {
+	Time   time.Time
+	Offset float64 // in percent
+}
+
+// Timeline shows issues and pull requests according to their deadlines
+type Timeline struct {
+	Start       timeutil.TimeStamp
+	End         timeutil.TimeStamp
+	Items       []*TimelineItem
+	Marks       []*TimelineMark
+	Unscheduled issues_model.IssueList // the items without deadline
+}
+
+// BuildTimeline places issues on a timeline ordered by their deadlines, their milestones must be loaded
+func BuildTimeline(issues issues_model.IssueList) *Timeline {
+	timeline := &Timeline{}
+	for _, issue := range issues {
+		item := &TimelineItem{Issue: issue, Start: issue.CreatedUnix, End: issue.DeadlineUnix}
+		if item.End == 0 && issue.Milestone != nil && issue.Milestone.DeadlineString != "" {
+			item.End = issue.Milestone.DeadlineUnix
+			item.FromMilestone = true
+		}
+		if item.End == 0 {
+			timeline.Unscheduled = append(timeline.Unscheduled, issue)
+			continue
+		}
+		if item.Start > item.End {
+			item.Start = item.End
+		}
+		if timeline.Start == 0 || item.Start < timeline.Start {
+			timeline.Start = item.Start
+		}
+		if item.End > timeline.End {
+			timeline.End = item.End
+		}
+		timeline.Items = append(timeline.Items, item)
+	}
+	if len(timeline.Items) == 0 {
+		return timeline
+	}
+
+	sort.SliceStable(timeline.Items, func(i, j int) bool {
+		return timeline.Items[i].End < timeline.Items[j].End
+	})
+
+	// show at least a day, and leave some room after the last deadline
+	span := float64(timeline.End - timeline.Start)
+	if span < 24*3600 {
+		span = 24 * 3600
+	}
+	span *= 1.02
+	for _, item := range timeline.Items {
+		item.Offset = float64(item.Start-timeline.Start) * 100 / span
+		item.Width = float64(item.End-item.Start) * 100 / span
+		if item.Width < 1 {
+			item.Width = 1
+		}
+	}
+
+	start := timeline.Start.AsLocalTime()
+	month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()).AddDate(0, 1, 0)
+	end := timeline.End.AsLocalTime()
+	months := (end.Year()-month.Year())*12 + int(end.Month()) - int(month.Month()) + 1
+	step := months/timelineMaxMarks + 1
+	for ; !month.After(end); month = month.AddDate(0, step, 0) {
+		timeline.Marks = append(timeline.Marks, &TimelineMark{
+			Time:   month,
+			Offset: float64(month.Unix()-int64(timeline.Start)) * 100 / span,
+		})
+	}
+	return timeline
+}
@@ -41,6 +41,7 @@ var (
 	ErrIsChecking            = errors.New("cannot merge while conflict checking is in progress")
 	ErrNotMergeableState     = errors.New("not in mergeable state")
 	ErrDependenciesLeft      = errors.New("is blocked by an open dependency")
+	ErrMergeOutOfStackOrder  = errors.New("is stacked on top of an open pull request")
 )
 
 // AddToTaskQueue adds itself to pull request test task queue.
@@ -148,6 +149,12 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return ErrDependenciesLeft
 		}
 
+		if parent, err := GetBlockingStackParent(ctx, pr); err != nil {
+			return err
+		} else if parent != nil {
+			return ErrMergeOutOfStackOrder
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,538 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"forgejo.org/models"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// conflictAncestorLabel labels the lines of the common ancestor in the conflict markers
+const conflictAncestorLabel = "common ancestor"
+
+// ConflictSide is the side of a conflict kept to resolve it
+type ConflictSide string
+
+const (
+	// ConflictSideHead keeps the lines of the head branch
+	ConflictSideHead ConflictSide = "head"
+	// ConflictSideBase keeps the lines of the base branch
+	ConflictSideBase ConflictSide = "base"
+	// ConflictSideBoth keeps the lines of the head branch followed by the ones of the base branch
+	ConflictSideBoth ConflictSide = "both"
+)
+
+// ConflictSection is a part of a conflicted file: either lines which were merged cleanly,
+// or a conflict between the lines of the head branch and the ones of the base branch
+type ConflictSection struct {
+	IsConflict bool
+	Merged     string
+	Head       string
+	Ancestor   string
+	Base       string
+}
+
+// ConflictedFile is a text file which can't be merged cleanly
+type ConflictedFile struct {
+	Path string
+	// Content is the result of the merge, with conflict markers around the conflicts
+	Content  string
+	Sections []*ConflictSection
+
+	mode       string
+	crlf       bool
+	headMarker string
+	baseMarker string
+}
+
+// NumConflicts returns the number of conflicts in the file
+func (f *ConflictedFile) NumConflicts() int {
+	n := 0
+	for _, section := range f.Sections {
+		if section.IsConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// Resolve returns the content of the file with each conflict replaced by the lines of the side chosen for it
+func (f *ConflictedFile) Resolve(sides []ConflictSide) (string, error) {
+	var content strings.Builder
+	i := 0
+	for _, section := range f.Sections {
+		if !section.IsConflict {
+			content.WriteString(section.Merged)
+			continue
+		}
+		if i >= len(sides) {
+			return "", ErrConflictUnresolved{Path: f.Path}
+		}
+		switch sides[i] {
+		case ConflictSideHead:
+			content.WriteString(section.Head)
+		case ConflictSideBase:
+			content.WriteString(section.Base)
+		case ConflictSideBoth:
+			content.WriteString(section.Head)
+			content.WriteString(section.Base)
+		default:
+			return "", ErrConflictUnresolved{Path: f.Path}
+		}
+		i++
+	}
+	return content.String(), nil
+}
+
+// resolve returns the content of the file resolved as asked
+func (f *ConflictedFile) resolve(resolution *ConflictResolution) (string, error) {
+	if !resolution.Edited {
+		return f.Resolve(resolution.Sides)
+	}
+
+	content := resolution.Content
+	if !f.crlf {
+		// browsers send the line breaks of text areas as CRLF
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == f.headMarker || line == f.baseMarker {
+			return "", ErrConflictUnresolved{Path: f.Path}
+		}
+	}
+	return content, nil
+}
+
+// MergeConflicts are the conflicts of the merge of the base branch of a pull request into its head branch
+type MergeConflicts struct {
+	HeadCommitID string
+	BaseCommitID string
+	Files        []*ConflictedFile
+}
+
+// ConflictResolution is the resolution of a conflicted file: either a side for each of its conflicts,
+// or its whole content if it was edited
+type ConflictResolution struct {
+	Sides   []ConflictSide
+	Edited  bool
+	Content string
+}
+
+// ResolveConflictsOptions are the options to resolve the conflicts of a pull request
+type ResolveConflictsOptions struct {
+	// HeadCommitID and BaseCommitID are the commits of the branches the conflicts were resolved for
+	HeadCommitID string
+	BaseCommitID string
+	Message      string
+	// Files are the resolutions of the conflicted files, by path
+	Files map[string]*ConflictResolution
+}
+
+// ErrConflictNotResolvable represents a conflict which can't be resolved in the browser
+type ErrConflictNotResolvable struct {
+	Path   string
+	Reason string
+}
+
+// IsErrConflictNotResolvable checks if an error is an ErrConflictNotResolvable.
+func IsErrConflictNotResolvable(err error) bool {
+	_, ok := err.(ErrConflictNotResolvable)
+	return ok
+}
+
+func (err ErrConflictNotResolvable) Error() string {
+	return fmt.Sprintf("conflict in %s can't be resolved: %s", err.Path, err.Reason)
+}
+
+func (err ErrConflictNotResolvable) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrConflictUnresolved represents a conflicted file whose resolution is missing or incomplete
+type ErrConflictUnresolved struct {
+	Path string
+}
+
+// IsErrConflictUnresolved checks if an error is an ErrConflictUnresolved.
+func IsErrConflictUnresolved(err error) bool {
+	_, ok := err.(ErrConflictUnresolved)
+	return ok
+}
+
+func (err ErrConflictUnresolved) Error() string {
+	return fmt.Sprintf("conflicts in %s are not resolved", err.Path)
+}
+
+func (err ErrConflictUnresolved) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// CanResolveConflicts checks if the user can resolve the conflicts of a pull request in the browser, which
+// commits to its head branch. It is refused if the head branch is protected, or if it belongs to a fork
+// and the user can't push to it, either directly or through the maintainer edits.
+func CanResolveConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (bool, error) {
+	if doer == nil || pr.HasMerged || pr.Flow == issues_model.PullRequestFlowAGit {
+		return false, nil
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return false, err
+	}
+	if pr.Issue.IsClosed {
+		return false, nil
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return false, err
+	}
+	if pr.HeadRepo == nil || pr.HeadRepo.IsArchived {
+		return false, nil
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.HeadRepoID, pr.HeadBranch)
+	if err != nil {
+		return false, err
+	}
+	if pb != nil {
+		return false, nil
+	}
+
+	headRepoPerm, err := access_model.GetUserRepoPermission(ctx, pr.HeadRepo, doer)
+	if err != nil {
+		return false, err
+	}
+	return issues_model.CanMaintainerWriteToBranch(ctx, headRepoPerm, pr.HeadBranch, doer), nil
+}
+
+// GetMergeConflicts returns the conflicts of the merge of the base branch of a pull request into its head branch
+func GetMergeConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) (*MergeConflicts, error) {
+	_, cancel, conflicts, err := mergeBaseIntoHead(ctx, pr, doer, "")
+	if err != nil {
+		return nil, err
+	}
+	cancel()
+	return conflicts, nil
+}
+
+// ResolveMergeConflicts merges the base branch of a pull request into its head branch, resolving the conflicts
+// as given, and pushes the merge to the head branch
+func ResolveMergeConflicts(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, opts *ResolveConflictsOptions) error {
+	if ok, err := CanResolveConflicts(ctx, pr, doer); err != nil {
+		return err
+	} else if !ok {
+		return util.NewPermissionDeniedErrorf("user can't push to the head branch")
+	}
+
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	mergeCtx, cancel, conflicts, err := mergeBaseIntoHead(ctx, pr, doer, opts.BaseCommitID)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if conflicts.HeadCommitID != opts.HeadCommitID {
+		return models.ErrSHADoesNotMatch{
+			GivenSHA:   opts.HeadCommitID,
+			CurrentSHA: conflicts.HeadCommitID,
+		}
+	}
+	if len(conflicts.Files) == 0 {
+		return util.NewInvalidArgumentErrorf("the pull request has no conflicts")
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, mergeCtx.tmpBasePath)
+	if err != nil {
+		return fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	filesToAdd := make([]git.IndexObjectInfo, 0, len(conflicts.Files))
+	for _, file := range conflicts.Files {
+		resolution, ok := opts.Files[file.Path]
+		if !ok {
+			return ErrConflictUnresolved{Path: file.Path}
+		}
+		content, err := file.resolve(resolution)
+		if err != nil {
+			return err
+		}
+		objectID, err := gitRepo.HashObject(strings.NewReader(content))
+		if err != nil {
+			return err
+		}
+		filesToAdd = append(filesToAdd, git.IndexObjectInfo{Mode: file.mode, Object: objectID, Filename: file.Path})
+	}
+	if err := gitRepo.AddObjectsToIndex(filesToAdd...); err != nil {
+		return err
+	}
+
+	message := strings.TrimSpace(opts.Message)
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into %s", pr.BaseBranch, pr.HeadBranch)
+	}
+	if err := commitAndSignNoAuthor(mergeCtx, message); err != nil {
+		return err
+	}
+
+	defer func() {
+		AddTestPullRequestTask(ctx, doer, pr.BaseRepo.ID, pr.BaseBranch, false, "", "", 0)
+	}()
+
+	_, err = pushMerge(ctx, mergeCtx, mergeCtx.pr, doer, repo_module.PushTriggerPRUpdateWithBase)
+	return err
+}
+
+// mergeBaseIntoHead merges the base branch of a pull request into its head branch in a temporary repository
+// without committing, and returns the conflicts left in the index. As when a pull request is updated by merge,
+// the merge functions are used with the repositories and branches switched.
+func mergeBaseIntoHead(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedBaseCommitID string) (*mergeContext, context.CancelFunc, *MergeConflicts, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	if pr.HeadRepo == nil {
+		return nil, nil, nil, repo_model.ErrRepoNotExist{ID: pr.HeadRepoID}
+	}
+
+	reversePR := &issues_model.PullRequest{
+		ID: pr.ID,
+
+		HeadRepoID: pr.BaseRepoID,
+		HeadRepo:   pr.BaseRepo,
+		HeadBranch: pr.BaseBranch,
+
+		BaseRepoID: pr.HeadRepoID,
+		BaseRepo:   pr.HeadRepo,
+		BaseBranch: pr.HeadBranch,
+	}
+
+	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, reversePR, doer, expectedBaseCommitID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	conflicts := &MergeConflicts{}
+	if conflicts.HeadCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch); err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	if conflicts.BaseCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, trackingBranch); err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+
+	cmd := git.NewCommand(ctx, "merge", "--no-ff", "--no-commit").AddDynamicArguments(trackingBranch)
+	if err := runMergeCommand(mergeCtx, repo_model.MergeStyleMerge, cmd); err != nil {
+		if !models.IsErrMergeConflicts(err) {
+			cancel()
+			return nil, nil, nil, err
+		}
+		if conflicts.Files, err = readConflictedFiles(ctx, mergeCtx.tmpBasePath, pr); err != nil {
+			cancel()
+			return nil, nil, nil, err
+		}
+	}
+
+	return mergeCtx, cancel, conflicts, nil
+}
+
+// readConflictedFiles reads the unmerged files of the index of a temporary repository
+func readConflictedFiles(ctx context.Context, tmpBasePath string, pr *issues_model.PullRequest) ([]*ConflictedFile, error) {
+	gitRepo, err := git.OpenRepository(ctx, tmpBasePath)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	unmerged := make(chan *unmergedFile)
+	go unmergedFiles(ctx, tmpBasePath, unmerged)
+	defer func() {
+		cancel()
+		for range unmerged {
+			// empty the unmerged channel
+		}
+	}()
+
+	var files []*ConflictedFile
+	for file := range unmerged {
+		if file == nil {
+			break
+		}
+		if file.err != nil {
+			return nil, file.err
+		}
+		conflicted, err := readConflictedFile(ctx, gitRepo, tmpBasePath, pr, file)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, conflicted)
+	}
+	return files, nil
+}
+
+// readConflictedFile merges the head and base versions of an unmerged file, keeping the conflicts
+func readConflictedFile(ctx context.Context, gitRepo *git.Repository, tmpBasePath string, pr *issues_model.PullRequest, file *unmergedFile) (*ConflictedFile, error) {
+	// stage 2 is the head branch, which is checked out, and stage 3 the base branch
+	switch {
+	case file.stage2 == nil:
+		return nil, ErrConflictNotResolvable{Path: file.stage3.path, Reason: "deleted in the head branch"}
+	case file.stage3 == nil:
+		return nil, ErrConflictNotResolvable{Path: file.stage2.path, Reason: "deleted in the base branch"}
+	case file.stage2.mode != file.stage3.mode:
+		return nil, ErrConflictNotResolvable{Path: file.stage2.path, Reason: "mode changed"}
+	case file.stage2.mode != "100644" && file.stage2.mode != "100755":
+		return nil, ErrConflictNotResolvable{Path: file.stage2.path, Reason: "not a regular file"}
+	}
+	path := file.stage2.path
+
+	// the versions of the head branch, of the common ancestor if there is one, and of the base branch,
+	// in the order git merge-file takes them
+	stages := []*lsFileLine{file.stage2, file.stage1, file.stage3}
+	versions := make([]string, len(stages))
+	for i, stage := range stages {
+		var content string
+		if stage != nil {
+			blob, err := gitRepo.GetBlob(stage.sha)
+			if err != nil {
+				return nil, err
+			}
+			if blob.Size() > setting.UI.MaxDisplayFileSize {
+				return nil, ErrConflictNotResolvable{Path: path, Reason: "too large"}
+			}
+			if content, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize); err != nil {
+				return nil, err
+			}
+			if strings.ContainsRune(content, 0) || !utf8.ValidString(content) {
+				return nil, ErrConflictNotResolvable{Path: path, Reason: "binary"}
+			}
+		}
+
+		tmpFile, err := os.CreateTemp(filepath.Join(tmpBasePath, ".git"), "conflict-")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = util.Remove(tmpFile.Name())
+		}()
+		_, err = tmpFile.WriteString(content)
+		if closeErr := tmpFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions[i] = tmpFile.Name()
+	}
+
+	content, _, runErr := git.NewCommand(ctx, "merge-file", "--stdout", "--diff3").
+		AddOptionValues("-L", pr.HeadBranch).
+		AddOptionValues("-L", conflictAncestorLabel).
+		AddOptionValues("-L", pr.BaseBranch).
+		AddDashesAndList(versions...).
+		RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	// git merge-file exits with the number of conflicts, or a negative code on error
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || exitErr.ExitCode() > 127) {
+		log.Error("git merge-file %s of %-v: %v", path, pr, runErr)
+		return nil, fmt.Errorf("git merge-file: %w", runErr)
+	}
+
+	conflicted := &ConflictedFile{
+		Path:       path,
+		Content:    content,
+		mode:       file.stage2.mode,
+		crlf:       strings.Contains(content, "\r\n"),
+		headMarker: "<<<<<<< " + pr.HeadBranch,
+		baseMarker: ">>>>>>> " + pr.BaseBranch,
+	}
+	var err error
+	if conflicted.Sections, err = parseConflictSections(content, conflicted.headMarker, conflicted.baseMarker); err != nil {
+		return nil, ErrConflictNotResolvable{Path: path, Reason: err.Error()}
+	}
+	return conflicted, nil
+}
+
+// parseConflictSections splits the result of a merge into the lines merged cleanly and the conflicts,
+// delimited by conflict markers in the diff3 style
+func parseConflictSections(content, headMarker, baseMarker string) ([]*ConflictSection, error) {
+	const (
+		inMerged = iota
+		inHead
+		inAncestor
+		inBase
+	)
+	ancestorMarker := "||||||| " + conflictAncestorLabel
+
+	var sections []*ConflictSection
+	var merged, head, ancestor, base strings.Builder
+	state := inMerged
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		marker := strings.TrimRight(line, "\r\n")
+		switch {
+		case state == inMerged && marker == headMarker:
+			if merged.Len() > 0 {
+				sections = append(sections, &ConflictSection{Merged: merged.String()})
+				merged.Reset()
+			}
+			state = inHead
+		case state == inHead && marker == ancestorMarker:
+			state = inAncestor
+		case (state == inHead || state == inAncestor) && marker == "=======":
+			state = inBase
+		case state == inBase && marker == baseMarker:
+			sections = append(sections, &ConflictSection{
+				IsConflict: true,
+				Head:       head.String(),
+				Ancestor:   ancestor.String(),
+				Base:       base.String(),
+			})
+			head.Reset()
+			ancestor.Reset()
+			base.Reset()
+			state = inMerged
+		case state == inMerged:
+			merged.WriteString(line)
+		case state == inHead:
+			head.WriteString(line)
+		case state == inAncestor:
+			ancestor.WriteString(line)
+		case state == inBase:
+			base.WriteString(line)
+		}
+	}
+	if state != inMerged {
+		return nil, errors.New("unterminated conflict")
+	}
+	if merged.Len() > 0 {
+		sections = append(sections, &ConflictSection{Merged: merged.String()})
+	}
+	return sections, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflictSections(t *testing.T) {
+	content := "a\n<<<<<<< feature\nb\n||||||| common ancestor\nc\n=======\nd\ne\n>>>>>>> main\nf\n"
+	sections, err := parseConflictSections(content, "<<<<<<< feature", ">>>>>>> main")
+	require.NoError(t, err)
+	assert.Equal(t, []*ConflictSection{
+		{Merged: "a\n"},
+		{IsConflict: true, Head: "b\n", Ancestor: "c\n", Base: "d\ne\n"},
+		{Merged: "f\n"},
+	}, sections)
+
+	file := &ConflictedFile{Path: "README.md", Content: content, Sections: sections, headMarker: "<<<<<<< feature", baseMarker: ">>>>>>> main"}
+	assert.Equal(t, 1, file.NumConflicts())
+
+	resolved, err := file.Resolve([]ConflictSide{ConflictSideHead})
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nf\n", resolved)
+	resolved, err = file.Resolve([]ConflictSide{ConflictSideBase})
+	require.NoError(t, err)
+	assert.Equal(t, "a\nd\ne\nf\n", resolved)
+	resolved, err = file.Resolve([]ConflictSide{ConflictSideBoth})
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nd\ne\nf\n", resolved)
+
+	_, err = file.Resolve(nil)
+	assert.True(t, IsErrConflictUnresolved(err))
+
+	resolved, err = file.resolve(&ConflictResolution{Edited: true, Content: "a\r\nbd\r\nf\r\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "a\nbd\nf\n", resolved)
+	_, err = file.resolve(&ConflictResolution{Edited: true, Content: content})
+	assert.True(t, IsErrConflictUnresolved(err))
+
+	t.Run("Unterminated", func(t *testing.T) {
+		_, err := parseConflictSections("<<<<<<< feature\nb\n=======\n", "<<<<<<< feature", ">>>>>>> main")
+		require.Error(t, err)
+	})
+
+	t.Run("Without ancestor", func(t *testing.T) {
+		sections, err := parseConflictSections("<<<<<<< feature\nb\n=======\nd\n>>>>>>> main\n", "<<<<<<< feature", ">>>>>>> main")
+		require.NoError(t, err)
+		assert.Equal(t, []*ConflictSection{{IsConflict: true, Head: "b\n", Base: "d\n"}}, sections)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -65,23 +66,11 @@ func getMergeMessage(ctx context.Context, baseGitRepo *git.Repository, pr *issue
 	reviewedBy := pr.GetApprovers(ctx)
 
 	if mergeStyle != "" {
-		commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
+		templateContent, ok, err := getMergeMessageTemplate(ctx, baseGitRepo, pr, mergeStyle)
 		if err != nil {
 			return "", "", err
 		}
-
-		templateFilepathForgejo := fmt.Sprintf(".forgejo/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
-		templateFilepathGitea := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
-
-		templateContent, err := commit.GetFileContent(templateFilepathForgejo, setting.Repository.PullRequest.DefaultMergeMessageSize)
-		if _, ok := err.(git.ErrNotExist); ok {
-			templateContent, err = commit.GetFileContent(templateFilepathGitea, setting.Repository.PullRequest.DefaultMergeMessageSize)
-		}
-		if err != nil {
-			if !git.IsErrNotExist(err) {
-				return "", "", err
-			}
-		} else {
+		if ok {
 			vars := map[string]string{
 				"BaseRepoOwnerName":      pr.BaseRepo.OwnerName,
 				"BaseRepoName":           pr.BaseRepo.Name,
@@ -107,24 +96,44 @@ func getMergeMessage(ctx context.Context, baseGitRepo *git.Repository, pr *issue
 			refs, err := pr.ResolveCrossReferences(ctx)
 			if err == nil {
 				closeIssueIndexes := make([]string, 0, len(refs))
+				linkedIssueIndexes := make([]string, 0, len(refs))
 				closeWord := "close"
 				if len(setting.Repository.PullRequest.CloseKeywords) > 0 {
 					closeWord = setting.Repository.PullRequest.CloseKeywords[0]
 				}
 				for _, ref := range refs {
+					if err := ref.LoadIssue(ctx); err != nil {
+						return "", "", err
+					}
+					linkedIssueIndexes = append(linkedIssueIndexes, fmt.Sprintf("%s%d", issueReference, ref.Issue.Index))
 					if ref.RefAction == references.XRefActionCloses {
-						if err := ref.LoadIssue(ctx); err != nil {
-							return "", "", err
-						}
 						closeIssueIndexes = append(closeIssueIndexes, fmt.Sprintf("%s %s%d", closeWord, issueReference, ref.Issue.Index))
 					}
 				}
-				if len(closeIssueIndexes) > 0 {
-					vars["ClosingIssues"] = strings.Join(closeIssueIndexes, ", ")
-				} else {
-					vars["ClosingIssues"] = ""
+				vars["ClosingIssues"] = strings.Join(closeIssueIndexes, ", ")
+				vars["LinkedIssues"] = strings.Join(linkedIssueIndexes, ", ")
+			}
+
+			// the variables which are costly to compute are only set if the template uses them
+			usedVariables := mergeMessageTemplateVariables(templateContent)
+			if slices.Contains(usedVariables, "PullRequestReviewers") {
+				if vars["PullRequestReviewers"], err = getReviewerNames(ctx, pr); err != nil {
+					return "", "", err
 				}
 			}
+			if slices.Contains(usedVariables, "CoAuthors") || slices.Contains(usedVariables, "Trailers") {
+				if vars["CoAuthors"], err = getCoAuthorTrailers(ctx, baseGitRepo, pr); err != nil {
+					return "", "", err
+				}
+				trailers := []string{reviewedOn}
+				if reviewedBy := strings.TrimSpace(reviewedBy); reviewedBy != "" {
+					trailers = append(trailers, reviewedBy)
+				}
+				if vars["CoAuthors"] != "" {
+					trailers = append(trailers, vars["CoAuthors"])
+				}
+				vars["Trailers"] = strings.Join(trailers, "\n")
+			}
 			message, body = expandDefaultMergeMessage(templateContent, vars)
 			return message, body, nil
 		}
@@ -229,6 +238,10 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 	// Reset cached commit count
 	cache.Remove(pr.Issue.Repo.GetCommitsCountCacheKey(pr.BaseBranch, true))
 
+	if err := RetargetChildrenOnMerge(ctx, doer, pr, mergeStyle); err != nil {
+		log.Error("RetargetChildrenOnMerge %-v: %v", pr, err)
+	}
+
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
@@ -291,6 +304,11 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 		return "", models.ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
 	}
 
+	return pushMerge(ctx, mergeCtx, pr, doer, pushTrigger)
+}
+
+// pushMerge pushes the merge committed in the temporary repository to the base branch of the pull request
+func pushMerge(ctx context.Context, mergeCtx *mergeContext, pr *issues_model.PullRequest, doer *user_model.User, pushTrigger repo_module.PushTrigger) (string, error) {
 	// OK we should cache our current head and origin/headbranch
 	mergeHeadSHA, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "HEAD")
 	if err != nil {
@@ -466,6 +484,17 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 			Reason: "Does not have enough approvals",
 		}
 	}
+	reviewRuleStatuses, err := GetReviewRuleStatuses(ctx, pb, pr)
+	if err != nil {
+		return nil, fmt.Errorf("GetReviewRuleStatuses: %w", err)
+	}
+	for _, status := range reviewRuleStatuses {
+		if !status.IsSatisfied() {
+			return pb, models.ErrDisallowedToMerge{
+				Reason: fmt.Sprintf("Changes to %s do not have enough approvals", status.Rule.FilePatterns),
+			}
+		}
+	}
 	if issues_model.MergeBlockedByRejectedReview(ctx, pb, pr) {
 		return pb, models.ErrDisallowedToMerge{
 			Reason: "There are requested changes",
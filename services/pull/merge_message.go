@@ -0,0 +1,198 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// MergeMessageTemplateVariables are the variables which can be used in the merge message templates of all merge styles
+var MergeMessageTemplateVariables = []string{
+	"BaseRepoOwnerName",
+	"BaseRepoName",
+	"BaseBranch",
+	"HeadRepoOwnerName",
+	"HeadRepoName",
+	"HeadBranch",
+	"PullRequestTitle",
+	"PullRequestDescription",
+	"PullRequestPosterName",
+	"PullRequestIndex",
+	"PullRequestReference",
+	"PullRequestReviewers",
+	"CoAuthors",
+	"LinkedIssues",
+	"ClosingIssues",
+	"ReviewedOn",
+	"ReviewedBy",
+	"Trailers",
+}
+
+// rebaseMergeMessageTemplateVariables are the variables which can only be used in the template of the rebase
+// merge style, whose message is used to amend each rebased commit
+var rebaseMergeMessageTemplateVariables = []string{"CommitTitle", "CommitBody"}
+
+// MergeStylesWithMessageTemplate are the merge styles whose default merge message can be set by a template
+var MergeStylesWithMessageTemplate = []repo_model.MergeStyle{
+	repo_model.MergeStyleMerge,
+	repo_model.MergeStyleRebase,
+	repo_model.MergeStyleRebaseMerge,
+	repo_model.MergeStyleSquash,
+}
+
+// ErrInvalidMergeMessageTemplate represents a merge message template which can't be used
+type ErrInvalidMergeMessageTemplate struct {
+	MergeStyle repo_model.MergeStyle
+	Reason     string
+}
+
+// IsErrInvalidMergeMessageTemplate checks if an error is an ErrInvalidMergeMessageTemplate.
+func IsErrInvalidMergeMessageTemplate(err error) bool {
+	_, ok := err.(ErrInvalidMergeMessageTemplate)
+	return ok
+}
+
+func (err ErrInvalidMergeMessageTemplate) Error() string {
+	return fmt.Sprintf("invalid merge message template for %s: %s", err.MergeStyle, err.Reason)
+}
+
+func (err ErrInvalidMergeMessageTemplate) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ValidateMergeMessageTemplates checks that the merge message templates of a repository only use known
+// variables and can be used by their merge style
+func ValidateMergeMessageTemplates(templates map[repo_model.MergeStyle]string) error {
+	for mergeStyle, template := range templates {
+		if template == "" {
+			continue
+		}
+		if !slices.Contains(MergeStylesWithMessageTemplate, mergeStyle) {
+			return ErrInvalidMergeMessageTemplate{MergeStyle: mergeStyle, Reason: "the merge style does not have a merge message"}
+		}
+		if maxSize := setting.Repository.PullRequest.DefaultMergeMessageSize; maxSize > 0 && len(template) > maxSize {
+			return ErrInvalidMergeMessageTemplate{MergeStyle: mergeStyle, Reason: fmt.Sprintf("the template is longer than %d bytes", maxSize)}
+		}
+		for _, name := range mergeMessageTemplateVariables(template) {
+			if !slices.Contains(MergeMessageTemplateVariables, name) &&
+				(mergeStyle != repo_model.MergeStyleRebase || !slices.Contains(rebaseMergeMessageTemplateVariables, name)) {
+				return ErrInvalidMergeMessageTemplate{MergeStyle: mergeStyle, Reason: fmt.Sprintf("unknown variable %q", name)}
+			}
+		}
+	}
+	return nil
+}
+
+// mergeMessageTemplateVariables returns the names of the variables used in a merge message template
+func mergeMessageTemplateVariables(template string) []string {
+	var names []string
+	os.Expand(template, func(name string) string {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		return ""
+	})
+	return names
+}
+
+// getMergeMessageTemplate returns the template of the default merge message of a merge style: the one set
+// in the settings of the repository, or else the one of the default branch of the repository
+func getMergeMessageTemplate(ctx context.Context, baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (string, bool, error) {
+	if prUnit, err := pr.BaseRepo.GetUnit(ctx, unit.TypePullRequests); err == nil {
+		if template := prUnit.PullRequestsConfig().GetMergeMessageTemplate(mergeStyle); template != "" {
+			return template, true, nil
+		}
+	}
+
+	commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
+	if err != nil {
+		return "", false, err
+	}
+
+	templateFilepathForgejo := fmt.Sprintf(".forgejo/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
+	templateFilepathGitea := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
+
+	templateContent, err := commit.GetFileContent(templateFilepathForgejo, setting.Repository.PullRequest.DefaultMergeMessageSize)
+	if _, ok := err.(git.ErrNotExist); ok {
+		templateContent, err = commit.GetFileContent(templateFilepathGitea, setting.Repository.PullRequest.DefaultMergeMessageSize)
+	}
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return templateContent, true, nil
+}
+
+// getReviewerNames returns the names of the users who approved a pull request, separated by commas
+func getReviewerNames(ctx context.Context, pr *issues_model.PullRequest) (string, error) {
+	reviews, err := issues_model.FindLatestReviews(ctx, issues_model.FindReviewOptions{
+		Types:        []issues_model.ReviewType{issues_model.ReviewTypeApprove},
+		IssueID:      pr.IssueID,
+		OfficialOnly: setting.Repository.PullRequest.DefaultMergeMessageOfficialApproversOnly,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		if err := review.LoadReviewer(ctx); err != nil && !user_model.IsErrUserNotExist(err) {
+			return "", err
+		} else if review.Reviewer == nil {
+			continue
+		}
+		names = append(names, review.Reviewer.Name)
+	}
+	return strings.Join(names, ", "), nil
+}
+
+// getCoAuthorTrailers returns a Co-authored-by trailer for each author of the commits of a pull request,
+// except its poster, within the limits of the default squash merge message
+func getCoAuthorTrailers(ctx context.Context, baseGitRepo *git.Repository, pr *issues_model.PullRequest) (string, error) {
+	headCommitID, err := baseGitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return "", err
+	}
+	headCommit, err := baseGitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return "", err
+	}
+	mergeBase, err := baseGitRepo.GetCommit(pr.MergeBase)
+	if err != nil {
+		return "", err
+	}
+
+	limit := setting.Repository.PullRequest.DefaultMergeMessageCommitsLimit
+	commits, err := baseGitRepo.CommitsBetweenLimit(headCommit, mergeBase, limit, 0)
+	if err != nil {
+		return "", err
+	}
+
+	authors, err := newCoAuthors(ctx, pr.Issue.Poster)
+	if err != nil {
+		return "", err
+	}
+	// commits list is in reverse chronological order
+	for i := len(commits) - 1; i >= 0; i-- {
+		authors.add(commits[i])
+	}
+	if err := authors.addRemaining(baseGitRepo, headCommit, mergeBase, limit); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(authors.trailers(), "\n"), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/references"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMessageTemplateVariables(t *testing.T) {
+	assert.Equal(t, []string{"PullRequestTitle", "PullRequestIndex"},
+		mergeMessageTemplateVariables("${PullRequestTitle} (#$PullRequestIndex)\n\n${PullRequestTitle}"))
+	assert.Empty(t, mergeMessageTemplateVariables("Merge pull request"))
+}
+
+func TestValidateMergeMessageTemplates(t *testing.T) {
+	require.NoError(t, ValidateMergeMessageTemplates(nil))
+	require.NoError(t, ValidateMergeMessageTemplates(map[repo_model.MergeStyle]string{
+		repo_model.MergeStyleMerge:  "Merge ${PullRequestReference}: ${PullRequestTitle}\n\n${ClosingIssues}\n${Trailers}",
+		repo_model.MergeStyleRebase: "${CommitTitle}\n\n${CommitBody}\n\n${ReviewedOn}",
+		repo_model.MergeStyleSquash: "${PullRequestTitle}\n\n${PullRequestDescription}\n\n${CoAuthors}",
+	}))
+	// an empty template is not set
+	require.NoError(t, ValidateMergeMessageTemplates(map[repo_model.MergeStyle]string{
+		repo_model.MergeStyleFastForwardOnly: "",
+	}))
+
+	err := ValidateMergeMessageTemplates(map[repo_model.MergeStyle]string{
+		repo_model.MergeStyleMerge: "Merge ${PullRequestTitel}",
+	})
+	require.True(t, IsErrInvalidMergeMessageTemplate(err))
+	assert.Equal(t, `invalid merge message template for merge: unknown variable "PullRequestTitel"`, err.Error())
+
+	// the message of the rebased commit is only known when rebasing
+	err = ValidateMergeMessageTemplates(map[repo_model.MergeStyle]string{
+		repo_model.MergeStyleSquash: "${CommitTitle}",
+	})
+	assert.True(t, IsErrInvalidMergeMessageTemplate(err))
+
+	err = ValidateMergeMessageTemplates(map[repo_model.MergeStyle]string{
+		repo_model.MergeStyleFastForwardOnly: "${PullRequestTitle}",
+	})
+	assert.True(t, IsErrInvalidMergeMessageTemplate(err))
+}
+
+// addMergeMessageTemplateBranch creates a branch on top of master which adds a merge message template file
+func addMergeMessageTemplateBranch(t *testing.T, repoPath, branch, treePath, template string) {
+	t.Helper()
+	env := []string{
+		"GIT_INDEX_FILE=" + filepath.Join(t.TempDir(), "index"),
+		"GIT_AUTHOR_NAME=User Two", "GIT_AUTHOR_EMAIL=user2@example.com",
+		"GIT_COMMITTER_NAME=User Two", "GIT_COMMITTER_EMAIL=user2@example.com",
+	}
+	run := func(cmd *git.Command, stdin string) string {
+		stdout, _, err := cmd.RunStdString(&git.RunOpts{Dir: repoPath, Env: env, Stdin: strings.NewReader(stdin)})
+		require.NoError(t, err)
+		return strings.TrimSpace(stdout)
+	}
+
+	blobID := run(git.NewCommand(git.DefaultContext, "hash-object", "-w", "--stdin"), template)
+	run(git.NewCommand(git.DefaultContext, "read-tree", "master"), "")
+	run(git.NewCommand(git.DefaultContext, "update-index", "--add", "--cacheinfo").AddDynamicArguments("100644,"+blobID+","+treePath), "")
+	treeID := run(git.NewCommand(git.DefaultContext, "write-tree"), "")
+	commitID := run(git.NewCommand(git.DefaultContext, "commit-tree", "-p", "master", "-m", "Add a merge message template").AddDynamicArguments(treeID), "")
+	run(git.NewCommand(git.DefaultContext, "update-ref").AddDynamicArguments("refs/heads/"+branch, commitID), "")
+	t.Cleanup(func() {
+		run(git.NewCommand(git.DefaultContext, "update-ref", "-d").AddDynamicArguments("refs/heads/"+branch), "")
+	})
+}
+
+func TestGetDefaultMergeMessageTemplates(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Repository.PullRequest.DefaultMergeMessageOfficialApproversOnly, true)()
+
+	// pull request 2 is #3 of user2/repo1 from branch2 into master, its commit is authored by someone else than its poster
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	require.NoError(t, pr.LoadBaseRepo(db.DefaultContext))
+	// the pull request closes issue #1 and is approved by user2
+	require.NoError(t, db.Insert(db.DefaultContext, &issues_model.Comment{
+		Type:       issues_model.CommentTypePullRef,
+		PosterID:   2,
+		IssueID:    1,
+		RefRepoID:  1,
+		RefIssueID: pr.IssueID,
+		RefIsPull:  true,
+		RefAction:  references.XRefActionCloses,
+	}))
+	require.NoError(t, db.Insert(db.DefaultContext, &issues_model.Review{Type: issues_model.ReviewTypeApprove, ReviewerID: 2, IssueID: pr.IssueID, Official: true}))
+
+	// the default branch has a template file
+	addMergeMessageTemplateBranch(t, pr.BaseRepo.RepoPath(), "merge-message-template",
+		".forgejo/default_merge_message/MERGE_TEMPLATE.md", "Template file for ${PullRequestTitle}\n")
+	pr.BaseRepo.DefaultBranch = "merge-message-template"
+
+	gitRepo, err := gitrepo.OpenRepository(git.DefaultContext, pr.BaseRepo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	message, body, err := GetDefaultMergeMessage(db.DefaultContext, gitRepo, pr, repo_model.MergeStyleMerge)
+	require.NoError(t, err)
+	assert.Equal(t, "Template file for issue3", message)
+	assert.Empty(t, body)
+
+	// without template, the rebase-merge message is the one of a merge commit
+	message, body, err = GetDefaultMergeMessage(db.DefaultContext, gitRepo, pr, repo_model.MergeStyleRebaseMerge)
+	require.NoError(t, err)
+	assert.Equal(t, "Merge pull request 'issue3' (#3) from branch2 into master", message)
+	assert.Equal(t, "Reviewed-on: https://try.gitea.io/user2/repo1/pulls/3\n"+pr.GetApprovers(db.DefaultContext), body)
+
+	// the templates of the settings take precedence over the template files
+	pr.BaseRepo.Units = []*repo_model.RepoUnit{{
+		RepoID: pr.BaseRepoID,
+		Type:   unit.TypePullRequests,
+		Config: &repo_model.PullRequestsConfig{
+			MergeMessageTemplates: map[repo_model.MergeStyle]string{
+				repo_model.MergeStyleMerge: "Merge ${PullRequestReference}: ${PullRequestTitle}\n\n" +
+					"Linked: ${LinkedIssues}\nReviewers: ${PullRequestReviewers}\n\n${CoAuthors}\n---\n${Trailers}",
+				repo_model.MergeStyleRebaseMerge: "Rebase ${HeadBranch} onto ${BaseBranch} (${PullRequestReference})\n\n${ClosingIssues}",
+			},
+		},
+	}}
+
+	coAuthor := "Co-authored-by: user1 address1@example.com <art27@cantab.net>"
+	approvers := pr.GetApprovers(db.DefaultContext)
+	assert.True(t, strings.HasPrefix(approvers, "Reviewed-by: "))
+
+	message, body, err = GetDefaultMergeMessage(db.DefaultContext, gitRepo, pr, repo_model.MergeStyleMerge)
+	require.NoError(t, err)
+	assert.Equal(t, "Merge #3: issue3", message)
+	assert.Equal(t, "Linked: #1\nReviewers: user2\n\n"+coAuthor+"\n---\n"+
+		"Reviewed-on: https://try.gitea.io/user2/repo1/pulls/3\n"+strings.TrimSpace(approvers)+"\n"+coAuthor, body)
+
+	message, body, err = GetDefaultMergeMessage(db.DefaultContext, gitRepo, pr, repo_model.MergeStyleRebaseMerge)
+	require.NoError(t, err)
+	assert.Equal(t, "Rebase branch2 onto master (#3)", message)
+	assert.Equal(t, "close #1", body)
+}
@@ -236,6 +236,12 @@ func getDiffTree(ctx context.Context, repoPath, baseBranch, headBranch string, o
 // rebaseTrackingOnToBase checks out the tracking branch as staging and rebases it on to the base branch
 // if there is a conflict it will return a models.ErrRebaseConflicts
 func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle) error {
+	return rebaseTrackingOnToBaseFrom(ctx, mergeStyle, baseBranch)
+}
+
+// rebaseTrackingOnToBaseFrom does the same as rebaseTrackingOnToBase, but only rebases the commits of the
+// tracking branch which are not reachable from upstream
+func rebaseTrackingOnToBaseFrom(ctx *mergeContext, mergeStyle repo_model.MergeStyle, upstream string) error {
 	// Create staging branch
 	if err := git.NewCommand(ctx, "branch").AddDynamicArguments(stagingBranch, trackingBranch).
 		Run(ctx.RunOpts()); err != nil {
@@ -257,7 +263,7 @@ func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle)
 		// Use git-replay for performance and to preserve unknown headers,
 		// like the "change-id" header used by Jujutsu and GitButler.
 		if err := git.NewCommand(ctx, "replay", "--onto").AddDynamicArguments(baseBranch).
-			AddDynamicArguments(fmt.Sprintf("%s..%s", baseBranch, stagingBranch)).
+			AddDynamicArguments(fmt.Sprintf("%s..%s", upstream, stagingBranch)).
 			Run(ctx.RunOpts()); err != nil {
 			// git-replay doesn't tell us which commit first created a merge conflict.
 			// In order to preserve the quality of our error messages, fall back to
@@ -307,7 +313,11 @@ regular_rebase:
 	ctx.errbuf.Reset()
 
 	// Rebase before merging
-	if err := git.NewCommand(ctx, "rebase").AddDynamicArguments(baseBranch).
+	rebaseCmd := git.NewCommand(ctx, "rebase")
+	if upstream != baseBranch {
+		rebaseCmd.AddOptionValues("--onto", baseBranch)
+	}
+	if err := rebaseCmd.AddDynamicArguments(upstream).
 		Run(ctx.RunOpts()); err != nil {
 		// Rebase will leave a REBASE_HEAD file in .git if there is a conflict
 		if _, statErr := os.Stat(filepath.Join(ctx.tmpBasePath, ".git", "REBASE_HEAD")); statErr == nil {
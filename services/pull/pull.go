@@ -40,7 +40,7 @@ import (
 var pullWorkingPool = sync.NewExclusivePool()
 
 // NewPullRequest creates new pull request with labels for repository.
-func NewPullRequest(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, pr *issues_model.PullRequest, assigneeIDs []int64) error {
+func NewPullRequest(ctx context.Context, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, uuids []string, pr *issues_model.PullRequest, assigneeIDs []int64, formValues *issues_model.IssueFormValues) error {
 	// Check if the doer is not blocked by the repository's owner.
 	if user_model.IsBlocked(ctx, repo.OwnerID, issue.PosterID) {
 		return user_model.ErrBlockedByUser
@@ -80,6 +80,9 @@ func NewPullRequest(ctx context.Context, repo *repo_model.Repository, issue *iss
 		if err := issues_model.NewPullRequest(ctx, repo, issue, labelIDs, uuids, pr); err != nil {
 			return err
 		}
+		if err := issue_service.SaveTemplateFormValues(ctx, issue, formValues); err != nil {
+			return err
+		}
 
 		for _, assigneeID := range assigneeIDs {
 			comment, err := issue_service.AddAssigneeIfNotAssigned(ctx, issue, issue.Poster, assigneeID, false)
@@ -577,41 +580,78 @@ func (errs errlist) Error() string {
 	return ""
 }
 
-// RetargetChildrenOnMerge retarget children pull requests on merge if possible
-func RetargetChildrenOnMerge(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
-	if setting.Repository.PullRequest.RetargetChildrenOnMerge && pr.BaseRepoID == pr.HeadRepoID {
-		return RetargetBranchPulls(ctx, doer, pr.HeadRepoID, pr.HeadBranch, pr.BaseBranch)
+// RetargetChildrenOnMerge retarget children pull requests on merge if possible. When the merge style rewrote
+// the commits of pr, the head branches of the children are also rebased on to the base branch, so that they
+// only contain their own commits. mergeStyle is empty when the pull request is not being merged.
+func RetargetChildrenOnMerge(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) error {
+	if !setting.Repository.PullRequest.RetargetChildrenOnMerge || pr.BaseRepoID != pr.HeadRepoID {
+		return nil
 	}
-	return nil
+
+	children, err := retargetBranchPulls(ctx, doer, pr.HeadRepoID, pr.HeadBranch, pr.BaseBranch)
+	if mergeStyle == "" || mergeStyle == repo_model.MergeStyleMerge || mergeStyle == repo_model.MergeStyleFastForwardOnly {
+		return err
+	}
+
+	for _, child := range children {
+		if child.Flow != issues_model.PullRequestFlowGithub {
+			continue
+		}
+		if err := child.LoadHeadRepo(ctx); err != nil {
+			log.Error("LoadHeadRepo %-v: %v", child, err)
+			continue
+		}
+		_, rebaseAllowed, err := IsUserAllowedToUpdate(ctx, child, doer)
+		if err != nil {
+			log.Error("IsUserAllowedToUpdate %-v: %v", child, err)
+			continue
+		} else if !rebaseAllowed {
+			continue
+		}
+		if err := rebaseStackedPullRequest(ctx, doer, child, pr); err != nil {
+			log.Warn("Unable to rebase stacked %-v on to %s: %v", child, pr.BaseBranch, err)
+		}
+	}
+	return err
 }
 
 // RetargetBranchPulls change target branch for all pull requests whose base branch is the branch
 // Both branch and targetBranch must be in the same repo (for security reasons)
 func RetargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, targetBranch string) error {
+	_, err := retargetBranchPulls(ctx, doer, repoID, branch, targetBranch)
+	return err
+}
+
+// retargetBranchPulls is RetargetBranchPulls returning the pull requests which have been retargeted
+func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, targetBranch string) ([]*issues_model.PullRequest, error) {
 	prs, err := issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, repoID, branch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := issues_model.PullRequestList(prs).LoadAttributes(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
+	retargeted := make([]*issues_model.PullRequest, 0, len(prs))
 	var errs errlist
 	for _, pr := range prs {
 		if err = pr.Issue.LoadRepo(ctx); err != nil {
 			errs = append(errs, err)
-		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil &&
-			!issues_model.IsErrIssueIsClosed(err) && !models.IsErrPullRequestHasMerged(err) &&
-			!issues_model.IsErrPullRequestAlreadyExists(err) {
-			errs = append(errs, err)
+		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil {
+			if !issues_model.IsErrIssueIsClosed(err) && !models.IsErrPullRequestHasMerged(err) &&
+				!issues_model.IsErrPullRequestAlreadyExists(err) {
+				errs = append(errs, err)
+			}
+		} else {
+			retargeted = append(retargeted, pr)
 		}
 	}
 
 	if len(errs) > 0 {
-		return errs
+		return retargeted, errs
 	}
-	return nil
+	return retargeted, nil
 }
 
 // CloseBranchPulls close all the pull requests who's head branch is the branch
@@ -681,6 +721,79 @@ func CloseRepoBranchesPulls(ctx context.Context, doer *user_model.User, repo *re
 
 var commitMessageTrailersPattern = regexp.MustCompile(`(?:^|\n\n)(?:[\w-]+[ \t]*:[^\n]+\n*(?:[ \t]+[^\n]+\n*)*)+$`)
 
+// coAuthors collects the authors of the commits of a pull request other than its poster
+type coAuthors struct {
+	posterSig    string
+	posterEmails container.Set[string]
+	unique       container.Set[string]
+	authors      []string
+}
+
+func newCoAuthors(ctx context.Context, poster *user_model.User) (*coAuthors, error) {
+	emails, err := user_model.GetEmailAddresses(ctx, poster.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the poster may have authored commits with any of their addresses, which are compared
+	// instead of looking up the user of every author
+	posterEmails := container.SetOf(strings.ToLower(poster.GetPlaceholderEmail()))
+	for _, email := range emails {
+		if email.IsActivated {
+			posterEmails.Add(email.LowerEmail)
+		}
+	}
+	return &coAuthors{
+		posterSig:    poster.NewGitSig().String(),
+		posterEmails: posterEmails,
+		unique:       make(container.Set[string]),
+	}, nil
+}
+
+// add adds the author of the commit unless it is the poster or was already added
+func (c *coAuthors) add(commit *git.Commit) {
+	if commit.Author == nil {
+		return
+	}
+	authorString := commit.Author.String()
+	if !c.unique.Add(authorString) || authorString == c.posterSig || c.posterEmails.Contains(strings.ToLower(commit.Author.Email)) {
+		return
+	}
+	c.authors = append(c.authors, authorString)
+}
+
+// addRemaining adds the authors of the commits after the limit first ones, if all authors are to be listed
+func (c *coAuthors) addRemaining(gitRepo *git.Repository, headCommit, mergeBase *git.Commit, limit int) error {
+	if limit < 0 || !setting.Repository.PullRequest.DefaultMergeMessageAllAuthors {
+		return nil
+	}
+	skip := limit
+	limit = 30
+	for {
+		commits, err := gitRepo.CommitsBetweenLimit(headCommit, mergeBase, limit, skip)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			return nil
+		}
+		for _, commit := range commits {
+			c.add(commit)
+		}
+		skip += limit
+	}
+}
+
+// trailers returns a Co-authored-by trailer for each author, one per line
+func (c *coAuthors) trailers() string {
+	var trailers strings.Builder
+	for _, author := range c.authors {
+		trailers.WriteString("Co-authored-by: ")
+		trailers.WriteString(author)
+		trailers.WriteByte('\n')
+	}
+	return trailers.String()
+}
+
 // GetSquashMergeCommitMessages returns the commit messages between head and merge base (if there is one)
 func GetSquashMergeCommitMessages(ctx context.Context, pr *issues_model.PullRequest) string {
 	if err := pr.LoadIssue(ctx); err != nil {
@@ -739,10 +852,11 @@ func GetSquashMergeCommitMessages(ctx context.Context, pr *issues_model.PullRequ
 		return ""
 	}
 
-	posterSig := pr.Issue.Poster.NewGitSig().String()
-
-	uniqueAuthors := make(container.Set[string])
-	authors := make([]string, 0, len(commits))
+	authors, err := newCoAuthors(ctx, pr.Issue.Poster)
+	if err != nil {
+		log.Error("Unable to get the email addresses of the poster %d: %v", pr.Issue.PosterID, err)
+		return ""
+	}
 	stringBuilder := strings.Builder{}
 
 	if !setting.Repository.PullRequest.PopulateSquashCommentWithCommitMessages {
@@ -787,58 +901,16 @@ func GetSquashMergeCommitMessages(ctx context.Context, pr *issues_model.PullRequ
 			}
 		}
 
-		authorString := commit.Author.String()
-		if uniqueAuthors.Add(authorString) && authorString != posterSig {
-			// Compare use account as well to avoid adding the same author multiple times
-			// times when email addresses are private or multiple emails are used.
-			commitUser, _ := user_model.GetUserByEmail(ctx, commit.Author.Email)
-			if commitUser == nil || commitUser.ID != pr.Issue.Poster.ID {
-				authors = append(authors, authorString)
-			}
-		}
+		authors.add(commit)
 	}
 
 	// Consider collecting the remaining authors
-	if limit >= 0 && setting.Repository.PullRequest.DefaultMergeMessageAllAuthors {
-		skip := limit
-		limit = 30
-		for {
-			commits, err := gitRepo.CommitsBetweenLimit(headCommit, mergeBase, limit, skip)
-			if err != nil {
-				log.Error("Unable to get commits between: %s %s Error: %v", pr.HeadBranch, pr.MergeBase, err)
-				return ""
-			}
-			if len(commits) == 0 {
-				break
-			}
-			for _, commit := range commits {
-				authorString := commit.Author.String()
-				if uniqueAuthors.Add(authorString) && authorString != posterSig {
-					commitUser, _ := user_model.GetUserByEmail(ctx, commit.Author.Email)
-					if commitUser == nil || commitUser.ID != pr.Issue.Poster.ID {
-						authors = append(authors, authorString)
-					}
-				}
-			}
-			skip += limit
-		}
-	}
-
-	for _, author := range authors {
-		if _, err := stringBuilder.WriteString("Co-authored-by: "); err != nil {
-			log.Error("Unable to write to string builder Error: %v", err)
-			return ""
-		}
-		if _, err := stringBuilder.WriteString(author); err != nil {
-			log.Error("Unable to write to string builder Error: %v", err)
-			return ""
-		}
-		if _, err := stringBuilder.WriteRune('\n'); err != nil {
-			log.Error("Unable to write to string builder Error: %v", err)
-			return ""
-		}
+	if err := authors.addRemaining(gitRepo, headCommit, mergeBase, limit); err != nil {
+		log.Error("Unable to get commits between: %s %s Error: %v", pr.HeadBranch, pr.MergeBase, err)
+		return ""
 	}
 
+	stringBuilder.WriteString(authors.trailers())
 	return stringBuilder.String()
 }
 
@@ -12,6 +12,7 @@ import (
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
 
@@ -92,3 +93,25 @@ func TestPullRequest_GetDefaultMergeMessage_ExternalTracker(t *testing.T) {
 
 	assert.Equal(t, "Merge pull request 'issue3' (#3) from user2/repo2:branch2 into master", mergeMessage)
 }
+
+func TestCoAuthors(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	poster := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	authors, err := newCoAuthors(db.DefaultContext, poster)
+	require.NoError(t, err)
+	for _, author := range []*git.Signature{
+		{Name: "User Two", Email: "User2@example.com"},
+		{Name: "user2", Email: poster.GetPlaceholderEmail()},
+		{Name: "User Two", Email: "user2-2@example.com"},
+		{Name: "User Five", Email: "user5@example.com"},
+		{Name: "User Five", Email: "user5@example.com"},
+	} {
+		authors.add(&git.Commit{Author: author})
+	}
+	authors.add(&git.Commit{})
+
+	// the inactive email address of the poster is not theirs
+	assert.Equal(t, "Co-authored-by: User Two <user2-2@example.com>\n"+
+		"Co-authored-by: User Five <user5@example.com>\n", authors.trailers())
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
+)
+
+// ReviewRuleStatus is the state of a review rule of a protected branch for a pull request
+type ReviewRuleStatus struct {
+	Rule *git_model.ReviewRule
+	// Teams are the teams whose members can approve the changes, empty if anyone with write access can
+	Teams            []*organization.Team
+	MatchedFiles     []string
+	GrantedApprovals int64
+}
+
+// IsSatisfied returns true if the changes matched by the rule have enough approvals
+func (status *ReviewRuleStatus) IsSatisfied() bool {
+	return status.GrantedApprovals >= status.Rule.RequiredApprovals
+}
+
+// GetReviewRuleStatuses evaluates the review rules of a protected branch for the changes of pr.
+// Only the rules matching at least one of the changed files are returned.
+func GetReviewRuleStatuses(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*ReviewRuleStatus, error) {
+	if len(pb.ReviewRules) == 0 || pr.IsEmpty() {
+		return nil, nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	changedFiles, err := gitRepo.GetFilesChangedBetween(pr.MergeBase, pr.GetGitRefName())
+	if err != nil {
+		return nil, fmt.Errorf("GetFilesChangedBetween: %w", err)
+	}
+
+	var approvers []*user_model.User
+	reviews, err := issues_model.GetReviewsByIssueID(ctx, pr.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range reviews {
+		if review.Type != issues_model.ReviewTypeApprove || review.ReviewerID <= 0 ||
+			(pb.IgnoreStaleApprovals && review.Stale) {
+			continue
+		}
+		if err := review.LoadReviewer(ctx); err != nil {
+			return nil, err
+		}
+		if review.Reviewer.IsGhost() {
+			continue
+		}
+		approvers = append(approvers, review.Reviewer)
+	}
+
+	statuses := make([]*ReviewRuleStatus, 0, len(pb.ReviewRules))
+	for _, rule := range pb.ReviewRules {
+		matchedFiles := rule.MatchFiles(changedFiles)
+		if len(matchedFiles) == 0 {
+			continue
+		}
+		status := &ReviewRuleStatus{Rule: rule, MatchedFiles: matchedFiles}
+		for _, teamID := range rule.TeamIDs {
+			team, err := organization.GetTeamByID(ctx, teamID)
+			if err != nil {
+				if organization.IsErrTeamNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			status.Teams = append(status.Teams, team)
+		}
+
+		for _, approver := range approvers {
+			canApprove, err := canApproveReviewRule(ctx, pr, rule, approver)
+			if err != nil {
+				return nil, err
+			}
+			if canApprove {
+				status.GrantedApprovals++
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// canApproveReviewRule returns true if the approval of user counts towards the required approvals of rule
+func canApproveReviewRule(ctx context.Context, pr *issues_model.PullRequest, rule *git_model.ReviewRule, user *user_model.User) (bool, error) {
+	if len(rule.TeamIDs) > 0 {
+		return organization.IsUserInTeams(ctx, user.ID, rule.TeamIDs)
+	}
+	return access_model.HasAccessUnit(ctx, user, pr.BaseRepo, unit.TypeCode, perm.AccessModeWrite)
+}
+
+// MergeBlockedByReviewRules returns true if one of the review rule statuses does not have enough approvals
+func MergeBlockedByReviewRules(statuses []*ReviewRuleStatus) bool {
+	for _, status := range statuses {
+		if !status.IsSatisfied() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"forgejo.org/models"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReviewRuleStatuses(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// pull request 2 changes iso-8859-1.txt, it has a stale approval by user4 who cannot write to the repository
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	pb := &git_model.ProtectedBranch{
+		RepoID:   repo.ID,
+		RuleName: "master",
+		ReviewRules: []*git_model.ReviewRule{
+			{FilePatterns: "*.txt", RequiredApprovals: 1},
+			{FilePatterns: "docs/**", RequiredApprovals: 2},
+		},
+	}
+	require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, pb, git_model.WhitelistOptions{}))
+
+	statuses, err := GetReviewRuleStatuses(db.DefaultContext, pb, pr)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "*.txt", statuses[0].Rule.FilePatterns)
+	assert.Equal(t, []string{"iso-8859-1.txt"}, statuses[0].MatchedFiles)
+	assert.EqualValues(t, 0, statuses[0].GrantedApprovals)
+	assert.True(t, MergeBlockedByReviewRules(statuses))
+
+	_, err = CheckPullBranchProtections(db.DefaultContext, pr, false)
+	require.Error(t, err)
+	assert.True(t, models.IsErrDisallowedToMerge(err))
+	assert.Contains(t, err.Error(), "Changes to *.txt do not have enough approvals")
+
+	// the owner of the repository approves the changes
+	require.NoError(t, db.Insert(db.DefaultContext, &issues_model.Review{Type: issues_model.ReviewTypeApprove, ReviewerID: 2, IssueID: pr.IssueID, Official: true}))
+
+	statuses, err = GetReviewRuleStatuses(db.DefaultContext, pb, pr)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.EqualValues(t, 1, statuses[0].GrantedApprovals)
+	assert.False(t, MergeBlockedByReviewRules(statuses))
+
+	_, err = CheckPullBranchProtections(db.DefaultContext, pr, false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+)
+
+// StackedPullRequest is a pull request of a stack, with its depth in the stack
+type StackedPullRequest struct {
+	*issues_model.PullRequest
+	Depth int
+}
+
+// GetParentPullRequest returns the open pull request whose head branch is the base branch of pr,
+// or nil if pr is at the bottom of its stack
+func GetParentPullRequest(ctx context.Context, pr *issues_model.PullRequest) (*issues_model.PullRequest, error) {
+	prs, err := issues_model.GetUnmergedPullRequestsByHeadInfo(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range prs {
+		if parent.ID != pr.ID && parent.BaseRepoID == pr.BaseRepoID {
+			return parent, nil
+		}
+	}
+	return nil, nil
+}
+
+// getChildPullRequests returns the open pull requests whose base branch is the head branch of pr.
+// Only the pull requests within the same repository are considered to be stacked.
+func getChildPullRequests(ctx context.Context, pr *issues_model.PullRequest) ([]*issues_model.PullRequest, error) {
+	if pr.HeadRepoID != pr.BaseRepoID || pr.Flow != issues_model.PullRequestFlowGithub {
+		return nil, nil
+	}
+	prs, err := issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, pr.HeadRepoID, pr.HeadBranch)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]*issues_model.PullRequest, 0, len(prs))
+	for _, child := range prs {
+		if child.ID != pr.ID {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Index < children[j].Index
+	})
+	return children, nil
+}
+
+// GetPullRequestStack returns the stack pr belongs to, from its bottom to its top. The pull requests which
+// are based on the same branch are siblings and share the same depth.
+func GetPullRequestStack(ctx context.Context, pr *issues_model.PullRequest) ([]*StackedPullRequest, error) {
+	visited := container.SetOf(pr.ID)
+
+	var parents []*issues_model.PullRequest
+	for current := pr; ; {
+		parent, err := GetParentPullRequest(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || !visited.Add(parent.ID) {
+			break
+		}
+		parents = append(parents, parent)
+		current = parent
+	}
+
+	stack := make([]*StackedPullRequest, 0, len(parents)+1)
+	for i := len(parents) - 1; i >= 0; i-- {
+		stack = append(stack, &StackedPullRequest{PullRequest: parents[i], Depth: len(parents) - 1 - i})
+	}
+	stack = append(stack, &StackedPullRequest{PullRequest: pr, Depth: len(parents)})
+
+	var appendChildren func(parent *issues_model.PullRequest, depth int) error
+	appendChildren = func(parent *issues_model.PullRequest, depth int) error {
+		children, err := getChildPullRequests(ctx, parent)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if !visited.Add(child.ID) {
+				continue
+			}
+			stack = append(stack, &StackedPullRequest{PullRequest: child, Depth: depth})
+			if err := appendChildren(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := appendChildren(pr, len(parents)+1); err != nil {
+		return nil, err
+	}
+
+	prs := make(issues_model.PullRequestList, 0, len(stack))
+	for _, stacked := range stack {
+		prs = append(prs, stacked.PullRequest)
+	}
+	if err := prs.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// GetBlockingStackParent returns the parent of pr in its stack if the repository requires the stacked
+// pull requests to be merged in order, or nil if pr can be merged regarding its stack
+func GetBlockingStackParent(ctx context.Context, pr *issues_model.PullRequest) (*issues_model.PullRequest, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	prUnit, err := pr.BaseRepo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !prUnit.PullRequestsConfig().BlockMergeOutOfStackOrder {
+		return nil, nil
+	}
+	return GetParentPullRequest(ctx, pr)
+}
+
+// rebaseStackedPullRequest rebases the commits of pr which are not part of its merged parent on to its base branch
+func rebaseStackedPullRequest(ctx context.Context, doer *user_model.User, pr, parent *issues_model.PullRequest) error {
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	return updateHeadByRebaseOnToBase(ctx, pr, doer, parent.GetGitRefName())
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPullRequestStack(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// pull request 5 (pr-to-update) is based on the head branch of pull request 2 (branch2), based on master
+	bottom := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	top := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+
+	parent, err := GetParentPullRequest(db.DefaultContext, top)
+	require.NoError(t, err)
+	require.NotNil(t, parent)
+	assert.EqualValues(t, 2, parent.ID)
+
+	parent, err = GetParentPullRequest(db.DefaultContext, bottom)
+	require.NoError(t, err)
+	assert.Nil(t, parent)
+
+	for _, pr := range []*issues_model.PullRequest{bottom, top} {
+		stack, err := GetPullRequestStack(db.DefaultContext, pr)
+		require.NoError(t, err)
+		require.Len(t, stack, 2)
+		assert.EqualValues(t, 2, stack[0].ID)
+		assert.Equal(t, 0, stack[0].Depth)
+		assert.EqualValues(t, 5, stack[1].ID)
+		assert.Equal(t, 1, stack[1].Depth)
+		assert.NotNil(t, stack[1].Issue)
+	}
+
+	t.Run("Block merge out of stack order", func(t *testing.T) {
+		blocking, err := GetBlockingStackParent(db.DefaultContext, top)
+		require.NoError(t, err)
+		assert.Nil(t, blocking)
+
+		repoUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: 1, Type: unit.TypePullRequests})
+		repoUnit.PullRequestsConfig().BlockMergeOutOfStackOrder = true
+		require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, repoUnit))
+
+		top := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+		blocking, err = GetBlockingStackParent(db.DefaultContext, top)
+		require.NoError(t, err)
+		require.NotNil(t, blocking)
+		assert.EqualValues(t, 2, blocking.ID)
+
+		blocking, err = GetBlockingStackParent(db.DefaultContext, bottom)
+		require.NoError(t, err)
+		assert.Nil(t, blocking)
+	})
+}
@@ -25,6 +25,7 @@ const (
 	baseBranch     = "base"     // equivalent to pr.BaseBranch
 	trackingBranch = "tracking" // equivalent to pr.HeadBranch
 	stagingBranch  = "staging"  // this is used for a working branch
+	upstreamBranch = "upstream" // the commits not to rebase, when only a part of the tracking branch is rebased
 )
 
 type prContext struct {
@@ -17,8 +17,9 @@ import (
 	"forgejo.org/modules/setting"
 )
 
-// updateHeadByRebaseOnToBase handles updating a PR's head branch by rebasing it on the PR current base branch
-func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User) error {
+// updateHeadByRebaseOnToBase handles updating a PR's head branch by rebasing it on the PR current base branch.
+// If upstreamRef is not empty, only the commits which are not reachable from this ref of the base repository are rebased.
+func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, upstreamRef string) error {
 	// "Clone" base repo and add the cache headers for the head repo and branch
 	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, pr, doer, "")
 	if err != nil {
@@ -26,12 +27,21 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 	}
 	defer cancel()
 
+	upstream := baseBranch
+	if upstreamRef != "" {
+		if err := git.NewCommand(ctx, "fetch", "--no-tags", "origin").AddDynamicArguments(upstreamRef + ":" + upstreamBranch).
+			Run(mergeCtx.RunOpts()); err != nil {
+			return fmt.Errorf("unable to fetch %s in temp repo for %v: %w\n%s\n%s", upstreamRef, pr, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+		}
+		upstream = upstreamBranch
+	}
+
 	// Determine the old merge-base before the rebase - we use this for LFS push later on
 	oldMergeBase, _, _ := git.NewCommand(ctx, "merge-base").AddDashesAndList(baseBranch, trackingBranch).RunStdString(&git.RunOpts{Dir: mergeCtx.tmpBasePath})
 	oldMergeBase = strings.TrimSpace(oldMergeBase)
 
 	// Rebase the tracking branch on to the base as the staging branch
-	if err := rebaseTrackingOnToBase(mergeCtx, repo_model.MergeStyleRebaseUpdate); err != nil {
+	if err := rebaseTrackingOnToBaseFrom(mergeCtx, repo_model.MergeStyleRebaseUpdate, upstream); err != nil {
 		return err
 	}
 
@@ -0,0 +1,114 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	pull_model "forgejo.org/models/pull"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/services/gitdiff"
+)
+
+// ViewedFile is the viewed state of a changed file of a pull request
+type ViewedFile struct {
+	Path  string
+	State pull_model.ViewedState
+}
+
+// ViewedFiles is the progress of the review of a user: which changed files of a pull request the user has viewed
+type ViewedFiles struct {
+	HeadCommitID   string
+	Files          []*ViewedFile
+	NumViewedFiles int
+}
+
+// UpdateViewedFiles marks the given files of a pull request as viewed or not viewed by doer at the given head commit.
+// The content of the viewed files is recorded, so that they stay viewed as long as their content does not change.
+func UpdateViewedFiles(ctx context.Context, gitRepo *git.Repository, pr *issues_model.PullRequest, doer *user_model.User, headCommitID string, files map[string]bool) error {
+	commit, err := gitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return err
+	}
+
+	updatedFiles := make(map[string]pull_model.ViewedState, len(files))
+	viewedBlobs := make(map[string]string, len(files))
+	for file, viewed := range files {
+		// Only unviewed and viewed are possible, has-changed can not be set from the outside
+		if !viewed {
+			updatedFiles[file] = pull_model.Unviewed
+			continue
+		}
+		updatedFiles[file] = pull_model.Viewed
+		if blobID := gitdiff.GetBlobID(commit, file); blobID != "" {
+			viewedBlobs[file] = blobID
+		}
+	}
+
+	return pull_model.UpdateReviewState(ctx, doer.ID, pr.ID, commit.ID.String(), updatedFiles, viewedBlobs)
+}
+
+// GetViewedFiles returns the viewed state of each changed file of a pull request for a user, at the current head commit
+func GetViewedFiles(ctx context.Context, gitRepo *git.Repository, pr *issues_model.PullRequest, userID int64) (*ViewedFiles, error) {
+	progress, err := GetReviewersProgress(ctx, gitRepo, pr, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	return progress[userID], nil
+}
+
+// GetReviewersProgress returns the viewed state of each changed file of a pull request for each of the given users,
+// at the current head commit
+func GetReviewersProgress(ctx context.Context, gitRepo *git.Repository, pr *issues_model.PullRequest, userIDs []int64) (map[int64]*ViewedFiles, error) {
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := gitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return nil, err
+	}
+	changedFiles, err := gitRepo.GetFilesChangedBetween(pr.MergeBase, headCommitID)
+	if err != nil {
+		return nil, err
+	}
+	blobIDs := make(map[string]string, len(changedFiles))
+	for _, file := range changedFiles {
+		blobIDs[file] = gitdiff.GetBlobID(headCommit, file)
+	}
+
+	progress := make(map[int64]*ViewedFiles, len(userIDs))
+	for _, userID := range userIDs {
+		review, err := pull_model.GetNewestReviewState(ctx, userID, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var changedSinceReview []string
+		if review != nil && review.CommitSHA != headCommitID {
+			if changedSinceReview, err = gitRepo.GetFilesChangedBetween(review.CommitSHA, headCommitID); err != nil {
+				// e.g. the commit of the review has been garbage collected after a force push
+				log.Warn("Could not get changed files between %s and %s for %-v: %v", review.CommitSHA, headCommitID, pr, err)
+			}
+		}
+
+		viewedFiles := &ViewedFiles{HeadCommitID: headCommitID, Files: make([]*ViewedFile, 0, len(changedFiles))}
+		for _, file := range changedFiles {
+			state := pull_model.Unviewed
+			if review != nil {
+				state = review.GetViewedState(file, blobIDs[file], slices.Contains(changedSinceReview, file))
+			}
+			if state == pull_model.Viewed {
+				viewedFiles.NumViewedFiles++
+			}
+			viewedFiles.Files = append(viewedFiles.Files, &ViewedFile{Path: file, State: state})
+		}
+		progress[userID] = viewedFiles
+	}
+	return progress, nil
+}
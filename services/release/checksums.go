@@ -0,0 +1,153 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package release
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	"forgejo.org/services/asymkey"
+	"forgejo.org/services/attachment"
+)
+
+// checksumsQueue holds the IDs of the releases whose checksums need to be computed and signed
+var checksumsQueue *queue.WorkerPoolQueue[int64]
+
+func initChecksumsQueue(ctx context.Context) error {
+	handler := func(items ...int64) []int64 {
+		for _, releaseID := range items {
+			if err := signReleaseChecksums(ctx, releaseID); err != nil {
+				log.Error("Signing the checksums of release %d failed: %v", releaseID, err)
+			}
+		}
+		return nil
+	}
+
+	checksumsQueue = queue.CreateUniqueQueue(ctx, "release_checksums", handler)
+	if checksumsQueue == nil {
+		return errors.New("unable to create release_checksums queue")
+	}
+	go graceful.GetManager().RunWithCancel(checksumsQueue)
+	return nil
+}
+
+// UpdateReleaseChecksums queues the release for the checksums of its assets to be computed and signed again,
+// it must be called whenever assets are added to, renamed in or removed from the release
+func UpdateReleaseChecksums(releaseID int64) {
+	if checksumsQueue == nil {
+		return
+	}
+	if err := checksumsQueue.Push(releaseID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			log.Error("Unable to queue the checksums of release %d: %v", releaseID, err)
+		}
+	}
+}
+
+// signReleaseChecksums computes the missing checksums of the assets of a release and signs the checksums
+// if they changed since they were signed last
+func signReleaseChecksums(ctx context.Context, releaseID int64) error {
+	rel, err := repo_model.GetReleaseByID(ctx, releaseID)
+	if err != nil {
+		if repo_model.IsErrReleaseNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := rel.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	for _, attach := range rel.Attachments {
+		if attach.ExternalURL == "" && attach.SHA256 == "" {
+			if err := attachment.UpdateAttachmentSHA256(ctx, attach); err != nil {
+				return err
+			}
+		}
+	}
+
+	checksums, complete := releaseChecksums(rel.Attachments)
+	if !complete || checksums == "" || rel.SignedChecksums == checksums {
+		return nil
+	}
+
+	rel.SignedChecksums = checksums
+	rel.ChecksumsSignature = ""
+	sign, signingKey, err := asymkey.SignRelease(ctx, rel.Repo, rel.Publisher)
+	if err != nil && !asymkey.IsErrWontSign(err) {
+		return err
+	}
+	if sign {
+		signature, err := asymkey.DetachedSign(ctx, rel.Repo.RepoPath(), signingKey, checksums)
+		if err != nil {
+			// the checksums are still useful without signature
+			log.Error("Unable to sign the checksums of release %d: %v", rel.ID, err)
+		} else {
+			rel.ChecksumsSignature = signature
+		}
+	}
+	return repo_model.UpdateReleaseChecksumsSignature(ctx, rel)
+}
+
+// GetReleaseChecksums returns the checksums of the assets of a release, in the format of sha256sum, and the
+// detached signature of the checksums if they were signed. It does not compute nor sign anything: the
+// release is queued instead when the checksums of some assets are missing or the signature is outdated.
+func GetReleaseChecksums(ctx context.Context, rel *repo_model.Release) (string, string, error) {
+	if err := rel.LoadAttributes(ctx); err != nil {
+		return "", "", err
+	}
+
+	checksums, complete := releaseChecksums(rel.Attachments)
+	if !complete || rel.SignedChecksums != checksums {
+		UpdateReleaseChecksums(rel.ID)
+	}
+	if checksums == "" || rel.SignedChecksums != checksums {
+		return checksums, "", nil
+	}
+	return checksums, rel.ChecksumsSignature, nil
+}
+
+// ChecksumsSignatureFormat returns the format of a detached signature of the checksums of a release
+func ChecksumsSignatureFormat(signature string) string {
+	if signature == "" {
+		return ""
+	}
+	if strings.HasSuffix(repo_model.ReleaseChecksumsSignatureFileName(signature), ".sig") {
+		return "ssh"
+	}
+	return "openpgp"
+}
+
+// releaseChecksums returns the checksums of the attachments of a release sorted by name, one line per
+// attachment in the format of sha256sum, and whether all of them are known. The external attachments
+// are skipped, and so are the attachments uploaded before checksums were stored until they are computed.
+func releaseChecksums(attachments []*repo_model.Attachment) (string, bool) {
+	attachments = slices.SortedFunc(slices.Values(attachments), func(a, b *repo_model.Attachment) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	complete := true
+	var checksums strings.Builder
+	for _, attach := range attachments {
+		if attach.ExternalURL != "" {
+			continue
+		}
+		if attach.SHA256 == "" {
+			complete = false
+			continue
+		}
+		checksums.WriteString(attach.SHA256)
+		checksums.WriteString("  ")
+		checksums.WriteString(attach.Name)
+		checksums.WriteByte('\n')
+	}
+	return checksums.String(), complete
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/services/attachment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseChecksums(t *testing.T) {
+	checksums, complete := releaseChecksums([]*repo_model.Attachment{
+		{Name: "forgejo-linux-arm64", SHA256: "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"},
+		{Name: "forgejo-docs", ExternalURL: "https://forgejo.org/docs/"},
+		{Name: "forgejo-linux-amd64", SHA256: "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730"},
+	})
+	assert.True(t, complete)
+	assert.Equal(t, "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730  forgejo-linux-amd64\n"+
+		"b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c  forgejo-linux-arm64\n", checksums)
+
+	checksums, complete = releaseChecksums([]*repo_model.Attachment{
+		{Name: "forgejo-docs", ExternalURL: "https://forgejo.org/docs/"},
+	})
+	assert.True(t, complete)
+	assert.Empty(t, checksums)
+
+	// the checksums of the assets uploaded before they were stored are left out until they are computed
+	checksums, complete = releaseChecksums([]*repo_model.Attachment{
+		{Name: "forgejo-linux-arm64", SHA256: "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"},
+		{Name: "forgejo-linux-amd64"},
+	})
+	assert.False(t, complete)
+	assert.Equal(t, "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c  forgejo-linux-arm64\n", checksums)
+}
+
+func TestChecksumsSignatureFormat(t *testing.T) {
+	assert.Empty(t, ChecksumsSignatureFormat(""))
+	assert.Equal(t, "openpgp", ChecksumsSignatureFormat("-----BEGIN PGP SIGNATURE-----\n\niQ==\n-----END PGP SIGNATURE-----\n"))
+	assert.Equal(t, "ssh", ChecksumsSignatureFormat("-----BEGIN SSH SIGNATURE-----\nU1NI\n-----END SSH SIGNATURE-----\n"))
+}
+
+func TestSignReleaseChecksums(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	gitRepo, err := gitrepo.OpenRepository(git.DefaultContext, repo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	signingKeyPath := filepath.Join(t.TempDir(), "signing-key")
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", signingKeyPath).Run())
+	defer test.MockVariableValue(&setting.Repository.Signing.Format, "ssh")()
+	defer test.MockVariableValue(&setting.Repository.Signing.SigningKey, signingKeyPath)()
+	defer test.MockVariableValue(&setting.Repository.Signing.Releases, []string{"always"})()
+
+	addAttachment := func(t *testing.T, name, content string) *repo_model.Attachment {
+		t.Helper()
+		attach, err := attachment.NewAttachment(db.DefaultContext, &repo_model.Attachment{
+			RepoID:     repo.ID,
+			UploaderID: user.ID,
+			Name:       name,
+		}, strings.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		return attach
+	}
+	checksum := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	amd64 := addAttachment(t, "forgejo-linux-amd64", "amd64 binary")
+	arm64 := addAttachment(t, "forgejo-linux-arm64", "arm64 binary")
+	// the asset was uploaded before the checksums were stored
+	arm64.SHA256 = ""
+	require.NoError(t, repo_model.UpdateAttachmentByUUID(db.DefaultContext, arm64, "sha256"))
+
+	rel := &repo_model.Release{
+		RepoID:      repo.ID,
+		Repo:        repo,
+		PublisherID: user.ID,
+		Publisher:   user,
+		TagName:     "v1.0-checksums",
+		Target:      "master",
+		Title:       "v1.0 with checksums",
+	}
+	require.NoError(t, CreateRelease(gitRepo, rel, "", []*AttachmentChange{
+		{Action: "add", Type: "attachment", UUID: amd64.UUID},
+		{Action: "add", Type: "attachment", UUID: arm64.UUID},
+	}))
+	expected := checksum("amd64 binary") + "  forgejo-linux-amd64\n" + checksum("arm64 binary") + "  forgejo-linux-arm64\n"
+
+	t.Run("Incomplete checksums", func(t *testing.T) {
+		rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+		checksums, signature, err := GetReleaseChecksums(db.DefaultContext, rel)
+		require.NoError(t, err)
+		assert.Equal(t, checksum("amd64 binary")+"  forgejo-linux-amd64\n", checksums)
+		assert.Empty(t, signature)
+	})
+
+	t.Run("Sign", func(t *testing.T) {
+		require.NoError(t, signReleaseChecksums(db.DefaultContext, rel.ID))
+
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: arm64.ID, SHA256: checksum("arm64 binary")})
+		rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+		assert.Equal(t, expected, rel.SignedChecksums)
+		assert.Equal(t, "SHA256SUMS.sig", rel.ChecksumsSignatureFileName())
+
+		checksums, signature, err := GetReleaseChecksums(db.DefaultContext, rel)
+		require.NoError(t, err)
+		assert.Equal(t, expected, checksums)
+		assert.Equal(t, rel.ChecksumsSignature, signature)
+		assert.Equal(t, "ssh", ChecksumsSignatureFormat(signature))
+
+		// the signature is valid for the checksums
+		signaturePath := filepath.Join(t.TempDir(), "SHA256SUMS.sig")
+		require.NoError(t, os.WriteFile(signaturePath, []byte(signature), 0o600))
+		cmd := exec.Command("ssh-keygen", "-Y", "check-novalidate", "-n", "file", "-s", signaturePath)
+		cmd.Stdin = strings.NewReader(checksums)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	})
+
+	t.Run("Outdated signature", func(t *testing.T) {
+		// the signature is not returned for checksums which changed since they were signed
+		riscv64 := addAttachment(t, "forgejo-linux-riscv64", "riscv64 binary")
+		require.NoError(t, repo_model.AddReleaseAttachments(db.DefaultContext, rel.ID, []string{riscv64.UUID}))
+
+		rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+		checksums, signature, err := GetReleaseChecksums(db.DefaultContext, rel)
+		require.NoError(t, err)
+		assert.Equal(t, expected+checksum("riscv64 binary")+"  forgejo-linux-riscv64\n", checksums)
+		assert.Empty(t, signature)
+	})
+
+	t.Run("Not signed", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Signing.Releases, []string{"never"})()
+
+		require.NoError(t, signReleaseChecksums(db.DefaultContext, rel.ID))
+		rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+		assert.Equal(t, expected+checksum("riscv64 binary")+"  forgejo-linux-riscv64\n", rel.SignedChecksums)
+		assert.Empty(t, rel.ChecksumsSignature)
+		assert.Empty(t, rel.ChecksumsSignatureFileName())
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		defer test.MockProtect(&checksumsQueue)()
+		setting.LoadQueueSettings()
+		require.NoError(t, initChecksumsQueue(t.Context()))
+
+		// removing an asset queues the release for its checksums to be signed again
+		require.NoError(t, UpdateRelease(db.DefaultContext, user, gitRepo, rel, false, []*AttachmentChange{
+			{Action: "delete", UUID: amd64.UUID},
+		}))
+		assert.Eventually(t, func() bool {
+			rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+			return !strings.Contains(rel.SignedChecksums, "forgejo-linux-amd64") && rel.ChecksumsSignature != ""
+		}, 10*time.Second, 100*time.Millisecond)
+
+		rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+		checksums, signature, err := GetReleaseChecksums(db.DefaultContext, rel)
+		require.NoError(t, err)
+		assert.Equal(t, checksum("arm64 binary")+"  forgejo-linux-arm64\n"+checksum("riscv64 binary")+"  forgejo-linux-riscv64\n", checksums)
+		assert.Equal(t, rel.ChecksumsSignature, signature)
+	})
+}
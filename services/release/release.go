@@ -195,6 +195,9 @@ func CreateRelease(gitRepo *git.Repository, rel *repo_model.Release, msg string,
 	if err = repo_model.AddReleaseAttachments(gitRepo.Ctx, rel.ID, addAttachmentUUIDs.Values()); err != nil {
 		return err
 	}
+	if len(addAttachmentUUIDs) > 0 {
+		UpdateReleaseChecksums(rel.ID)
+	}
 
 	if !rel.IsDraft {
 		notify_service.NewRelease(gitRepo.Ctx, rel)
@@ -248,6 +251,10 @@ func UpdateRelease(ctx context.Context, doer *user_model.User, gitRepo *git.Repo
 	if rel.ID == 0 {
 		return errors.New("UpdateRelease only accepts an exist release")
 	}
+	oldRel, err := repo_model.GetReleaseByID(ctx, rel.ID)
+	if err != nil {
+		return err
+	}
 	isCreated, err := createTag(gitRepo.Ctx, gitRepo, rel, "")
 	if err != nil {
 		return err
@@ -381,9 +388,14 @@ func UpdateRelease(ctx context.Context, doer *user_model.User, gitRepo *git.Repo
 			log.Error("delete attachment[uuid: %s] failed: %v", uuid, err)
 		}
 	}
+	if len(addAttachmentUUIDs) > 0 || len(delAttachmentUUIDs) > 0 || len(updateAttachments) > 0 {
+		UpdateReleaseChecksums(rel.ID)
+	}
 
 	if !rel.IsDraft {
-		if createdFromTag || isCreated {
+		// a draft is published when it is no longer a draft, e.g. by a workflow once it uploaded the
+		// assets of the release, including when it was created for a tag which already existed
+		if createdFromTag || isCreated || oldRel.IsDraft {
 			notify_service.NewRelease(gitRepo.Ctx, rel)
 			return nil
 		}
@@ -467,5 +479,8 @@ func DeleteReleaseByID(ctx context.Context, repo *repo_model.Repository, rel *re
 
 // Init start release service
 func Init() error {
-	return initTagSyncQueue(graceful.GetManager().ShutdownContext())
+	if err := initTagSyncQueue(graceful.GetManager().ShutdownContext()); err != nil {
+		return err
+	}
+	return initChecksumsQueue(graceful.GetManager().ShutdownContext())
 }
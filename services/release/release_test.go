@@ -4,7 +4,9 @@
 package release
 
 import (
+	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
 	"forgejo.org/services/attachment"
+	notify_service "forgejo.org/services/notify"
 
 	_ "forgejo.org/models/actions"
 	_ "forgejo.org/models/forgefed"
@@ -474,3 +477,74 @@ func TestCreateNewTag(t *testing.T) {
 	require.NoError(t, CreateNewTag(git.DefaultContext, user, repo, "master", "v2.0",
 		"v2.0 is released \n\n BUGFIX: .... \n\n 123"))
 }
+
+type releaseNotifier struct {
+	notify_service.NullNotifier
+	published []int64
+	updated   []int64
+}
+
+func (n *releaseNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	n.published = append(n.published, rel.ID)
+}
+
+func (n *releaseNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
+	n.updated = append(n.updated, rel.ID)
+}
+
+var (
+	testReleaseNotifier     = &releaseNotifier{}
+	registerReleaseNotifier sync.Once
+)
+
+func TestRelease_PublishDraftFromActions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	registerReleaseNotifier.Do(func() {
+		notify_service.RegisterNotifier(testReleaseNotifier)
+	})
+	testReleaseNotifier.published = nil
+	testReleaseNotifier.updated = nil
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	actionsUser := user_model.NewActionsUser()
+
+	gitRepo, err := gitrepo.OpenRepository(git.DefaultContext, repo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	// a workflow triggered by the push of a tag creates a draft release for it, uploads the assets and
+	// publishes the release
+	require.NoError(t, CreateNewTag(git.DefaultContext, user, repo, "master", "v2.0-draft", "v2.0"))
+	rel, err := repo_model.GetRelease(db.DefaultContext, repo.ID, "v2.0-draft")
+	require.NoError(t, err)
+	rel.Title = "v2.0"
+	rel.IsDraft = true
+	rel.IsTag = false
+	rel.PublisherID = actionsUser.ID
+	rel.Publisher = actionsUser
+	rel.Repo = repo
+	require.NoError(t, UpdateRelease(db.DefaultContext, actionsUser, gitRepo, rel, true, nil))
+	assert.Empty(t, testReleaseNotifier.published)
+	assert.Empty(t, testReleaseNotifier.updated)
+
+	rel.IsDraft = false
+	require.NoError(t, UpdateRelease(db.DefaultContext, actionsUser, gitRepo, rel, false, nil))
+	assert.Equal(t, []int64{rel.ID}, testReleaseNotifier.published)
+	assert.Empty(t, testReleaseNotifier.updated)
+
+	// editing the published release is an update
+	rel.Note = "Release notes"
+	require.NoError(t, UpdateRelease(db.DefaultContext, actionsUser, gitRepo, rel, false, nil))
+	assert.Equal(t, []int64{rel.ID}, testReleaseNotifier.published)
+	assert.Equal(t, []int64{rel.ID}, testReleaseNotifier.updated)
+
+	// the release is shown as published by Actions rather than by a deleted user
+	rel = unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})
+	require.NoError(t, rel.LoadAttributes(db.DefaultContext))
+	assert.True(t, rel.Publisher.IsActions())
+
+	releases := repo_model.ReleaseList{unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: rel.ID})}
+	require.NoError(t, releases.LoadAttributes(db.DefaultContext))
+	assert.True(t, releases[0].Publisher.IsActions())
+}
@@ -79,6 +79,12 @@ func ParseFileName(uri string) (ext string, tp git.ArchiveType, err error) {
 	case strings.HasSuffix(uri, ".bundle"):
 		ext = ".bundle"
 		tp = git.BUNDLE
+	case strings.HasSuffix(uri, ".tar.zst"):
+		ext = ".tar.zst"
+		tp = git.TARZST
+	case strings.HasSuffix(uri, ".tar.xz"):
+		ext = ".tar.xz"
+		tp = git.TARXZ
 	default:
 		return "", 0, ErrUnknownArchiveFormat{RequestFormat: uri}
 	}
@@ -89,7 +95,7 @@ func ParseFileName(uri string) (ext string, tp git.ArchiveType, err error) {
 // resulting ArchiveRequest is suitable for being passed to Await()
 // if it's determined that the request still needs to be satisfied.
 func NewRequest(ctx context.Context, repoID int64, repo *git.Repository, refName string, fileType git.ArchiveType) (*ArchiveRequest, error) {
-	if fileType < git.ZIP || fileType > git.BUNDLE {
+	if fileType < git.ZIP || fileType > git.TARXZ {
 		return nil, ErrUnknownArchiveFormat{RequestFormat: fileType.String()}
 	}
 
@@ -254,19 +260,23 @@ func doArchive(ctx context.Context, r *ArchiveRequest) (*repo_model.RepoArchiver
 				w,
 			)
 		} else {
-			err = gitRepo.CreateArchive(
+			var filter git.ArchiveEntryFilter
+			if setting.LFS.StartServer && setting.RepoArchive.IncludeLFS {
+				filter = lfsContentFilter(ctx, archiver.RepoID)
+			}
+			err = gitRepo.CreateFilteredArchive(
 				ctx,
 				archiver.Type,
 				w,
 				setting.Repository.PrefixArchiveFiles,
 				archiver.CommitID,
+				filter,
 			)
 		}
 		_ = w.CloseWithError(err)
 		done <- err
 	}(done, w, archiver, gitRepo)
 
-	// TODO: add lfs data to zip
 	// TODO: add submodule data to zip
 
 	if _, err := storage.RepoArchives.Save(rPath, rd, -1); err != nil {
@@ -134,3 +134,21 @@ func TestErrUnknownArchiveFormat(t *testing.T) {
 	err := ErrUnknownArchiveFormat{RequestFormat: "master"}
 	assert.ErrorIs(t, err, ErrUnknownArchiveFormat{})
 }
+
+func TestParseFileName(t *testing.T) {
+	for uri, expected := range map[string]git.ArchiveType{
+		"master.zip":        git.ZIP,
+		"v1.0.tar.gz":       git.TARGZ,
+		"master.bundle":     git.BUNDLE,
+		"release/1.tar.zst": git.TARZST,
+		"v1.0.tar.xz":       git.TARXZ,
+	} {
+		ext, tp, err := ParseFileName(uri)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tp)
+		assert.Equal(t, "."+expected.String(), ext)
+	}
+
+	_, _, err := ParseFileName("master.tar.bz2")
+	require.ErrorIs(t, err, ErrUnknownArchiveFormat{})
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package archiver
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+
+	git_model "forgejo.org/models/git"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/lfs"
+	"forgejo.org/modules/log"
+)
+
+// lfsPointerMaxSize is the largest file that is inspected for being an LFS pointer.
+const lfsPointerMaxSize = 1024
+
+// lfsContentFilter returns an archive filter which replaces LFS pointer files
+// with the content they point to, as long as that content is stored for the
+// repository. Pointers to missing objects are kept as they are.
+func lfsContentFilter(ctx context.Context, repoID int64) git.ArchiveEntryFilter {
+	return func(hdr *tar.Header, r io.Reader) (io.Reader, int64, error) {
+		if hdr.Size > lfsPointerMaxSize {
+			return r, hdr.Size, nil
+		}
+
+		buf, err := io.ReadAll(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		original := bytes.NewReader(buf)
+
+		pointer, _ := lfs.ReadPointerFromBuffer(buf)
+		if !pointer.IsValid() {
+			return original, hdr.Size, nil
+		}
+
+		meta, err := git_model.GetLFSMetaObjectByOid(ctx, repoID, pointer.Oid)
+		if err != nil {
+			if err != git_model.ErrLFSObjectNotExist {
+				return nil, 0, err
+			}
+			return original, hdr.Size, nil
+		}
+
+		content, err := lfs.ReadMetaObject(meta.Pointer)
+		if err != nil {
+			log.Warn("Unable to read LFS object %s for archive of repo %d: %v", pointer.Oid, repoID, err)
+			return original, hdr.Size, nil
+		}
+		return content, meta.Size, nil
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package archiver
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/lfs"
+	"forgejo.org/modules/zstd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveLFSAndGitAttributes(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	lfsContent := []byte("the content of a file stored in LFS\n")
+	pointer, err := lfs.GeneratePointer(bytes.NewReader(lfsContent))
+	require.NoError(t, err)
+	_, err = git_model.NewLFSMetaObject(db.DefaultContext, 1, pointer)
+	require.NoError(t, err)
+	require.NoError(t, lfs.NewContentStore().Put(pointer, bytes.NewReader(lfsContent)))
+
+	missingPointer, err := lfs.GeneratePointer(strings.NewReader("an object which is not stored\n"))
+	require.NoError(t, err)
+
+	repoPath := t.TempDir()
+	require.NoError(t, git.InitRepository(t.Context(), repoPath, false, git.Sha1ObjectFormat.Name()))
+	for name, content := range map[string]string{
+		".gitattributes": "ignored.txt export-ignore\nversion.txt export-subst\n",
+		"ignored.txt":    "not archived\n",
+		"version.txt":    "$Format:%H$",
+		"stored.bin":     pointer.StringContent(),
+		"missing.bin":    missingPointer.StringContent(),
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o644))
+	}
+	require.NoError(t, git.AddChanges(repoPath, true))
+	require.NoError(t, git.CommitChanges(repoPath, git.CommitChangesOptions{
+		Committer: &git.Signature{Name: "User Two", Email: "user2@example.com", When: time.Now()},
+		Message:   "add files",
+	}))
+	commitID, _, err := git.NewCommand(t.Context(), "rev-parse", "HEAD").RunStdString(&git.RunOpts{Dir: repoPath})
+	require.NoError(t, err)
+	commitID = strings.TrimSpace(commitID)
+
+	gitRepo, err := git.OpenRepository(t.Context(), repoPath)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	readArchive := func(t *testing.T, filter git.ArchiveEntryFilter) map[string]string {
+		t.Helper()
+		var buf bytes.Buffer
+		require.NoError(t, gitRepo.CreateFilteredArchive(t.Context(), git.TARZST, &buf, false, commitID, filter))
+		zr, err := zstd.NewReader(&buf)
+		require.NoError(t, err)
+		defer zr.Close()
+
+		files := map[string]string{}
+		tr := tar.NewReader(zr)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(content)
+		}
+		return files
+	}
+
+	t.Run("Pointers", func(t *testing.T) {
+		files := readArchive(t, nil)
+		assert.NotContains(t, files, "ignored.txt")
+		assert.Equal(t, commitID, files["version.txt"])
+		assert.Equal(t, pointer.StringContent(), files["stored.bin"])
+		assert.Equal(t, missingPointer.StringContent(), files["missing.bin"])
+	})
+
+	t.Run("LFS content", func(t *testing.T) {
+		files := readArchive(t, lfsContentFilter(db.DefaultContext, 1))
+		assert.NotContains(t, files, "ignored.txt")
+		assert.Equal(t, commitID, files["version.txt"])
+		assert.Equal(t, string(lfsContent), files["stored.bin"])
+		// pointers to objects which are not stored for the repository are kept
+		assert.Equal(t, missingPointer.StringContent(), files["missing.bin"])
+	})
+
+	t.Run("Other repository", func(t *testing.T) {
+		files := readArchive(t, lfsContentFilter(db.DefaultContext, 2))
+		assert.Equal(t, pointer.StringContent(), files["stored.bin"])
+	})
+}
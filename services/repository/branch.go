@@ -500,7 +500,7 @@ func DeleteBranchAfterMerge(ctx context.Context, doer *user_model.User, pr *issu
 		return util.NewPermissionDeniedErrorf("Must have write permission to the head repository")
 	}
 
-	if err := pull_service.RetargetChildrenOnMerge(ctx, doer, pr); err != nil {
+	if err := pull_service.RetargetChildrenOnMerge(ctx, doer, pr, ""); err != nil {
 		return err
 	}
 	if err := DeleteBranch(ctx, doer, pr.HeadRepo, headRepo, pr.HeadBranch); err != nil {
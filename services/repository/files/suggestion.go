@@ -0,0 +1,218 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+)
+
+// ErrSuggestionNotApplicable represents a code comment whose suggestion can't be applied
+type ErrSuggestionNotApplicable struct {
+	CommentID int64
+	Reason    string
+}
+
+// IsErrSuggestionNotApplicable checks if an error is an ErrSuggestionNotApplicable.
+func IsErrSuggestionNotApplicable(err error) bool {
+	_, ok := err.(ErrSuggestionNotApplicable)
+	return ok
+}
+
+func (err ErrSuggestionNotApplicable) Error() string {
+	return fmt.Sprintf("suggestion of comment %d can't be applied: %s", err.CommentID, err.Reason)
+}
+
+func (err ErrSuggestionNotApplicable) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// DefaultSuggestionsCommitMessage returns the message of the commit applying suggestions if none is given
+func DefaultSuggestionsCommitMessage(count int) string {
+	if count == 1 {
+		return "Apply suggestion from code review"
+	}
+	return "Apply suggestions from code review"
+}
+
+// ApplySuggestions replaces the lines commented by code comments of a pull request with their suggestions,
+// in a single commit on the head branch. The doer must be allowed to push to it and the commented lines must
+// not have changed since the comments were made. The authors of the suggestions are credited as co-authors.
+func ApplySuggestions(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, commentIDs []int64, message string) (*structs.FilesResponse, error) {
+	if len(commentIDs) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("no suggestion to apply")
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HasMerged || pr.Issue.IsClosed {
+		return nil, util.NewInvalidArgumentErrorf("the pull request is closed")
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HeadRepo == nil {
+		return nil, util.NewInvalidArgumentErrorf("the head repository of the pull request doesn't exist anymore")
+	}
+	if !pr.HeadRepo.CanEnableEditor() {
+		return nil, util.NewPermissionDeniedErrorf("the head repository can't be edited")
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, pr.HeadRepo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !issues_model.CanMaintainerWriteToBranch(ctx, perm, pr.HeadBranch, doer) {
+		return nil, util.NewPermissionDeniedErrorf("user can't push to the head branch")
+	}
+
+	// the suggestions of each file, by line
+	suggestionsByPath := make(map[string]map[int64]string)
+	treePaths := make([]string, 0, len(commentIDs))
+	comments := make([]*issues_model.Comment, 0, len(commentIDs))
+	coAuthors := make([]string, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		comment, err := issues_model.GetCommentByID(ctx, commentID)
+		if err != nil {
+			return nil, err
+		}
+		if comment.IssueID != pr.IssueID {
+			return nil, issues_model.ErrCommentNotExist{ID: commentID}
+		}
+		comments = append(comments, comment)
+		suggestion, ok := comment.CodeSuggestion()
+		if !ok {
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "no single suggestion on a line of the head"}
+		}
+		if comment.Invalidated {
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "outdated"}
+		}
+		if err := comment.LoadReview(ctx); err != nil {
+			return nil, err
+		}
+		if comment.Review != nil && comment.Review.Type == issues_model.ReviewTypePending {
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "part of a pending review"}
+		}
+
+		suggestions, ok := suggestionsByPath[comment.TreePath]
+		if !ok {
+			suggestions = make(map[int64]string)
+			suggestionsByPath[comment.TreePath] = suggestions
+			treePaths = append(treePaths, comment.TreePath)
+		}
+		if _, ok := suggestions[comment.Line]; ok {
+			return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "another suggestion is applied to the same line"}
+		}
+		suggestions[comment.Line] = suggestion
+
+		if err := comment.LoadPoster(ctx); err != nil {
+			return nil, err
+		}
+		if comment.Poster.ID != doer.ID && !comment.Poster.IsGhost() {
+			coAuthor := fmt.Sprintf("Co-authored-by: %s <%s>", comment.Poster.GetDisplayName(), comment.Poster.GetEmail())
+			if !slices.Contains(coAuthors, coAuthor) {
+				coAuthors = append(coAuthors, coAuthor)
+			}
+		}
+	}
+
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, pr.HeadRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	headCommit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ChangeRepoFile, 0, len(treePaths))
+	for _, treePath := range treePaths {
+		entry, err := headCommit.GetTreeEntryByPath(treePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err := entry.Blob().GetBlobContent(setting.UI.MaxDisplayFileSize)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(content)) >= setting.UI.MaxDisplayFileSize {
+			return nil, util.NewInvalidArgumentErrorf("%s is too large", treePath)
+		}
+
+		eol := "\n"
+		if strings.Contains(content, "\r\n") {
+			eol = "\r\n"
+		}
+		lines := strings.SplitAfter(content, "\n")
+		suggestions := suggestionsByPath[treePath]
+		lineNums := make([]int64, 0, len(suggestions))
+		for lineNum := range suggestions {
+			lineNums = append(lineNums, lineNum)
+		}
+		// replace the last lines first, so that the numbers of the others don't change
+		slices.Sort(lineNums)
+		slices.Reverse(lineNums)
+		for _, lineNum := range lineNums {
+			comment := findCommentOnLine(comments, treePath, lineNum)
+			if lineNum > int64(len(lines)) || (lineNum == int64(len(lines)) && lines[lineNum-1] == "") {
+				return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the commented line doesn't exist anymore"}
+			}
+			current := strings.TrimRight(lines[lineNum-1], "\r\n")
+			if commented, ok := comment.CommentedLine(); ok && commented != current {
+				return nil, ErrSuggestionNotApplicable{CommentID: comment.ID, Reason: "the commented line has changed"}
+			}
+
+			var replacement []string
+			if suggestion := suggestions[lineNum]; suggestion != "" {
+				// the suggestion takes the line breaks of the file, and keeps the one of the line if it's the last
+				lineEnd := lines[lineNum-1][len(current):]
+				suggestion = strings.ReplaceAll(suggestion, "\r\n", "\n")
+				replacement = []string{strings.ReplaceAll(suggestion, "\n", eol) + lineEnd}
+			}
+			lines = slices.Replace(lines, int(lineNum-1), int(lineNum), replacement...)
+		}
+
+		files = append(files, &ChangeRepoFile{
+			Operation:     "update",
+			TreePath:      treePath,
+			ContentReader: strings.NewReader(strings.Join(lines, "")),
+			SHA:           entry.ID.String(),
+		})
+	}
+
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = DefaultSuggestionsCommitMessage(len(commentIDs))
+	}
+	if len(coAuthors) > 0 {
+		message += "\n\n" + strings.Join(coAuthors, "\n")
+	}
+
+	return ChangeRepoFiles(ctx, pr.HeadRepo, doer, &ChangeRepoFilesOptions{
+		LastCommitID: headCommit.ID.String(),
+		OldBranch:    pr.HeadBranch,
+		NewBranch:    pr.HeadBranch,
+		Message:      message,
+		Files:        files,
+	})
+}
+
+func findCommentOnLine(comments []*issues_model.Comment, treePath string, line int64) *issues_model.Comment {
+	for _, comment := range comments {
+		if comment.TreePath == treePath && comment.Line == line {
+			return comment
+		}
+	}
+	return nil
+}
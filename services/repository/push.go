@@ -27,6 +27,7 @@ import (
 	issue_service "forgejo.org/services/issue"
 	notify_service "forgejo.org/services/notify"
 	pull_service "forgejo.org/services/pull"
+	secretscan_service "forgejo.org/services/secretscan"
 )
 
 // pushQueue represents a queue to handle update pull request tests
@@ -108,6 +109,9 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 		if opts.IsNewRef() && opts.IsDelRef() {
 			return fmt.Errorf("old and new revisions are both %s", objectFormat.EmptyObjectID())
 		}
+		if opts.RefFullName.IsBranch() || opts.RefFullName.IsTag() {
+			secretscan_service.ScanPush(repo.ID, opts)
+		}
 		if opts.RefFullName.IsTag() {
 			if pusher == nil || pusher.ID != opts.PusherID {
 				if opts.PusherID == user_model.ActionsUserID {
@@ -237,6 +237,10 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		) AS il_too)`, issues_model.CommentTypeLabel, repo.ID, newOwner.ID); err != nil {
 			return fmt.Errorf("Unable to remove old org label comments: %w", err)
 		}
+
+		if err := issues_model.DeleteOwnerIssueCustomFieldValuesOfRepo(ctx, oldOwner.ID, repo.ID); err != nil {
+			return fmt.Errorf("Unable to remove old org custom issue field values: %w", err)
+		}
 	}
 
 	// Rename remote repository to new path and delete local copy.
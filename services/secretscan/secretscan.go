@@ -0,0 +1,310 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/process"
+	"forgejo.org/modules/queue"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/secretscan"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/mailer"
+)
+
+// ScanRequest asks for the content pushed to a ref to be scanned
+type ScanRequest struct {
+	RepoID      int64
+	PusherID    int64
+	RefFullName git.RefName
+	OldCommitID string
+	NewCommitID string
+}
+
+var scanQueue *queue.WorkerPoolQueue[*ScanRequest]
+
+// Init starts the secret scanning queue
+func Init(ctx context.Context) error {
+	if !setting.SecretScanning.Enabled {
+		return nil
+	}
+
+	handler := func(items ...*ScanRequest) []*ScanRequest {
+		for _, req := range items {
+			if err := scanPush(ctx, req); err != nil {
+				log.Error("Secret scanning of %s in repo %d failed: %v", req.RefFullName, req.RepoID, err)
+			}
+		}
+		return nil
+	}
+
+	scanQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "secret_scanning", handler)
+	if scanQueue == nil {
+		return errors.New("unable to create secret_scanning queue")
+	}
+	go graceful.GetManager().RunWithCancel(scanQueue)
+	return nil
+}
+
+// ScanPush queues the content pushed to a branch or tag for scanning
+func ScanPush(repoID int64, opts *repo_module.PushUpdateOptions) {
+	if scanQueue == nil || opts.IsDelRef() {
+		return
+	}
+	if err := scanQueue.Push(&ScanRequest{
+		RepoID:      repoID,
+		PusherID:    opts.PusherID,
+		RefFullName: opts.RefFullName,
+		OldCommitID: opts.OldCommitID,
+		NewCommitID: opts.NewCommitID,
+	}); err != nil {
+		log.Error("Unable to queue secret scanning of %s in repo %d: %v", opts.RefFullName, repoID, err)
+	}
+}
+
+type pushedBlob struct {
+	id   string
+	path string
+}
+
+func scanPush(ctx context.Context, req *ScanRequest) error {
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("SecretScan: repo %d %s", req.RepoID, req.RefFullName))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, req.RepoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	blobs, err := listPushedBlobs(ctx, gitRepo, req)
+	if err != nil || len(blobs) == 0 {
+		return err
+	}
+
+	rules, err := LoadRules(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	rules = append([]*secretscan.Rule{secretscan.AccessTokenRule}, rules...)
+
+	var alerts []*secretscan_model.SecretScanAlert
+	insertAlert := func(alert *secretscan_model.SecretScanAlert) error {
+		inserted, err := secretscan_model.InsertAlertIfNotExist(ctx, alert)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			alerts = append(alerts, alert)
+		}
+		return nil
+	}
+
+	// the candidates for access tokens are looked up once all the blobs were read
+	tokenAlerts := make(map[string][]*secretscan_model.SecretScanAlert)
+	err = readBlobs(ctx, gitRepo, blobs, func(blob pushedBlob, content []byte) error {
+		for _, finding := range secretscan.Scan(rules, content) {
+			alert := &secretscan_model.SecretScanAlert{
+				RepoID:      repo.ID,
+				SecretHash:  secretscan_model.HashSecret(finding.Secret),
+				RuleID:      finding.RuleID,
+				Description: finding.Description,
+				Redacted:    finding.Redacted(),
+				Path:        blob.path,
+				Line:        finding.Line,
+				RefName:     req.RefFullName.String(),
+				CommitID:    req.NewCommitID,
+				PusherID:    req.PusherID,
+			}
+
+			if finding.RuleID == secretscan.AccessTokenRule.ID {
+				tokenAlerts[finding.Secret] = append(tokenAlerts[finding.Secret], alert)
+				continue
+			}
+			if err := insertAlert(alert); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tokens, err := auth_model.FindAccessTokensBySHA(ctx, slices.Collect(maps.Keys(tokenAlerts)))
+	if err != nil {
+		return err
+	}
+	for candidate, token := range tokens {
+		isRevoked, err := handleAccessToken(ctx, repo, token)
+		if err != nil {
+			return err
+		}
+		for _, alert := range tokenAlerts[candidate] {
+			alert.IsRevoked = isRevoked
+			if err := insertAlert(alert); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(alerts) > 0 {
+		log.Info("Secret scanning found %d new secrets pushed to %s in %-v", len(alerts), req.RefFullName, repo)
+		mailer.MailSecretScanAlerts(ctx, repo, alerts)
+	}
+	return nil
+}
+
+// LoadRules returns the built-in rules and the custom patterns which apply to the repository
+func LoadRules(ctx context.Context, repoID int64) ([]*secretscan.Rule, error) {
+	patterns, err := db.Find[secretscan_model.SecretScanPattern](ctx, secretscan_model.FindPatternsOptions{
+		ListOptions:   db.ListOptionsAll,
+		RepoID:        repoID,
+		IncludeGlobal: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := slices.Clone(secretscan.BuiltinRules())
+	for _, pattern := range patterns {
+		rule, err := pattern.ToRule()
+		if err != nil {
+			log.Warn("Invalid secret scanning pattern %d: %v", pattern.ID, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// handleAccessToken revokes an access token of this instance found in pushed content if configured to do so,
+// and returns whether it was revoked
+func handleAccessToken(ctx context.Context, repo *repo_model.Repository, token *auth_model.AccessToken) (bool, error) {
+	if !setting.SecretScanning.RevokeAccessTokens {
+		return false, nil
+	}
+
+	if err := auth_model.DeleteAccessTokenByID(ctx, token.ID, token.UID); err != nil {
+		return false, err
+	}
+	log.Info("Revoked access token %d of user %d which was pushed to %-v", token.ID, token.UID, repo)
+
+	owner, err := user_model.GetUserByID(ctx, token.UID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	mailer.MailAccessTokenRevoked(ctx, owner, token.Name, repo)
+	return true, nil
+}
+
+// listPushedBlobs returns the blobs which were added by a push, along with the first path they were found at
+func listPushedBlobs(ctx context.Context, gitRepo *git.Repository, req *ScanRequest) ([]pushedBlob, error) {
+	cmd := git.NewCommand(ctx, "rev-list", "--objects").AddDynamicArguments(req.NewCommitID)
+	objectFormat, err := gitRepo.GetObjectFormat()
+	if err != nil {
+		return nil, err
+	}
+	if req.OldCommitID == objectFormat.EmptyObjectID().String() {
+		// The ref already exists at this point, so exclude it from the refs the new objects must not be reachable from
+		cmd.AddArguments("--not").AddOptionFormat("--exclude=%s", req.RefFullName.String()).AddArguments("--all")
+	} else {
+		cmd.AddArguments("--not").AddDynamicArguments(req.OldCommitID)
+	}
+
+	stdout, _, runErr := cmd.RunStdString(&git.RunOpts{Dir: gitRepo.Path})
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	// Each line holds an object ID, followed by a path for trees and blobs
+	var candidates []pushedBlob
+	for _, line := range strings.Split(stdout, "\n") {
+		id, path, ok := strings.Cut(line, " ")
+		if ok && path != "" {
+			candidates = append(candidates, pushedBlob{id: id, path: path})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	wr, rd, cancel, err := gitRepo.CatFileBatchCheck(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	var blobs []pushedBlob
+	for _, candidate := range candidates {
+		if _, err := wr.Write([]byte(candidate.id + "\n")); err != nil {
+			return nil, err
+		}
+		_, typ, size, err := git.ReadBatchLine(rd)
+		if err != nil {
+			return nil, err
+		}
+		if typ == "blob" && size <= setting.SecretScanning.MaxFileSize {
+			blobs = append(blobs, candidate)
+		}
+	}
+	return blobs, nil
+}
+
+// readBlobs calls fn with the content of each blob
+func readBlobs(ctx context.Context, gitRepo *git.Repository, blobs []pushedBlob, fn func(blob pushedBlob, content []byte) error) error {
+	wr, rd, cancel, err := gitRepo.CatFileBatch(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for _, blob := range blobs {
+		if _, err := wr.Write([]byte(blob.id + "\n")); err != nil {
+			return err
+		}
+		_, _, size, err := git.ReadBatchLine(rd)
+		if err != nil {
+			return err
+		}
+		content := make([]byte, size)
+		if _, err := io.ReadFull(rd, content); err != nil {
+			return err
+		}
+		if _, err := rd.Discard(1); err != nil {
+			return err
+		}
+		if err := fn(blob, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -105,6 +105,10 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		return err
 	}
 
+	if err := issues_model.DeleteSavedSearchesOfUser(ctx, u.ID); err != nil {
+		return fmt.Errorf("DeleteSavedSearchesOfUser: %w", err)
+	}
+
 	if purge || (setting.Service.UserDeleteWithCommentsMaxTime != 0 &&
 		u.CreatedUnix.AsTime().Add(setting.Service.UserDeleteWithCommentsMaxTime).After(time.Now())) {
 		// Delete Comments
@@ -0,0 +1,45 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIBulkEditIssuesQueryTooLarge(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.API.MaxResponseItems, 1)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	closeIssues := &api.BulkEditIssuesOption{
+		Query:      &api.BulkIssueQuery{State: "all", Type: "issues"},
+		Operations: []*api.BulkIssueOperation{{Type: "close"}},
+	}
+
+	// repo1 has more issues than can be edited at once
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/bulk", closeIssues).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusUnprocessableEntity)
+	assert.Contains(t, resp.Body.String(), "at most 1 issues can be edited at once")
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Index: 1, IsClosed: false})
+
+	closeIssues.Query.State = "open"
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/bulk", closeIssues).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var result api.BulkEditIssuesResult
+	DecodeJSON(t, resp, &result)
+	assert.Len(t, result.Results, 1)
+	assert.Equal(t, 1, result.Changed)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Index: 1, IsClosed: true})
+}
@@ -120,6 +120,44 @@ func TestAPIListIssues(t *testing.T) {
 	})
 }
 
+func TestAPIListIssuesCustomField(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	field := &issues_model.IssueCustomField{RepoID: repo.ID, Name: "Priority", Type: issues_model.IssueCustomFieldTypeSelect, Options: "High\nLow"}
+	require.NoError(t, issues_model.NewIssueCustomField(db.DefaultContext, field))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, 1, field, []string{"High"}))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, 2, field, []string{"Low"}))
+
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeReadIssue)
+	link, _ := url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/issues", owner.Name, repo.Name))
+
+	link.RawQuery = url.Values{"state": {"all"}, "custom_field": {"priority:high"}}.Encode()
+	resp := MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
+	var apiIssues []*api.Issue
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 1) {
+		assert.EqualValues(t, 1, apiIssues[0].ID)
+	}
+
+	// all the filters must match
+	link.RawQuery = url.Values{"state": {"all"}, "custom_field": {"Priority:High", "Priority:Low"}}.Encode()
+	resp = MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	assert.Empty(t, apiIssues)
+
+	link.RawQuery = url.Values{"state": {"all"}, "custom_field": {"Severity:High"}}.Encode()
+	MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusUnprocessableEntity)
+
+	link.RawQuery = url.Values{"state": {"all"}, "custom_field": {"Priority:Urgent"}}.Encode()
+	MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusUnprocessableEntity)
+
+	link.RawQuery = url.Values{"state": {"all"}, "custom_field": {"Priority"}}.Encode()
+	MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusUnprocessableEntity)
+}
+
 func TestAPIListIssuesPublicOnly(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -620,3 +658,35 @@ func TestAPISearchIssuesWithLabels(t *testing.T) {
 	DecodeJSON(t, resp, &apiIssues)
 	assert.Len(t, apiIssues, 2)
 }
+
+func TestAPIGetTransferredIssue(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Index: 1})
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+
+	// user2/repo2 is private
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/transfer", &api.TransferIssueOption{
+		Repository: "user2/repo2",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+	assert.EqualValues(t, 2, issue.RepoID)
+
+	// the former location redirects to the issue
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1").AddTokenAuth(token), http.StatusMovedPermanently)
+	location := resp.Header().Get("Location")
+	assert.Equal(t, fmt.Sprintf("%sapi/v1/repos/user2/repo2/issues/%d", setting.AppURL, issue.Index), location)
+
+	locationURL, err := url.Parse(location)
+	require.NoError(t, err)
+	resp = MakeRequest(t, NewRequest(t, "GET", locationURL.Path).AddTokenAuth(token), http.StatusOK)
+	var apiIssue api.Issue
+	DecodeJSON(t, resp, &apiIssue)
+	assert.Equal(t, issue.ID, apiIssue.ID)
+
+	// the new location is not disclosed to those who can't read it
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1"), http.StatusNotFound)
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/9999").AddTokenAuth(token), http.StatusNotFound)
+}
@@ -13,6 +13,7 @@ import (
 
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
@@ -347,3 +348,54 @@ func TestAPIPullDeleteBranchPerms(t *testing.T) {
 		user4Session.MakeRequest(t, req, http.StatusOK)
 	})
 }
+
+func TestAPIPullMergeability(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// pull request 2 (#3 of user2/repo1) changes iso-8859-1.txt
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	require.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{
+		RepoID:   repo.ID,
+		RuleName: "master",
+		ReviewRules: []*git_model.ReviewRule{
+			{FilePatterns: "*.txt", RequiredApprovals: 1},
+			{FilePatterns: "docs/**", RequiredApprovals: 2},
+		},
+	}, git_model.WhitelistOptions{}))
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+	getMergeability := func(t *testing.T) *api.PullRequestMergeability {
+		t.Helper()
+		req := NewRequestf(t, "GET", "/api/v1/repos/%s/pulls/%d/mergeability", repo.FullName(), pr.Index).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var mergeability *api.PullRequestMergeability
+		DecodeJSON(t, resp, &mergeability)
+		return mergeability
+	}
+
+	mergeability := getMergeability(t)
+	assert.True(t, mergeability.Mergeable)
+	assert.False(t, mergeability.Allowed)
+	assert.Contains(t, mergeability.Reason, "Changes to *.txt do not have enough approvals")
+	// only the rules matching changed files are listed
+	assert.Equal(t, []*api.PullRequestReviewRuleStatus{{
+		FilePatterns:      "*.txt",
+		RequiredApprovals: 1,
+		GrantedApprovals:  0,
+		Teams:             []string{},
+		MatchedFiles:      []string{"iso-8859-1.txt"},
+		Satisfied:         false,
+	}}, mergeability.ReviewRules)
+
+	// the owner of the repository approves the changes
+	require.NoError(t, db.Insert(db.DefaultContext, &issues_model.Review{Type: issues_model.ReviewTypeApprove, ReviewerID: 2, IssueID: pr.IssueID, Official: true}))
+
+	mergeability = getMergeability(t)
+	assert.True(t, mergeability.Allowed)
+	assert.Empty(t, mergeability.Reason)
+	if assert.Len(t, mergeability.ReviewRules, 1) {
+		assert.EqualValues(t, 1, mergeability.ReviewRules[0].GrantedApprovals)
+		assert.True(t, mergeability.ReviewRules[0].Satisfied)
+	}
+}
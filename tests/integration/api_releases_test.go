@@ -5,6 +5,8 @@ package integration
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -14,6 +16,7 @@ import (
 	"testing"
 
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
@@ -472,3 +475,42 @@ func TestAPIMissingAssetRelease(t *testing.T) {
 		AddTokenAuth(token)
 	MakeRequest(t, req, http.StatusBadRequest)
 }
+
+func TestReleaseChecksumsDownload(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.LowerName, auth_model.AccessTokenScopeWriteRepository)
+
+	r := createNewReleaseUsingAPI(t, token, owner, repo, "release-checksums", "", "Release with checksums", "test")
+	req := NewRequestWithBody(t, http.MethodPost, fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d/assets?name=forgejo.bin", owner.Name, repo.Name, r.ID), strings.NewReader("forgejo binary")).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	sum := sha256.Sum256([]byte("forgejo binary"))
+	checksums := hex.EncodeToString(sum[:]) + "  forgejo.bin\n"
+	downloadURL := fmt.Sprintf("/%s/%s/releases/download/release-checksums/", owner.Name, repo.Name)
+
+	resp := MakeRequest(t, NewRequest(t, "GET", downloadURL+"SHA256SUMS"), http.StatusOK)
+	assert.Equal(t, checksums, resp.Body.String())
+	// the checksums are not signed
+	MakeRequest(t, NewRequest(t, "GET", downloadURL+"SHA256SUMS.asc"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", downloadURL+"SHA256SUMS.sig"), http.StatusNotFound)
+
+	signature := "-----BEGIN SSH SIGNATURE-----\nU1NI\n-----END SSH SIGNATURE-----\n"
+	require.NoError(t, repo_model.UpdateReleaseChecksumsSignature(db.DefaultContext, &repo_model.Release{
+		ID:                 r.ID,
+		SignedChecksums:    checksums,
+		ChecksumsSignature: signature,
+	}))
+
+	resp = MakeRequest(t, NewRequest(t, "GET", downloadURL+"SHA256SUMS.sig"), http.StatusOK)
+	assert.Equal(t, signature, resp.Body.String())
+	// the file is named after the format of the signature
+	MakeRequest(t, NewRequest(t, "GET", downloadURL+"SHA256SUMS.asc"), http.StatusNotFound)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/%s/releases", owner.Name, repo.Name)), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	htmlDoc.AssertElement(t, fmt.Sprintf(`a[href="%sSHA256SUMS.sig"]`, downloadURL), true)
+}
@@ -100,6 +100,18 @@ func TestAPIDownloadArchive2(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, bs, 382)
 
+	link, _ = url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/tar.zst/master", user2.Name, repo.Name))
+	resp = MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
+	bs, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x28, 0xb5, 0x2f, 0xfd}, bs[:4])
+
+	link, _ = url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/tar.xz/master", user2.Name, repo.Name))
+	resp = MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusOK)
+	bs, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, bs[:6])
+
 	link, _ = url.Parse(fmt.Sprintf("/api/v1/repos/%s/%s/archive/master", user2.Name, repo.Name))
 	MakeRequest(t, NewRequest(t, "GET", link.String()).AddTokenAuth(token), http.StatusBadRequest)
 }
@@ -6,6 +6,7 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 
@@ -269,6 +270,49 @@ type TeamSearchResults struct {
 	Data []*api.Team `json:"data"`
 }
 
+func TestAPITeamReviewAssignment(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user1")
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteOrganization)
+	org := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 6})
+
+	createTeam := func(t *testing.T, mode string, count, status int) *httptest.ResponseRecorder {
+		t.Helper()
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/orgs/%s/teams", org.Name), &api.CreateTeamOption{
+			Name:                  "reviewers",
+			Permission:            "write",
+			Units:                 []string{"repo.code", "repo.pulls"},
+			ReviewAssignmentMode:  mode,
+			ReviewAssignmentCount: count,
+		}).AddTokenAuth(token)
+		return MakeRequest(t, req, status)
+	}
+
+	createTeam(t, "random", 1, http.StatusUnprocessableEntity)
+	createTeam(t, "round-robin", organization.MaxReviewAssignmentCount+1, http.StatusUnprocessableEntity)
+	unittest.AssertNotExistsBean(t, &organization.Team{OrgID: org.ID, LowerName: "reviewers"})
+
+	var apiTeam api.Team
+	DecodeJSON(t, createTeam(t, "round-robin", 2, http.StatusCreated), &apiTeam)
+	assert.Equal(t, "round-robin", apiTeam.ReviewAssignmentMode)
+	assert.Equal(t, 2, apiTeam.ReviewAssignmentCount)
+
+	mode := "random"
+	req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/teams/%d", apiTeam.ID), &api.EditTeamOption{ReviewAssignmentMode: &mode}).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	count := 0
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/teams/%d", apiTeam.ID), &api.EditTeamOption{ReviewAssignmentCount: &count}).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: apiTeam.ID})
+	assert.Equal(t, organization.ReviewAssignmentRoundRobin, team.ReviewAssignmentMode)
+	assert.Equal(t, 2, team.ReviewAssignmentCount)
+}
+
 func TestAPITeamSearch(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
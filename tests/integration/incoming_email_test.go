@@ -14,6 +14,7 @@ import (
 
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
@@ -216,6 +217,47 @@ func TestIncomingEmail(t *testing.T) {
 		})
 	})
 
+	t.Run("NewIssue", func(t *testing.T) {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		incomingEmail, err := repo_model.SetIncomingEmail(db.DefaultContext, repo.ID, false)
+		require.NoError(t, err)
+
+		t.Run("Forged sender", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// without service desk, the address of the repository doesn't trust the sender address
+			content := &incoming.MailContent{
+				Subject:     "issue from a forged sender",
+				FromAddress: user.Email,
+				Content:     "opened by mail",
+			}
+			require.NoError(t, (&incoming.NewIssueHandler{}).Handle(db.DefaultContext, content, incomingEmail.Token))
+			unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: repo.ID, Title: content.Subject})
+		})
+
+		t.Run("Personal address", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			payload, err := incoming.CreateNewIssuePayload(incomingEmail)
+			require.NoError(t, err)
+			content := &incoming.MailContent{
+				Subject:     "issue from a personal address",
+				FromAddress: "forged@example.com",
+				Content:     "opened by mail",
+			}
+			require.NoError(t, (&incoming.UserNewIssueHandler{}).Handle(db.DefaultContext, content, user, payload))
+			issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Title: content.Subject})
+			assert.Equal(t, user.ID, issue.PosterID)
+			assert.Equal(t, content.Content, issue.Content)
+
+			// the personal addresses stop working when the address of the repository is changed
+			require.NoError(t, repo_model.ResetIncomingEmailToken(db.DefaultContext, incomingEmail))
+			content.Subject = "issue from a former personal address"
+			require.NoError(t, (&incoming.UserNewIssueHandler{}).Handle(db.DefaultContext, content, user, payload))
+			unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: repo.ID, Title: content.Subject})
+		})
+	})
+
 	if setting.IncomingEmail.Enabled {
 		// This test connects to the configured email server and is currently only enabled for MySql integration tests.
 		// It sends a reply to create a comment. If the comment is not detected after 10 seconds the test fails.
@@ -391,6 +391,22 @@ func TestNewIssue(t *testing.T) {
 	testNewIssue(t, session, "user2", "repo1", "Title", "Description")
 }
 
+func TestNewIssueMissingTemplate(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	session := loginUser(t, "user2")
+
+	// the values of a form template which does not exist can't be validated
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", map[string]string{
+		"_csrf":         GetCSRF(t, session, "/user2/repo1/issues/new"),
+		"title":         "Title",
+		"content":       "Description",
+		"template-file": ".forgejo/issue_template/missing.yaml",
+	})
+	resp := session.MakeRequest(t, req, http.StatusBadRequest)
+	assert.Contains(t, resp.Body.String(), "missing.yaml")
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: 1, Title: "Title"})
+}
+
 func TestIssueCheckboxes(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 	session := loginUser(t, "user2")
@@ -1481,3 +1497,39 @@ func TestIssuePostersSearch(t *testing.T) {
 		assert.EqualValues(t, 1, data.Results[0].UserID)
 	})
 }
+
+func TestIssueCustomFieldDelete(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	field := &issues_model.IssueCustomField{RepoID: repo.ID, Name: "Priority", Type: issues_model.IssueCustomFieldTypeSelect, Options: "High\nLow"}
+	require.NoError(t, issues_model.NewIssueCustomField(db.DefaultContext, field))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(db.DefaultContext, 1, field, []string{"High"}))
+	issues.UpdateIssueIndexer(t.Context(), 1)
+
+	searchByField := func() []int64 {
+		// the keyword makes the search use the indexer rather than the database
+		ids, _, err := issues.SearchIssues(t.Context(), &issues.SearchOptions{
+			Keyword:      "first",
+			RepoIDs:      []int64{repo.ID},
+			CustomFields: []issues.CustomFieldFilter{{FieldID: field.ID, Value: "High"}},
+		})
+		require.NoError(t, err)
+		return ids
+	}
+	assert.Eventually(t, func() bool {
+		return len(searchByField()) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", fmt.Sprintf("%s/settings/issue_fields/%d/delete", repo.Link(), field.ID), map[string]string{
+		"_csrf": GetCSRF(t, session, repo.Link()+"/settings/issue_fields"),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueCustomField{ID: field.ID})
+
+	// the issues which had a value for the field are indexed again
+	assert.Eventually(t, func() bool {
+		return len(searchByField()) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
@@ -247,7 +247,7 @@ func createPullRequest(t *testing.T, user *user_model.User, repo *repo_model.Rep
 		BaseRepo:   repo,
 		Type:       issues_model.PullRequestGitea,
 	}
-	err = pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pullRequest, nil)
+	err = pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pullRequest, nil, nil)
 	require.NoError(t, err)
 
 	return pullRequest
@@ -0,0 +1,172 @@
+// Copyright 2025 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	unit_model "forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	api "forgejo.org/modules/structs"
+	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullApplySuggestions(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "",
+			[]unit_model.Type{unit_model.TypeCode, unit_model.TypePullRequests}, nil,
+			[]*files_service.ChangeRepoFile{
+				{Operation: "create", TreePath: "lf.txt", ContentReader: strings.NewReader("one\ntwo\nthree\n")},
+				{Operation: "create", TreePath: "crlf.txt", ContentReader: strings.NewReader("one\r\ntwo\r\nthree\r\n")},
+			},
+		)
+		defer f()
+
+		// the last line of lf.txt has no line break on the head branch
+		_, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{Operation: "update", TreePath: "lf.txt", ContentReader: strings.NewReader("one\ntwo\nthree\nfour")},
+			},
+			Message:   "Add a line",
+			OldBranch: "main",
+			NewBranch: "suggestions",
+		})
+		require.NoError(t, err)
+
+		pullIssue := &issues_model.Issue{
+			RepoID:   repo.ID,
+			Title:    "Testing suggestions",
+			PosterID: user2.ID,
+			Poster:   user2,
+			IsPull:   true,
+		}
+		pr := &issues_model.PullRequest{
+			HeadRepoID: repo.ID,
+			BaseRepoID: repo.ID,
+			HeadBranch: "suggestions",
+			BaseBranch: "main",
+			HeadRepo:   repo,
+			BaseRepo:   repo,
+			Type:       issues_model.PullRequestGitea,
+		}
+		require.NoError(t, pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pr, nil, nil))
+
+		addComment := func(t *testing.T, poster *user_model.User, treePath string, line int64, commented, suggestion string) int64 {
+			t.Helper()
+			content := "```suggestion\n"
+			if suggestion != "" {
+				content += suggestion + "\n"
+			}
+			comment := &issues_model.Comment{
+				Type:     issues_model.CommentTypeCode,
+				PosterID: poster.ID,
+				IssueID:  pr.IssueID,
+				TreePath: treePath,
+				Line:     line,
+				Patch:    fmt.Sprintf("@@ -%d,1 +%d,1 @@\n+%s", line, line, commented),
+				Content:  content + "```",
+			}
+			require.NoError(t, db.Insert(db.DefaultContext, comment))
+			return comment.ID
+		}
+
+		headCommit := func(t *testing.T) *git.Commit {
+			t.Helper()
+			gitRepo, err := gitrepo.OpenRepository(git.DefaultContext, repo)
+			require.NoError(t, err)
+			defer gitRepo.Close()
+			commit, err := gitRepo.GetBranchCommit("suggestions")
+			require.NoError(t, err)
+			return commit
+		}
+		fileContent := func(t *testing.T, commit *git.Commit, treePath string) string {
+			t.Helper()
+			content, err := commit.GetFileContent(treePath, 1024)
+			require.NoError(t, err)
+			return content
+		}
+
+		t.Run("Several files", func(t *testing.T) {
+			oldHead := headCommit(t)
+			commentIDs := []int64{
+				addComment(t, user4, "lf.txt", 2, "two", "2\n2.5"),
+				// an empty suggestion removes the last line, which has no line break
+				addComment(t, user5, "lf.txt", 4, "four", ""),
+				addComment(t, user4, "crlf.txt", 2, "two", "zwei\ndrei"),
+				addComment(t, user2, "crlf.txt", 3, "three", "vier"),
+			}
+
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user2, pr, commentIDs, "")
+			require.NoError(t, err)
+
+			commit := headCommit(t)
+			parentID, err := commit.ParentID(0)
+			require.NoError(t, err)
+			assert.Equal(t, oldHead.ID, parentID, "all suggestions are applied in a single commit")
+			assert.Equal(t, "one\n2\n2.5\nthree\n", fileContent(t, commit, "lf.txt"))
+			// the suggestions take the line breaks of the file
+			assert.Equal(t, "one\r\nzwei\r\ndrei\r\nvier\r\n", fileContent(t, commit, "crlf.txt"))
+
+			// the authors of the suggestions other than the doer are credited once each
+			assert.Equal(t, fmt.Sprintf("Apply suggestions from code review\n\nCo-authored-by: %s <%s>\nCo-authored-by: %s <%s>",
+				user4.GetDisplayName(), user4.GetEmail(), user5.GetDisplayName(), user5.GetEmail()), strings.TrimSpace(commit.CommitMessage))
+		})
+
+		t.Run("Same line", func(t *testing.T) {
+			commentIDs := []int64{
+				addComment(t, user4, "lf.txt", 1, "one", "1"),
+				addComment(t, user5, "lf.txt", 1, "one", "eins"),
+			}
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user2, pr, commentIDs, "")
+			require.ErrorIs(t, err, files_service.ErrSuggestionNotApplicable{CommentID: commentIDs[1], Reason: "another suggestion is applied to the same line"})
+		})
+
+		t.Run("Changed line", func(t *testing.T) {
+			// the comment was made when the second line was still "two"
+			commentID := addComment(t, user4, "lf.txt", 2, "two", "deux")
+			_, err := files_service.ApplySuggestions(db.DefaultContext, user2, pr, []int64{commentID}, "")
+			require.ErrorIs(t, err, files_service.ErrSuggestionNotApplicable{CommentID: commentID, Reason: "the commented line has changed"})
+			assert.Equal(t, "one\n2\n2.5\nthree\n", fileContent(t, headCommit(t), "lf.txt"))
+		})
+
+		t.Run("API", func(t *testing.T) {
+			token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteRepository)
+			link := fmt.Sprintf("/api/v1/repos/%s/pulls/%d/suggestions", repo.FullName(), pr.Index)
+			commentID := addComment(t, user4, "lf.txt", 1, "one", "uno")
+
+			// a push rejected by the pre-receive hook is a conflict
+			require.NoError(t, git_model.SetPushPolicy(db.DefaultContext, &git_model.PushPolicy{RepoID: repo.ID, CommitMessagePattern: `^fix: `}))
+			req := NewRequestWithJSON(t, "POST", link, &api.ApplySuggestionsOptions{CommentIDs: []int64{commentID}}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusConflict)
+			assert.Contains(t, resp.Body.String(), "PushRejected")
+
+			req = NewRequestWithJSON(t, "POST", link, &api.ApplySuggestionsOptions{CommentIDs: []int64{commentID}, Message: "fix: apply the suggestion"}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			commit := headCommit(t)
+			assert.Equal(t, "uno\n2\n2.5\nthree\n", fileContent(t, commit, "lf.txt"))
+			assert.Equal(t, fmt.Sprintf("fix: apply the suggestion\n\nCo-authored-by: %s <%s>", user4.GetDisplayName(), user4.GetEmail()), strings.TrimSpace(commit.CommitMessage))
+		})
+	})
+}
@@ -121,7 +121,7 @@ func TestIssueTitles(t *testing.T) {
 				Type:       issues_model.PullRequestGitea,
 			}
 
-			err = pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pullRequest, nil)
+			err = pull_service.NewPullRequest(git.DefaultContext, repo, pullIssue, nil, nil, pullRequest, nil, nil)
 			require.NoError(t, err)
 
 			html := extractHTML(t, session, issue1, "div.timeline > div:nth-child(4) > div.detail > * > a")
@@ -139,7 +139,7 @@ func createIssue(t *testing.T, user *user_model.User, repo *repo_model.Repositor
 		Poster:   user,
 	}
 
-	err := issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil)
+	err := issue_service.NewIssue(db.DefaultContext, repo, issue, nil, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	return issue
@@ -6,7 +6,15 @@ export function initRepoEllipsisButton() {
     button.addEventListener('click', function (e) {
       e.preventDefault();
       const expanded = this.getAttribute('aria-expanded') === 'true';
-      toggleElem(this.parentElement.querySelector('.commit-body'));
+      toggleElem(this.parentElement.querySelector('.commit-body:not(.commit-note)'));
+      this.setAttribute('aria-expanded', String(!expanded));
+    });
+  }
+  for (const button of document.querySelectorAll('.js-toggle-commit-note')) {
+    button.addEventListener('click', function (e) {
+      e.preventDefault();
+      const expanded = this.getAttribute('aria-expanded') === 'true';
+      toggleElem(this.parentElement.querySelector('.commit-note'));
       this.setAttribute('aria-expanded', String(!expanded));
     });
   }
@@ -92,12 +92,20 @@ async function initRepoProjectSortable() {
   }
 }
 
+// the fields of the table view are saved as soon as they are changed
+function initRepoProjectTable() {
+  for (const form of document.querySelectorAll('.project-table-edit')) {
+    form.addEventListener('change', () => form.requestSubmit());
+  }
+}
+
 export function initRepoProject() {
   if (!document.querySelector('.repository.projects')) {
     return;
   }
 
   const _promise = initRepoProjectSortable();
+  initRepoProjectTable();
 
   for (const modal of document.getElementsByClassName('edit-project-column-modal')) {
     const projectHeader = modal.closest('.project-column-header');